@@ -1,17 +1,60 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"devflow/internal/bitbucket"
 	"devflow/internal/config"
+	"devflow/internal/httpx"
+	"devflow/internal/jenkins"
+	"devflow/internal/jira"
 	"github.com/spf13/cobra"
 )
 
 type authChecker interface {
-	TestAuth() error
+	TestAuthContext(ctx context.Context) error
+}
+
+// authReporter is implemented by clients that can also say who the
+// credentials belong to.
+type authReporter interface {
+	CheckAuthContext(ctx context.Context) (*httpx.AuthReport, error)
+}
+
+// authClients builds the checker for each integration. A nil constructor
+// skips that integration.
+type authClients struct {
+	jira      func(*config.JiraConfig) authChecker
+	bitbucket func(*config.BitbucketConfig) authChecker
+	jenkins   func(*config.JenkinsConfig) authChecker
+}
+
+var defaultAuthClients = authClients{
+	jira:      func(cfg *config.JiraConfig) authChecker { return jira.NewClient(cfg) },
+	bitbucket: func(cfg *config.BitbucketConfig) authChecker { return bitbucket.NewClient(cfg) },
+	jenkins:   func(cfg *config.JenkinsConfig) authChecker { return jenkins.NewClient(cfg) },
+}
+
+// authStatus is the outcome of checking one integration.
+type authStatus struct {
+	Service       string   `json:"service"`
+	Authenticated bool     `json:"authenticated"`
+	Identity      string   `json:"identity,omitempty"`
+	Workspace     string   `json:"workspace,omitempty"`
+	TokenType     string   `json:"token_type"`
+	LatencyMS     int64    `json:"latency_ms"`
+	MissingScopes []string `json:"missing_scopes,omitempty"`
+	Error         string   `json:"error,omitempty"`
+
+	err error
 }
 
 func runAuthStatus(loadConfig func() (*config.Config, error), newClient func(*config.BitbucketConfig) authChecker, out io.Writer) error {
@@ -19,47 +62,186 @@ func runAuthStatus(loadConfig func() (*config.Config, error), newClient func(*co
 }
 
 func runAuthStatusWithFormat(loadConfig func() (*config.Config, error), newClient func(*config.BitbucketConfig) authChecker, out io.Writer, jsonOutput bool) error {
+	format := formatDetailed
+	if jsonOutput {
+		format = formatJSON
+	}
+	return runAuthChecks(context.Background(), loadConfig, authClients{bitbucket: newClient}, out, format)
+}
+
+// runAuthChecks checks every configured integration in parallel and reports
+// the results in the requested format. It fails when any check fails.
+func runAuthChecks(ctx context.Context, loadConfig func() (*config.Config, error), clients authClients, out io.Writer, format string) error {
 	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	if cfg.Bitbucket.Token == "" {
-		return fmt.Errorf("bitbucket token not configured. Run: devflow config set bitbucket.token <token>")
+	var checks []func() authStatus
+	if clients.jira != nil && cfg.Jira.URL != "" {
+		checks = append(checks, func() authStatus {
+			status := authStatus{Service: "jira", TokenType: httpx.BasicAuthScheme(cfg.Jira.Username, cfg.Jira.Token)}
+			if jiraUsernameMissing(cfg.Jira) || cfg.Jira.Token == "" {
+				status.err = fmt.Errorf("jira credentials not configured. Run: devflow config set jira.username|jira.token ...")
+				return status
+			}
+			return checkAuth(ctx, status, clients.jira(&cfg.Jira))
+		})
+	}
+	if clients.bitbucket != nil && (cfg.Bitbucket.Workspace != "" || cfg.Bitbucket.Token != "") {
+		checks = append(checks, func() authStatus {
+			status := authStatus{Service: "bitbucket", Workspace: cfg.Bitbucket.Workspace, TokenType: httpx.AuthScheme(cfg.Bitbucket.Username, cfg.Bitbucket.Token)}
+			if cfg.Bitbucket.Token == "" {
+				status.err = fmt.Errorf("bitbucket token not configured. Run: devflow config set bitbucket.token <token>")
+				return status
+			}
+			return checkAuth(ctx, status, clients.bitbucket(&cfg.Bitbucket))
+		})
+	}
+	if clients.jenkins != nil && cfg.Jenkins.URL != "" {
+		checks = append(checks, func() authStatus {
+			status := authStatus{Service: "jenkins", TokenType: httpx.BasicAuthScheme(cfg.Jenkins.Username, cfg.Jenkins.Token)}
+			return checkAuth(ctx, status, clients.jenkins(&cfg.Jenkins))
+		})
+	}
+	if len(checks) == 0 {
+		return fmt.Errorf("no integrations configured. Run: devflow config setup")
 	}
 
-	client := newClient(&cfg.Bitbucket)
-	if err := client.TestAuth(); err != nil {
-		return fmt.Errorf("bitbucket authentication failed: %w", err)
+	results := make([]authStatus, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check func() authStatus) {
+			defer wg.Done()
+			results[i] = check()
+		}(i, check)
 	}
+	wg.Wait()
 
-	if cfg.Bitbucket.Workspace != "" {
-		if jsonOutput {
-			return writeAuthStatusJSON(out, cfg.Bitbucket.Workspace)
+	var errs []error
+	for i := range results {
+		if results[i].err != nil {
+			results[i].Error = results[i].err.Error()
+			errs = append(errs, results[i].err)
 		}
-		_, _ = fmt.Fprintf(out, "Bitbucket authentication is valid for workspace %q\n", cfg.Bitbucket.Workspace)
+	}
+
+	switch format {
+	case formatJSON, formatRaw:
+		if err := writeAuthStatusJSON(out, results); err != nil {
+			return err
+		}
+	case formatTabular:
+		rows := make([][]any, 0, len(results))
+		for _, result := range results {
+			rows = append(rows, []any{result.Service, result.Authenticated, result.Identity, result.TokenType, fmt.Sprintf("%dms", result.LatencyMS), strings.Join(result.MissingScopes, ", "), result.Error})
+		}
+		renderTable([]string{"Service", "Authenticated", "Identity", "Token Type", "Latency", "Missing Scopes", "Error"}, rows)
+	default:
+		for _, result := range results {
+			writeAuthStatusText(out, result)
+		}
+	}
+
+	if len(errs) == 0 {
 		return nil
 	}
+	// Every failure is already part of the output above.
+	return reportedError{errors.Join(errs...)}
+}
 
-	if jsonOutput {
-		return writeAuthStatusJSON(out, "")
+func checkAuth(ctx context.Context, status authStatus, client authChecker) authStatus {
+	start := time.Now()
+	var err error
+	if reporter, ok := client.(authReporter); ok {
+		var report *httpx.AuthReport
+		report, err = reporter.CheckAuthContext(ctx)
+		if report != nil {
+			status.Identity = report.Identity
+			if report.Scheme != "" {
+				status.TokenType = report.Scheme
+			}
+			status.MissingScopes = report.MissingScopes
+		}
+	} else {
+		err = client.TestAuthContext(ctx)
+	}
+	status.LatencyMS = time.Since(start).Milliseconds()
+
+	if err != nil {
+		var authErr *httpx.AuthError
+		if errors.As(err, &authErr) {
+			status.MissingScopes = authErr.MissingScopes
+		}
+		status.err = fmt.Errorf("%s authentication failed: %w", status.Service, err)
+		return status
+	}
+	status.Authenticated = true
+	return status
+}
+
+func writeAuthStatusText(out io.Writer, status authStatus) {
+	name := serviceTitle(status.Service)
+	if status.err != nil {
+		_, _ = fmt.Fprintf(out, "❌ %s: %v\n", name, status.err)
+	} else if status.Workspace != "" {
+		_, _ = fmt.Fprintf(out, "%s authentication is valid for workspace %q\n", name, status.Workspace)
+	} else {
+		_, _ = fmt.Fprintf(out, "%s authentication is valid\n", name)
+	}
+
+	details := []string{}
+	if status.Identity != "" {
+		details = append(details, "👤 "+status.Identity)
+	}
+	if status.TokenType != "" {
+		details = append(details, "🔑 "+status.TokenType)
+	}
+	if status.err == nil || status.LatencyMS > 0 {
+		details = append(details, fmt.Sprintf("⏱️  %dms", status.LatencyMS))
+	}
+	_, _ = fmt.Fprintf(out, "   %s\n", strings.Join(details, "  "))
+	if len(status.MissingScopes) > 0 {
+		_, _ = fmt.Fprintf(out, "   ⚠️  Missing scopes/permissions: %s\n", strings.Join(status.MissingScopes, ", "))
 	}
-	_, _ = fmt.Fprintln(out, "Bitbucket authentication is valid")
-	return nil
 }
 
-func writeAuthStatusJSON(out io.Writer, workspace string) error {
-	_, err := fmt.Fprintf(out, "{\n  \"authenticated\": true,\n  \"workspace\": %q\n}\n", workspace)
-	return err
+func serviceTitle(service string) string {
+	if service == "" {
+		return service
+	}
+	return strings.ToUpper(service[:1]) + service[1:]
+}
+
+func writeAuthStatusJSON(out io.Writer, results []authStatus) error {
+	authenticated := true
+	for _, result := range results {
+		authenticated = authenticated && result.Authenticated
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Authenticated bool         `json:"authenticated"`
+		Services      []authStatus `json:"services"`
+	}{authenticated, results})
 }
 
 var authStatusCmd = &cobra.Command{
 	Use:   "status",
-	Short: "Check Bitbucket authentication status",
-	Long:  `Verify the configured Bitbucket credentials against the Bitbucket API.`,
+	Short: "Check authentication status of all integrations",
+	Long: `Verify the configured Jira, Bitbucket and Jenkins credentials in parallel.
+
+For each configured integration the command reports the authenticated
+identity, the request latency, the token type (Basic or Bearer) and any
+scopes or permissions the server reported as missing.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runAuthStatusWithFormat(config.Load, func(cfg *config.BitbucketConfig) authChecker {
-			return bitbucket.NewClient(cfg)
-		}, os.Stdout, wantsJSON(cmd))
+		format := formatDetailed
+		if wantsJSON(cmd) {
+			format = formatJSON
+		} else if wantsTabular(cmd) {
+			format = formatTabular
+		}
+		return runAuthChecks(commandContext(cmd), loadConfig, defaultAuthClients, os.Stdout, format)
 	},
 }
@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"strings"
 	"testing"
 
 	"devflow/internal/config"
+	"devflow/internal/httpx"
 )
 
 type fakeAuthClient struct {
@@ -14,7 +17,7 @@ type fakeAuthClient struct {
 	calls       int
 }
 
-func (f *fakeAuthClient) TestAuth() error {
+func (f *fakeAuthClient) TestAuthContext(ctx context.Context) error {
 	f.calls++
 	return f.testAuthErr
 }
@@ -66,7 +69,7 @@ func TestRunAuthStatus_SuccessWithoutWorkspace(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if got := strings.TrimSpace(out.String()); got != "Bitbucket authentication is valid" {
+	if got := strings.SplitN(out.String(), "\n", 2)[0]; got != "Bitbucket authentication is valid" {
 		t.Fatalf("unexpected output: %q", got)
 	}
 }
@@ -146,3 +149,70 @@ func TestRunAuthStatus_JSONOutput(t *testing.T) {
 		})
 	}
 }
+
+type fakeAuthReporter struct {
+	report *httpx.AuthReport
+	err    error
+}
+
+func (f *fakeAuthReporter) TestAuthContext(ctx context.Context) error {
+	return f.err
+}
+
+func (f *fakeAuthReporter) CheckAuthContext(ctx context.Context) (*httpx.AuthReport, error) {
+	return f.report, f.err
+}
+
+func TestRunAuthChecks_AllIntegrations(t *testing.T) {
+	cfg := &config.Config{
+		Jira:      config.JiraConfig{URL: "https://jira.example", Username: "me@example.com", Token: "token", Deployment: "cloud"},
+		Bitbucket: config.BitbucketConfig{Workspace: "workspace", Token: "token"},
+		Jenkins:   config.JenkinsConfig{URL: "https://jenkins.example", Username: "me", Token: "token"},
+	}
+	clients := authClients{
+		jira: func(*config.JiraConfig) authChecker {
+			return &fakeAuthReporter{report: &httpx.AuthReport{Identity: "Me <me@example.com>"}}
+		},
+		bitbucket: func(*config.BitbucketConfig) authChecker {
+			return &fakeAuthReporter{report: &httpx.AuthReport{MissingScopes: []string{"account"}}}
+		},
+		jenkins: func(*config.JenkinsConfig) authChecker {
+			return &fakeAuthReporter{err: &httpx.AuthError{StatusCode: 403, MissingScopes: []string{"Overall/Read"}}}
+		},
+	}
+
+	var out bytes.Buffer
+	err := runAuthChecks(context.Background(), func() (*config.Config, error) { return cfg, nil }, clients, &out, formatJSON)
+	if err == nil || !strings.Contains(err.Error(), "jenkins authentication failed") {
+		t.Fatalf("expected jenkins failure, got %v", err)
+	}
+
+	var payload struct {
+		Authenticated bool         `json:"authenticated"`
+		Services      []authStatus `json:"services"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode JSON: %v\n%s", err, out.String())
+	}
+	if payload.Authenticated || len(payload.Services) != 3 {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+	jiraStatus, bitbucketStatus, jenkinsStatus := payload.Services[0], payload.Services[1], payload.Services[2]
+	if jiraStatus.Service != "jira" || !jiraStatus.Authenticated || jiraStatus.Identity != "Me <me@example.com>" || jiraStatus.TokenType != "Basic" {
+		t.Fatalf("unexpected jira status: %+v", jiraStatus)
+	}
+	if !bitbucketStatus.Authenticated || bitbucketStatus.TokenType != "Bearer" || len(bitbucketStatus.MissingScopes) != 1 {
+		t.Fatalf("unexpected bitbucket status: %+v", bitbucketStatus)
+	}
+	if jenkinsStatus.Authenticated || jenkinsStatus.Error == "" || jenkinsStatus.MissingScopes[0] != "Overall/Read" {
+		t.Fatalf("unexpected jenkins status: %+v", jenkinsStatus)
+	}
+}
+
+func TestRunAuthChecks_NothingConfigured(t *testing.T) {
+	var out bytes.Buffer
+	err := runAuthChecks(context.Background(), func() (*config.Config, error) { return &config.Config{}, nil }, defaultAuthClients, &out, formatDetailed)
+	if err == nil || !strings.Contains(err.Error(), "no integrations configured") {
+		t.Fatalf("expected no integrations error, got %v", err)
+	}
+}
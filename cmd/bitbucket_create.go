@@ -40,13 +40,14 @@ var createPRCmd = &cobra.Command{
 	Long:    `Create a new pull request with the specified title, description, reviewers, and optional auto-detected branches`,
 	Args:    cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
 		jsonOutput := wantsJSON(cmd)
 		title := args[0]
 
 		// Load configuration
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 
 		// Validate required config
@@ -80,17 +81,23 @@ var createPRCmd = &cobra.Command{
 		destBranch := destinationBranch
 		client := bitbucket.NewClient(&cfg.Bitbucket)
 		if destBranch == "" {
-			if mainBranch, err := client.GetRepositoryMainBranch(slug); err == nil && mainBranch != "" {
+			if mainBranch, err := client.GetRepositoryMainBranchContext(ctx, slug); err == nil && mainBranch != "" {
 				destBranch = mainBranch
 			} else {
 				destBranch = "main"
 			}
 		}
 
+		// Reviewers from the flag win over configured defaults
+		reviewers := prReviewers
+		if !cmd.Flags().Changed("reviewer") {
+			reviewers = cfg.Bitbucket.DefaultReviewers
+		}
+
 		// Create pull request with description and reviewers
-		pr, err := client.CreatePullRequest(slug, title, prDescription, srcBranch, destBranch, prReviewers)
+		pr, err := client.CreatePullRequestContext(ctx, slug, title, prDescription, srcBranch, destBranch, reviewers)
 		if err != nil {
-			log.Fatalf("Error creating pull request: %v", err)
+			fatalError("Error creating pull request", err)
 		}
 
 		if jsonOutput {
@@ -108,7 +115,7 @@ var createPRCmd = &cobra.Command{
 
 			jsonBytes, err := json.MarshalIndent(output, "", "  ")
 			if err != nil {
-				log.Fatalf("Error marshaling JSON: %v", err)
+				fatalError("Error marshaling JSON", err)
 			}
 			fmt.Println(string(jsonBytes))
 			return
@@ -126,8 +133,8 @@ var createPRCmd = &cobra.Command{
 			fmt.Printf("📝 %s\n", pr.Description)
 		}
 		fmt.Printf("👤 Author: %s\n", pr.Author.DisplayName)
-		if len(prReviewers) > 0 {
-			fmt.Printf("👀 Reviewers: %s\n", strings.Join(prReviewers, ", "))
+		if len(reviewers) > 0 {
+			fmt.Printf("👀 Reviewers: %s\n", strings.Join(reviewers, ", "))
 		}
 		if pr.Links.HTML.Href != "" {
 			fmt.Printf("🌐 URL: %s\n", pr.Links.HTML.Href)
@@ -143,7 +150,7 @@ func init() {
 	createPRCmd.Flags().StringVarP(&sourceBranch, "source", "s", "", "Source branch (auto-detect current)")
 	createPRCmd.Flags().StringVarP(&destinationBranch, "dest", "d", "", "Destination branch (auto-detect main)")
 	createPRCmd.Flags().StringVarP(&prDescription, "description", "m", "", "Pull request description/body")
-	createPRCmd.Flags().StringSliceVarP(&prReviewers, "reviewer", "R", []string{}, "Reviewer username (repeatable)")
+	createPRCmd.Flags().StringSliceVarP(&prReviewers, "reviewer", "R", []string{}, "Reviewer username (repeatable, defaults to bitbucket.default_reviewers)")
 	createPRCmd.Flags().BoolVarP(&openInBrowser, "open", "o", false, "Open PR in browser after creation")
 	createPRCmd.Flags().Bool("json", false, "Output in JSON format")
 	if err := createPRCmd.MarkFlagRequired("repo"); err != nil {
@@ -26,10 +26,11 @@ Behavior:
 - Without a slug: aggregates PRs across all watched repositories.
 - A repository must be added via 'devflow bitbucket repo watch add <repo>' to be included.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
 		jsonOutput = wantsJSON(cmd)
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 		if cfg.Bitbucket.Workspace == "" {
 			log.Fatal("Bitbucket workspace not configured. Run: devflow config set bitbucket.workspace <workspace>")
@@ -58,9 +59,9 @@ Behavior:
 			if _, ok := watched[key]; !ok {
 				log.Fatalf("Repository '%s' is not in watched list. Add it first with: devflow bitbucket repo watch add %s", slug, slug)
 			}
-			prs, err := client.GetPullRequests(slug)
+			prs, err := client.GetPullRequestsContext(ctx, slug)
 			if err != nil {
-				log.Fatalf("Error fetching pull requests: %v", err)
+				fatalError("Error fetching pull requests", err)
 			}
 
 			if jsonOutput {
@@ -83,7 +84,7 @@ Behavior:
 		var total int
 
 		for w := range watched {
-			prs, err := client.GetPullRequests(w)
+			prs, err := client.GetPullRequestsContext(ctx, w)
 			if err != nil {
 				if !jsonOutput {
 					fmt.Printf("Warning: failed to fetch '%s': %v\n", w, err)
@@ -119,7 +120,7 @@ Behavior:
 			}
 			jsonBytes, err := json.MarshalIndent(output, "", "  ")
 			if err != nil {
-				log.Fatalf("Error marshaling JSON: %v", err)
+				fatalError("Error marshaling JSON", err)
 			}
 			fmt.Println(string(jsonBytes))
 		} else if total == 0 {
@@ -148,7 +149,7 @@ func printPRsJSON(workspace, slug string, prs []bitbucket.PullRequest) {
 
 	jsonBytes, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
-		log.Fatalf("Error marshaling JSON: %v", err)
+		fatalError("Error marshaling JSON", err)
 	}
 	fmt.Println(string(jsonBytes))
 }
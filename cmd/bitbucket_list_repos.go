@@ -19,9 +19,10 @@ var listReposCmd = &cobra.Command{
 	Short:   "List repositories in the workspace",
 	Long:    `List repositories in the configured Bitbucket workspace with pagination support`,
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 
 		if cfg.Bitbucket.Workspace == "" {
@@ -37,9 +38,9 @@ var listReposCmd = &cobra.Command{
 		client := bitbucket.NewClient(&cfg.Bitbucket)
 
 		if wantsJSON(cmd) {
-			repos, totalCount, err := client.GetRepositoriesPaged(startPage-1, pageSize)
+			repos, totalCount, err := client.GetRepositoriesPagedContext(ctx, startPage-1, pageSize)
 			if err != nil {
-				log.Fatalf("Error fetching repositories: %v", err)
+				fatalError("Error fetching repositories", err)
 			}
 			if err := printJSON(map[string]any{
 				"workspace":    cfg.Bitbucket.Workspace,
@@ -48,14 +49,14 @@ var listReposCmd = &cobra.Command{
 				"total_count":  totalCount,
 				"repositories": repos,
 			}); err != nil {
-				log.Fatalf("Error encoding JSON: %v", err)
+				fatalError("Error encoding JSON", err)
 			}
 			return
 		}
 		if wantsTabular(cmd) {
-			repos, _, err := client.GetRepositoriesPaged(startPage-1, pageSize)
+			repos, _, err := client.GetRepositoriesPagedContext(ctx, startPage-1, pageSize)
 			if err != nil {
-				log.Fatalf("Error fetching repositories: %v", err)
+				fatalError("Error fetching repositories", err)
 			}
 			rows := make([][]any, 0, len(repos))
 			for _, repo := range repos {
@@ -66,13 +67,13 @@ var listReposCmd = &cobra.Command{
 		}
 
 		if interactive {
-			runInteractiveMode(client, cfg.Bitbucket.Workspace)
+			runInteractiveMode(ctx, client, cfg.Bitbucket.Workspace)
 			return
 		}
 		if startPage < 1 {
 			log.Fatal("Page numbers are 1-based. Use --page 1 or higher.")
 		}
-		runPagedMode(client, cfg.Bitbucket.Workspace, startPage-1, pageSize)
+		runPagedMode(ctx, client, cfg.Bitbucket.Workspace, startPage-1, pageSize)
 	},
 }
 
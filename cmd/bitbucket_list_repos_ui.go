@@ -1,8 +1,8 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"os"
 	"sort"
 	"strconv"
@@ -14,16 +14,16 @@ import (
 )
 
 type repositoryPager interface {
-	GetRepositoriesPaged(page, size int) ([]bitbucket.Repository, int, error)
+	GetRepositoriesPagedContext(ctx context.Context, page, size int) ([]bitbucket.Repository, int, error)
 }
 
 var makeRaw = term.MakeRaw
 var restoreRaw = term.Restore
 
-func runPagedMode(client repositoryPager, workspace string, page, size int) {
-	repos, totalCount, err := client.GetRepositoriesPaged(page, size)
+func runPagedMode(ctx context.Context, client repositoryPager, workspace string, page, size int) {
+	repos, totalCount, err := client.GetRepositoriesPagedContext(ctx, page, size)
 	if err != nil {
-		log.Fatalf("Error fetching repositories: %v", err)
+		fatalError("Error fetching repositories", err)
 	}
 
 	if len(repos) == 0 {
@@ -52,7 +52,7 @@ func runPagedMode(client repositoryPager, workspace string, page, size int) {
 //	g: go to page number
 //	s: save (no-op; autosave already happens)
 //	q: quit
-func runInteractiveMode(client repositoryPager, workspace string) {
+func runInteractiveMode(ctx context.Context, client repositoryPager, workspace string) {
 	interactivePageSize := pageSize
 	if pageSize == 20 {
 		fmt.Print("Enter page size (default 10): ")
@@ -83,7 +83,7 @@ func runInteractiveMode(client repositoryPager, workspace string) {
 
 	oldState, err := makeRaw(int(os.Stdin.Fd()))
 	if err != nil {
-		log.Fatalf("Failed to set raw mode: %v", err)
+		fatalError("Failed to set raw mode", err)
 	}
 	defer func() {
 		_ = restoreRaw(int(os.Stdin.Fd()), oldState)
@@ -93,7 +93,7 @@ func runInteractiveMode(client repositoryPager, workspace string) {
 	buf := make([]byte, 3)
 
 	fetchPage := func(page int) ([]bitbucket.Repository, error) {
-		repos, count, err := client.GetRepositoriesPaged(page, interactivePageSize)
+		repos, count, err := client.GetRepositoriesPagedContext(ctx, page, interactivePageSize)
 		if err != nil {
 			return nil, err
 		}
@@ -110,7 +110,7 @@ func runInteractiveMode(client repositoryPager, workspace string) {
 
 	repos, err := fetchPage(currentPage)
 	if err != nil {
-		log.Fatalf("Error fetching repositories: %v", err)
+		fatalError("Error fetching repositories", err)
 	}
 	if len(repos) == 0 {
 		fmt.Printf("No repositories found in workspace '%s'.\n", workspace)
@@ -174,7 +174,7 @@ func runInteractiveMode(client repositoryPager, workspace string) {
 					currentPage++
 					repos, err = fetchPage(currentPage)
 					if err != nil {
-						log.Fatalf("Error fetching repositories: %v", err)
+						fatalError("Error fetching repositories", err)
 					}
 				}
 			case 'D':
@@ -182,7 +182,7 @@ func runInteractiveMode(client repositoryPager, workspace string) {
 					currentPage--
 					repos, err = fetchPage(currentPage)
 					if err != nil {
-						log.Fatalf("Error fetching repositories: %v", err)
+						fatalError("Error fetching repositories", err)
 					}
 				}
 			}
@@ -246,7 +246,7 @@ func runInteractiveMode(client repositoryPager, workspace string) {
 				currentPage = p - 1
 				repos, err = fetchPage(currentPage)
 				if err != nil {
-					log.Fatalf("Error fetching repositories: %v", err)
+					fatalError("Error fetching repositories", err)
 				}
 				selection = 0
 			}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -16,7 +17,7 @@ var (
 )
 
 type workspacePullRequestLister interface {
-	GetWorkspacePullRequestsForUser(username string) ([]bitbucket.PullRequestWithReviewers, error)
+	GetWorkspacePullRequestsForUserContext(ctx context.Context, username string) ([]bitbucket.PullRequestWithReviewers, error)
 }
 
 var myPRsCmd = &cobra.Command{
@@ -30,10 +31,11 @@ Behavior changes:
 - With a slug: requires that slug to be watched.
 - --all-repos still uses workspace-level endpoint (ignores watch list).`,
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
 		jsonOutput := wantsJSON(cmd)
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 
 		// Validate required config
@@ -58,9 +60,9 @@ Behavior changes:
 		client := bitbucket.NewClient(&cfg.Bitbucket)
 
 		if myPRsAllRepos {
-			userPRs, err := getPRsToReviewFromAllRepos(client, cfg.Bitbucket.Username, jsonOutput)
+			userPRs, err := getPRsToReviewFromAllRepos(ctx, client, cfg.Bitbucket.Username, jsonOutput)
 			if err != nil {
-				log.Fatalf("Error fetching pull requests: %v", err)
+				fatalError("Error fetching pull requests", err)
 			}
 			displayPRsToReview(userPRs, "all repositories", true, jsonOutput, cfg.Bitbucket.Workspace)
 			return
@@ -85,9 +87,9 @@ Behavior changes:
 			if _, ok := watched[strings.ToLower(slug)]; !ok {
 				log.Fatalf("Repository '%s' not watched. Add with: devflow bitbucket repo watch add %s", slug, slug)
 			}
-			prs, err := client.GetPullRequestsWithReviewers(slug)
+			prs, err := client.GetPullRequestsWithReviewersContext(ctx, slug)
 			if err != nil {
-				log.Fatalf("Error fetching pull requests: %v", err)
+				fatalError("Error fetching pull requests", err)
 			}
 			userPRs := filterPRsForUser(prs, cfg.Bitbucket.Username, jsonOutput)
 			var prsWithRepo []PRWithRepo
@@ -101,7 +103,7 @@ Behavior changes:
 		// Aggregate across watched
 		var all []PRWithRepo
 		for w := range watched {
-			prs, err := client.GetPullRequestsWithReviewers(w)
+			prs, err := client.GetPullRequestsWithReviewersContext(ctx, w)
 			if err != nil {
 				if !jsonOutput {
 					fmt.Printf("Warning: failed fetching PRs for %s: %v\n", w, err)
@@ -130,7 +132,7 @@ type PRWithRepo struct {
 }
 
 // getPRsToReviewFromAllRepos fetches PRs to review from all repositories using the efficient workspace endpoint
-func getPRsToReviewFromAllRepos(client workspacePullRequestLister, username string, jsonOutput bool) ([]PRWithRepo, error) {
+func getPRsToReviewFromAllRepos(ctx context.Context, client workspacePullRequestLister, username string, jsonOutput bool) ([]PRWithRepo, error) {
 	// Load config to get workspace info
 	cfg, _ := loadConfig()
 	workspace := cfg.Bitbucket.Workspace
@@ -151,7 +153,7 @@ func getPRsToReviewFromAllRepos(client workspacePullRequestLister, username stri
 		}
 	}
 
-	prs, err := client.GetWorkspacePullRequestsForUser(bitbucketUsername)
+	prs, err := client.GetWorkspacePullRequestsForUserContext(ctx, bitbucketUsername)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get workspace PRs for user: %w", err)
 	}
@@ -219,7 +221,7 @@ func displayPRsToReview(prs []PRWithRepo, source string, showRepo bool, jsonOutp
 
 		jsonBytes, err := json.MarshalIndent(output, "", "  ")
 		if err != nil {
-			log.Fatalf("Error marshaling JSON: %v", err)
+			fatalError("Error marshaling JSON", err)
 		}
 		fmt.Println(string(jsonBytes))
 		return
@@ -23,10 +23,11 @@ Behavior:
 - With a repo slug: requires it be watched.
 - Without slug: aggregates across all watched repositories.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
 		jsonOutput := wantsJSON(cmd)
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 
 		if cfg.Bitbucket.Workspace == "" {
@@ -67,9 +68,9 @@ Behavior:
 			if _, ok := watched[key]; !ok {
 				log.Fatalf("Repository '%s' is not watched. Add with: devflow bitbucket repo watch add %s", slug, slug)
 			}
-			prs, err := client.GetParticipatingPullRequests(slug, username)
+			prs, err := client.GetParticipatingPullRequestsContext(ctx, slug, username)
 			if err != nil {
-				log.Fatalf("Error fetching participating pull requests: %v", err)
+				fatalError("Error fetching participating pull requests", err)
 			}
 
 			if jsonOutput {
@@ -89,7 +90,7 @@ Behavior:
 
 				jsonBytes, err := json.MarshalIndent(output, "", "  ")
 				if err != nil {
-					log.Fatalf("Error marshaling JSON: %v", err)
+					fatalError("Error marshaling JSON", err)
 				}
 				fmt.Println(string(jsonBytes))
 				return
@@ -116,7 +117,7 @@ Behavior:
 		var total, printed int
 
 		for w := range watched {
-			prs, err := client.GetParticipatingPullRequests(w, username)
+			prs, err := client.GetParticipatingPullRequestsContext(ctx, w, username)
 			if err != nil {
 				if !jsonOutput {
 					fmt.Printf("Warning: failed to fetch '%s': %v\n", w, err)
@@ -159,7 +160,7 @@ Behavior:
 
 			jsonBytes, err := json.MarshalIndent(output, "", "  ")
 			if err != nil {
-				log.Fatalf("Error marshaling JSON: %v", err)
+				fatalError("Error marshaling JSON", err)
 			}
 			fmt.Println(string(jsonBytes))
 			return
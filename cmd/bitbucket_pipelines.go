@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -11,7 +12,7 @@ import (
 )
 
 type pipelineLister interface {
-	GetPipelines(repoSlug string, limit int) ([]bitbucket.Pipeline, error)
+	GetPipelinesContext(ctx context.Context, repoSlug string, limit int) ([]bitbucket.Pipeline, error)
 }
 
 var pipelinesCmd = &cobra.Command{
@@ -45,7 +46,7 @@ Examples:
 
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 		if cfg.Bitbucket.Workspace == "" {
 			log.Fatal("Bitbucket workspace not configured. Run: devflow config set bitbucket.workspace <workspace>")
@@ -60,9 +61,9 @@ Examples:
 			fmt.Printf("Fetching pipelines for %s/%s...\n\n", cfg.Bitbucket.Workspace, repoSlug)
 		}
 
-		pipelines, err := client.GetPipelines(repoSlug, limit)
+		pipelines, err := client.GetPipelinesContext(commandContext(cmd), repoSlug, limit)
 		if err != nil {
-			log.Fatalf("Error fetching pipelines: %v", err)
+			fatalError("Error fetching pipelines", err)
 		}
 
 		if len(pipelines) == 0 {
@@ -77,7 +78,7 @@ Examples:
 		if jsonOutput {
 			jsonBytes, err := json.MarshalIndent(pipelines, "", "  ")
 			if err != nil {
-				log.Fatalf("Error marshaling JSON: %v", err)
+				fatalError("Error marshaling JSON", err)
 			}
 			fmt.Println(string(jsonBytes))
 			return
@@ -138,13 +139,14 @@ Examples:
   devflow repo pipelines show my-repo 42 --json`,
 	Args: cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
 		repoSlug := args[0]
 		pipelineRef := args[1]
 		jsonOutput := wantsJSON(cmd)
 
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 		if cfg.Bitbucket.Workspace == "" {
 			log.Fatal("Bitbucket workspace not configured. Run: devflow config set bitbucket.workspace <workspace>")
@@ -157,19 +159,19 @@ Examples:
 
 		// If the user passed a plain build number, resolve to a UUID by listing
 		// pipelines and finding the matching one.
-		pipelineUUID, err := resolvePipelineUUID(client, repoSlug, pipelineRef)
+		pipelineUUID, err := resolvePipelineUUID(ctx, client, repoSlug, pipelineRef)
 		if err != nil {
-			log.Fatalf("Error resolving pipeline: %v", err)
+			fatalError("Error resolving pipeline", err)
 		}
 
-		pipeline, err := client.GetPipeline(repoSlug, pipelineUUID)
+		pipeline, err := client.GetPipelineContext(ctx, repoSlug, pipelineUUID)
 		if err != nil {
-			log.Fatalf("Error fetching pipeline: %v", err)
+			fatalError("Error fetching pipeline", err)
 		}
 
-		steps, err := client.GetPipelineSteps(repoSlug, pipelineUUID)
+		steps, err := client.GetPipelineStepsContext(ctx, repoSlug, pipelineUUID)
 		if err != nil {
-			log.Fatalf("Error fetching pipeline steps: %v", err)
+			fatalError("Error fetching pipeline steps", err)
 		}
 
 		if jsonOutput {
@@ -182,7 +184,7 @@ Examples:
 			}
 			jsonBytes, err := json.MarshalIndent(output, "", "  ")
 			if err != nil {
-				log.Fatalf("Error marshaling JSON: %v", err)
+				fatalError("Error marshaling JSON", err)
 			}
 			fmt.Println(string(jsonBytes))
 			return
@@ -266,7 +268,7 @@ Examples:
 
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 		if cfg.Bitbucket.Workspace == "" {
 			log.Fatal("Bitbucket workspace not configured. Run: devflow config set bitbucket.workspace <workspace>")
@@ -277,13 +279,13 @@ Examples:
 
 		client := bitbucket.NewClient(&cfg.Bitbucket)
 
-		logOutput, err := client.GetPipelineStepLog(repoSlug, pipelineUUID, stepUUID)
+		logOutput, err := client.GetPipelineStepLogContext(commandContext(cmd), repoSlug, pipelineUUID, stepUUID)
 		if err != nil {
-			log.Fatalf("Error fetching step log: %v", err)
+			fatalError("Error fetching step log", err)
 		}
 		if wantsJSON(cmd) {
 			if err := printJSON(map[string]string{"repository": repoSlug, "pipeline_uuid": pipelineUUID, "step_uuid": stepUUID, "log": logOutput}); err != nil {
-				log.Fatalf("Error encoding JSON: %v", err)
+				fatalError("Error encoding JSON", err)
 			}
 			return
 		}
@@ -304,7 +306,7 @@ func init() {
 
 // resolvePipelineUUID accepts either a UUID (contains "{" or "-") or a build
 // number string and returns the pipeline UUID.
-func resolvePipelineUUID(client pipelineLister, repoSlug, ref string) (string, error) {
+func resolvePipelineUUID(ctx context.Context, client pipelineLister, repoSlug, ref string) (string, error) {
 	// If it looks like a UUID (contains braces or multiple hyphens), use it directly.
 	if len(ref) > 10 && (ref[0] == '{' || countRune(ref, '-') >= 4) {
 		return ref, nil
@@ -318,7 +320,7 @@ func resolvePipelineUUID(client pipelineLister, repoSlug, ref string) (string, e
 	}
 
 	// Fetch recent pipelines and match by build number.
-	pipelines, err := client.GetPipelines(repoSlug, 100)
+	pipelines, err := client.GetPipelinesContext(ctx, repoSlug, 100)
 	if err != nil {
 		return "", fmt.Errorf("fetching pipelines to resolve build number: %w", err)
 	}
@@ -17,6 +17,7 @@ var addCommentCmd = &cobra.Command{
 	Long:    `Create a new comment on a specific pull request. Use --file and --line to post an inline comment anchored to a specific location in the diff.`,
 	Args:    cobra.MinimumNArgs(3),
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
 		jsonOutput := wantsJSON(cmd)
 		filePath, _ := cmd.Flags().GetString("file")
 		line, _ := cmd.Flags().GetInt("line")
@@ -45,7 +46,7 @@ var addCommentCmd = &cobra.Command{
 		// Load configuration
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 
 		// Validate required config
@@ -65,12 +66,12 @@ var addCommentCmd = &cobra.Command{
 		// Create comment (inline or top-level)
 		var comment *bitbucket.Comment
 		if filePath != "" {
-			comment, err = client.CreatePullRequestInlineComment(repoSlug, prID, content, filePath, line)
+			comment, err = client.CreatePullRequestInlineCommentContext(ctx, repoSlug, prID, content, filePath, line)
 		} else {
-			comment, err = client.CreatePullRequestComment(repoSlug, prID, content)
+			comment, err = client.CreatePullRequestCommentContext(ctx, repoSlug, prID, content)
 		}
 		if err != nil {
-			log.Fatalf("Error creating pull request comment: %v", err)
+			fatalError("Error creating pull request comment", err)
 		}
 
 		if jsonOutput {
@@ -88,7 +89,7 @@ var addCommentCmd = &cobra.Command{
 
 			jsonBytes, err := json.MarshalIndent(output, "", "  ")
 			if err != nil {
-				log.Fatalf("Error marshaling JSON: %v", err)
+				fatalError("Error marshaling JSON", err)
 			}
 			fmt.Println(string(jsonBytes))
 			return
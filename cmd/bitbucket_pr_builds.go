@@ -20,6 +20,7 @@ var buildsCmd = &cobra.Command{
 Displays per-commit status including state, key/name, and URL.`,
 	Args: cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
 		jsonOutput := wantsJSON(cmd)
 		repoSlug := args[0]
 		prIDStr := args[1]
@@ -31,7 +32,7 @@ Displays per-commit status including state, key/name, and URL.`,
 
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 		if cfg.Bitbucket.Workspace == "" {
 			log.Fatal("Bitbucket workspace not configured. Run: devflow config set bitbucket.workspace <workspace>")
@@ -45,9 +46,9 @@ Displays per-commit status including state, key/name, and URL.`,
 		if !jsonOutput {
 			fmt.Printf("Fetching commits for PR #%d in %s...\n", prID, repoSlug)
 		}
-		commits, err := client.GetPullRequestCommits(repoSlug, prID)
+		commits, err := client.GetPullRequestCommitsContext(ctx, repoSlug, prID)
 		if err != nil {
-			log.Fatalf("Error fetching pull request commits: %v", err)
+			fatalError("Error fetching pull request commits", err)
 		}
 		if len(commits) == 0 {
 			if jsonOutput {
@@ -64,7 +65,7 @@ Displays per-commit status including state, key/name, and URL.`,
 				}
 				jsonBytes, err := json.MarshalIndent(output, "", "  ")
 				if err != nil {
-					log.Fatalf("Error marshaling JSON output: %v", err)
+					fatalError("Error marshaling JSON output", err)
 				}
 				fmt.Println(string(jsonBytes))
 			} else {
@@ -93,7 +94,7 @@ Displays per-commit status including state, key/name, and URL.`,
 				shortHash = shortHash[:12]
 			}
 
-			statuses, err := client.GetCommitStatuses(repoSlug, commit.Hash)
+			statuses, err := client.GetCommitStatusesContext(ctx, repoSlug, commit.Hash)
 			if err != nil && !jsonOutput {
 				fmt.Printf("  Warning: Failed to fetch statuses: %v\n\n", err)
 				continue
@@ -156,7 +157,7 @@ Displays per-commit status including state, key/name, and URL.`,
 
 			jsonBytes, err := json.MarshalIndent(output, "", "  ")
 			if err != nil {
-				log.Fatalf("Error marshaling JSON: %v", err)
+				fatalError("Error marshaling JSON", err)
 			}
 			fmt.Println(string(jsonBytes))
 		}
@@ -36,7 +36,7 @@ var commentReplyCmd = &cobra.Command{
 		// Load configuration
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 
 		// Validate required config
@@ -54,9 +54,9 @@ var commentReplyCmd = &cobra.Command{
 		client := bitbucket.NewClient(&cfg.Bitbucket)
 
 		// Reply to comment
-		comment, err := client.ReplyToPullRequestComment(repoSlug, prID, threadID, message)
+		comment, err := client.ReplyToPullRequestCommentContext(commandContext(cmd), repoSlug, prID, threadID, message)
 		if err != nil {
-			log.Fatalf("Error replying to comment: %v", err)
+			fatalError("Error replying to comment", err)
 		}
 
 		if jsonOutput {
@@ -76,7 +76,7 @@ var commentReplyCmd = &cobra.Command{
 
 			jsonBytes, err := json.MarshalIndent(output, "", "  ")
 			if err != nil {
-				log.Fatalf("Error marshaling JSON: %v", err)
+				fatalError("Error marshaling JSON", err)
 			}
 			fmt.Println(string(jsonBytes))
 			return
@@ -30,7 +30,7 @@ var prCommentsCmd = &cobra.Command{
 		// Load configuration
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 
 		// Validate required config
@@ -48,9 +48,9 @@ var prCommentsCmd = &cobra.Command{
 		client := bitbucket.NewClient(&cfg.Bitbucket)
 
 		// Get comments
-		comments, err := client.GetPullRequestComments(repoSlug, prID)
+		comments, err := client.GetPullRequestCommentsContext(commandContext(cmd), repoSlug, prID)
 		if err != nil {
-			log.Fatalf("Error fetching pull request comments: %v", err)
+			fatalError("Error fetching pull request comments", err)
 		}
 
 		// Organize comments into threads
@@ -75,7 +75,7 @@ var prCommentsCmd = &cobra.Command{
 
 			jsonBytes, err := json.MarshalIndent(output, "", "  ")
 			if err != nil {
-				log.Fatalf("Error marshaling JSON: %v", err)
+				fatalError("Error marshaling JSON", err)
 			}
 			fmt.Println(string(jsonBytes))
 			return
@@ -29,7 +29,7 @@ var prDiffCmd = &cobra.Command{
 		// Load configuration
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 
 		// Validate required config
@@ -47,9 +47,9 @@ var prDiffCmd = &cobra.Command{
 		client := bitbucket.NewClient(&cfg.Bitbucket)
 
 		// Get diff
-		diff, err := client.GetPullRequestDiff(repoSlug, prID)
+		diff, err := client.GetPullRequestDiffContext(commandContext(cmd), repoSlug, prID)
 		if err != nil {
-			log.Fatalf("Error fetching pull request diff: %v", err)
+			fatalError("Error fetching pull request diff", err)
 		}
 
 		if jsonOutput {
@@ -67,7 +67,7 @@ var prDiffCmd = &cobra.Command{
 
 			jsonBytes, err := json.MarshalIndent(output, "", "  ")
 			if err != nil {
-				log.Fatalf("Error marshaling JSON: %v", err)
+				fatalError("Error marshaling JSON", err)
 			}
 			fmt.Println(string(jsonBytes))
 			return
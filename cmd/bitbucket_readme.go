@@ -20,7 +20,7 @@ var readmeCmd = &cobra.Command{
 
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 		if cfg.Bitbucket.Workspace == "" {
 			log.Fatal("Bitbucket workspace not configured. Run: devflow config set bitbucket.workspace <workspace>")
@@ -33,13 +33,13 @@ var readmeCmd = &cobra.Command{
 		}
 
 		client := bitbucket.NewClient(&cfg.Bitbucket)
-		filename, contents, err := client.GetRepositoryReadme(repoSlug)
+		filename, contents, err := client.GetRepositoryReadmeContext(commandContext(cmd), repoSlug)
 		if err != nil {
-			log.Fatalf("%v", err)
+			fatalError("Error fetching README", err)
 		}
 		if wantsJSON(cmd) {
 			if err := printJSON(map[string]string{"repository": repoSlug, "filename": filename, "contents": contents}); err != nil {
-				log.Fatalf("Error encoding JSON: %v", err)
+				fatalError("Error encoding JSON", err)
 			}
 			return
 		}
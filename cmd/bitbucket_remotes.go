@@ -6,7 +6,6 @@ import (
 	"regexp"
 	"strings"
 
-	"devflow/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -42,9 +41,9 @@ Slug Tips:
 
 		repoSlug := deriveSlug(args[0])
 
-		cfg, err := config.Load()
+		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 		if cfg.Bitbucket.Workspace == "" {
 			log.Fatal("Bitbucket workspace not configured. Run: devflow config set bitbucket.workspace <workspace>")
@@ -56,7 +55,7 @@ Slug Tips:
 		sshURL := fmt.Sprintf("git@bitbucket.org:%s/%s.git", workspace, repoSlug)
 		if wantsJSON(cmd) {
 			if err := printJSON(map[string]string{"repository": workspace + "/" + repoSlug, "https": httpsURL, "ssh": sshURL}); err != nil {
-				log.Fatalf("Error encoding JSON: %v", err)
+				fatalError("Error encoding JSON", err)
 			}
 			return
 		}
@@ -32,7 +32,7 @@ Examples:
 		// Load configuration
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 
 		if cfg.Bitbucket.Workspace == "" {
@@ -48,13 +48,13 @@ Examples:
 		}
 		re, err := regexp.Compile(pattern)
 		if err != nil {
-			log.Fatalf("Invalid regex: %v", err)
+			fatalError("Invalid regex", err)
 		}
 
 		client := bitbucket.NewClient(&cfg.Bitbucket)
-		repos, err := client.GetRepositories()
+		repos, err := client.GetRepositoriesContext(commandContext(cmd))
 		if err != nil {
-			log.Fatalf("Error fetching repositories: %v", err)
+			fatalError("Error fetching repositories", err)
 		}
 
 		var matches []bitbucket.Repository
@@ -73,7 +73,7 @@ Examples:
 		sort.Slice(matches, func(i, j int) bool { return strings.ToLower(matches[i].Name) < strings.ToLower(matches[j].Name) })
 		if wantsJSON(cmd) {
 			if err := printJSON(matches); err != nil {
-				log.Fatalf("Error encoding JSON: %v", err)
+				fatalError("Error encoding JSON", err)
 			}
 			return
 		}
@@ -42,12 +42,12 @@ Reusing the same --key upserts (updates) the existing status.`,
 		description, _ := cmd.Flags().GetString("description")
 
 		if err := validateSetStatusInputs(state, key, name, urlStr); err != nil {
-			log.Fatalf("Input validation error: %v", err)
+			fatalError("Input validation error", err)
 		}
 
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 		if cfg.Bitbucket.Workspace == "" {
 			log.Fatal("Bitbucket workspace not configured. Run: devflow config set bitbucket.workspace <workspace>")
@@ -61,9 +61,9 @@ Reusing the same --key upserts (updates) the existing status.`,
 			fmt.Printf("Setting status for commit %s in %s...\n", shortHash(commitHash), repoSlug)
 		}
 
-		st, err := client.SetCommitStatus(repoSlug, commitHash, state, key, name, urlStr, description)
+		st, err := client.SetCommitStatusContext(commandContext(cmd), repoSlug, commitHash, state, key, name, urlStr, description)
 		if err != nil {
-			log.Fatalf("Failed to set commit status: %v", err)
+			fatalError("Failed to set commit status", err)
 		}
 
 		if jsonOutput {
@@ -81,7 +81,7 @@ Reusing the same --key upserts (updates) the existing status.`,
 
 			jsonBytes, err := json.MarshalIndent(output, "", "  ")
 			if err != nil {
-				log.Fatalf("Error marshaling JSON: %v", err)
+				fatalError("Error marshaling JSON", err)
 			}
 			fmt.Println(string(jsonBytes))
 			return
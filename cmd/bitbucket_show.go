@@ -18,6 +18,7 @@ var showPRCmd = &cobra.Command{
 	Long:    `Display comprehensive details about a specific pull request including description, author, branches, reviewers, and status. Use --diff to show file changes.`,
 	Args:    cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
 		showDiff, _ := cmd.Flags().GetBool("diff")
 		jsonOutput := wantsJSON(cmd)
 		repoSlug := args[0]
@@ -31,7 +32,7 @@ var showPRCmd = &cobra.Command{
 		// Load configuration
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 
 		// Validate required config
@@ -49,9 +50,9 @@ var showPRCmd = &cobra.Command{
 		client := bitbucket.NewClient(&cfg.Bitbucket)
 
 		// Get pull request details
-		pr, err := client.GetPullRequestDetails(repoSlug, prID)
+		pr, err := client.GetPullRequestDetailsContext(ctx, repoSlug, prID)
 		if err != nil {
-			log.Fatalf("Error fetching pull request details: %v", err)
+			fatalError("Error fetching pull request details", err)
 		}
 
 		if jsonOutput {
@@ -69,16 +70,16 @@ var showPRCmd = &cobra.Command{
 			}
 
 			if showDiff {
-				diff, err := client.GetPullRequestDiff(repoSlug, prID)
+				diff, err := client.GetPullRequestDiffContext(ctx, repoSlug, prID)
 				if err != nil {
-					log.Fatalf("Error fetching diff: %v", err)
+					fatalError("Error fetching diff", err)
 				}
 				output.Diff = diff
 			}
 
 			jsonBytes, err := json.MarshalIndent(output, "", "  ")
 			if err != nil {
-				log.Fatalf("Error marshaling JSON: %v", err)
+				fatalError("Error marshaling JSON", err)
 			}
 			fmt.Println(string(jsonBytes))
 			return
@@ -86,9 +87,9 @@ var showPRCmd = &cobra.Command{
 		if wantsTabular(cmd) {
 			rows := [][2]string{{"Repository", cfg.Bitbucket.Workspace + "/" + repoSlug}, {"ID", strconv.Itoa(pr.ID)}, {"Title", pr.Title}, {"State", pr.State}, {"Author", pr.Author.DisplayName}, {"Source", pr.Source.Branch.Name}, {"Target", pr.Destination.Branch.Name}, {"Created", pr.CreatedOn}, {"Updated", pr.UpdatedOn}, {"URL", fmt.Sprintf("https://bitbucket.org/%s/%s/pull-requests/%d", cfg.Bitbucket.Workspace, repoSlug, pr.ID)}}
 			if showDiff {
-				diff, err := client.GetPullRequestDiff(repoSlug, prID)
+				diff, err := client.GetPullRequestDiffContext(ctx, repoSlug, prID)
 				if err != nil {
-					log.Fatalf("Error fetching diff: %v", err)
+					fatalError("Error fetching diff", err)
 				}
 				rows = append(rows, [2]string{"Diff", diff})
 			}
@@ -101,7 +102,7 @@ var showPRCmd = &cobra.Command{
 
 		if showDiff {
 			fmt.Println(strings.Repeat("-", 80))
-			diff, err := client.GetPullRequestDiff(repoSlug, prID)
+			diff, err := client.GetPullRequestDiffContext(ctx, repoSlug, prID)
 			if err != nil {
 				fmt.Printf("Error fetching diff: %v\n", err)
 			} else {
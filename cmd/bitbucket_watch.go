@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"log"
 	"sort"
 	"strings"
 
@@ -21,11 +20,11 @@ var watchListCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("load config: %v", err)
+			fatalError("load config", err)
 		}
 		if wantsJSON(cmd) {
 			if err := printJSON(cfg.Bitbucket.WatchedRepos); err != nil {
-				log.Fatalf("encode watched repositories: %v", err)
+				fatalError("encode watched repositories", err)
 			}
 			return
 		}
@@ -68,7 +67,7 @@ var watchToggleCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("load config: %v", err)
+			fatalError("load config", err)
 		}
 		set := make(map[string]struct{}, len(cfg.Bitbucket.WatchedRepos))
 		for _, r := range cfg.Bitbucket.WatchedRepos {
@@ -87,7 +86,7 @@ var watchToggleCmd = &cobra.Command{
 		if changed {
 			cfg.Bitbucket.WatchedRepos = setToSortedSlice(set)
 			if err := saveConfig(cfg); err != nil {
-				log.Fatalf("save config: %v", err)
+				fatalError("save config", err)
 			}
 		}
 		printWatchedSummary(cfg.Bitbucket.WatchedRepos)
@@ -97,7 +96,7 @@ var watchToggleCmd = &cobra.Command{
 func modifyWatched(add []string, remove []string) {
 	cfg, err := loadConfig()
 	if err != nil {
-		log.Fatalf("load config: %v", err)
+		fatalError("load config", err)
 	}
 
 	set := make(map[string]struct{}, len(cfg.Bitbucket.WatchedRepos))
@@ -114,7 +113,7 @@ func modifyWatched(add []string, remove []string) {
 
 	cfg.Bitbucket.WatchedRepos = setToSortedSlice(set)
 	if err := saveConfig(cfg); err != nil {
-		log.Fatalf("save config: %v", err)
+		fatalError("save config", err)
 	}
 	printWatchedSummary(cfg.Bitbucket.WatchedRepos)
 }
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"devflow/internal/httpx"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect or clear the HTTP response cache",
+	Long: `Inspect or clear the on-disk cache of API responses kept in ~/.devflow/cache.
+
+GET responses are cached per host, URL and credentials. Each endpoint class
+has its own time-to-live (e.g. 15m for repository listings, 1m for pull
+requests); once it expires the response is revalidated with the server using
+its ETag or Last-Modified date. Pass --refresh to any command to bypass cached
+entries, or --no-cache to disable the cache entirely.
+
+Subcommands:
+  stats       Show the number and size of cached responses per host
+  clear       Remove every cached response`,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show HTTP cache statistics",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := cacheDir()
+		stats, err := httpx.CacheStats(dir)
+		if err != nil {
+			return err
+		}
+
+		if wantsJSON(cmd) {
+			if stats == nil {
+				stats = []httpx.CacheHostStats{}
+			}
+			return printJSON(map[string]any{"dir": dir, "hosts": stats})
+		}
+		if wantsTabular(cmd) {
+			rows := make([][]any, 0, len(stats))
+			for _, host := range stats {
+				rows = append(rows, []any{host.Host, host.Entries, formatFileSize(host.Bytes), host.Newest.Format(time.DateTime)})
+			}
+			renderTable([]string{"Host", "Entries", "Size", "Last Stored"}, rows)
+			return nil
+		}
+
+		fmt.Printf("Cache directory: %s\n", dir)
+		if len(stats) == 0 {
+			fmt.Println("The cache is empty.")
+			return nil
+		}
+		var entries int
+		var size int64
+		for _, host := range stats {
+			entries += host.Entries
+			size += host.Bytes
+			fmt.Printf("  %-40s %6d entries  %10s  last stored %s\n", host.Host, host.Entries, formatFileSize(host.Bytes), host.Newest.Format(time.DateTime))
+		}
+		fmt.Printf("Total: %d entries, %s\n", entries, formatFileSize(size))
+		return nil
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all cached HTTP responses",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		removed, err := httpx.ClearCache(cacheDir())
+		if err != nil {
+			return err
+		}
+		if wantsJSON(cmd) {
+			return printJSON(map[string]int{"removed": removed})
+		}
+		if wantsTabular(cmd) {
+			renderKeyValueTable([][2]string{{"Removed", fmt.Sprint(removed)}})
+			return nil
+		}
+		fmt.Printf("🗑️  Removed %d cached responses\n", removed)
+		return nil
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
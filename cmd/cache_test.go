@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"devflow/internal/httpx"
+)
+
+func TestCacheCommands(t *testing.T) {
+	origCacheDir := cacheDir
+	t.Cleanup(func() { cacheDir = origCacheDir })
+	dir := t.TempDir()
+	cacheDir = func() string { return dir }
+
+	out := captureStdout(func() {
+		if err := cacheStatsCmd.RunE(cacheStatsCmd, nil); err != nil {
+			t.Fatalf("stats: %v", err)
+		}
+	})
+	if !strings.Contains(out, "The cache is empty.") {
+		t.Fatalf("unexpected empty stats output: %q", out)
+	}
+
+	hostDir := filepath.Join(dir, "api.bitbucket.org")
+	if err := os.MkdirAll(hostDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.json", "b.json"} {
+		if err := os.WriteFile(filepath.Join(hostDir, name), make([]byte, 1536), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out = captureStdout(func() {
+		if err := cacheStatsCmd.RunE(cacheStatsCmd, nil); err != nil {
+			t.Fatalf("stats: %v", err)
+		}
+	})
+	if !strings.Contains(out, "api.bitbucket.org") || !strings.Contains(out, "Total: 2 entries, 3.0 KB") {
+		t.Fatalf("unexpected stats output: %q", out)
+	}
+
+	out = captureStdout(func() {
+		if err := cacheClearCmd.RunE(cacheClearCmd, nil); err != nil {
+			t.Fatalf("clear: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Removed 2 cached responses") {
+		t.Fatalf("unexpected clear output: %q", out)
+	}
+	if _, err := os.Stat(hostDir); !os.IsNotExist(err) {
+		t.Fatalf("expected the cache directory to be removed, got %v", err)
+	}
+}
+
+func TestCacheMode(t *testing.T) {
+	origNoCache, origRefresh := noCache, refreshCache
+	t.Cleanup(func() { noCache, refreshCache = origNoCache, origRefresh })
+
+	cases := []struct {
+		noCache, refresh bool
+		want             httpx.CacheMode
+	}{
+		{false, false, httpx.CacheNormal},
+		{false, true, httpx.CacheRefresh},
+		{true, false, httpx.CacheOff},
+	}
+	for _, tc := range cases {
+		noCache, refreshCache = tc.noCache, tc.refresh
+		if got := cacheMode(); got != tc.want {
+			t.Fatalf("cacheMode() with no-cache=%v refresh=%v = %v, want %v", tc.noCache, tc.refresh, got, tc.want)
+		}
+	}
+}
@@ -323,14 +323,16 @@ func TestPrCommentsCmdTextAndJSON(t *testing.T) {
 
 func TestConfigGetAndSetCommands(t *testing.T) {
 	origLoad := loadConfig
+	origLoadForEdit := loadConfigForEdit
 	origSave := saveConfig
 	defer func() {
 		loadConfig = origLoad
+		loadConfigForEdit = origLoadForEdit
 		saveConfig = origSave
 	}()
 
 	var saved *config.Config
-	loadConfig = func() (*config.Config, error) {
+	loadConfigForEdit = func() (*config.Config, error) {
 		return &config.Config{}, nil
 	}
 	saveConfig = func(cfg *config.Config) error {
@@ -508,10 +510,14 @@ func TestJenkinsBuildsCmd(t *testing.T) {
 func TestShowIssueCmd(t *testing.T) {
 	host := "jira.example"
 	httpx.RegisterTestServer(host, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/api/3/field" {
+			_, _ = w.Write([]byte(`[{"id":"customfield_10001","name":"Team","custom":true,"schema":{"type":"team","custom":"com.atlassian.jira.plugin.system.customfieldtypes:atlassian-team"}}]`))
+			return
+		}
 		if r.URL.Path != "/rest/api/3/issue/ABC-1" {
 			t.Fatalf("unexpected path: %s", r.URL.Path)
 		}
-		if got := r.URL.Query().Get("fields"); !strings.Contains(got, "summary") || !strings.Contains(got, "comment") {
+		if got := r.URL.Query().Get("fields"); !strings.Contains(got, "summary") || !strings.Contains(got, "comment") || !strings.Contains(got, "customfield_10001") {
 			t.Fatalf("unexpected fields query: %s", got)
 		}
 		issue := jira.IssueDetails{Key: "ABC-1"}
@@ -522,8 +528,6 @@ func TestShowIssueCmd(t *testing.T) {
 		issue.Fields.Reporter.DisplayName = "Bob"
 		issue.Fields.Created = "2026-07-01T10:00:00Z"
 		issue.Fields.Updated = "2026-07-02T10:00:00Z"
-		issue.Fields.TeamAssigned.Name = "Platform"
-		issue.Fields.TeamAssigned.ID = "team-1"
 		issue.Fields.Description = map[string]any{"type": "doc", "content": []any{map[string]any{"type": "paragraph", "content": []any{map[string]any{"type": "text", "text": "Hello"}}}}}
 		issue.Fields.Comment.Comments = []jira.Comment{
 			{
@@ -535,7 +539,11 @@ func TestShowIssueCmd(t *testing.T) {
 			},
 		}
 		issue.Fields.Attachment = []jira.Attachment{{Filename: "log.txt", Size: 1024}}
-		_ = json.NewEncoder(w).Encode(issue)
+		data, _ := json.Marshal(issue)
+		var raw map[string]any
+		_ = json.Unmarshal(data, &raw)
+		raw["fields"].(map[string]any)["customfield_10001"] = map[string]string{"id": "team-1", "name": "Platform"}
+		_ = json.NewEncoder(w).Encode(raw)
 	}))
 	t.Cleanup(func() {
 		httpx.UnregisterTestServer(host)
@@ -545,9 +553,10 @@ func TestShowIssueCmd(t *testing.T) {
 	loadConfig = func() (*config.Config, error) {
 		return &config.Config{
 			Jira: config.JiraConfig{
-				URL:      "https://" + host,
-				Username: "alice",
-				Token:    "token",
+				URL:        "https://" + host,
+				Username:   "alice",
+				Token:      "token",
+				Deployment: "cloud",
 			},
 		}, nil
 	}
@@ -18,4 +18,5 @@ func init() {
 	configCmd.AddCommand(setConfigCmd)
 	configCmd.AddCommand(getConfigCmd)
 	configCmd.AddCommand(setupConfigCmd)
+	configCmd.AddCommand(profileCmd)
 }
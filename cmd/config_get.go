@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -8,11 +9,17 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var showConfigOrigin bool
+
 var getConfigCmd = &cobra.Command{
 	Use:   "get [key]",
 	Short: "Get configuration value",
-	Long:  `Get a configuration value`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Get a configuration value.
+
+Values are layered: built-in defaults, the global config file, a repo-local
+.devflow.json, then DEVFLOW_<SECTION>_<KEY> environment variables. Use
+--show-origin to see which layer supplied the value.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
 
@@ -29,22 +36,35 @@ var getConfigCmd = &cobra.Command{
 			fmt.Printf("Error getting config: %v\n", err)
 			return
 		}
+		origin := cfg.Origin(key)
 		if wantsJSON(cmd) {
-			if err := printJSON(map[string]string{"key": key, "value": value}); err != nil {
+			output := map[string]string{"key": key, "value": value}
+			if showConfigOrigin {
+				output["origin"] = origin
+			}
+			if err := printJSON(output); err != nil {
 				fmt.Printf("Error encoding JSON: %v\n", err)
 			}
 			return
 		}
 		if wantsTabular(cmd) {
+			if showConfigOrigin {
+				renderTable([]string{"Key", "Value", "Origin"}, [][]any{{key, value, origin}})
+				return
+			}
 			renderKeyValueTable([][2]string{{key, value}})
 			return
 		}
 
 		if value == "" {
-			fmt.Printf("No value set for %s\n", key)
+			fmt.Printf("No value set for %s", key)
 		} else {
-			fmt.Printf("%s = %s\n", key, value)
+			fmt.Printf("%s = %s", key, value)
+		}
+		if showConfigOrigin {
+			fmt.Printf(" (%s)", origin)
 		}
+		fmt.Println()
 	},
 }
 
@@ -66,6 +86,20 @@ func getConfigValue(cfg *config.Config, key string) (string, error) {
 			return cfg.Jira.Username, nil
 		case "token":
 			return cfg.Jira.Token, nil
+		case "token_ref":
+			return cfg.Jira.TokenRef, nil
+		case "project_key":
+			return cfg.Jira.ProjectKey, nil
+		case "board_id":
+			return cfg.Jira.BoardID, nil
+		case "deployment":
+			return cfg.Jira.Deployment, nil
+		case "saved_queries":
+			if len(cfg.Jira.SavedQueries) == 0 {
+				return "", nil
+			}
+			data, err := json.Marshal(cfg.Jira.SavedQueries)
+			return string(data), err
 		default:
 			return "", fmt.Errorf("unknown jira field: %s", field)
 		}
@@ -79,10 +113,53 @@ func getConfigValue(cfg *config.Config, key string) (string, error) {
 			return cfg.Bitbucket.BitbucketUser, nil
 		case "token":
 			return cfg.Bitbucket.Token, nil
+		case "token_ref":
+			return cfg.Bitbucket.TokenRef, nil
+		case "default_reviewers":
+			return strings.Join(cfg.Bitbucket.DefaultReviewers, ","), nil
 		default:
 			return "", fmt.Errorf("unknown bitbucket field: %s", field)
 		}
+	case "jenkins":
+		switch field {
+		case "url":
+			return cfg.Jenkins.URL, nil
+		case "username":
+			return cfg.Jenkins.Username, nil
+		case "token":
+			return cfg.Jenkins.Token, nil
+		case "token_ref":
+			return cfg.Jenkins.TokenRef, nil
+		default:
+			return "", fmt.Errorf("unknown jenkins field: %s", field)
+		}
+	case "http":
+		switch field {
+		case "max_retries":
+			return formatOptionalInt(cfg.HTTP.MaxRetries), nil
+		case "retry_base_delay":
+			return cfg.HTTP.RetryBaseDelay, nil
+		case "retry_max_delay":
+			return cfg.HTTP.RetryMaxDelay, nil
+		case "retry_budget":
+			return formatOptionalInt(cfg.HTTP.RetryBudget), nil
+		default:
+			return "", fmt.Errorf("unknown http field: %s", field)
+		}
+	case "secrets":
+		switch field {
+		case "backend":
+			return cfg.Secrets.Backend, nil
+		case "helper":
+			return cfg.Secrets.Helper, nil
+		default:
+			return "", fmt.Errorf("unknown secrets field: %s", field)
+		}
 	default:
 		return "", fmt.Errorf("unknown section: %s", section)
 	}
 }
+
+func init() {
+	getConfigCmd.Flags().BoolVar(&showConfigOrigin, "show-origin", false, "Show which configuration layer supplied the value")
+}
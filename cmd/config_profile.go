@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage configuration profiles",
+	Long: `Manage named configuration profiles.
+
+Each profile holds its own Jira, Bitbucket and Jenkins settings. The active
+profile is chosen by --profile, then DEVFLOW_PROFILE, then the profile
+selected with 'devflow config profile use', falling back to "default".
+
+Subcommands:
+  list        List profiles and mark the active one
+  use         Make a profile the active profile
+  copy        Copy a profile to a new name
+  delete      Delete a profile`,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configuration profiles",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names, err := listProfiles()
+		if err != nil {
+			return fmt.Errorf("list profiles: %w", err)
+		}
+		active, err := activeProfile()
+		if err != nil {
+			return fmt.Errorf("resolve active profile: %w", err)
+		}
+
+		if wantsJSON(cmd) {
+			return printJSON(map[string]any{"active": active, "profiles": names})
+		}
+		if wantsTabular(cmd) {
+			rows := make([][]any, 0, len(names))
+			for _, name := range names {
+				rows = append(rows, []any{name, name == active})
+			}
+			renderTable([]string{"Profile", "Active"}, rows)
+			return nil
+		}
+
+		for _, name := range names {
+			marker := "  "
+			if name == active {
+				marker = "* "
+			}
+			fmt.Printf("%s%s\n", marker, name)
+		}
+		return nil
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the active configuration profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := useProfile(args[0]); err != nil {
+			return err
+		}
+		if wantsJSON(cmd) {
+			return printJSON(map[string]string{"active": args[0]})
+		}
+		if wantsTabular(cmd) {
+			renderKeyValueTable([][2]string{{"Active", args[0]}})
+			return nil
+		}
+		fmt.Printf("✅ Active profile is now %q\n", args[0])
+		return nil
+	},
+}
+
+var profileCopyCmd = &cobra.Command{
+	Use:   "copy <source> <destination>",
+	Short: "Copy a configuration profile",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := copyProfile(args[0], args[1]); err != nil {
+			return err
+		}
+		if wantsJSON(cmd) {
+			return printJSON(map[string]string{"source": args[0], "destination": args[1]})
+		}
+		if wantsTabular(cmd) {
+			renderKeyValueTable([][2]string{{"Source", args[0]}, {"Destination", args[1]}})
+			return nil
+		}
+		fmt.Printf("✅ Copied profile %q to %q\n", args[0], args[1])
+		return nil
+	},
+}
+
+var profileDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a configuration profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := deleteProfile(args[0]); err != nil {
+			return err
+		}
+		if wantsJSON(cmd) {
+			return printJSON(map[string]any{"profile": args[0], "deleted": true})
+		}
+		if wantsTabular(cmd) {
+			renderKeyValueTable([][2]string{{"Profile", args[0]}, {"Deleted", "true"}})
+			return nil
+		}
+		fmt.Printf("🗑️  Deleted profile %q\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileCopyCmd)
+	profileCmd.AddCommand(profileDeleteCmd)
+}
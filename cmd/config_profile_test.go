@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"devflow/internal/config"
+)
+
+func TestProfileCommands(t *testing.T) {
+	origList, origActive, origUse, origCopy, origDelete := listProfiles, activeProfile, useProfile, copyProfile, deleteProfile
+	t.Cleanup(func() {
+		listProfiles, activeProfile, useProfile, copyProfile, deleteProfile = origList, origActive, origUse, origCopy, origDelete
+	})
+
+	active := "default"
+	profiles := []string{"default", "sandbox"}
+	listProfiles = func() ([]string, error) { return profiles, nil }
+	activeProfile = func() (string, error) { return active, nil }
+	useProfile = func(name string) error {
+		active = name
+		return nil
+	}
+	var copied [2]string
+	copyProfile = func(src, dst string) error {
+		copied = [2]string{src, dst}
+		return nil
+	}
+	var deleted string
+	deleteProfile = func(name string) error {
+		deleted = name
+		return nil
+	}
+
+	out := captureStdout(func() {
+		if err := profileUseCmd.RunE(profileUseCmd, []string{"sandbox"}); err != nil {
+			t.Fatalf("use: %v", err)
+		}
+	})
+	if active != "sandbox" || !strings.Contains(out, `Active profile is now "sandbox"`) {
+		t.Fatalf("unexpected use output %q (active %q)", out, active)
+	}
+
+	out = captureStdout(func() {
+		if err := profileListCmd.RunE(profileListCmd, nil); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+	if !strings.Contains(out, "* sandbox") || !strings.Contains(out, "  default") {
+		t.Fatalf("unexpected list output: %q", out)
+	}
+
+	captureStdout(func() {
+		if err := profileCopyCmd.RunE(profileCopyCmd, []string{"sandbox", "staging"}); err != nil {
+			t.Fatalf("copy: %v", err)
+		}
+		if err := profileDeleteCmd.RunE(profileDeleteCmd, []string{"staging"}); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+	})
+	if copied != [2]string{"sandbox", "staging"} || deleted != "staging" {
+		t.Fatalf("unexpected copy/delete calls: %v %q", copied, deleted)
+	}
+}
+
+func TestGetConfigShowOrigin(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{Jira: config.JiraConfig{ProjectKey: "ENG"}})
+	origShowOrigin := showConfigOrigin
+	t.Cleanup(func() { showConfigOrigin = origShowOrigin })
+
+	showConfigOrigin = true
+	out := captureStdout(func() {
+		getConfigCmd.Run(getConfigCmd, []string{"jira.project_key"})
+	})
+	if !strings.Contains(out, "jira.project_key = ENG (default)") {
+		t.Fatalf("unexpected get output: %q", out)
+	}
+}
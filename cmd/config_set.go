@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"devflow/internal/config"
+	"devflow/internal/jira"
 	"github.com/spf13/cobra"
 )
 
@@ -18,7 +22,7 @@ var setConfigCmd = &cobra.Command{
 		value := args[1]
 
 		// Load existing config
-		cfg, err := loadConfig()
+		cfg, err := loadConfigForEdit()
 		if err != nil {
 			fmt.Printf("Error loading config: %v\n", err)
 			return
@@ -46,6 +50,10 @@ var setConfigCmd = &cobra.Command{
 			return
 		}
 
+		if cfg.Profile != "" && cfg.Profile != config.DefaultProfile {
+			fmt.Printf("Setting config %s = %s (profile %s)\n", key, value, cfg.Profile)
+			return
+		}
 		fmt.Printf("Setting config %s = %s\n", key, value)
 	},
 }
@@ -68,6 +76,27 @@ func setConfigValue(cfg *config.Config, key, value string) error {
 			cfg.Jira.Username = value
 		case "token":
 			cfg.Jira.Token = value
+		case "token_ref":
+			cfg.Jira.TokenRef = value
+			cfg.Jira.Token = ""
+		case "project_key":
+			cfg.Jira.ProjectKey = value
+		case "board_id":
+			cfg.Jira.BoardID = value
+		case "deployment":
+			deployment, err := jira.ParseDeployment(value)
+			if err != nil {
+				return err
+			}
+			cfg.Jira.Deployment = string(deployment)
+		case "saved_queries":
+			queries := map[string]string{}
+			if strings.TrimSpace(value) != "" {
+				if err := json.Unmarshal([]byte(value), &queries); err != nil {
+					return fmt.Errorf("saved_queries must be a JSON object of names to JQL: %w", err)
+				}
+			}
+			cfg.Jira.SavedQueries = queries
 		default:
 			return fmt.Errorf("unknown jira field: %s", field)
 		}
@@ -81,9 +110,68 @@ func setConfigValue(cfg *config.Config, key, value string) error {
 			cfg.Bitbucket.BitbucketUser = value
 		case "token":
 			cfg.Bitbucket.Token = value
+		case "token_ref":
+			cfg.Bitbucket.TokenRef = value
+			cfg.Bitbucket.Token = ""
+		case "default_reviewers":
+			cfg.Bitbucket.DefaultReviewers = parseLabels(value)
 		default:
 			return fmt.Errorf("unknown bitbucket field: %s", field)
 		}
+	case "jenkins":
+		switch field {
+		case "url":
+			cfg.Jenkins.URL = value
+		case "username":
+			cfg.Jenkins.Username = value
+		case "token":
+			cfg.Jenkins.Token = value
+		case "token_ref":
+			cfg.Jenkins.TokenRef = value
+			cfg.Jenkins.Token = ""
+		default:
+			return fmt.Errorf("unknown jenkins field: %s", field)
+		}
+	case "http":
+		switch field {
+		case "max_retries":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid http.max_retries %q: expected a number", value)
+			}
+			cfg.HTTP.MaxRetries = n
+		case "retry_base_delay":
+			if _, err := time.ParseDuration(value); err != nil {
+				return fmt.Errorf("invalid http.retry_base_delay %q: %w", value, err)
+			}
+			cfg.HTTP.RetryBaseDelay = value
+		case "retry_max_delay":
+			if _, err := time.ParseDuration(value); err != nil {
+				return fmt.Errorf("invalid http.retry_max_delay %q: %w", value, err)
+			}
+			cfg.HTTP.RetryMaxDelay = value
+		case "retry_budget":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid http.retry_budget %q: expected a number", value)
+			}
+			cfg.HTTP.RetryBudget = n
+		default:
+			return fmt.Errorf("unknown http field: %s", field)
+		}
+	case "secrets":
+		switch field {
+		case "backend":
+			// Tokens already in config.json move to the new backend on save.
+			if err := config.ValidateSecretsBackend(value); err != nil {
+				return err
+			}
+			cfg.Secrets.Backend = value
+		case "helper":
+			cfg.Secrets.Helper = value
+		default:
+			return fmt.Errorf("unknown secrets field: %s", field)
+		}
 	default:
 		return fmt.Errorf("unknown section: %s", section)
 	}
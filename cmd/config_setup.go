@@ -6,6 +6,8 @@ import (
 	"os"
 	"strings"
 
+	"devflow/internal/config"
+	"devflow/internal/jira"
 	"github.com/spf13/cobra"
 )
 
@@ -19,19 +21,39 @@ var setupConfigCmd = &cobra.Command{
 		fmt.Println("")
 
 		// Load existing config
-		cfg, err := loadConfig()
+		cfg, err := loadConfigForEdit()
 		if err != nil {
 			fmt.Printf("Error loading config: %v\n", err)
 			return
 		}
 
+		if cfg.Profile != "" && cfg.Profile != config.DefaultProfile {
+			fmt.Printf("Configuring profile %q\n\n", cfg.Profile)
+		}
+
 		reader := bufio.NewReader(os.Stdin)
 
 		// Configure Jira
 		fmt.Println("=== Jira Configuration ===")
 		cfg.Jira.URL = promptWithDefault(reader, "Jira URL (e.g., https://company.atlassian.net)", cfg.Jira.URL)
-		cfg.Jira.Username = promptWithDefault(reader, "Jira username/email", cfg.Jira.Username)
-		cfg.Jira.Token = promptWithDefault(reader, "Jira API token", cfg.Jira.Token)
+		if cfg.Jira.Deployment == "" && cfg.Jira.URL != "" {
+			if detected, err := jira.NewClient(&cfg.Jira).DetectDeploymentContext(commandContext(cmd)); err == nil {
+				cfg.Jira.Deployment = string(detected)
+			}
+		}
+		if deployment, err := jira.ParseDeployment(promptWithDefault(reader, "Jira deployment (cloud, server or auto)", cfg.Jira.Deployment)); err == nil {
+			cfg.Jira.Deployment = string(deployment)
+		} else {
+			fmt.Printf("%v; using cloud\n", err)
+			cfg.Jira.Deployment = string(jira.DeploymentCloud)
+		}
+		if cfg.Jira.Deployment == string(jira.DeploymentServer) {
+			cfg.Jira.Username = promptWithDefault(reader, "Jira username (leave empty to use a personal access token)", cfg.Jira.Username)
+			cfg.Jira.Token = promptWithDefault(reader, "Jira password or personal access token", cfg.Jira.Token)
+		} else {
+			cfg.Jira.Username = promptWithDefault(reader, "Jira username/email", cfg.Jira.Username)
+			cfg.Jira.Token = promptWithDefault(reader, "Jira API token", cfg.Jira.Token)
+		}
 
 		fmt.Println("")
 
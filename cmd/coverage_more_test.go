@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"strings"
 	"testing"
@@ -20,7 +21,7 @@ type fakeRepositoryPager struct {
 	lastSize   int
 }
 
-func (f *fakeRepositoryPager) GetRepositoriesPaged(page, size int) ([]bitbucket.Repository, int, error) {
+func (f *fakeRepositoryPager) GetRepositoriesPagedContext(ctx context.Context, page, size int) ([]bitbucket.Repository, int, error) {
 	f.pageCount++
 	f.lastPage = page
 	f.lastSize = size
@@ -33,7 +34,7 @@ type fakePipelineLister struct {
 	limitSeen int
 }
 
-func (f *fakePipelineLister) GetPipelines(repoSlug string, limit int) ([]bitbucket.Pipeline, error) {
+func (f *fakePipelineLister) GetPipelinesContext(ctx context.Context, repoSlug string, limit int) ([]bitbucket.Pipeline, error) {
 	f.limitSeen = limit
 	return f.pipelines, f.err
 }
@@ -58,6 +59,18 @@ func TestGetConfigValueAndSetConfigValue(t *testing.T) {
 	if err := setConfigValue(cfg, "jira.url", "https://jira.example"); err != nil {
 		t.Fatalf("setConfigValue jira.url failed: %v", err)
 	}
+	if err := setConfigValue(cfg, "jira.deployment", "DataCenter"); err != nil {
+		t.Fatalf("setConfigValue jira.deployment failed: %v", err)
+	}
+	if err := setConfigValue(cfg, "jira.deployment", "on-prem"); err == nil {
+		t.Fatal("expected an unknown jira.deployment to be rejected")
+	}
+	if jiraUsernameMissing(cfg.Jira) {
+		t.Fatal("expected a server deployment to accept a token without a username")
+	}
+	if !jiraUsernameMissing(config.JiraConfig{URL: "https://acme.atlassian.net"}) || jiraUsernameMissing(config.JiraConfig{URL: "https://jira.corp"}) {
+		t.Fatal("expected an undetected deployment to need a username only on Atlassian URLs")
+	}
 	if err := setConfigValue(cfg, "bitbucket.workspace", "workspace"); err != nil {
 		t.Fatalf("setConfigValue bitbucket.workspace failed: %v", err)
 	}
@@ -67,6 +80,7 @@ func TestGetConfigValueAndSetConfigValue(t *testing.T) {
 
 	cases := map[string]string{
 		"jira.url":                 "https://jira.example",
+		"jira.deployment":          "server",
 		"bitbucket.workspace":      "workspace",
 		"bitbucket.bitbucket_user": "bb-user",
 	}
@@ -138,7 +152,7 @@ func TestRunPagedMode(t *testing.T) {
 	}
 
 	out := captureStdout(func() {
-		runPagedMode(pager, "workspace", 0, 20)
+		runPagedMode(context.Background(), pager, "workspace", 0, 20)
 	})
 	if pager.pageCount != 1 || pager.lastPage != 0 || pager.lastSize != 20 {
 		t.Fatalf("unexpected pager calls: %+v", pager)
@@ -151,7 +165,7 @@ func TestRunPagedMode(t *testing.T) {
 func TestRunPagedMode_Empty(t *testing.T) {
 	pager := &fakeRepositoryPager{}
 	out := captureStdout(func() {
-		runPagedMode(pager, "workspace", 0, 20)
+		runPagedMode(context.Background(), pager, "workspace", 0, 20)
 	})
 	if !strings.Contains(out, "No repositories found in workspace 'workspace'.") {
 		t.Fatalf("unexpected empty paging output: %q", out)
@@ -163,7 +177,7 @@ func TestResolvePipelineUUID(t *testing.T) {
 		pipelines: []bitbucket.Pipeline{{UUID: "{abc-123}", BuildNumber: 42}},
 	}
 
-	got, err := resolvePipelineUUID(lister, "repo", "42")
+	got, err := resolvePipelineUUID(context.Background(), lister, "repo", "42")
 	if err != nil {
 		t.Fatalf("resolvePipelineUUID failed: %v", err)
 	}
@@ -174,11 +188,11 @@ func TestResolvePipelineUUID(t *testing.T) {
 		t.Fatalf("expected limit 100, got %d", lister.limitSeen)
 	}
 
-	if got, err := resolvePipelineUUID(lister, "repo", "{direct-uuid}"); err != nil || got != "{direct-uuid}" {
+	if got, err := resolvePipelineUUID(context.Background(), lister, "repo", "{direct-uuid}"); err != nil || got != "{direct-uuid}" {
 		t.Fatalf("resolvePipelineUUID direct uuid = %q, err=%v", got, err)
 	}
 
-	if _, err := resolvePipelineUUID(lister, "repo", "999"); err == nil || !strings.Contains(err.Error(), "no pipeline found") {
+	if _, err := resolvePipelineUUID(context.Background(), lister, "repo", "999"); err == nil || !strings.Contains(err.Error(), "no pipeline found") {
 		t.Fatalf("expected not-found error, got %v", err)
 	}
 }
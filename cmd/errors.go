@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"devflow/internal/httpx"
+	"github.com/spf13/cobra"
+)
+
+// Process exit codes. Scripts can rely on these to tell failures apart.
+const (
+	exitOK           = 0
+	exitFailure      = 1 // any other error
+	exitUnauthorized = 3 // credentials missing, expired or lacking permission (401/403)
+	exitNotFound     = 4 // the issue, repository, job or build does not exist (404)
+	exitRateLimited  = 5 // still rate limited after retries (429)
+	exitUnavailable  = 6 // the service failed (5xx)
+	exitTimeout      = 7 // --timeout expired
+	exitInterrupted  = 130
+)
+
+// exit terminates the process; tests replace it.
+var exit = os.Exit
+
+// errorOutput and jsonErrorOutput receive error reports in human and JSON
+// formats respectively; tests replace them.
+var errorOutput io.Writer = os.Stderr
+var jsonErrorOutput io.Writer = os.Stdout
+
+// currentCmd is the command being executed, recorded so errors raised deep
+// inside helpers are reported in the selected output format.
+var currentCmd *cobra.Command
+
+// errorReport is the machine-readable error emitted with --format json.
+type errorReport struct {
+	Error errorDetails `json:"error"`
+}
+
+type errorDetails struct {
+	Message     string            `json:"message"`
+	Context     string            `json:"context,omitempty"`
+	Hint        string            `json:"hint,omitempty"`
+	Service     string            `json:"service,omitempty"`
+	Status      int               `json:"status,omitempty"`
+	Method      string            `json:"method,omitempty"`
+	Endpoint    string            `json:"endpoint,omitempty"`
+	Messages    []string          `json:"messages,omitempty"`
+	FieldErrors map[string]string `json:"field_errors,omitempty"`
+	ExitCode    int               `json:"exit_code"`
+}
+
+// reportedError marks an error whose details a command already printed, so
+// Execute only uses it for the exit code.
+type reportedError struct {
+	err error
+}
+
+func (e reportedError) Error() string { return e.err.Error() }
+
+func (e reportedError) Unwrap() error { return e.err }
+
+// ExitCode maps an error to the process exit code.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, context.DeadlineExceeded):
+		return exitTimeout
+	case errors.Is(err, context.Canceled):
+		return exitInterrupted
+	case httpx.IsUnauthorized(err), httpx.IsForbidden(err):
+		return exitUnauthorized
+	case httpx.IsNotFound(err):
+		return exitNotFound
+	case httpx.IsRateLimited(err):
+		return exitRateLimited
+	case httpx.IsServerError(err):
+		return exitUnavailable
+	default:
+		return exitFailure
+	}
+}
+
+// errorHint suggests what to do about an API error.
+func errorHint(err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "The command ran out of time. Retry with a longer --timeout."
+	case httpx.IsUnauthorized(err):
+		return "The credentials were rejected. Check them with: devflow auth status"
+	case httpx.IsForbidden(err):
+		return "The credentials lack permission for this request. Check the token scopes with: devflow auth status"
+	case httpx.IsNotFound(err):
+		return "Check that the key or name is correct and visible to your account."
+	case httpx.IsRateLimited(err):
+		return "The service is rate limiting requests. Wait and try again, or raise http.retry_max_delay."
+	case httpx.IsServerError(err):
+		return "The service is unavailable. Try again later."
+	default:
+		return ""
+	}
+}
+
+// describeError builds the report for err, raised while doing context
+// (e.g. "Error fetching issue details").
+func describeError(context string, err error) errorDetails {
+	details := errorDetails{
+		Message:  err.Error(),
+		Context:  context,
+		Hint:     errorHint(err),
+		ExitCode: ExitCode(err),
+	}
+	var apiErr *httpx.APIError
+	if errors.As(err, &apiErr) {
+		details.Service = apiErr.Service
+		details.Status = apiErr.StatusCode
+		details.Method = apiErr.Method
+		details.Endpoint = apiErr.Endpoint
+		details.Messages = apiErr.Messages
+		details.FieldErrors = apiErr.FieldErrors
+	} else if status := httpx.StatusCode(err); status != 0 {
+		details.Status = status
+	}
+	return details
+}
+
+// reportError prints err for cmd: a JSON error object with --format json,
+// otherwise a concise message and hint on stderr.
+func reportError(cmd *cobra.Command, context string, err error) {
+	details := describeError(context, err)
+	if cmd != nil && wantsJSON(cmd) {
+		_ = encodeJSON(jsonErrorOutput, errorReport{Error: details})
+		return
+	}
+	if context != "" {
+		fmt.Fprintf(errorOutput, "%s: %s\n", context, details.Message)
+	} else {
+		fmt.Fprintf(errorOutput, "Error: %s\n", details.Message)
+	}
+	if details.Hint != "" {
+		fmt.Fprintln(errorOutput, details.Hint)
+	}
+}
+
+// fatalError reports err and exits with the code matching its cause. It
+// replaces log.Fatalf for failures that may come from an API.
+func fatalError(context string, err error) {
+	reportError(currentCmd, context, err)
+	exit(ExitCode(err))
+}
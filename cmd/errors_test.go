@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"devflow/internal/httpx"
+	"github.com/spf13/cobra"
+)
+
+func notFoundError() error {
+	resp := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/rest/api/3/issue/ABC-1"}},
+	}
+	return httpx.NewAPIError(httpx.ServiceJira, resp, []byte(`{"errorMessages":["Issue does not exist"]}`))
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, exitOK},
+		{errors.New("boom"), exitFailure},
+		{notFoundError(), exitNotFound},
+		{fmt.Errorf("wrapped: %w", &httpx.AuthError{StatusCode: http.StatusUnauthorized}), exitUnauthorized},
+		{&httpx.APIError{StatusCode: http.StatusForbidden}, exitUnauthorized},
+		{&httpx.APIError{StatusCode: http.StatusTooManyRequests}, exitRateLimited},
+		{&httpx.APIError{StatusCode: http.StatusServiceUnavailable}, exitUnavailable},
+		{reportedError{errors.Join(errors.New("x"), notFoundError())}, exitNotFound},
+		{fmt.Errorf("failed to make request: %w", context.DeadlineExceeded), exitTimeout},
+		{fmt.Errorf("failed to make request: %w", context.Canceled), exitInterrupted},
+	}
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Fatalf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestFatalErrorReportsConciseMessage(t *testing.T) {
+	var stderr bytes.Buffer
+	origOutput, origExit, origCmd := errorOutput, exit, currentCmd
+	t.Cleanup(func() { errorOutput, exit, currentCmd = origOutput, origExit, origCmd })
+
+	code := -1
+	errorOutput = &stderr
+	exit = func(c int) { code = c }
+	currentCmd = nil
+
+	fatalError("Error fetching issue details", notFoundError())
+	if code != exitNotFound {
+		t.Fatalf("exit code = %d, want %d", code, exitNotFound)
+	}
+	out := stderr.String()
+	if !strings.Contains(out, "Error fetching issue details: jira API returned 404 Not Found for GET /rest/api/3/issue/ABC-1: Issue does not exist") ||
+		!strings.Contains(out, "Check that the key or name is correct") {
+		t.Fatalf("unexpected error output: %q", out)
+	}
+}
+
+func TestReportErrorJSON(t *testing.T) {
+	var stdout bytes.Buffer
+	origOutput := jsonErrorOutput
+	t.Cleanup(func() { jsonErrorOutput = origOutput })
+	jsonErrorOutput = &stdout
+
+	command := &cobra.Command{Use: "show"}
+	command.Flags().String("format", formatDetailed, "")
+	_ = command.Flags().Set("format", formatJSON)
+
+	reportError(command, "Error fetching issue details", notFoundError())
+
+	var report struct {
+		Error struct {
+			Service  string   `json:"service"`
+			Status   int      `json:"status"`
+			Endpoint string   `json:"endpoint"`
+			Messages []string `json:"messages"`
+			ExitCode int      `json:"exit_code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON error %q: %v", stdout.String(), err)
+	}
+	if report.Error.Service != "jira" || report.Error.Status != 404 || report.Error.Endpoint != "/rest/api/3/issue/ABC-1" ||
+		len(report.Error.Messages) != 1 || report.Error.ExitCode != exitNotFound {
+		t.Fatalf("unexpected JSON error: %+v", report.Error)
+	}
+}
+
+func TestTimeoutFlagSetsCommandDeadline(t *testing.T) {
+	origTimeout, origCancel, origCmd, origCacheDir := commandTimeout, cancelTimeout, currentCmd, cacheDir
+	t.Cleanup(func() {
+		commandTimeout, cancelTimeout, currentCmd, cacheDir = origTimeout, origCancel, origCmd, origCacheDir
+		httpx.ConfigureCache("", httpx.CacheOff)
+	})
+	dir := t.TempDir()
+	cacheDir = func() string { return dir }
+
+	command := &cobra.Command{Use: "list"}
+	command.Flags().String("format", formatDetailed, "")
+	if _, ok := commandContext(command).Deadline(); ok {
+		t.Fatal("expected no deadline without --timeout")
+	}
+
+	commandTimeout = time.Minute
+	if err := persistentPreRun(command, nil); err != nil {
+		t.Fatalf("persistentPreRun: %v", err)
+	}
+	deadline, ok := commandContext(command).Deadline()
+	if !ok || time.Until(deadline) > time.Minute {
+		t.Fatalf("expected a deadline within a minute, got %v, %v", deadline, ok)
+	}
+	cancelTimeout()
+	if !errors.Is(commandContext(command).Err(), context.Canceled) {
+		t.Fatal("expected cancelTimeout to release the context")
+	}
+}
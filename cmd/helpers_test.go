@@ -106,6 +106,32 @@ func TestCleanDescriptionAndADF(t *testing.T) {
 	}
 }
 
+func TestRenderedText(t *testing.T) {
+	doc := map[string]any{
+		"type": "doc", "version": 1,
+		"content": []any{
+			map[string]any{"type": "paragraph", "content": []any{
+				map[string]any{"type": "text", "text": "Hello "},
+				map[string]any{"type": "text", "text": "World", "marks": []any{map[string]any{"type": "strong"}}},
+			}},
+			map[string]any{"type": "bulletList", "content": []any{
+				map[string]any{"type": "listItem", "content": []any{
+					map[string]any{"type": "paragraph", "content": []any{map[string]any{"type": "text", "text": "item"}}},
+				}},
+			}},
+		},
+	}
+	if got := renderedText(doc); got != "Hello **World**\n\n- item" {
+		t.Fatalf("renderedText ADF mismatch: %q", got)
+	}
+	if got := renderedText("<p>plain</p>"); got != "plain" {
+		t.Fatalf("renderedText string mismatch: %q", got)
+	}
+	if got := renderedText(nil); got != "" {
+		t.Fatalf("renderedText nil mismatch: %q", got)
+	}
+}
+
 func TestFormatFileSize(t *testing.T) {
 	cases := []struct {
 		in  int64
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"devflow/internal/config"
+	"devflow/internal/httpx"
+)
+
+// loadConfigAndApply loads the configuration and applies its http section
+// to the retrying transport shared by the Jira, Bitbucket and Jenkins clients.
+func loadConfigAndApply() (*config.Config, error) {
+	return applyConfig(config.Load())
+}
+
+// loadConfigForEditAndApply is loadConfigAndApply for config set and setup,
+// which may create the selected profile.
+func loadConfigForEditAndApply() (*config.Config, error) {
+	return applyConfig(config.LoadForEdit())
+}
+
+func applyConfig(cfg *config.Config, err error) (*config.Config, error) {
+	if err != nil {
+		return nil, err
+	}
+	policy, err := retryPolicy(cfg.HTTP)
+	if err != nil {
+		return nil, err
+	}
+	httpx.SetRetryPolicy(policy)
+	return cfg, nil
+}
+
+// retryPolicy converts the http config section into a retry policy, falling
+// back to httpx.DefaultRetryPolicy for unset values.
+func retryPolicy(cfg config.HTTPConfig) (httpx.RetryPolicy, error) {
+	policy := httpx.DefaultRetryPolicy
+	switch {
+	case cfg.MaxRetries < 0:
+		policy.MaxRetries = 0
+	case cfg.MaxRetries > 0:
+		policy.MaxRetries = cfg.MaxRetries
+	}
+	switch {
+	case cfg.RetryBudget < 0:
+		policy.HostBudget = 0
+	case cfg.RetryBudget > 0:
+		policy.HostBudget = cfg.RetryBudget
+	}
+	if cfg.RetryBaseDelay != "" {
+		delay, err := time.ParseDuration(cfg.RetryBaseDelay)
+		if err != nil {
+			return policy, fmt.Errorf("invalid http.retry_base_delay %q: %w", cfg.RetryBaseDelay, err)
+		}
+		policy.BaseDelay = delay
+	}
+	if cfg.RetryMaxDelay != "" {
+		delay, err := time.ParseDuration(cfg.RetryMaxDelay)
+		if err != nil {
+			return policy, fmt.Errorf("invalid http.retry_max_delay %q: %w", cfg.RetryMaxDelay, err)
+		}
+		policy.MaxDelay = delay
+	}
+	return policy, nil
+}
+
+// formatOptionalInt renders an integer setting, leaving zero (unset) empty.
+func formatOptionalInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
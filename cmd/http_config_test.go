@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"devflow/internal/config"
+	"devflow/internal/httpx"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	policy, err := retryPolicy(config.HTTPConfig{})
+	if err != nil || policy != httpx.DefaultRetryPolicy {
+		t.Fatalf("expected defaults for an empty section, got %+v, %v", policy, err)
+	}
+
+	policy, err = retryPolicy(config.HTTPConfig{MaxRetries: 5, RetryBaseDelay: "1s", RetryMaxDelay: "10s", RetryBudget: -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := httpx.RetryPolicy{MaxRetries: 5, BaseDelay: time.Second, MaxDelay: 10 * time.Second, HostBudget: 0}
+	if policy != want {
+		t.Fatalf("retryPolicy = %+v, want %+v", policy, want)
+	}
+
+	policy, _ = retryPolicy(config.HTTPConfig{MaxRetries: -1})
+	if policy.MaxRetries != 0 {
+		t.Fatalf("expected negative max_retries to disable retries, got %d", policy.MaxRetries)
+	}
+
+	if _, err := retryPolicy(config.HTTPConfig{RetryMaxDelay: "soon"}); err == nil {
+		t.Fatal("expected an invalid duration to be rejected")
+	}
+}
+
+func TestSetConfigValueHTTP(t *testing.T) {
+	cfg := &config.Config{}
+	if err := setConfigValue(cfg, "http.max_retries", "4"); err != nil {
+		t.Fatalf("set max_retries: %v", err)
+	}
+	if err := setConfigValue(cfg, "http.retry_max_delay", "45s"); err != nil {
+		t.Fatalf("set retry_max_delay: %v", err)
+	}
+	if err := setConfigValue(cfg, "http.retry_base_delay", "fast"); err == nil {
+		t.Fatal("expected an invalid duration to be rejected")
+	}
+	if value, _ := getConfigValue(cfg, "http.max_retries"); value != "4" {
+		t.Fatalf("http.max_retries = %q", value)
+	}
+	if value, _ := getConfigValue(cfg, "http.retry_max_delay"); value != "45s" {
+		t.Fatalf("http.retry_max_delay = %q", value)
+	}
+}
@@ -23,7 +23,7 @@ var jenkinsBuildsCmd = &cobra.Command{
 		// Load configuration
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 
 		// Validate required config
@@ -35,13 +35,13 @@ var jenkinsBuildsCmd = &cobra.Command{
 		client := jenkins.NewClient(&cfg.Jenkins)
 
 		// Get builds
-		builds, err := client.GetJobBuilds(jobName, limit)
+		builds, err := client.GetJobBuildsContext(commandContext(cmd), jobName, limit)
 		if err != nil {
-			log.Fatalf("Error fetching builds: %v", err)
+			fatalError("Error fetching builds", err)
 		}
 		if wantsJSON(cmd) {
 			if err := printJSON(builds); err != nil {
-				log.Fatalf("Error encoding JSON: %v", err)
+				fatalError("Error encoding JSON", err)
 			}
 			return
 		}
@@ -16,6 +16,7 @@ var jenkinsLogsCmd = &cobra.Command{
 	Long:    `Retrieve console output for a specific Jenkins build. Use --failed-step to scope to the failing stage.`,
 	Args:    cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
 		jobName := args[0]
 		buildNumberStr := args[1]
 		failedStep, _ := cmd.Flags().GetBool("failed-step")
@@ -28,7 +29,7 @@ var jenkinsLogsCmd = &cobra.Command{
 		// Load configuration
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 
 		// Validate required config
@@ -42,21 +43,21 @@ var jenkinsLogsCmd = &cobra.Command{
 		var logs string
 		if failedStep {
 			// Get logs for failed step only
-			logs, err = client.GetFailedStepLog(jobName, buildNumber)
+			logs, err = client.GetFailedStepLogContext(ctx, jobName, buildNumber)
 			if err != nil {
-				log.Fatalf("Error fetching failed step logs: %v", err)
+				fatalError("Error fetching failed step logs", err)
 			}
 		} else {
 			// Get full console log
-			logs, err = client.GetBuildLog(jobName, buildNumber)
+			logs, err = client.GetBuildLogContext(ctx, jobName, buildNumber)
 			if err != nil {
-				log.Fatalf("Error fetching build logs: %v", err)
+				fatalError("Error fetching build logs", err)
 			}
 		}
 
 		if wantsJSON(cmd) {
 			if err := printJSON(map[string]any{"job": jobName, "build": buildNumber, "failed_step": failedStep, "logs": logs}); err != nil {
-				log.Fatalf("Error encoding JSON: %v", err)
+				fatalError("Error encoding JSON", err)
 			}
 			return
 		}
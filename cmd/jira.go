@@ -10,22 +10,46 @@ var tasksCmd = &cobra.Command{
 	Long: `Manage Jira tasks, issues, and workflow related actions.
 
 Available actions:
-  list        List your assigned issues
+  list        List your assigned issues, live or from the offline snapshot
+  changes     Report what changed in your assigned issues since the last sync
+  query       Save, list and delete named JQL queries for list --query
   show        Show detailed issue information (includes Assigned Team)
   mentioned   Find issues where you are mentioned
   create      Create a new issue (supports epic, story points, sprint, team, labels)
   comment     Add a comment to an issue
+  move        Move an issue to another workflow status
+  bulk        Update, move, comment on or relabel every issue a JQL query matches
+  history     Show an issue's changes and time spent in each status
+  progress    Roll up the status, story points and open pull requests of an epic
+  log         Log time against an issue, or list its worklogs
+  timesheet   Report your logged time per day and per issue
+  attach      Upload files as attachments to an issue
+  attachments List or download an issue's attachments
   link        Add an external document / remote link to an issue
+  relate      Link two issues (blocks, is blocked by, duplicates, ...)
+  fields      List fields and their types for use with --field
   spaces      List available Jira projects (spaces)`,
 }
 
 func init() {
 	tasksCmd.AddCommand(listTasksCmd)
+	tasksCmd.AddCommand(changesCmd)
+	tasksCmd.AddCommand(queryCmd)
 	tasksCmd.AddCommand(createTaskCmd)
 	tasksCmd.AddCommand(showIssueCmd)
 	tasksCmd.AddCommand(mentionedCmd)
 	tasksCmd.AddCommand(commentCmd)
+	tasksCmd.AddCommand(attachCmd)
+	tasksCmd.AddCommand(attachmentsCmd)
 	tasksCmd.AddCommand(linkCmd)
+	tasksCmd.AddCommand(relateCmd)
+	tasksCmd.AddCommand(fieldsCmd)
 	tasksCmd.AddCommand(updateTaskCmd)
+	tasksCmd.AddCommand(moveTaskCmd)
+	tasksCmd.AddCommand(bulkCmd)
+	tasksCmd.AddCommand(historyCmd)
+	tasksCmd.AddCommand(progressCmd)
+	tasksCmd.AddCommand(logWorkCmd)
+	tasksCmd.AddCommand(timesheetCmd)
 	tasksCmd.AddCommand(spacesCmd)
 }
@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"devflow/internal/config"
+	"devflow/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var (
+	attachmentName string
+	attachmentDir  string
+)
+
+var attachCmd = &cobra.Command{
+	Use:   "attach [issue-key] [file...]",
+	Short: "Upload files as attachments to a Jira issue",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		issueKey, paths := args[0], args[1:]
+
+		client := newJiraClient()
+		attachments, err := client.AddAttachmentsContext(commandContext(cmd), issueKey, paths)
+		if err != nil {
+			fatalError("Failed to attach files", err)
+		}
+
+		if wantsJSON(cmd) {
+			out := make([]normalizedAttachment, 0, len(attachments))
+			for _, attachment := range attachments {
+				out = append(out, normalizedAttachmentFrom(attachment))
+			}
+			if err := printJSON(out); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			displayAttachmentTable(attachments)
+			return
+		}
+		for _, attachment := range attachments {
+			fmt.Printf("📎 Attached %s (%s) to %s\n", attachment.Filename, formatFileSize(attachment.Size), issueKey)
+		}
+	},
+}
+
+var attachmentsCmd = &cobra.Command{
+	Use:   "attachments",
+	Short: "List and download the attachments of a Jira issue",
+}
+
+var listAttachmentsCmd = &cobra.Command{
+	Use:   "list [issue-key]",
+	Short: "List the attachments of a Jira issue",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		attachments, err := newJiraClient().ListAttachmentsContext(commandContext(cmd), args[0])
+		if err != nil {
+			fatalError("Failed to list attachments", err)
+		}
+		attachments = filterAttachments(attachments, attachmentName)
+
+		if wantsJSON(cmd) {
+			out := make([]normalizedAttachment, 0, len(attachments))
+			for _, attachment := range attachments {
+				out = append(out, normalizedAttachmentFrom(attachment))
+			}
+			if err := printJSON(out); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			displayAttachmentTable(attachments)
+			return
+		}
+		if len(attachments) == 0 {
+			fmt.Printf("No attachments on %s\n", args[0])
+			return
+		}
+		fmt.Printf("📎 Attachments on %s (%d):\n", args[0], len(attachments))
+		for _, attachment := range attachments {
+			fmt.Printf("• %s (%s, %s) [%s]\n", attachment.Filename, formatFileSize(attachment.Size), attachment.MimeType, attachment.ID)
+		}
+	},
+}
+
+var downloadAttachmentsCmd = &cobra.Command{
+	Use:   "download [issue-key]",
+	Short: "Download the attachments of a Jira issue",
+	Long: `Download an issue's attachments into --dir (default: the current
+directory), optionally only those whose file name matches --name, e.g.
+
+  devflow tasks attachments download ENG-123 --name "*.log" --dir ./logs
+
+Files are written to a ".part" file next to the target and renamed once
+complete. An interrupted download resumes from its ".part" file, and files
+already present with the expected size are skipped.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		issueKey := args[0]
+
+		client := newJiraClient()
+		attachments, err := client.ListAttachmentsContext(ctx, issueKey)
+		if err != nil {
+			fatalError("Failed to list attachments", err)
+		}
+		attachments = filterAttachments(attachments, attachmentName)
+		if len(attachments) == 0 && !wantsJSON(cmd) {
+			fmt.Printf("No attachments to download from %s\n", issueKey)
+			return
+		}
+		if err := os.MkdirAll(attachmentDir, 0o755); err != nil {
+			fatalError("Failed to create download directory", err)
+		}
+
+		var progress io.Writer
+		if !wantsJSON(cmd) && !wantsTabular(cmd) {
+			progress = os.Stderr
+		}
+		names := attachmentFileNames(attachments)
+		results := make([]attachmentDownload, 0, len(attachments))
+		for i, attachment := range attachments {
+			path := filepath.Join(attachmentDir, names[i])
+			status, err := downloadAttachment(ctx, client, attachment, path, progress)
+			if err != nil {
+				fatalError("Failed to download "+attachment.Filename, err)
+			}
+			results = append(results, attachmentDownload{ID: attachment.ID, Filename: attachment.Filename, Path: path, Size: attachment.Size, Status: status})
+		}
+
+		if wantsJSON(cmd) {
+			if err := printJSON(results); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			rows := make([][]any, 0, len(results))
+			for _, r := range results {
+				rows = append(rows, []any{r.ID, r.Path, formatFileSize(r.Size), r.Status})
+			}
+			renderTable([]string{"ID", "Path", "Size", "Status"}, rows)
+			return
+		}
+		for _, r := range results {
+			if r.Status == "skipped" {
+				fmt.Printf("⏭️  %s already downloaded\n", r.Path)
+				continue
+			}
+			fmt.Printf("✅ Saved %s (%s)\n", r.Path, formatFileSize(r.Size))
+		}
+	},
+}
+
+func init() {
+	attachmentsCmd.PersistentFlags().StringVar(&attachmentName, "name", "", `Only attachments whose file name matches this glob (e.g. "*.png")`)
+	downloadAttachmentsCmd.Flags().StringVar(&attachmentDir, "dir", ".", "Directory to save attachments in")
+	attachmentsCmd.AddCommand(listAttachmentsCmd)
+	attachmentsCmd.AddCommand(downloadAttachmentsCmd)
+}
+
+// newJiraClient builds a client from the loaded configuration, exiting when
+// Jira is not configured.
+func newJiraClient() *jira.Client {
+	_, client := loadJiraClient()
+	return client
+}
+
+// loadJiraClient is newJiraClient for commands that also need other
+// configuration values, such as the default project or board.
+func loadJiraClient() (*config.Config, *jira.Client) {
+	cfg, err := loadConfig()
+	if err != nil {
+		fatalError("Error loading config", err)
+	}
+	if cfg.Jira.URL == "" || jiraUsernameMissing(cfg.Jira) || cfg.Jira.Token == "" {
+		log.Fatal("Jira configuration incomplete. Run: devflow config set jira.url|jira.username|jira.token ...")
+	}
+	return cfg, jira.NewClient(&cfg.Jira)
+}
+
+// jiraUsernameMissing reports whether the Jira credentials lack a required
+// username. Cloud authenticates with an email and API token; Server and Data
+// Center also accept a personal access token on its own, so a deployment
+// still to be detected is only held to Cloud's rules on Atlassian URLs.
+func jiraUsernameMissing(cfg config.JiraConfig) bool {
+	if cfg.Username != "" {
+		return false
+	}
+	deployment, _ := jira.ParseDeployment(cfg.Deployment)
+	return deployment == jira.DeploymentCloud || (deployment == jira.DeploymentAuto && jira.IsCloudURL(cfg.URL))
+}
+
+type attachmentDownload struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	Status   string `json:"status"`
+}
+
+func normalizedAttachmentFrom(attachment jira.Attachment) normalizedAttachment {
+	return normalizedAttachment{
+		ID:       attachment.ID,
+		Filename: attachment.Filename,
+		Size:     attachment.Size,
+		MimeType: attachment.MimeType,
+		URL:      attachment.Content,
+		Created:  attachment.Created,
+	}
+}
+
+func displayAttachmentTable(attachments []jira.Attachment) {
+	rows := make([][]any, 0, len(attachments))
+	for _, attachment := range attachments {
+		rows = append(rows, []any{attachment.ID, attachment.Filename, formatFileSize(attachment.Size), attachment.MimeType})
+	}
+	renderTable([]string{"ID", "Filename", "Size", "Type"}, rows)
+}
+
+func filterAttachments(attachments []jira.Attachment, pattern string) []jira.Attachment {
+	if pattern == "" {
+		return attachments
+	}
+	var matched []jira.Attachment
+	for _, attachment := range attachments {
+		if ok, _ := filepath.Match(pattern, attachment.Filename); ok {
+			matched = append(matched, attachment)
+		}
+	}
+	return matched
+}
+
+// attachmentFileNames picks a local file name for each attachment. Names are
+// reduced to their base name so they cannot escape the download directory,
+// and attachments sharing a name get their ID appended.
+func attachmentFileNames(attachments []jira.Attachment) []string {
+	counts := make(map[string]int, len(attachments))
+	names := make([]string, len(attachments))
+	for i, attachment := range attachments {
+		name := filepath.Base(filepath.Clean("/" + attachment.Filename))
+		if name == "/" || name == "." {
+			name = "attachment-" + attachment.ID
+		}
+		names[i] = name
+		counts[name]++
+	}
+	for i, name := range names {
+		if counts[name] > 1 {
+			ext := filepath.Ext(name)
+			names[i] = fmt.Sprintf("%s-%s%s", strings.TrimSuffix(name, ext), attachments[i].ID, ext)
+		}
+	}
+	return names
+}
+
+// downloadAttachment saves attachment at path through path + ".part",
+// resuming a previous partial download when the server supports ranges. It
+// reports "downloaded", "resumed" or "skipped".
+func downloadAttachment(ctx context.Context, client *jira.Client, attachment jira.Attachment, path string, progress io.Writer) (string, error) {
+	if info, err := os.Stat(path); err == nil && info.Size() == attachment.Size {
+		return "skipped", nil
+	}
+
+	partPath := path + ".part"
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil && info.Size() < attachment.Size {
+		offset = info.Size()
+	}
+
+	body, resumed, err := client.DownloadAttachmentContext(ctx, attachment, offset)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = body.Close() }()
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if resumed {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	} else {
+		offset = 0
+	}
+	file, err := os.OpenFile(partPath, flags, 0o644)
+	if err != nil {
+		return "", err
+	}
+
+	var dst io.Writer = file
+	var meter *progressMeter
+	if progress != nil {
+		meter = &progressMeter{out: progress, label: attachment.Filename, total: attachment.Size, done: offset}
+		dst = io.MultiWriter(file, meter)
+	}
+	written, copyErr := io.Copy(dst, body)
+	closeErr := file.Close()
+	if meter != nil {
+		meter.finish()
+	}
+	if copyErr != nil {
+		return "", copyErr
+	}
+	if closeErr != nil {
+		return "", closeErr
+	}
+	if attachment.Size > 0 && offset+written != attachment.Size {
+		return "", fmt.Errorf("downloaded %d of %d bytes; run the command again to resume", offset+written, attachment.Size)
+	}
+	if err := os.Rename(partPath, path); err != nil {
+		return "", err
+	}
+	if resumed {
+		return "resumed", nil
+	}
+	return "downloaded", nil
+}
+
+// progressMeter writes a single, rewritten progress line as bytes pass
+// through it.
+type progressMeter struct {
+	out     io.Writer
+	label   string
+	total   int64
+	done    int64
+	percent int
+	shown   bool
+}
+
+func (p *progressMeter) Write(b []byte) (int, error) {
+	p.done += int64(len(b))
+	percent := 100
+	if p.total > 0 {
+		percent = int(p.done * 100 / p.total)
+	}
+	if percent != p.percent || !p.shown {
+		p.percent, p.shown = percent, true
+		fmt.Fprintf(p.out, "\r⬇️  %s %3d%% (%s / %s)", p.label, percent, formatFileSize(p.done), formatFileSize(p.total))
+	}
+	return len(b), nil
+}
+
+func (p *progressMeter) finish() {
+	fmt.Fprintln(p.out)
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"devflow/internal/config"
+	"devflow/internal/jira"
+)
+
+func TestAttachmentFileNames(t *testing.T) {
+	names := attachmentFileNames([]jira.Attachment{
+		{ID: "1", Filename: "log.txt"},
+		{ID: "2", Filename: "../../etc/passwd"},
+		{ID: "3", Filename: "log.txt"},
+		{ID: "4", Filename: ""},
+	})
+	want := []string{"log-1.txt", "passwd", "log-3.txt", "attachment-4"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("attachmentFileNames = %v, want %v", names, want)
+	}
+
+	filtered := filterAttachments([]jira.Attachment{{Filename: "a.png"}, {Filename: "b.log"}}, "*.png")
+	if len(filtered) != 1 || filtered[0].Filename != "a.png" {
+		t.Fatalf("unexpected filtered attachments: %+v", filtered)
+	}
+}
+
+func TestDownloadAttachmentResumesPartFile(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"},
+	})
+	content := "0123456789"
+	var ranges []string
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		if r.Header.Get("Range") == "bytes=6-" {
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write([]byte(content[6:]))
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	})
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "digits.txt")
+	_ = os.WriteFile(path+".part", []byte(content[:6]), 0o644)
+	client := jira.NewClient(&config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"})
+	attachment := jira.Attachment{ID: "7", Filename: "digits.txt", Size: int64(len(content)), Content: "https://jira.example/rest/api/3/attachment/content/7"}
+
+	var progress bytes.Buffer
+	status, err := downloadAttachment(context.Background(), client, attachment, path, &progress)
+	if err != nil || status != "resumed" {
+		t.Fatalf("expected a resumed download, got %q, %v", status, err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != content {
+		t.Fatalf("unexpected file content %q", data)
+	}
+	if _, err := os.Stat(path + ".part"); !os.IsNotExist(err) {
+		t.Fatalf("expected the part file to be renamed, got %v", err)
+	}
+	if !strings.Contains(progress.String(), "100%") {
+		t.Fatalf("expected progress output, got %q", progress.String())
+	}
+
+	status, err = downloadAttachment(context.Background(), client, attachment, path, nil)
+	if err != nil || status != "skipped" || len(ranges) != 1 {
+		t.Fatalf("expected a complete file to be skipped, got %q, %v after %d requests", status, err, len(ranges))
+	}
+
+	attachment.Size = 20
+	if _, err := downloadAttachment(context.Background(), client, attachment, filepath.Join(dir, "short.txt"), nil); err == nil ||
+		!strings.Contains(err.Error(), "run the command again to resume") {
+		t.Fatalf("expected a truncated download to fail, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "short.txt.part")); err != nil {
+		t.Fatalf("expected the partial file to be kept for resuming: %v", err)
+	}
+}
+
+func TestAttachCmd(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"},
+	})
+	var token string
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/3/issue/ENG-123/attachments" {
+			t.Fatalf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+		}
+		token = r.Header.Get("X-Atlassian-Token")
+		_, _ = w.Write([]byte(`[{"id":"10","filename":"report.txt","size":2048}]`))
+	})
+
+	path := filepath.Join(t.TempDir(), "report.txt")
+	_ = os.WriteFile(path, []byte("report"), 0o644)
+	out := captureStdout(func() {
+		attachCmd.Run(attachCmd, []string{"ENG-123", path})
+	})
+	if token != "no-check" || !strings.Contains(out, "Attached report.txt (2.0 KB) to ENG-123") {
+		t.Fatalf("unexpected attach output %q (token %q)", out, token)
+	}
+}
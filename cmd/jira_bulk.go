@@ -0,0 +1,427 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+
+	"devflow/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bulkJQL          string
+	bulkDryRun       bool
+	bulkYes          bool
+	bulkConcurrency  int
+	bulkConfirmAbove int
+	bulkMax          int
+
+	bulkAssignee    string
+	bulkPriority    string
+	bulkLabels      string
+	bulkEpic        string
+	bulkStoryPoints float64
+	bulkSprint      string
+	bulkTeam        string
+	bulkFields      []string
+
+	bulkMoveComment    string
+	bulkMoveResolution string
+	bulkMoveFields     []string
+
+	bulkCommentBody     string
+	bulkCommentBodyFile string
+)
+
+var bulkCmd = &cobra.Command{
+	Use:   "bulk",
+	Short: "Apply one change to every issue matching a JQL query",
+	Long: `Update, move, comment on or relabel every issue a JQL query matches, e.g.
+
+  devflow tasks bulk --jql 'sprint in openSprints() AND labels = triage' label remove triage
+  devflow tasks bulk --jql 'project = ENG AND assignee is EMPTY' update --assignee alice
+  devflow tasks bulk --jql 'fixVersion = 2.4' move done --resolution Fixed --dry-run
+
+--dry-run lists the matching issues without changing them. Above
+--confirm-above issues the command asks before applying the change unless
+--yes is given. Issues are changed --concurrency at a time; the report lists
+the outcome per issue and the command exits non-zero when any of them failed.`,
+}
+
+var bulkUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update fields on every matching issue",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if bulkAssignee == "" && bulkPriority == "" && bulkLabels == "" && bulkEpic == "" &&
+			bulkStoryPoints == 0 && bulkSprint == "" && bulkTeam == "" && len(bulkFields) == 0 {
+			return errors.New("provide at least one field to update (use --help for flags)")
+		}
+		fieldValues, err := parseFieldAssignments(bulkFields)
+		if err != nil {
+			return fmt.Errorf("invalid --field: %w", err)
+		}
+
+		fields := make(map[string]interface{})
+		if bulkPriority != "" {
+			fields["priority"] = map[string]string{"name": bulkPriority}
+		}
+		if strings.TrimSpace(bulkLabels) != "" {
+			fields["labels"] = parseLabels(bulkLabels)
+		}
+		cfg, client := loadJiraClient()
+		sprint, err := resolveSprintFlag(commandContext(cmd), client, cfg, "", bulkSprint)
+		if err != nil {
+			return fmt.Errorf("cannot resolve --sprint: %w", err)
+		}
+		planning := jira.PlanningFields{Epic: bulkEpic, StoryPoints: bulkStoryPoints, Sprint: sprint, Team: bulkTeam}
+
+		if bulkAssignee != "" {
+			assignee, err := client.UserFieldContext(commandContext(cmd), bulkAssignee)
+			if err != nil {
+				return fmt.Errorf("cannot resolve assignee: %w", err)
+			}
+			fields["assignee"] = assignee
+		}
+		return runBulk(cmd, client, func(n int) string { return fmt.Sprintf("update %d issues", n) }, func(ctx context.Context, issue jira.Issue) (string, error) {
+			issueFields := maps.Clone(fields)
+			if !planning.IsZero() || len(fieldValues) > 0 {
+				screen, err := client.EditMetaContext(ctx, issue.Key)
+				if err != nil {
+					return "", fmt.Errorf("failed to read the edit screen: %w", err)
+				}
+				values, omitted := planning.Resolve(screen)
+				if len(omitted) > 0 {
+					return "", fmt.Errorf("the edit screen has no %s field", strings.Join(omitted, " or "))
+				}
+				custom, err := resolveFieldValues(ctx, client, screen, fieldValues, "edit screen of "+issue.Key)
+				if err != nil {
+					return "", err
+				}
+				maps.Copy(issueFields, values)
+				maps.Copy(issueFields, custom)
+			}
+			return "", client.UpdateIssueContext(ctx, issue.Key, issueFields)
+		})
+	},
+}
+
+var bulkMoveCmd = &cobra.Command{
+	Use:     "move [status]",
+	Aliases: []string{"transition"},
+	Short:   "Move every matching issue to a workflow status",
+	Long: `Move every matching issue to a workflow status, matched against each issue's
+transitions as by "devflow tasks move". Issues already in the status are
+skipped.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := args[0]
+		fieldValues, err := parseFieldAssignments(bulkMoveFields)
+		if err != nil {
+			return fmt.Errorf("invalid --field: %w", err)
+		}
+		if bulkMoveResolution != "" {
+			fieldValues["resolution"] = bulkMoveResolution
+		}
+
+		client := newJiraClient()
+		return runBulk(cmd, client, func(n int) string { return fmt.Sprintf("move %d issues to %s", n, target) }, func(ctx context.Context, issue jira.Issue) (string, error) {
+			if normalizeStatusName(issue.Fields.Status.Name) == normalizeStatusName(target) {
+				return "already " + issue.Fields.Status.Name, nil
+			}
+			transitions, err := client.GetTransitionsContext(ctx, issue.Key)
+			if err != nil {
+				return "", fmt.Errorf("failed to get transitions: %w", err)
+			}
+			transition, err := matchTransition(transitions, target)
+			if err != nil {
+				return "", err
+			}
+			fields, err := resolveFieldValues(ctx, client, transition.Fields, fieldValues, fmt.Sprintf("%q transition screen", transition.Name))
+			if err != nil {
+				return "", err
+			}
+			if missing := missingRequiredFields(transition.Fields, fields); len(missing) > 0 {
+				return "", fmt.Errorf("transition %q requires: %s; set them with --field \"Name=value\"", transition.Name, strings.Join(missing, "; "))
+			}
+			return "", client.DoTransitionContext(ctx, issue.Key, transition.ID, fields, bulkMoveComment)
+		})
+	},
+}
+
+var bulkCommentCmd = &cobra.Command{
+	Use:   "comment",
+	Short: "Add the same comment to every matching issue",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if bulkCommentBody == "" && bulkCommentBodyFile == "" {
+			return errors.New("provide a comment with --body or --body-file")
+		}
+		body, err := resolveCommentBody(bulkCommentBody, bulkCommentBodyFile)
+		if err != nil {
+			return fmt.Errorf("failed to read comment body: %w", err)
+		}
+
+		client := newJiraClient()
+		return runBulk(cmd, client, func(n int) string { return fmt.Sprintf("comment on %d issues", n) }, func(ctx context.Context, issue jira.Issue) (string, error) {
+			return "", client.AddCommentContext(ctx, issue.Key, body)
+		})
+	},
+}
+
+var bulkLabelCmd = &cobra.Command{
+	Use:   "label",
+	Short: "Add or remove labels on every matching issue",
+}
+
+var bulkLabelAddCmd = &cobra.Command{
+	Use:   "add [label...]",
+	Short: "Add labels to every matching issue",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		labels := parseLabels(strings.Join(args, ","))
+		client := newJiraClient()
+		return runBulk(cmd, client, func(n int) string { return fmt.Sprintf("add labels %s to %d issues", strings.Join(labels, ", "), n) }, func(ctx context.Context, issue jira.Issue) (string, error) {
+			return "", client.UpdateLabelsContext(ctx, issue.Key, labels, nil)
+		})
+	},
+}
+
+var bulkLabelRemoveCmd = &cobra.Command{
+	Use:   "remove [label...]",
+	Short: "Remove labels from every matching issue",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		labels := parseLabels(strings.Join(args, ","))
+		client := newJiraClient()
+		return runBulk(cmd, client, func(n int) string {
+			return fmt.Sprintf("remove labels %s from %d issues", strings.Join(labels, ", "), n)
+		}, func(ctx context.Context, issue jira.Issue) (string, error) {
+			return "", client.UpdateLabelsContext(ctx, issue.Key, nil, labels)
+		})
+	},
+}
+
+func init() {
+	bulkCmd.PersistentFlags().StringVar(&bulkJQL, "jql", "", "JQL query selecting the issues to change (required)")
+	bulkCmd.PersistentFlags().BoolVar(&bulkDryRun, "dry-run", false, "List the matching issues without changing them")
+	bulkCmd.PersistentFlags().BoolVarP(&bulkYes, "yes", "y", false, "Do not ask for confirmation")
+	bulkCmd.PersistentFlags().IntVar(&bulkConcurrency, "concurrency", 4, "Number of issues changed at a time")
+	bulkCmd.PersistentFlags().IntVar(&bulkConfirmAbove, "confirm-above", 10, "Ask for confirmation when more issues than this match")
+	bulkCmd.PersistentFlags().IntVar(&bulkMax, "max", 500, "Maximum number of issues to change (0 for no limit)")
+	_ = bulkCmd.MarkPersistentFlagRequired("jql")
+
+	bulkUpdateCmd.Flags().StringVar(&bulkAssignee, "assignee", "", "Assignee: me, an email address, display name or accountId; none to unassign")
+	bulkUpdateCmd.Flags().StringVar(&bulkPriority, "priority", "", "Priority (Highest, High, Medium, Low, Lowest)")
+	bulkUpdateCmd.Flags().StringVar(&bulkLabels, "labels", "", "Comma-separated labels replacing the current ones")
+	bulkUpdateCmd.Flags().StringVar(&bulkEpic, "epic", "", "Epic key to link")
+	bulkUpdateCmd.Flags().Float64Var(&bulkStoryPoints, "story-points", 0, "Story points value")
+	bulkUpdateCmd.Flags().StringVar(&bulkSprint, "sprint", "", "Sprint ID or name, or active or next, of the board in jira.board_id")
+	bulkUpdateCmd.Flags().StringVar(&bulkTeam, "team", "", "Team id or name for Team Assigned custom field")
+	bulkUpdateCmd.Flags().StringArrayVar(&bulkFields, "field", nil, "Editable field as \"Name=value\"; an empty value clears it (repeatable)")
+
+	bulkMoveCmd.Flags().StringVarP(&bulkMoveComment, "comment", "m", "", "Comment to add with each transition")
+	bulkMoveCmd.Flags().StringVar(&bulkMoveResolution, "resolution", "", "Resolution to set when the transition requires one")
+	bulkMoveCmd.Flags().StringArrayVar(&bulkMoveFields, "field", nil, "Transition screen field as \"Name=value\" (repeatable)")
+
+	bulkCommentCmd.Flags().StringVarP(&bulkCommentBody, "body", "b", "", "Inline comment body (Markdown)")
+	bulkCommentCmd.Flags().StringVar(&bulkCommentBodyFile, "body-file", "", "Path to a Markdown file containing the comment body")
+
+	bulkLabelCmd.AddCommand(bulkLabelAddCmd)
+	bulkLabelCmd.AddCommand(bulkLabelRemoveCmd)
+	bulkCmd.AddCommand(bulkUpdateCmd)
+	bulkCmd.AddCommand(bulkMoveCmd)
+	bulkCmd.AddCommand(bulkCommentCmd)
+	bulkCmd.AddCommand(bulkLabelCmd)
+}
+
+// bulkApply changes one issue. A non-empty skipped reason reports the issue
+// as left alone on purpose.
+type bulkApply func(ctx context.Context, issue jira.Issue) (skipped string, err error)
+
+// bulkResult is the outcome of a bulk change on one issue.
+type bulkResult struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	Result  string `json:"result"` // ok, skipped or failed
+	Reason  string `json:"reason,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type bulkReport struct {
+	Action    string       `json:"action"`
+	JQL       string       `json:"jql"`
+	DryRun    bool         `json:"dry_run,omitempty"`
+	Succeeded int          `json:"succeeded"`
+	Skipped   int          `json:"skipped"`
+	Failed    int          `json:"failed"`
+	Results   []bulkResult `json:"results"`
+}
+
+// runBulk finds the issues matching --jql and applies the change to each
+// of them, after a confirmation where one is needed. describe phrases the
+// change for a number of issues, e.g. "comment on 3 issues". Failures are
+// reported per issue; the returned error only sets the exit code.
+func runBulk(cmd *cobra.Command, client *jira.Client, describe func(n int) string, apply bulkApply) error {
+	if strings.TrimSpace(bulkJQL) == "" {
+		return errors.New("--jql is required")
+	}
+	if bulkConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1, got %d", bulkConcurrency)
+	}
+
+	ctx := commandContext(cmd)
+	issues, err := client.SearchAllContext(ctx, bulkJQL, true, 100, bulkMax)
+	if err != nil {
+		fatalError("Error searching issues", err)
+	}
+	if bulkMax > 0 && len(issues) == bulkMax {
+		fmt.Fprintf(errorOutput, "Stopped at --max %d issues; more may match.\n", bulkMax)
+	}
+
+	action := describe(len(issues))
+
+	if bulkDryRun {
+		report := bulkReport{Action: action, JQL: bulkJQL, DryRun: true, Results: make([]bulkResult, 0, len(issues))}
+		for _, issue := range issues {
+			report.Results = append(report.Results, bulkResult{Key: issue.Key, Summary: issue.Fields.Summary, Result: "pending"})
+		}
+		displayBulkPreview(cmd, report)
+		return nil
+	}
+	if len(issues) == 0 {
+		displayBulkReport(cmd, bulkReport{Action: action, JQL: bulkJQL, Results: []bulkResult{}})
+		return nil
+	}
+	if len(issues) > bulkConfirmAbove && !bulkYes {
+		if !confirmBulk(action, issues) {
+			return errors.New("aborted; no issues were changed")
+		}
+	}
+
+	report := bulkReport{Action: action, JQL: bulkJQL, Results: applyBulk(ctx, issues, apply, bulkConcurrency)}
+	for _, result := range report.Results {
+		switch result.Result {
+		case "ok":
+			report.Succeeded++
+		case "skipped":
+			report.Skipped++
+		default:
+			report.Failed++
+		}
+	}
+	displayBulkReport(cmd, report)
+	if report.Failed > 0 {
+		// Every failure is already part of the report.
+		return reportedError{fmt.Errorf("%d of %d issues failed", report.Failed, len(issues))}
+	}
+	return nil
+}
+
+// applyBulk runs apply on the issues, at most concurrency at a time. The
+// results keep the order of the issues.
+func applyBulk(ctx context.Context, issues []jira.Issue, apply bulkApply, concurrency int) []bulkResult {
+	results := make([]bulkResult, len(issues))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, issue := range issues {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, issue jira.Issue) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			result := bulkResult{Key: issue.Key, Summary: issue.Fields.Summary, Result: "ok"}
+			skipped, err := apply(ctx, issue)
+			switch {
+			case err != nil:
+				result.Result, result.Error = "failed", err.Error()
+			case skipped != "":
+				result.Result, result.Reason = "skipped", skipped
+			}
+			results[i] = result
+		}(i, issue)
+	}
+	wg.Wait()
+	return results
+}
+
+// confirmBulk lists the issues on stderr and asks whether to go ahead.
+func confirmBulk(action string, issues []jira.Issue) bool {
+	fmt.Fprintf(errorOutput, "%d issues match:\n", len(issues))
+	for _, issue := range issues {
+		fmt.Fprintf(errorOutput, "  %s  %s\n", issue.Key, issue.Fields.Summary)
+	}
+	fmt.Fprintf(errorOutput, "%s? [y/N]: ", capitalize(action))
+	answer, _ := bufio.NewReader(stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func displayBulkPreview(cmd *cobra.Command, report bulkReport) {
+	if wantsJSON(cmd) {
+		if err := printJSON(report); err != nil {
+			fatalError("Error encoding JSON", err)
+		}
+		return
+	}
+	if wantsTabular(cmd) {
+		rows := make([][]any, 0, len(report.Results))
+		for _, result := range report.Results {
+			rows = append(rows, []any{result.Key, result.Summary})
+		}
+		renderTable([]string{"Key", "Summary"}, rows)
+		return
+	}
+	if len(report.Results) == 0 {
+		fmt.Println("No issues match the query")
+		return
+	}
+	fmt.Printf("🔎 Would %s:\n", report.Action)
+	for _, result := range report.Results {
+		fmt.Printf("  %s  %s\n", result.Key, result.Summary)
+	}
+}
+
+func displayBulkReport(cmd *cobra.Command, report bulkReport) {
+	if wantsJSON(cmd) {
+		if err := printJSON(report); err != nil {
+			fatalError("Error encoding JSON", err)
+		}
+		return
+	}
+	if wantsTabular(cmd) {
+		rows := make([][]any, 0, len(report.Results))
+		for _, result := range report.Results {
+			rows = append(rows, []any{result.Key, result.Result, result.Reason + result.Error})
+		}
+		renderTable([]string{"Key", "Result", "Details"}, rows)
+		return
+	}
+	if len(report.Results) == 0 {
+		fmt.Println("No issues match the query")
+		return
+	}
+	for _, result := range report.Results {
+		switch result.Result {
+		case "ok":
+			fmt.Printf("✅ %s  %s\n", result.Key, result.Summary)
+		case "skipped":
+			fmt.Printf("⏭️  %s  skipped: %s\n", result.Key, result.Reason)
+		default:
+			fmt.Printf("❌ %s  %s\n", result.Key, result.Error)
+		}
+	}
+	fmt.Printf("\n%d succeeded, %d skipped, %d failed\n", report.Succeeded, report.Skipped, report.Failed)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
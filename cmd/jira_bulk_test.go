@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"devflow/internal/config"
+)
+
+const bulkSearchResponse = `{"isLast":true,"issues":[
+	{"key":"ENG-1","fields":{"summary":"Login fails","status":{"name":"To Do"}}},
+	{"key":"ENG-2","fields":{"summary":"Slow search","status":{"name":"Done"}}},
+	{"key":"ENG-3","fields":{"summary":"Gone","status":{"name":"To Do"}}}
+]}`
+
+func setBulkFlags(t *testing.T, jql string, confirmAbove int, answer string) *bytes.Buffer {
+	t.Helper()
+	origJQL, origDry, origYes, origConc, origConfirm, origMax := bulkJQL, bulkDryRun, bulkYes, bulkConcurrency, bulkConfirmAbove, bulkMax
+	origStdin, origOutput := stdin, errorOutput
+	t.Cleanup(func() {
+		bulkJQL, bulkDryRun, bulkYes, bulkConcurrency, bulkConfirmAbove, bulkMax = origJQL, origDry, origYes, origConc, origConfirm, origMax
+		stdin, errorOutput = origStdin, origOutput
+	})
+	bulkJQL, bulkDryRun, bulkYes, bulkConcurrency, bulkConfirmAbove, bulkMax = jql, false, false, 2, confirmAbove, 500
+	var stderr bytes.Buffer
+	stdin, errorOutput = strings.NewReader(answer), &stderr
+	return &stderr
+}
+
+func TestBulkLabelAddReportsEachIssue(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"},
+	})
+	var mu sync.Mutex
+	updated := make(map[string]string)
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/3/search/jql":
+			if !strings.HasPrefix(r.URL.Query().Get("jql"), "labels = triage") {
+				t.Fatalf("unexpected jql %q", r.URL.Query().Get("jql"))
+			}
+			_, _ = w.Write([]byte(bulkSearchResponse))
+		case r.Method == http.MethodPut && r.URL.Path == "/rest/api/3/issue/ENG-3":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorMessages":["Issue does not exist"]}`))
+		case r.Method == http.MethodPut:
+			var body map[string]map[string][]map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			updated[strings.TrimPrefix(r.URL.Path, "/rest/api/3/issue/")] = body["update"]["labels"][0]["add"]
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	setBulkFlags(t, "labels = triage", 10, "")
+
+	var err error
+	out := captureStdout(func() { err = bulkLabelAddCmd.RunE(commandWithFormat(formatJSON), []string{"planned"}) })
+	var reported reportedError
+	if !errors.As(err, &reported) || ExitCode(err) != exitFailure {
+		t.Fatalf("expected a reported failure, got %v", err)
+	}
+	var report bulkReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if report.Succeeded != 2 || report.Failed != 1 || report.Action != "add labels planned to 3 issues" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if report.Results[2].Key != "ENG-3" || !strings.Contains(report.Results[2].Error, "Issue does not exist") {
+		t.Fatalf("expected ENG-3 to fail, got %+v", report.Results[2])
+	}
+	if updated["ENG-1"] != "planned" || updated["ENG-2"] != "planned" {
+		t.Fatalf("unexpected label updates: %v", updated)
+	}
+}
+
+func TestBulkMoveConfirmsAndSkipsIssuesInStatus(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"},
+	})
+	var mu sync.Mutex
+	var moved []string
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/3/search/jql":
+			_, _ = w.Write([]byte(bulkSearchResponse))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/transitions"):
+			_, _ = w.Write([]byte(`{"transitions":[{"id":"31","name":"Done","to":{"name":"Done"}}]}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/transitions"):
+			mu.Lock()
+			moved = append(moved, strings.Split(r.URL.Path, "/")[5])
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	stderr := setBulkFlags(t, "project = ENG", 2, "n\n")
+	err := bulkMoveCmd.RunE(bulkMoveCmd, []string{"done"})
+	if err == nil || !strings.Contains(err.Error(), "aborted") || len(moved) != 0 {
+		t.Fatalf("expected the change to be aborted, got %v (moved %v)", err, moved)
+	}
+	if !strings.Contains(stderr.String(), "ENG-3  Gone") || !strings.Contains(stderr.String(), "Move 3 issues to done? [y/N]") {
+		t.Fatalf("unexpected prompt %q", stderr.String())
+	}
+
+	stdin = strings.NewReader("y\n")
+	out := captureStdout(func() { err = bulkMoveCmd.RunE(bulkMoveCmd, []string{"done"}) })
+	if err != nil {
+		t.Fatalf("bulk move failed: %v", err)
+	}
+	if len(moved) != 2 || !strings.Contains(out, "ENG-2  skipped: already Done") || !strings.Contains(out, "2 succeeded, 1 skipped, 0 failed") {
+		t.Fatalf("unexpected output %q (moved %v)", out, moved)
+	}
+}
+
+func TestBulkDryRunChangesNothing(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"},
+	})
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/search/jql" {
+			t.Fatalf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(bulkSearchResponse))
+	})
+	setBulkFlags(t, "project = ENG", 0, "")
+	bulkDryRun = true
+
+	origBody := bulkCommentBody
+	t.Cleanup(func() { bulkCommentBody = origBody })
+	bulkCommentBody = "Moved to the next release"
+	out := captureStdout(func() {
+		if err := bulkCommentCmd.RunE(bulkCommentCmd, nil); err != nil {
+			t.Fatalf("dry run failed: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Would comment on 3 issues:") || !strings.Contains(out, "ENG-2  Slow search") {
+		t.Fatalf("unexpected output %q", out)
+	}
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"devflow/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var (
+	changesSince   string
+	changesOffline bool
+)
+
+// changeKinds lists the kinds of change in the order they are reported,
+// with the heading of each group.
+var changeKinds = []struct {
+	kind, title string
+}{
+	{jira.ChangeAssigned, "🆕 Newly assigned"},
+	{jira.ChangePriority, "⬆️  Priority raised"},
+	{jira.ChangeStatus, "🔄 Status changed"},
+	{jira.ChangeComments, "💬 New comments"},
+	{jira.ChangeUnassigned, "👋 No longer assigned"},
+}
+
+var changesCmd = &cobra.Command{
+	Use:   "changes",
+	Short: "Report what changed in your assigned issues",
+	Long: `Compare your assigned issues with an offline snapshot saved by
+"devflow tasks list --sync" and report new assignments, issues no longer
+assigned to you, status changes, new comments and raised priorities, e.g.
+
+  devflow tasks changes
+  devflow tasks changes --since yesterday
+  devflow tasks changes --offline --format json
+
+--since is last-sync (the default) or a date such as YYYY-MM-DD [HH:MM],
+today or yesterday, which picks the newest snapshot taken by then. The
+current state is fetched from Jira, or with --offline is the latest
+snapshot. Comparing does not save a snapshot; run tasks list --sync for that.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := loadConfig()
+		if err != nil {
+			fatalError("Error loading config", err)
+		}
+		if cfg.Jira.URL == "" {
+			log.Fatal("Jira URL not configured. Run: devflow config set jira.url <url>")
+		}
+		snapshots, err := jira.LoadSnapshots(snapshotPath(), cfg.Jira.URL)
+		if err != nil {
+			fatalError("Error reading the offline snapshot", err)
+		}
+
+		var current jira.Snapshot
+		if changesOffline {
+			if len(snapshots) < 2 {
+				log.Fatal("The offline store needs two snapshots to compare. Run: devflow tasks list --sync")
+			}
+			current, snapshots = snapshots[len(snapshots)-1], snapshots[:len(snapshots)-1]
+		} else {
+			if len(snapshots) == 0 {
+				log.Fatal("No offline snapshot to compare with yet. Run: devflow tasks list --sync")
+			}
+			_, client := loadJiraClient()
+			snapshot, err := client.TakeSnapshotContext(commandContext(cmd))
+			if err != nil {
+				fatalError("Error fetching Jira issues", err)
+			}
+			snapshot.TakenAt = now()
+			current = *snapshot
+		}
+		base, err := snapshotSince(snapshots, changesSince)
+		if err != nil {
+			fatalError("Cannot pick a snapshot", err)
+		}
+		changes := jira.DiffSnapshots(base, current)
+
+		if wantsJSON(cmd) {
+			if err := printJSON(map[string]any{"since": base.TakenAt, "until": current.TakenAt, "changes": changes}); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			rows := make([][]any, 0, len(changes))
+			for _, change := range changes {
+				rows = append(rows, []any{change.Key, change.Kind, changeDetail(change), change.Summary})
+			}
+			renderTable([]string{"Ticket", "Change", "Detail", "Summary"}, rows)
+			return
+		}
+		displayChanges(base, current, changes)
+	},
+}
+
+func init() {
+	changesCmd.Flags().StringVar(&changesSince, "since", "last-sync", "Snapshot to compare with: last-sync or a date (YYYY-MM-DD [HH:MM], today, yesterday)")
+	changesCmd.Flags().BoolVar(&changesOffline, "offline", false, "Compare the two snapshots instead of querying Jira")
+}
+
+// latestSnapshot returns the newest snapshot of site, or exits when there is
+// none.
+func latestSnapshot(site string) jira.Snapshot {
+	snapshots, err := jira.LoadSnapshots(snapshotPath(), site)
+	if err != nil {
+		fatalError("Error reading the offline snapshot", err)
+	}
+	if len(snapshots) == 0 {
+		log.Fatal("No offline snapshot yet. Run: devflow tasks list --sync")
+	}
+	return snapshots[len(snapshots)-1]
+}
+
+// snapshotSince picks the snapshot to compare with: the newest for
+// "last-sync", otherwise the newest taken at or before the given time.
+func snapshotSince(snapshots []jira.Snapshot, since string) (jira.Snapshot, error) {
+	if since == "" || strings.EqualFold(since, "last-sync") {
+		return snapshots[len(snapshots)-1], nil
+	}
+	at, err := parseLocalTime(since)
+	if err != nil {
+		return jira.Snapshot{}, err
+	}
+	if day, dayErr := parseDay(since); dayErr == nil {
+		// A day means its end, so a sync that day counts.
+		at = day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if !snapshots[i].TakenAt.After(at) {
+			return snapshots[i], nil
+		}
+	}
+	return jira.Snapshot{}, fmt.Errorf("no snapshot was taken by %s; the oldest is from %s", since, snapshotTime(snapshots[0].TakenAt))
+}
+
+func snapshotTime(t time.Time) string {
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// changeDetail describes a change in a few words, e.g. "To Do → Done".
+func changeDetail(change jira.Change) string {
+	switch change.Kind {
+	case jira.ChangeComments:
+		detail := fmt.Sprintf("%d new", change.Comments)
+		if change.Author != "" {
+			detail += ", latest by " + change.Author
+		}
+		return detail
+	case jira.ChangeAssigned:
+		return change.To
+	case jira.ChangeUnassigned:
+		return change.From
+	default:
+		return change.From + " → " + change.To
+	}
+}
+
+func displayChanges(base, current jira.Snapshot, changes []jira.Change) {
+	if len(changes) == 0 {
+		fmt.Printf("No changes since %s.\n", snapshotTime(base.TakenAt))
+		return
+	}
+	fmt.Printf("Found %d changes between %s and %s:\n", len(changes), snapshotTime(base.TakenAt), snapshotTime(current.TakenAt))
+	for _, group := range changeKinds {
+		var items []jira.Change
+		for _, change := range changes {
+			if change.Kind == group.kind {
+				items = append(items, change)
+			}
+		}
+		if len(items) == 0 {
+			continue
+		}
+		fmt.Printf("\n%s (%d):\n", group.title, len(items))
+		for _, change := range items {
+			fmt.Printf("  %s - %s (%s)\n", change.Key, change.Summary, changeDetail(change))
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"devflow/internal/config"
+	"devflow/internal/jira"
+)
+
+func TestSyncOfflineAndChanges(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"},
+	})
+	origPath, origNow, origOutput := snapshotPath, now, errorOutput
+	origSync, origOffline, origSince, origChangesOffline := syncSnapshot, offline, changesSince, changesOffline
+	t.Cleanup(func() {
+		snapshotPath, now, errorOutput = origPath, origNow, origOutput
+		syncSnapshot, offline, changesSince, changesOffline = origSync, origOffline, origSince, origChangesOffline
+	})
+	path := filepath.Join(t.TempDir(), "snapshots.json")
+	snapshotPath = func() string { return path }
+	var stderr bytes.Buffer
+	errorOutput = &stderr
+
+	issues := `{"key":"ENG-1","fields":{"summary":"Crash","status":{"name":"To Do"},"priority":{"name":"Medium"}}}`
+	requests := 0
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/search/jql" {
+			t.Errorf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+		}
+		requests++
+		_, _ = w.Write([]byte(`{"issues":[` + issues + `],"isLast":true}`))
+	})
+
+	now = func() time.Time { return time.Date(2024, 6, 1, 9, 0, 0, 0, time.Local) }
+	syncSnapshot = true
+	out := captureStdout(func() { listTasksCmd.Run(listTasksCmd, nil) })
+	syncSnapshot = false
+	if !strings.Contains(out, "ENG-1 - Crash") || !strings.Contains(stderr.String(), "Synced 1 issues") {
+		t.Fatalf("unexpected sync output %q, %q", out, stderr.String())
+	}
+
+	offline = true
+	out = captureStdout(func() { listTasksCmd.Run(commandWithFormat(formatJSON), nil) })
+	offline = false
+	var listed []jira.Issue
+	if err := json.Unmarshal([]byte(out), &listed); err != nil || len(listed) != 1 || requests != 1 {
+		t.Fatalf("expected the offline list to read the snapshot, got %q after %d requests (%v)", out, requests, err)
+	}
+
+	issues = `{"key":"ENG-1","fields":{"summary":"Crash","status":{"name":"In Review"},"priority":{"name":"High"},"comment":{"total":1,"comments":[{"author":{"displayName":"Bob"}}]}}},
+		{"key":"ENG-2","fields":{"summary":"Login","status":{"name":"To Do"}}}`
+	now = func() time.Time { return time.Date(2024, 6, 2, 9, 0, 0, 0, time.Local) }
+	changesSince = "last-sync"
+	out = captureStdout(func() { changesCmd.Run(changesCmd, nil) })
+	for _, want := range []string{
+		"Found 4 changes between 2024-06-01 09:00 and 2024-06-02 09:00",
+		"Newly assigned (1):\n  ENG-2 - Login (To Do)",
+		"Priority raised (1):\n  ENG-1 - Crash (Medium → High)",
+		"Status changed (1):\n  ENG-1 - Crash (To Do → In Review)",
+		"New comments (1):\n  ENG-1 - Crash (1 new, latest by Bob)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("changes missing %q:\n%s", want, out)
+		}
+	}
+
+	syncSnapshot = true
+	captureStdout(func() { listTasksCmd.Run(listTasksCmd, nil) })
+	syncSnapshot = false
+	changesOffline, changesSince = true, "2024-06-01"
+	out = captureStdout(func() { changesCmd.Run(commandWithFormat(formatJSON), nil) })
+	var report struct {
+		Changes []jira.Change `json:"changes"`
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil || len(report.Changes) != 4 || requests != 3 {
+		t.Fatalf("expected the offline diff of both snapshots, got %q after %d requests (%v)", out, requests, err)
+	}
+
+	snapshots, _ := jira.LoadSnapshots(path, "https://jira.example")
+	if _, err := snapshotSince(snapshots, "2024-05-31"); err == nil || !strings.Contains(err.Error(), "the oldest is from 2024-06-01 09:00") {
+		t.Fatalf("expected no snapshot before the first sync, got %v", err)
+	}
+}
@@ -19,7 +19,7 @@ var (
 var commentCmd = &cobra.Command{
 	Use:   "comment [issue-key]",
 	Short: "Add a comment to a Jira issue",
-	Long:  "Add a textual comment to a Jira issue (supports inline text or file input)",
+	Long:  "Add a comment to a Jira issue from inline text or a file. The body is Markdown and is converted to Atlassian Document Format.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		issueKey := args[0]
@@ -34,25 +34,25 @@ var commentCmd = &cobra.Command{
 		// Load config
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
-		if cfg.Jira.URL == "" || cfg.Jira.Username == "" || cfg.Jira.Token == "" {
+		if cfg.Jira.URL == "" || jiraUsernameMissing(cfg.Jira) || cfg.Jira.Token == "" {
 			log.Fatal("Jira configuration incomplete. Run: devflow config set jira.url|jira.username|jira.token ...")
 		}
 
 		// Resolve comment body
 		body, err := resolveCommentBody(commentBody, commentBodyFile)
 		if err != nil {
-			log.Fatalf("Failed to read comment body: %v", err)
+			fatalError("Failed to read comment body", err)
 		}
 
 		client := jira.NewClient(&cfg.Jira)
-		if err := client.AddComment(issueKey, body); err != nil {
-			log.Fatalf("Failed to add comment: %v", err)
+		if err := client.AddCommentContext(commandContext(cmd), issueKey, body); err != nil {
+			fatalError("Failed to add comment", err)
 		}
 		if wantsJSON(cmd) {
 			if err := printJSON(map[string]string{"issue": issueKey, "body": body, "added": "true"}); err != nil {
-				log.Fatalf("Error encoding JSON: %v", err)
+				fatalError("Error encoding JSON", err)
 			}
 			return
 		}
@@ -66,8 +66,8 @@ var commentCmd = &cobra.Command{
 }
 
 func init() {
-	commentCmd.Flags().StringVarP(&commentBody, "body", "b", "", "Inline comment body text")
-	commentCmd.Flags().StringVar(&commentBodyFile, "body-file", "", "Path to file containing comment body")
+	commentCmd.Flags().StringVarP(&commentBody, "body", "b", "", "Inline comment body (Markdown)")
+	commentCmd.Flags().StringVar(&commentBodyFile, "body-file", "", "Path to a Markdown file containing the comment body")
 }
 
 func resolveCommentBody(inline, filePath string) (string, error) {
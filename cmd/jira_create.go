@@ -24,40 +24,89 @@ var (
 	createTeam            string
 	createDescription     string
 	createDescriptionFile string
+	createFields          []string
+	createTemplate        string
+	createVars            []string
 )
 
 var createTaskCmd = &cobra.Command{
 	Use:   "create [title]",
 	Short: "Create a new Jira task",
-	Long:  `Create a new Jira task with the specified title and optional metadata`,
-	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		title := args[0]
+	Long: `Create a new Jira task with the specified title and optional metadata.
+
+--epic, --story-points, --sprint and --team are set on the custom fields the
+project's create screen names "Epic Link", "Story Points", "Sprint" and
+"Team"; values without such a field are left out with a warning. Any other
+field on the create screen is set with --field "Name=value", e.g.
+
+  devflow tasks create "Fix login" --type Bug --field "Severity=High" --field "Due date=2024-06-01"
 
-		if createProjectKey == "" {
-			log.Fatal("--project is required (Jira project key)")
+--template creates an issue, and the sub-tasks it lists, from a YAML or JSON
+file in ~/.devflow/templates; flags override the template's values, and
+--var sets the variables its text refers to, e.g.
+
+  devflow tasks create --template bug-report --var component=api "Login fails"`,
+	Args: cobra.RangeArgs(0, 1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if createTemplate != "" {
+			createFromTemplate(cmd, args)
+			return
+		}
+		if len(args) == 0 {
+			log.Fatal("Provide a title, or create the issue from a --template")
 		}
+		title := args[0]
 
 		// Load config
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
+		}
+
+		// The flag wins over a project key pinned in config
+		projectKey := createProjectKey
+		if projectKey == "" {
+			projectKey = cfg.Jira.ProjectKey
+		}
+		if projectKey == "" {
+			log.Fatal("--project is required (Jira project key), or set jira.project_key")
 		}
-		if cfg.Jira.URL == "" || cfg.Jira.Username == "" || cfg.Jira.Token == "" {
+		if cfg.Jira.URL == "" || jiraUsernameMissing(cfg.Jira) || cfg.Jira.Token == "" {
 			log.Fatal("Jira configuration incomplete. Run: devflow config set jira.url|jira.username|jira.token ...")
 		}
 
 		description, err := resolveDescription(createDescription, createDescriptionFile)
 		if err != nil {
-			log.Fatalf("Failed to read description: %v", err)
+			fatalError("Failed to read description", err)
+		}
+		fieldValues, err := parseFieldAssignments(createFields)
+		if err != nil {
+			fatalError("Invalid --field", err)
 		}
 
 		labels := parseLabels(createLabels)
 
+		ctx := commandContext(cmd)
 		client := jira.NewClient(&cfg.Jira)
+		sprint, err := resolveSprintFlag(ctx, client, cfg, projectKey, createSprint)
+		if err != nil {
+			fatalError("Cannot resolve --sprint", err)
+		}
 
-		issue, err := client.CreateIssue(jira.CreateIssueOptions{
-			ProjectKey:  createProjectKey,
+		var fields map[string]interface{}
+		if len(fieldValues) > 0 {
+			screen, err := client.CreateMetaContext(ctx, projectKey, createIssueType)
+			if err != nil {
+				fatalError("Failed to read the create screen", err)
+			}
+			fields, err = resolveFieldValues(ctx, client, screen, fieldValues, fmt.Sprintf("create screen of %s %s", projectKey, createIssueType))
+			if err != nil {
+				fatalError("Cannot create issue", err)
+			}
+		}
+
+		issue, err := client.CreateIssueContext(ctx, jira.CreateIssueOptions{
+			ProjectKey:  projectKey,
 			Summary:     title,
 			Description: description,
 			IssueType:   createIssueType,
@@ -66,15 +115,16 @@ var createTaskCmd = &cobra.Command{
 			Labels:      labels,
 			Epic:        createEpic,
 			StoryPoints: createStoryPoints,
-			Sprint:      createSprint,
+			Sprint:      sprint,
 			Team:        createTeam,
+			Fields:      fields,
 		})
 		if err != nil {
-			log.Fatalf("Failed to create Jira issue: %v", err)
+			fatalError("Failed to create Jira issue", err)
 		}
 		if wantsJSON(cmd) {
 			if err := printJSON(map[string]string{"key": issue.Key, "title": title, "url": cfg.Jira.URL + "/browse/" + issue.Key}); err != nil {
-				log.Fatalf("Error encoding JSON: %v", err)
+				fatalError("Error encoding JSON", err)
 			}
 			return
 		}
@@ -89,17 +139,138 @@ var createTaskCmd = &cobra.Command{
 }
 
 func init() {
-	createTaskCmd.Flags().StringVarP(&createProjectKey, "project", "p", "", "Jira project key (required)")
+	createTaskCmd.Flags().StringVarP(&createProjectKey, "project", "p", "", "Jira project key (defaults to jira.project_key)")
 	createTaskCmd.Flags().StringVarP(&createIssueType, "type", "t", "Task", "Issue type (Task, Story, Bug, etc.)")
 	createTaskCmd.Flags().StringVar(&createPriority, "priority", "", "Priority (Highest, High, Medium, Low, Lowest)")
-	createTaskCmd.Flags().StringVar(&createAssignee, "assignee", "", "Assignee username (may require accountId in some instances)")
+	createTaskCmd.Flags().StringVar(&createAssignee, "assignee", "", "Assignee: me, an email address, display name or accountId")
 	createTaskCmd.Flags().StringVar(&createLabels, "labels", "", "Comma-separated labels (e.g. backend,api,urgent)")
 	createTaskCmd.Flags().StringVar(&createEpic, "epic", "", "Epic key to link (depends on Jira setup)")
-	createTaskCmd.Flags().Float64Var(&createStoryPoints, "story-points", 0, "Story points estimate (will use common custom field id)")
-	createTaskCmd.Flags().StringVar(&createSprint, "sprint", "", "Sprint name or ID (depends on Jira setup)")
+	createTaskCmd.Flags().Float64Var(&createStoryPoints, "story-points", 0, "Story points estimate")
+	createTaskCmd.Flags().StringVar(&createSprint, "sprint", "", "Sprint ID or name, or active or next, of the project's board")
 	createTaskCmd.Flags().StringVar(&createTeam, "team", "", "Team name or ID for Team Assigned custom field")
-	createTaskCmd.Flags().StringVarP(&createDescription, "description", "d", "", "Issue description text")
-	createTaskCmd.Flags().StringVar(&createDescriptionFile, "description-file", "", "Path to file for description body")
+	createTaskCmd.Flags().StringVarP(&createDescription, "description", "d", "", "Issue description (Markdown)")
+	createTaskCmd.Flags().StringVar(&createDescriptionFile, "description-file", "", "Path to a Markdown file for the description body")
+	createTaskCmd.Flags().StringArrayVar(&createFields, "field", nil, "Create screen field as \"Name=value\" (repeatable)")
+	createTaskCmd.Flags().StringVar(&createTemplate, "template", "", "Issue template name in ~/.devflow/templates, or a path to a template file")
+	createTaskCmd.Flags().StringArrayVar(&createVars, "var", nil, "Template variable as \"name=value\" (repeatable)")
+}
+
+// createFromTemplate creates the issue tree of --template, with the flags
+// overriding the template's values. The title argument, when given, is the
+// summary unless the template has one, and is available as {{.title}}.
+func createFromTemplate(cmd *cobra.Command, args []string) {
+	tmpl, err := loadIssueTemplate(createTemplate)
+	if err != nil {
+		fatalError("Cannot load template", err)
+	}
+	vars, err := parseTemplateVars(createVars)
+	if err != nil {
+		fatalError("Invalid --var", err)
+	}
+	for name, value := range tmpl.Vars {
+		if _, ok := vars[name]; !ok {
+			vars[name] = value
+		}
+	}
+	if len(args) > 0 {
+		vars["title"] = args[0]
+	}
+	rendered, err := tmpl.render(vars)
+	if err != nil {
+		fatalError("Cannot expand template "+createTemplate, err)
+	}
+
+	if rendered.Summary == "" && len(args) > 0 {
+		rendered.Summary = args[0]
+	}
+	if rendered.Summary == "" {
+		log.Fatal("The template has no summary; pass a title")
+	}
+	if cmd.Flags().Changed("type") || rendered.Type == "" {
+		rendered.Type = createIssueType
+	}
+	if createPriority != "" {
+		rendered.Priority = createPriority
+	}
+	if createAssignee != "" {
+		rendered.Assignee = createAssignee
+	}
+	if createEpic != "" {
+		rendered.Epic = createEpic
+	}
+	if createSprint != "" {
+		rendered.Sprint = createSprint
+	}
+	if createTeam != "" {
+		rendered.Team = createTeam
+	}
+	if createStoryPoints != 0 {
+		rendered.StoryPoints = createStoryPoints
+	}
+	rendered.Labels = append(rendered.Labels, parseLabels(createLabels)...)
+	description, err := resolveDescription(createDescription, createDescriptionFile)
+	if err != nil {
+		fatalError("Failed to read description", err)
+	}
+	if description != "" {
+		rendered.Description = description
+	}
+	fieldValues, err := parseFieldAssignments(createFields)
+	if err != nil {
+		fatalError("Invalid --field", err)
+	}
+	for name, value := range fieldValues {
+		rendered.Fields[name] = value
+	}
+
+	cfg, client := loadJiraClient()
+	projectKey := createProjectKey
+	if projectKey == "" {
+		projectKey = rendered.Project
+	}
+	if projectKey == "" {
+		projectKey = cfg.Jira.ProjectKey
+	}
+	if projectKey == "" {
+		log.Fatal("--project is required (Jira project key), or set project in the template or jira.project_key")
+	}
+
+	if rendered.Sprint, err = resolveSprintFlag(commandContext(cmd), client, cfg, projectKey, rendered.Sprint); err != nil {
+		fatalError("Cannot resolve the sprint", err)
+	}
+	for i := range rendered.Subtasks {
+		if rendered.Subtasks[i].Sprint, err = resolveSprintFlag(commandContext(cmd), client, cfg, projectKey, rendered.Subtasks[i].Sprint); err != nil {
+			fatalError("Cannot resolve the sprint of a sub-task", err)
+		}
+	}
+	created, err := createIssueTree(commandContext(cmd), client, cfg.Jira.URL, projectKey, rendered)
+	if err != nil {
+		context := "Failed to create Jira issue"
+		if keys := createdKeys(created); len(keys) > 0 {
+			context = fmt.Sprintf("Created %s, then failed", strings.Join(keys, ", "))
+		}
+		fatalError(context, err)
+	}
+
+	if wantsJSON(cmd) {
+		if err := printJSON(created); err != nil {
+			fatalError("Error encoding JSON", err)
+		}
+		return
+	}
+	if wantsTabular(cmd) {
+		rows := [][]any{{created.Key, created.Title, "", created.URL}}
+		for _, subtask := range created.Subtasks {
+			rows = append(rows, []any{subtask.Key, subtask.Title, created.Key, subtask.URL})
+		}
+		renderTable([]string{"Key", "Title", "Parent", "URL"}, rows)
+		return
+	}
+	fmt.Printf("Created Jira issue %s: %s\n", created.Key, created.Title)
+	fmt.Printf("URL: %s\n", created.URL)
+	for _, subtask := range created.Subtasks {
+		fmt.Printf("  └─ %s: %s\n", subtask.Key, subtask.Title)
+	}
 }
 
 func parseLabels(raw string) []string {
@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"devflow/internal/adf"
+	"devflow/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fieldsProject   string
+	fieldsIssueType string
+	fieldsIssue     string
+)
+
+var fieldsCmd = &cobra.Command{
+	Use:   "fields [filter]",
+	Short: "List Jira fields and their types",
+	Long: `List the fields defined on the Jira instance, or those of a create or edit
+screen, to find the names to use with --field "Name=value", e.g.
+
+  devflow tasks fields story
+  devflow tasks fields --project ENG --type Bug
+  devflow tasks fields --issue ENG-123
+
+The optional filter keeps fields whose name or ID contains it, ignoring case.
+With --type alone the project defaults to jira.project_key.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		cfg, client := loadJiraClient()
+
+		project := fieldsProject
+		if project == "" && cmd.Flags().Changed("type") {
+			project = cfg.Jira.ProjectKey
+		}
+		var fields []fieldInfo
+		switch {
+		case fieldsIssue != "":
+			screen, err := client.EditMetaContext(ctx, fieldsIssue)
+			if err != nil {
+				fatalError("Failed to read the edit screen", err)
+			}
+			fields = screenFieldInfo(screen)
+		case project != "":
+			screen, err := client.CreateMetaContext(ctx, project, fieldsIssueType)
+			if err != nil {
+				fatalError("Failed to read the create screen", err)
+			}
+			fields = screenFieldInfo(screen)
+		default:
+			all, err := client.ListFieldsContext(ctx)
+			if err != nil {
+				fatalError("Failed to list fields", err)
+			}
+			for _, field := range all {
+				fields = append(fields, fieldInfo{ID: field.ID, Name: field.Name, Type: fieldType(field.Schema), Custom: field.Custom})
+			}
+		}
+		if len(args) == 1 {
+			fields = filterFieldInfo(fields, args[0])
+		}
+		sort.Slice(fields, func(i, j int) bool {
+			if !strings.EqualFold(fields[i].Name, fields[j].Name) {
+				return strings.ToLower(fields[i].Name) < strings.ToLower(fields[j].Name)
+			}
+			return fields[i].ID < fields[j].ID
+		})
+
+		if wantsJSON(cmd) {
+			if err := printJSON(fields); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			rows := make([][]any, 0, len(fields))
+			for _, field := range fields {
+				rows = append(rows, []any{field.ID, field.Name, field.Type, field.Required, strings.Join(field.Allowed, ", ")})
+			}
+			renderTable([]string{"ID", "Name", "Type", "Required", "Allowed values"}, rows)
+			return
+		}
+		if len(fields) == 0 {
+			fmt.Println("No fields found.")
+			return
+		}
+		fmt.Printf("Found %d fields:\n\n", len(fields))
+		for _, field := range fields {
+			fmt.Printf("  %s (%s) %s", field.Name, field.ID, field.Type)
+			if field.Required {
+				fmt.Print(" required")
+			}
+			fmt.Println()
+			if len(field.Allowed) > 0 {
+				fmt.Printf("     one of: %s\n", strings.Join(field.Allowed, ", "))
+			}
+		}
+	},
+}
+
+func init() {
+	fieldsCmd.Flags().StringVarP(&fieldsProject, "project", "p", "", "List the create screen fields of this project")
+	fieldsCmd.Flags().StringVarP(&fieldsIssueType, "type", "t", "Task", "Issue type of the create screen")
+	fieldsCmd.Flags().StringVar(&fieldsIssue, "issue", "", "List the editable fields of this issue")
+	fieldsCmd.MarkFlagsMutuallyExclusive("project", "issue")
+}
+
+type fieldInfo struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Custom   bool     `json:"custom,omitempty"`
+	Required bool     `json:"required,omitempty"`
+	Allowed  []string `json:"allowed_values,omitempty"`
+}
+
+func screenFieldInfo(screen map[string]jira.FieldMeta) []fieldInfo {
+	fields := make([]fieldInfo, 0, len(screen))
+	for id, meta := range screen {
+		info := fieldInfo{ID: id, Name: fieldLabel(meta), Type: fieldType(meta.Schema), Custom: strings.HasPrefix(id, "customfield_"), Required: meta.Required && !meta.HasDefaultValue}
+		for _, allowed := range meta.AllowedValues {
+			info.Allowed = append(info.Allowed, allowed.Label())
+		}
+		fields = append(fields, info)
+	}
+	return fields
+}
+
+func filterFieldInfo(fields []fieldInfo, filter string) []fieldInfo {
+	filter = strings.ToLower(filter)
+	var matched []fieldInfo
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field.Name), filter) || strings.Contains(strings.ToLower(field.ID), filter) {
+			matched = append(matched, field)
+		}
+	}
+	return matched
+}
+
+// fieldType describes a schema as "type" or "array of items".
+func fieldType(schema jira.FieldSchema) string {
+	if schema.Type == "array" && schema.Items != "" {
+		return "array of " + schema.Items
+	}
+	return schema.Type
+}
+
+// parseFieldAssignments splits repeated "Name=value" flags into a map keyed by
+// the name as given.
+func parseFieldAssignments(assignments []string) (map[string]string, error) {
+	values := make(map[string]string, len(assignments))
+	for _, assignment := range assignments {
+		name, value, ok := strings.Cut(assignment, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid field %q: expected Name=value", assignment)
+		}
+		values[name] = strings.TrimSpace(value)
+	}
+	return values, nil
+}
+
+// findFieldMeta looks a field up by ID or, case-insensitively, by display name.
+func findFieldMeta(fields map[string]jira.FieldMeta, name string) (string, jira.FieldMeta, bool) {
+	if meta, ok := fields[name]; ok {
+		return name, meta, true
+	}
+	for id, meta := range fields {
+		if strings.EqualFold(meta.Name, name) || strings.EqualFold(id, name) {
+			return id, meta, true
+		}
+	}
+	return "", jira.FieldMeta{}, false
+}
+
+// userFieldFunc returns the value of a user field for "me", an email
+// address, a username, a display name or an accountId.
+type userFieldFunc func(who string) (interface{}, error)
+
+// resolveFieldValues encodes "Name=value" assignments for the fields of a
+// screen, keyed by field ID. Users are resolved through the client's user
+// directory. screenName describes the screen in errors, e.g. "create screen
+// of ENG Story".
+func resolveFieldValues(ctx context.Context, client *jira.Client, screen map[string]jira.FieldMeta, assignments map[string]string, screenName string) (map[string]interface{}, error) {
+	users := func(who string) (interface{}, error) { return client.UserFieldContext(ctx, who) }
+	values := make(map[string]interface{}, len(assignments))
+	for name, raw := range assignments {
+		id, meta, ok := findFieldMeta(screen, name)
+		if !ok {
+			return nil, fmt.Errorf("field %q is not on the %s", name, screenName)
+		}
+		value, err := encodeFieldValue(meta, raw, users)
+		if err != nil {
+			return nil, err
+		}
+		values[id] = value
+	}
+	return values, nil
+}
+
+// encodeFieldValue converts a command-line value to the JSON shape Jira
+// expects for the field: options are sent by ID, numbers as numbers, arrays
+// from comma-separated lists, users through users, dates as ISO dates and
+// multi-line text as Atlassian Document Format. An empty value clears the
+// field. The sprint field is an array in its schema but takes one bare
+// sprint ID.
+func encodeFieldValue(meta jira.FieldMeta, raw string, users userFieldFunc) (interface{}, error) {
+	if strings.HasSuffix(meta.Schema.Custom, ":gh-sprint") {
+		if raw == "" {
+			return nil, nil
+		}
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sprint %q for %s: expected a sprint ID", raw, fieldLabel(meta))
+		}
+		return id, nil
+	}
+	if meta.Schema.Type == "array" {
+		parts := parseLabels(raw)
+		values := make([]interface{}, 0, len(parts))
+		item := meta
+		item.Schema.Type = meta.Schema.Items
+		for _, part := range parts {
+			value, err := encodeFieldValue(item, part, users)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+	if raw == "" {
+		return nil, nil
+	}
+
+	if len(meta.AllowedValues) > 0 {
+		labels := make([]string, 0, len(meta.AllowedValues))
+		for _, allowed := range meta.AllowedValues {
+			if strings.EqualFold(allowed.Label(), raw) || allowed.ID == raw {
+				return map[string]string{"id": allowed.ID}, nil
+			}
+			labels = append(labels, allowed.Label())
+		}
+		return nil, fmt.Errorf("invalid value %q for %s (allowed: %s)", raw, fieldLabel(meta), strings.Join(labels, ", "))
+	}
+
+	if strings.HasSuffix(meta.Schema.Custom, ":textarea") {
+		return adf.FromMarkdown(raw), nil
+	}
+
+	switch meta.Schema.Type {
+	case "number":
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q for %s", raw, fieldLabel(meta))
+		}
+		return number, nil
+	case "date":
+		day, err := parseDay(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fieldLabel(meta), err)
+		}
+		return day.Format(time.DateOnly), nil
+	case "datetime":
+		moment, err := parseLocalTime(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fieldLabel(meta), err)
+		}
+		return moment.Format(jira.WorklogTimeLayout), nil
+	case "user":
+		value, err := users(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fieldLabel(meta), err)
+		}
+		return value, nil
+	case "option":
+		return map[string]string{"value": raw}, nil
+	case "priority", "resolution", "version", "component", "issuetype":
+		return map[string]string{"name": raw}, nil
+	case "issuelink":
+		return map[string]string{"key": raw}, nil
+	default:
+		return raw, nil
+	}
+}
+
+// missingRequiredFields lists the required fields without a default that
+// values does not set, sorted by label.
+func missingRequiredFields(fields map[string]jira.FieldMeta, values map[string]interface{}) []string {
+	var missing []string
+	for id, meta := range fields {
+		if !meta.Required || meta.HasDefaultValue {
+			continue
+		}
+		if _, ok := values[id]; ok {
+			continue
+		}
+		label := fieldLabel(meta)
+		if len(meta.AllowedValues) > 0 {
+			options := make([]string, 0, len(meta.AllowedValues))
+			for _, allowed := range meta.AllowedValues {
+				options = append(options, allowed.Label())
+			}
+			label += " (one of: " + strings.Join(options, ", ") + ")"
+		}
+		missing = append(missing, label)
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+func fieldLabel(meta jira.FieldMeta) string {
+	if meta.Name != "" {
+		return meta.Name
+	}
+	return meta.Key
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"devflow/internal/config"
+	"devflow/internal/jira"
+)
+
+func TestEncodeFieldValueTypes(t *testing.T) {
+	origNow := now
+	t.Cleanup(func() { now = origNow })
+	now = func() time.Time { return time.Date(2024, 5, 9, 15, 0, 0, 0, time.Local) }
+
+	meta := func(schema string) jira.FieldMeta {
+		var m jira.FieldMeta
+		if err := json.Unmarshal([]byte(`{"name":"Field","schema":`+schema+`}`), &m); err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	users := func(who string) (interface{}, error) { return map[string]string{"accountId": "id-" + who}, nil }
+	cases := []struct {
+		schema, raw, want string
+	}{
+		{`{"type":"date"}`, "today", `"2024-05-09"`},
+		{`{"type":"datetime"}`, "2024-05-06 14:30", `"2024-05-06T14:30:00.000` + time.Date(2024, 5, 6, 14, 30, 0, 0, time.Local).Format("-0700") + `"`},
+		{`{"type":"array","items":"json","custom":"com.pyxis.greenhopper.jira:gh-sprint"}`, "42", `42`},
+		{`{"type":"array","items":"json","custom":"com.pyxis.greenhopper.jira:gh-sprint"}`, "", `null`},
+		{`{"type":"array","items":"user"}`, "abc, def", `[{"accountId":"id-abc"},{"accountId":"id-def"}]`},
+		{`{"type":"array","items":"string"}`, "", `[]`},
+		{`{"type":"number"}`, "", `null`},
+		{`{"type":"issuelink"}`, "ENG-1", `{"key":"ENG-1"}`},
+		{`{"type":"string","custom":"com.atlassian.jira.plugin.system.customfieldtypes:textarea"}`, "**bold**", `{"type":"doc"`},
+	}
+	for _, c := range cases {
+		value, err := encodeFieldValue(meta(c.schema), c.raw, users)
+		if err != nil {
+			t.Fatalf("encodeFieldValue(%s, %q) failed: %v", c.schema, c.raw, err)
+		}
+		encoded, _ := json.Marshal(value)
+		if !strings.HasPrefix(string(encoded), strings.TrimSuffix(c.want, "}")) {
+			t.Fatalf("encodeFieldValue(%s, %q) = %s, want %s", c.schema, c.raw, encoded, c.want)
+		}
+	}
+	for _, bad := range [][2]string{{`{"type":"date"}`, "soon"}, {`{"type":"array","items":"json","custom":"com.pyxis.greenhopper.jira:gh-sprint"}`, "Sprint 4"}} {
+		if _, err := encodeFieldValue(meta(bad[0]), bad[1], users); err == nil {
+			t.Fatalf("expected encodeFieldValue(%s, %q) to fail", bad[0], bad[1])
+		}
+	}
+}
+
+func TestCreateAndUpdateWithFields(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", ProjectKey: "ENG", Deployment: "cloud"},
+	})
+	var created, updated map[string]map[string]any
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/issue/createmeta/ENG/issuetypes":
+			_, _ = w.Write([]byte(`{"issueTypes":[{"id":"1","name":"Bug"}]}`))
+		case "/rest/api/3/issue/createmeta/ENG/issuetypes/1":
+			_, _ = w.Write([]byte(`{"total":2,"fields":[
+				{"fieldId":"customfield_10050","name":"Severity","schema":{"type":"option"},"allowedValues":[{"id":"7","value":"High"}]},
+				{"fieldId":"customfield_10060","name":"Story point estimate","schema":{"type":"number"}}]}`))
+		case "/rest/api/3/issue":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"key":"ENG-9"}`))
+		case "/rest/api/3/issue/ENG-9/editmeta":
+			_, _ = w.Write([]byte(`{"fields":{"duedate":{"name":"Due date","schema":{"type":"date"}},"customfield_10060":{"name":"Story point estimate","schema":{"type":"number"}}}}`))
+		case "/rest/api/3/issue/ENG-9":
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	origType, origPoints, origFields, origUpdFields, origUpdPoints := createIssueType, createStoryPoints, createFields, updateFields, updateStoryPoints
+	origFixVersions := updateFixVersions
+	t.Cleanup(func() {
+		createIssueType, createStoryPoints, createFields, updateFields, updateStoryPoints = origType, origPoints, origFields, origUpdFields, origUpdPoints
+		updateFixVersions = origFixVersions
+	})
+	createIssueType, createStoryPoints, createFields = "Bug", 3, []string{"severity=high"}
+	out := captureStdout(func() { createTaskCmd.Run(createTaskCmd, []string{"Crash on save"}) })
+	if !strings.Contains(out, "Created Jira issue ENG-9") {
+		t.Fatalf("unexpected output %q", out)
+	}
+	fields := created["fields"]
+	if severity, _ := fields["customfield_10050"].(map[string]any); severity["id"] != "7" || fields["customfield_10060"] != 3.0 {
+		t.Fatalf("unexpected created fields: %+v", fields)
+	}
+
+	updateFields, updateStoryPoints, updateFixVersions = []string{"Due date=2024-06-01"}, 5, []string{"2.4.0"}
+	captureStdout(func() { updateTaskCmd.Run(updateTaskCmd, []string{"ENG-9"}) })
+	if updated["fields"]["duedate"] != "2024-06-01" || updated["fields"]["customfield_10060"] != 5.0 {
+		t.Fatalf("unexpected updated fields: %+v", updated)
+	}
+	if versions, _ := updated["fields"]["fixVersions"].([]any); len(versions) != 1 || versions[0].(map[string]any)["name"] != "2.4.0" {
+		t.Fatalf("unexpected fix versions: %+v", updated["fields"]["fixVersions"])
+	}
+	if cleared := fixVersionsField([]string{"none"}); len(cleared) != 0 || cleared == nil {
+		t.Fatalf("expected none to clear the fix versions, got %+v", cleared)
+	}
+}
+
+func TestResolveFieldValuesResolvesUsers(t *testing.T) {
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/myself":
+			_, _ = w.Write([]byte(`{"name":"alice","key":"alice","displayName":"Alice"}`))
+		case "/rest/api/2/user/search":
+			if r.URL.Query().Get("username") != "bob@example.com" {
+				t.Errorf("unexpected user search %q", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"name":"bob","key":"bob","displayName":"Bob","emailAddress":"bob@example.com"}]`))
+		default:
+			t.Fatalf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	client := jira.NewClient(&config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "server"})
+	screen := map[string]jira.FieldMeta{}
+	for id, name := range map[string]string{"customfield_1": "Reviewer", "customfield_2": "Approvers"} {
+		var meta jira.FieldMeta
+		meta.Name = name
+		meta.Schema.Type = "user"
+		if id == "customfield_2" {
+			meta.Schema.Type, meta.Schema.Items = "array", "user"
+		}
+		screen[id] = meta
+	}
+
+	values, err := resolveFieldValues(context.Background(), client, screen, map[string]string{"Reviewer": "me", "Approvers": "bob@example.com"}, "edit screen of ENG-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, _ := json.Marshal(values)
+	if string(encoded) != `{"customfield_1":{"name":"alice"},"customfield_2":[{"name":"bob"}]}` {
+		t.Fatalf("unexpected user fields: %s", encoded)
+	}
+}
+
+func TestFieldsCmd(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"},
+	})
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/field" {
+			t.Fatalf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`[
+			{"id":"customfield_10016","name":"Story Points","custom":true,"schema":{"type":"number"}},
+			{"id":"labels","name":"Labels","schema":{"type":"array","items":"string"}}
+		]`))
+	})
+
+	out := captureStdout(func() { fieldsCmd.Run(commandWithFormat(formatJSON), []string{"story"}) })
+	var fields []fieldInfo
+	if err := json.Unmarshal([]byte(out), &fields); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(fields) != 1 || fields[0].ID != "customfield_10016" || !fields[0].Custom {
+		t.Fatalf("unexpected fields: %+v", fields)
+	}
+	out = captureStdout(func() { fieldsCmd.Run(fieldsCmd, nil) })
+	if !strings.Contains(out, "Labels (labels) array of string") {
+		t.Fatalf("unexpected output %q", out)
+	}
+}
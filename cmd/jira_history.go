@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"devflow/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var historyFields []string
+
+var historyCmd = &cobra.Command{
+	Use:   "history [issue-key]",
+	Short: "Show who changed what on a Jira issue, and the time spent in each status",
+	Long: `Show the changelog of an issue as a timeline of field changes, followed by
+the total time the issue spent in each status, e.g.
+
+  devflow tasks history ENG-123
+  devflow tasks history ENG-123 --field status --field assignee
+
+--field limits the timeline to the named fields; the time-in-status totals
+always cover the whole history, up to now for the current status.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		issueKey := args[0]
+
+		client := newJiraClient()
+		issue, err := client.GetIssueDetailsContext(ctx, issueKey)
+		if err != nil {
+			fatalError("Error fetching issue details", err)
+		}
+		histories, err := client.GetChangelogContext(ctx, issueKey)
+		if err != nil {
+			fatalError("Failed to fetch the changelog", err)
+		}
+		history, err := buildIssueHistory(issue, histories, historyFields, now())
+		if err != nil {
+			fatalError("Cannot read the history of "+issueKey, err)
+		}
+
+		if wantsJSON(cmd) {
+			if err := printJSON(history); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			rows := make([][]any, 0, len(history.Changes))
+			for _, change := range history.Changes {
+				rows = append(rows, []any{historyTime(change.When), change.Author, change.Field, change.From, change.To})
+			}
+			renderTable([]string{"When", "Author", "Field", "From", "To"}, rows)
+			fmt.Println()
+			rows = make([][]any, 0, len(history.TimeInStatus))
+			for _, status := range history.TimeInStatus {
+				rows = append(rows, []any{status.Status, status.Duration, status.Visits, status.Current})
+			}
+			renderTable([]string{"Status", "Time", "Visits", "Current"}, rows)
+			return
+		}
+		displayIssueHistory(history)
+	},
+}
+
+func init() {
+	historyCmd.Flags().StringArrayVar(&historyFields, "field", nil, "Only show changes of this field, e.g. status (repeatable)")
+}
+
+type issueHistory struct {
+	Key          string          `json:"key"`
+	Changes      []historyChange `json:"changes"`
+	TimeInStatus []statusTime    `json:"time_in_status"`
+}
+
+type historyChange struct {
+	When   time.Time `json:"when"`
+	Author string    `json:"author"`
+	Field  string    `json:"field"`
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+}
+
+type statusTime struct {
+	Status   string `json:"status"`
+	Seconds  int64  `json:"seconds"`
+	Duration string `json:"duration"`
+	Visits   int    `json:"visits"`
+	Current  bool   `json:"current,omitempty"`
+}
+
+// buildIssueHistory flattens the changelog into a timeline, keeping only the
+// given fields when any are named, and totals the time spent in each status
+// from the issue's creation until at.
+func buildIssueHistory(issue *jira.IssueDetails, histories []jira.ChangeHistory, fields []string, at time.Time) (issueHistory, error) {
+	history := issueHistory{Key: issue.Key, Changes: []historyChange{}}
+	var statusChanges []historyChange
+	for _, entry := range histories {
+		when, err := entry.CreatedAt()
+		if err != nil {
+			return history, fmt.Errorf("invalid changelog time %q: %w", entry.Created, err)
+		}
+		for _, item := range entry.Items {
+			change := historyChange{When: when, Author: entry.Author.DisplayName, Field: item.Field, From: item.FromString, To: item.ToString}
+			if isStatusChange(item) {
+				statusChanges = append(statusChanges, change)
+			}
+			if historyFieldSelected(item, fields) {
+				history.Changes = append(history.Changes, change)
+			}
+		}
+	}
+	sort.SliceStable(history.Changes, func(i, j int) bool { return history.Changes[i].When.Before(history.Changes[j].When) })
+	sort.SliceStable(statusChanges, func(i, j int) bool { return statusChanges[i].When.Before(statusChanges[j].When) })
+
+	created, err := time.Parse(jira.WorklogTimeLayout, issue.Fields.Created)
+	if err != nil {
+		return history, fmt.Errorf("invalid creation time %q: %w", issue.Fields.Created, err)
+	}
+	history.TimeInStatus = timeInStatus(created, issue.Fields.Status.Name, statusChanges, at)
+	return history, nil
+}
+
+// timeInStatus totals the time between status changes per status, in the
+// order the statuses were first entered. The first status is the one the
+// first change left, or the current status when it never changed.
+func timeInStatus(created time.Time, current string, changes []historyChange, at time.Time) []statusTime {
+	status := current
+	if len(changes) > 0 {
+		status = changes[0].From
+	}
+	var totals []statusTime
+	index := make(map[string]int)
+	enter := func(name string) {
+		if _, ok := index[name]; !ok {
+			index[name] = len(totals)
+			totals = append(totals, statusTime{Status: name})
+		}
+		totals[index[name]].Visits++
+	}
+	add := func(name string, d time.Duration) {
+		if d > 0 {
+			totals[index[name]].Seconds += int64(d / time.Second)
+		}
+	}
+
+	enter(status)
+	since := created
+	for _, change := range changes {
+		add(status, change.When.Sub(since))
+		status, since = change.To, change.When
+		enter(status)
+	}
+	add(status, at.Sub(since))
+	totals[index[status]].Current = true
+
+	for i := range totals {
+		totals[i].Duration = formatElapsed(time.Duration(totals[i].Seconds) * time.Second)
+	}
+	return totals
+}
+
+func isStatusChange(item jira.ChangeItem) bool {
+	return item.FieldID == "status" || (item.FieldID == "" && strings.EqualFold(item.Field, "status"))
+}
+
+func historyFieldSelected(item jira.ChangeItem, fields []string) bool {
+	if len(fields) == 0 {
+		return true
+	}
+	for _, field := range fields {
+		if strings.EqualFold(item.Field, field) || strings.EqualFold(item.FieldID, field) {
+			return true
+		}
+	}
+	return false
+}
+
+func displayIssueHistory(history issueHistory) {
+	if len(history.Changes) == 0 {
+		fmt.Printf("No changes recorded on %s\n", history.Key)
+	} else {
+		fmt.Printf("📜 History of %s (%d changes):\n\n", history.Key, len(history.Changes))
+		for _, change := range history.Changes {
+			fmt.Printf("%s  %s  %s: %s → %s\n", historyTime(change.When), change.Author, change.Field, historyValue(change.From), historyValue(change.To))
+		}
+	}
+
+	fmt.Printf("\n⏱️  Time in status:\n")
+	width := 0
+	for _, status := range history.TimeInStatus {
+		width = max(width, len(status.Status))
+	}
+	for _, status := range history.TimeInStatus {
+		fmt.Printf("  %-*s  %s", width, status.Status, status.Duration)
+		if status.Visits > 1 {
+			fmt.Printf(" (%d visits)", status.Visits)
+		}
+		if status.Current {
+			fmt.Print(" (current)")
+		}
+		fmt.Println()
+	}
+}
+
+func historyTime(t time.Time) string {
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func historyValue(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+// formatElapsed renders a calendar duration with its two largest units,
+// e.g. "2d 3h", "5h 10m" or "45m".
+func formatElapsed(d time.Duration) string {
+	minutes := int64(d / time.Minute)
+	days, hours, mins := minutes/(24*60), minutes/60%24, minutes%60
+	switch {
+	case days > 0 && hours > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	case hours > 0 && mins > 0:
+		return fmt.Sprintf("%dh %dm", hours, mins)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", mins)
+	}
+}
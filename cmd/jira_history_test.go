@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"devflow/internal/config"
+)
+
+func TestFormatElapsed(t *testing.T) {
+	cases := map[time.Duration]string{
+		30 * time.Second:              "0m",
+		45 * time.Minute:              "45m",
+		2 * time.Hour:                 "2h",
+		5*time.Hour + 10*time.Minute:  "5h 10m",
+		51*time.Hour + 20*time.Minute: "2d 3h",
+		72 * time.Hour:                "3d",
+	}
+	for d, want := range cases {
+		if got := formatElapsed(d); got != want {
+			t.Fatalf("formatElapsed(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestHistoryCmdTimeInStatus(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"},
+	})
+	origNow, origFields := now, historyFields
+	t.Cleanup(func() { now, historyFields = origNow, origFields })
+	now = func() time.Time { return time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC) }
+
+	status := func(when, from, to string) string {
+		return `{"author":{"displayName":"Alice"},"created":"` + when + `","items":[{"field":"status","fieldId":"status","fromString":"` + from + `","toString":"` + to + `"}]}`
+	}
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/field":
+			_, _ = w.Write([]byte(`[]`))
+		case "/rest/api/3/issue/ENG-1":
+			_, _ = w.Write([]byte(`{"key":"ENG-1","fields":{"created":"2024-05-06T09:00:00.000+0000","status":{"name":"In Review"}}}`))
+		case "/rest/api/3/issue/ENG-1/changelog":
+			_, _ = w.Write([]byte(`{"total":4,"isLast":true,"values":[` +
+				status("2024-05-06T10:00:00.000+0000", "To Do", "In Progress") + `,` +
+				status("2024-05-07T10:00:00.000+0000", "In Progress", "In Review") + `,` +
+				`{"author":{"displayName":"Bob"},"created":"2024-05-07T12:00:00.000+0000","items":[{"field":"assignee","fieldId":"assignee","toString":"Bob"}]},` +
+				status("2024-05-08T10:00:00.000+0000", "In Review", "In Progress") + `,` +
+				status("2024-05-08T12:00:00.000+0000", "In Progress", "In Review") + `]}`))
+		default:
+			t.Fatalf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	historyFields = []string{"Status"}
+	out := captureStdout(func() { historyCmd.Run(commandWithFormat(formatJSON), []string{"ENG-1"}) })
+	var history issueHistory
+	if err := json.Unmarshal([]byte(out), &history); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(history.Changes) != 4 {
+		t.Fatalf("expected only the status changes, got %+v", history.Changes)
+	}
+	want := []statusTime{
+		{Status: "To Do", Seconds: 3600, Duration: "1h", Visits: 1},
+		{Status: "In Progress", Seconds: 26 * 3600, Duration: "1d 2h", Visits: 2},
+		{Status: "In Review", Seconds: (24 + 45) * 3600, Duration: "2d 21h", Visits: 2, Current: true},
+	}
+	if len(history.TimeInStatus) != len(want) {
+		t.Fatalf("unexpected time in status: %+v", history.TimeInStatus)
+	}
+	for i, status := range history.TimeInStatus {
+		if status != want[i] {
+			t.Fatalf("time in status %d = %+v, want %+v", i, status, want[i])
+		}
+	}
+
+	historyFields = nil
+	out = captureStdout(func() { historyCmd.Run(historyCmd, []string{"ENG-1"}) })
+	for _, s := range []string{"History of ENG-1 (5 changes)", "assignee: (none) → Bob", "In Review    2d 21h (2 visits) (current)"} {
+		if !strings.Contains(out, s) {
+			t.Fatalf("expected %q in output %q", s, out)
+		}
+	}
+}
@@ -27,7 +27,7 @@ func setJiraCmdConfig(t *testing.T, cfg *config.Config) {
 
 func TestJiraCommentLinkAndSpacesCmds(t *testing.T) {
 	setJiraCmdConfig(t, &config.Config{
-		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token"},
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"},
 	})
 
 	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
@@ -84,20 +84,43 @@ func TestJiraCommentLinkAndSpacesCmds(t *testing.T) {
 
 func TestJiraCreateUpdateAndMentionCmds(t *testing.T) {
 	setJiraCmdConfig(t, &config.Config{
-		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token"},
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"},
 	})
 
+	var updated map[string]map[string]any
 	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/3/issue":
 			w.WriteHeader(http.StatusCreated)
 			_ = json.NewEncoder(w).Encode(jira.Issue{Key: "ABC-1"})
 		case r.Method == http.MethodPut && r.URL.Path == "/rest/api/3/issue/ABC-1":
+			_ = json.NewDecoder(r.Body).Decode(&updated)
 			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/rest/agile/1.0/board":
+			if project := r.URL.Query().Get("projectKeyOrId"); project != "ABC" {
+				t.Fatalf("expected the boards of ABC, got %q", project)
+			}
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":1,"name":"ABC board","type":"scrum"}]}`))
+		case r.URL.Path == "/rest/agile/1.0/board/1/sprint":
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":7,"name":"Sprint 1","state":"active"},{"id":8,"name":"Sprint 2","state":"future"}]}`))
+		case r.URL.Path == "/rest/api/3/issue/createmeta/ABC/issuetypes":
+			_, _ = w.Write([]byte(`{"issueTypes":[{"id":"3","name":"Task"}]}`))
+		case r.URL.Path == "/rest/api/3/issue/createmeta/ABC/issuetypes/3":
+			_, _ = w.Write([]byte(`{"total":1,"fields":[{"fieldId":"customfield_10016","name":"Story Points","schema":{"type":"number"}}]}`))
+		case r.URL.Path == "/rest/api/3/issue/ABC-1/editmeta":
+			_, _ = w.Write([]byte(`{"fields":{
+				"customfield_10014":{"name":"Epic Link","schema":{"type":"any"}},
+				"customfield_10016":{"name":"Story Points","schema":{"type":"number"}},
+				"customfield_10020":{"name":"Sprint","schema":{"type":"array","items":"json"}},
+				"customfield_11887":{"name":"Team Assigned","schema":{"type":"option"}}}}`))
+		case r.URL.Path == "/rest/api/3/user/search":
+			_, _ = w.Write([]byte(`[{"accountId":"acc-alice","displayName":"Alice","emailAddress":"alice@example.com"}]`))
+		case r.URL.Path == "/rest/api/3/myself":
+			_, _ = w.Write([]byte(`{"accountId":"acc-me","displayName":"Me"}`))
 		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/rest/api/3/search/jql"):
-			_ = json.NewEncoder(w).Encode(jira.SearchResponse{
-				Issues: []jira.Issue{{Key: "ABC-2"}},
-			})
+			_, _ = w.Write([]byte(`{"issues":[
+				{"key":"ABC-2","fields":{"comment":{"comments":[{"body":{"type":"doc","content":[{"type":"paragraph","content":[{"type":"mention","attrs":{"id":"acc-me","text":"@Me"}}]}]}}]}}},
+				{"key":"ABC-3","fields":{"description":{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"Me and you"}]}]}}}]}`))
 		default:
 			t.Fatalf("unexpected jira request: %s %s", r.Method, r.URL.Path)
 		}
@@ -187,11 +210,14 @@ func TestJiraCreateUpdateAndMentionCmds(t *testing.T) {
 	if !strings.Contains(out, "Updated ABC-1") {
 		t.Fatalf("unexpected jira update output: %q", out)
 	}
+	if sprint := updated["fields"]["customfield_10020"]; sprint != float64(8) {
+		t.Fatalf("expected --sprint to be sent as the sprint ID, got %v", sprint)
+	}
 
 	out = captureStdout(func() {
 		mentionedCmd.Run(mentionedCmd, nil)
 	})
-	if !strings.Contains(out, "Found 1 Jira issues where you are mentioned") || !strings.Contains(out, "ABC-2") {
+	if !strings.Contains(out, "Found 1 Jira issues where you are mentioned") || !strings.Contains(out, "ABC-2") || strings.Contains(out, "ABC-3") {
 		t.Fatalf("unexpected jira mentioned output: %q", out)
 	}
 }
@@ -215,9 +241,11 @@ func TestJenkinsLogsAndConfigSetupCmd(t *testing.T) {
 	})
 
 	origLoad := loadConfig
+	origLoadForEdit := loadConfigForEdit
 	origSave := saveConfig
 	defer func() {
 		loadConfig = origLoad
+		loadConfigForEdit = origLoadForEdit
 		saveConfig = origSave
 	}()
 	loadConfig = func() (*config.Config, error) {
@@ -246,6 +274,7 @@ func TestJenkinsLogsAndConfigSetupCmd(t *testing.T) {
 	}
 
 	var saved *config.Config
+	loadConfigForEdit = func() (*config.Config, error) { return &config.Config{}, nil }
 	saveConfig = func(cfg *config.Config) error {
 		saved = cfg
 		return nil
@@ -24,24 +24,24 @@ var linkCmd = &cobra.Command{
 		linkURL := args[1]
 
 		if _, err := url.ParseRequestURI(linkURL); err != nil {
-			log.Fatalf("Invalid URL: %v", err)
+			fatalError("Invalid URL", err)
 		}
 
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
-		if cfg.Jira.URL == "" || cfg.Jira.Username == "" || cfg.Jira.Token == "" {
+		if cfg.Jira.URL == "" || jiraUsernameMissing(cfg.Jira) || cfg.Jira.Token == "" {
 			log.Fatal("Jira configuration incomplete. Run: devflow config set jira.url|jira.username|jira.token ...")
 		}
 
 		client := jira.NewClient(&cfg.Jira)
-		if err := client.AddRemoteLink(issueKey, linkURL, linkTitle, linkSummary); err != nil {
-			log.Fatalf("Failed to add link: %v", err)
+		if err := client.AddRemoteLinkContext(commandContext(cmd), issueKey, linkURL, linkTitle, linkSummary); err != nil {
+			fatalError("Failed to add link", err)
 		}
 		if wantsJSON(cmd) {
 			if err := printJSON(map[string]string{"issue": issueKey, "url": linkURL, "added": "true"}); err != nil {
-				log.Fatalf("Error encoding JSON: %v", err)
+				fatalError("Error encoding JSON", err)
 			}
 			return
 		}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 
+	"devflow/internal/config"
 	"devflow/internal/jira"
 	"github.com/spf13/cobra"
 )
@@ -21,33 +22,56 @@ var (
 	maxResults  int
 	page        int
 	fetchAll    bool
+	// Offline snapshot flags
+	syncSnapshot bool
+	offline      bool
 )
 
+// snapshotsKept is how many snapshots the store keeps per Jira site.
+const snapshotsKept = 10
+
 var listTasksCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List Jira tasks",
-	Long:  `List all Jira tasks assigned to the current user`,
+	Long: `List all Jira tasks assigned to the current user.
+
+--sync fetches every assigned issue and records it in the offline snapshot
+(~/.devflow/snapshots.json); --offline lists the latest snapshot without
+contacting Jira. See "devflow tasks changes" for what changed between syncs.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
 		// Load configuration
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
+		}
+
+		if syncSnapshot && offline {
+			log.Fatal("--sync and --offline cannot be combined")
+		}
+		if (syncSnapshot || offline) && (searchQuery != "" || searchJQL != "" || page > 0 || fetchAll) {
+			log.Fatal("--sync and --offline list your assigned issues and cannot be combined with --query, --jql, --page or --fetch-all")
 		}
 
 		// Validate required config
 		if cfg.Jira.URL == "" {
 			log.Fatal("Jira URL not configured. Run: devflow config set jira.url <url>")
 		}
-		if cfg.Jira.Username == "" {
+		if jiraUsernameMissing(cfg.Jira) && !offline {
 			log.Fatal("Jira username not configured. Run: devflow config set jira.username <username>")
 		}
-		if cfg.Jira.Token == "" {
+		if cfg.Jira.Token == "" && !offline {
 			log.Fatal("Jira token not configured. Run: devflow config set jira.token <token>")
 		}
 
 		// Create Jira client
 		client := jira.NewClient(&cfg.Jira)
 
+		query, jql, err := listSearch(cfg, searchQuery, searchJQL)
+		if err != nil {
+			fatalError("Cannot run --query", err)
+		}
+
 		// Get issues
 		var issues []jira.Issue
 
@@ -63,47 +87,62 @@ var listTasksCmd = &cobra.Command{
 			startAtArg = (page - 1) * maxResults
 		}
 
-		if fetchAll {
+		if offline {
+			snapshot := latestSnapshot(cfg.Jira.URL)
+			fmt.Fprintf(errorOutput, "Showing the offline snapshot from %s.\n", snapshotTime(snapshot.TakenAt))
+			issues = snapshot.Issues
+		} else if syncSnapshot {
+			snapshot, err := client.TakeSnapshotContext(ctx)
+			if err != nil {
+				fatalError("Error fetching Jira issues", err)
+			}
+			snapshot.TakenAt = now()
+			if err := jira.SaveSnapshot(snapshotPath(), *snapshot, snapshotsKept); err != nil {
+				fatalError("Error saving the offline snapshot", err)
+			}
+			fmt.Fprintf(errorOutput, "Synced %d issues to the offline snapshot.\n", len(snapshot.Issues))
+			issues = snapshot.Issues
+		} else if fetchAll {
 			// Fetch all results, ignoring --page
-			if searchJQL != "" {
-				iss, err := client.SearchAll(searchJQL, true, maxResults, 0)
+			if jql != "" {
+				iss, err := client.SearchAllContext(ctx, jql, true, maxResults, 0)
 				if err != nil {
-					log.Fatalf("Error searching Jira issues with JQL (fetch-all): %v", err)
+					fatalError("Error searching Jira issues with JQL (fetch-all)", err)
 				}
 				issues = iss
-			} else if searchQuery != "" {
-				iss, err := client.SearchAll(searchQuery, false, maxResults, 0)
+			} else if query != "" {
+				iss, err := client.SearchAllContext(ctx, query, false, maxResults, 0)
 				if err != nil {
-					log.Fatalf("Error searching Jira issues (fetch-all): %v", err)
+					fatalError("Error searching Jira issues (fetch-all)", err)
 				}
 				issues = iss
 			} else {
-				iss, err := client.SearchAll("", false, maxResults, 0)
+				iss, err := client.SearchAllContext(ctx, "", false, maxResults, 0)
 				if err != nil {
-					log.Fatalf("Error fetching Jira issues (fetch-all): %v", err)
+					fatalError("Error fetching Jira issues (fetch-all)", err)
 				}
 				issues = iss
 			}
 		} else {
-			if searchJQL != "" {
+			if jql != "" {
 				// Raw JQL provided
-				iss, err := client.Search(searchJQL, true, maxResults, startAtArg)
+				iss, err := client.SearchContext(ctx, jql, true, maxResults, startAtArg)
 				if err != nil {
-					log.Fatalf("Error searching Jira issues with JQL: %v", err)
+					fatalError("Error searching Jira issues with JQL", err)
 				}
 				issues = iss
-			} else if searchQuery != "" {
+			} else if query != "" {
 				// Free text search
-				iss, err := client.Search(searchQuery, false, maxResults, startAtArg)
+				iss, err := client.SearchContext(ctx, query, false, maxResults, startAtArg)
 				if err != nil {
-					log.Fatalf("Error searching Jira issues: %v", err)
+					fatalError("Error searching Jira issues", err)
 				}
 				issues = iss
 			} else {
 				// Default: issues assigned to current user
-				iss, err := client.GetMyIssues()
+				iss, err := client.GetMyIssuesContext(ctx)
 				if err != nil {
-					log.Fatalf("Error fetching Jira issues: %v", err)
+					fatalError("Error fetching Jira issues", err)
 				}
 				issues = iss
 			}
@@ -118,7 +157,7 @@ var listTasksCmd = &cobra.Command{
 		// Display results
 		if wantsJSON(cmd) {
 			if err := printJSON(sortedIssues); err != nil {
-				log.Fatalf("Error encoding JSON: %v", err)
+				fatalError("Error encoding JSON", err)
 			}
 			return
 		}
@@ -185,11 +224,35 @@ func init() {
 	listTasksCmd.Flags().BoolVarP(&showSprint, "sprint", "r", false, "Show sprint information")
 	listTasksCmd.Flags().BoolVar(&excludeDone, "exclude-done", false, "Exclude completed/done tasks")
 	// Search flags
-	listTasksCmd.Flags().StringVarP(&searchQuery, "query", "q", "", "Free-text search query (will be converted to JQL)")
+	listTasksCmd.Flags().StringVarP(&searchQuery, "query", "q", "", "Saved query name (see: devflow tasks query), or a multi-word free-text search converted to JQL")
 	listTasksCmd.Flags().StringVar(&searchJQL, "jql", "", "Raw JQL query (takes precedence over --query)")
 	listTasksCmd.Flags().IntVar(&maxResults, "max-results", 0, "Maximum number of results per page (0 = use server default)")
 	listTasksCmd.Flags().IntVar(&page, "page", 0, "Page number to retrieve (1-based). Use with --max-results")
 	listTasksCmd.Flags().BoolVar(&fetchAll, "fetch-all", false, "Follow pagination tokens and fetch all results (ignores --page)")
+	// Offline snapshot flags
+	listTasksCmd.Flags().BoolVar(&syncSnapshot, "sync", false, "Fetch all assigned issues and save them to the offline snapshot")
+	listTasksCmd.Flags().BoolVar(&offline, "offline", false, "List the offline snapshot instead of querying Jira")
+}
+
+// listSearch resolves --query and --jql to the free text or JQL to search.
+// A --query that looks like a saved query name must name one, so a typo
+// fails instead of running an unrelated text search; other values are free
+// text.
+func listSearch(cfg *config.Config, query, jql string) (string, string, error) {
+	if jql != "" || query == "" {
+		return query, jql, nil
+	}
+	if _, ok := cfg.Jira.SavedQueries[query]; ok {
+		expanded, err := expandSavedQuery(cfg, query)
+		if err != nil {
+			return "", "", fmt.Errorf("saved query %s: %w", query, err)
+		}
+		return "", expanded, nil
+	}
+	if validateQueryName(query) == nil {
+		return "", "", fmt.Errorf("no saved query named %q (see: devflow tasks query list); for a text search use --jql 'text ~ \"%s\"'", query, query)
+	}
+	return query, "", nil
 }
 
 // filterIssues filters issues based on status and exclude done flag
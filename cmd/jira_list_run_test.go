@@ -16,9 +16,10 @@ func TestJiraListCmd(t *testing.T) {
 	loadConfig = func() (*config.Config, error) {
 		return &config.Config{
 			Jira: config.JiraConfig{
-				URL:      "https://jira.example",
-				Username: "alice",
-				Token:    "token",
+				URL:        "https://jira.example",
+				Username:   "alice",
+				Token:      "token",
+				Deployment: "cloud",
 			},
 		}, nil
 	}
@@ -99,7 +100,7 @@ func TestJiraListCmd(t *testing.T) {
 		t.Fatalf("unexpected default jira list output: %q", out)
 	}
 
-	searchQuery = "api"
+	searchQuery = "api gateway"
 	maxResults = 10
 	showPriority = true
 	showSprint = true
@@ -16,14 +16,14 @@ var mentionedCmd = &cobra.Command{
 		// Load configuration
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 
 		// Validate required config
 		if cfg.Jira.URL == "" {
 			log.Fatal("Jira URL not configured. Run: devflow config set jira.url <url>")
 		}
-		if cfg.Jira.Username == "" {
+		if jiraUsernameMissing(cfg.Jira) {
 			log.Fatal("Jira username not configured. Run: devflow config set jira.username <username>")
 		}
 		if cfg.Jira.Token == "" {
@@ -34,15 +34,15 @@ var mentionedCmd = &cobra.Command{
 		client := jira.NewClient(&cfg.Jira)
 
 		// Search for mentions
-		issues, err := client.FindMentions()
+		issues, err := client.FindMentionsContext(commandContext(cmd))
 		if err != nil {
-			log.Fatalf("Error searching for mentions: %v", err)
+			fatalError("Error searching for mentions", err)
 		}
 
 		// Display results
 		if wantsJSON(cmd) {
 			if err := printJSON(issues); err != nil {
-				log.Fatalf("Error encoding JSON: %v", err)
+				fatalError("Error encoding JSON", err)
 			}
 			return
 		}
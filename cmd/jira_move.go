@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"unicode"
+
+	"devflow/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var (
+	moveComment    string
+	moveResolution string
+	moveFields     []string
+)
+
+var moveTaskCmd = &cobra.Command{
+	Use:     "move [issue-key] [status]",
+	Aliases: []string{"transition"},
+	Short:   "Move a Jira issue to another workflow status",
+	Long: `Move an issue through its workflow by naming the target status, e.g.
+
+  devflow tasks move ENG-123 "In Review"
+  devflow tasks move ENG-123 done --resolution Fixed -m "Released in 2.4"
+
+The status is matched against the available transitions by target status or
+transition name, ignoring case, spaces and punctuation; an unambiguous prefix
+or a close misspelling also matches. Fields required by the transition screen
+are set with --resolution or --field "Name=value". Without a status, the
+available transitions are listed.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		issueKey := args[0]
+
+		fieldValues, err := parseFieldAssignments(moveFields)
+		if err != nil {
+			fatalError("Invalid --field", err)
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			fatalError("Error loading config", err)
+		}
+		if cfg.Jira.URL == "" || jiraUsernameMissing(cfg.Jira) || cfg.Jira.Token == "" {
+			log.Fatal("Jira configuration incomplete. Run: devflow config set jira.url|jira.username|jira.token ...")
+		}
+
+		client := jira.NewClient(&cfg.Jira)
+		transitions, err := client.GetTransitionsContext(ctx, issueKey)
+		if err != nil {
+			fatalError("Failed to get transitions", err)
+		}
+
+		if len(args) == 1 {
+			displayTransitions(cmd, issueKey, transitions)
+			return
+		}
+
+		transition, err := matchTransition(transitions, args[1])
+		if err != nil {
+			fatalError("Cannot move "+issueKey, err)
+		}
+
+		if moveResolution != "" {
+			fieldValues["resolution"] = moveResolution
+		}
+		fields, err := resolveFieldValues(ctx, client, transition.Fields, fieldValues, fmt.Sprintf("%q transition screen", transition.Name))
+		if err != nil {
+			fatalError("Cannot move "+issueKey, err)
+		}
+		if missing := missingRequiredFields(transition.Fields, fields); len(missing) > 0 {
+			fatalError("Cannot move "+issueKey, fmt.Errorf("transition %q requires: %s; set them with --field \"Name=value\"", transition.Name, strings.Join(missing, "; ")))
+		}
+
+		if err := client.DoTransitionContext(ctx, issueKey, transition.ID, fields, moveComment); err != nil {
+			fatalError("Failed to move issue", err)
+		}
+
+		if wantsJSON(cmd) {
+			result := map[string]any{
+				"issue":         issueKey,
+				"transition_id": transition.ID,
+				"transition":    transition.Name,
+				"status":        transition.To.Name,
+			}
+			if len(fields) > 0 {
+				result["fields"] = fields
+			}
+			if moveComment != "" {
+				result["comment"] = moveComment
+			}
+			if err := printJSON(result); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			rows := [][2]string{{"Issue", issueKey}, {"Transition", transition.Name}, {"Status", transition.To.Name}}
+			if moveComment != "" {
+				rows = append(rows, [2]string{"Comment", moveComment})
+			}
+			renderKeyValueTable(rows)
+			return
+		}
+		fmt.Printf("✅ Moved %s to %s\n", issueKey, transitionTarget(*transition))
+	},
+}
+
+func init() {
+	moveTaskCmd.Flags().StringVarP(&moveComment, "comment", "m", "", "Comment to add with the transition")
+	moveTaskCmd.Flags().StringVar(&moveResolution, "resolution", "", "Resolution to set when the transition requires one (e.g. Done, Won't Do)")
+	moveTaskCmd.Flags().StringArrayVar(&moveFields, "field", nil, "Transition screen field as \"Name=value\" (repeatable)")
+}
+
+func displayTransitions(cmd *cobra.Command, issueKey string, transitions []jira.Transition) {
+	if wantsJSON(cmd) {
+		type transitionJSON struct {
+			ID             string   `json:"id"`
+			Name           string   `json:"name"`
+			Status         string   `json:"status"`
+			RequiredFields []string `json:"required_fields,omitempty"`
+		}
+		out := make([]transitionJSON, 0, len(transitions))
+		for _, t := range transitions {
+			out = append(out, transitionJSON{ID: t.ID, Name: t.Name, Status: t.To.Name, RequiredFields: missingRequiredFields(t.Fields, nil)})
+		}
+		if err := printJSON(map[string]any{"issue": issueKey, "transitions": out}); err != nil {
+			fatalError("Error encoding JSON", err)
+		}
+		return
+	}
+	if wantsTabular(cmd) {
+		rows := make([][]any, 0, len(transitions))
+		for _, t := range transitions {
+			rows = append(rows, []any{t.ID, t.Name, t.To.Name, strings.Join(missingRequiredFields(t.Fields, nil), "; ")})
+		}
+		renderTable([]string{"ID", "Transition", "Status", "Required Fields"}, rows)
+		return
+	}
+	if len(transitions) == 0 {
+		fmt.Printf("No transitions available for %s\n", issueKey)
+		return
+	}
+	fmt.Printf("Available transitions for %s:\n", issueKey)
+	for _, t := range transitions {
+		fmt.Printf("  %s\n", transitionTarget(t))
+		if missing := missingRequiredFields(t.Fields, nil); len(missing) > 0 {
+			fmt.Printf("      requires: %s\n", strings.Join(missing, "; "))
+		}
+	}
+}
+
+// transitionTarget describes a transition by its target status, adding the
+// transition name when it differs.
+func transitionTarget(t jira.Transition) string {
+	if t.To.Name == "" || strings.EqualFold(t.Name, t.To.Name) {
+		return t.Name
+	}
+	return fmt.Sprintf("%s (via %q)", t.To.Name, t.Name)
+}
+
+// matchTransition finds the transition leading to target, compared with the
+// target status and transition names. Exact matches win over matches that
+// ignore punctuation, which win over prefixes, which win over names within
+// a small edit distance. A tie at the winning level is reported as
+// ambiguous.
+func matchTransition(transitions []jira.Transition, target string) (*jira.Transition, error) {
+	want := normalizeStatusName(target)
+	if want == "" {
+		return nil, fmt.Errorf("empty status name")
+	}
+
+	matchers := []func(name string) bool{
+		func(name string) bool { return strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(target)) },
+		func(name string) bool { return normalizeStatusName(name) == want },
+		func(name string) bool { return strings.HasPrefix(normalizeStatusName(name), want) },
+		func(name string) bool {
+			return editDistance(normalizeStatusName(name), want) <= max(1, len(want)/4)
+		},
+	}
+	for _, matches := range matchers {
+		var found []int
+		for i, t := range transitions {
+			if matches(t.To.Name) || matches(t.Name) {
+				found = append(found, i)
+			}
+		}
+		switch {
+		case len(found) == 1:
+			return &transitions[found[0]], nil
+		case len(found) > 1:
+			return nil, fmt.Errorf("%q is ambiguous; it matches %s", target, describeTransitions(transitions, found))
+		}
+	}
+
+	all := make([]int, len(transitions))
+	for i := range transitions {
+		all[i] = i
+	}
+	if len(all) == 0 {
+		return nil, fmt.Errorf("no transitions are available from the current status")
+	}
+	return nil, fmt.Errorf("no transition to %q from the current status; available: %s", target, describeTransitions(transitions, all))
+}
+
+func describeTransitions(transitions []jira.Transition, indexes []int) string {
+	names := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		names = append(names, transitionTarget(transitions[i]))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// normalizeStatusName lowercases name and drops everything but letters and
+// digits, so "In-Review" and "in review" compare equal.
+func normalizeStatusName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
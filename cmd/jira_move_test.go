@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"devflow/internal/config"
+	"devflow/internal/jira"
+)
+
+func testTransitions(t *testing.T) []jira.Transition {
+	t.Helper()
+	var result struct {
+		Transitions []jira.Transition `json:"transitions"`
+	}
+	err := json.Unmarshal([]byte(`{"transitions":[
+		{"id":"11","name":"Start progress","to":{"name":"In Progress"}},
+		{"id":"21","name":"Request review","to":{"name":"In Review"}},
+		{"id":"22","name":"Reopen","to":{"name":"In Refinement"}},
+		{"id":"31","name":"Done","to":{"name":"Done"},"fields":{
+			"resolution":{"key":"resolution","name":"Resolution","required":true,"schema":{"type":"resolution"},
+				"allowedValues":[{"id":"1","name":"Fixed"},{"id":"2","name":"Won't Do"}]},
+			"customfield_10050":{"key":"customfield_10050","name":"Root Cause","required":false,"schema":{"type":"array","items":"option"},
+				"allowedValues":[{"id":"7","value":"Config"},{"id":"8","value":"Code"}]},
+			"customfield_10016":{"key":"customfield_10016","name":"Story Points","schema":{"type":"number"}}}}
+	]}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result.Transitions
+}
+
+func TestMatchTransition(t *testing.T) {
+	transitions := testTransitions(t)
+	cases := map[string]string{
+		"In Review":      "21",
+		"in-review":      "21",
+		"request review": "21",
+		"in prog":        "11",
+		"In Reveiw":      "21",
+		"DONE":           "31",
+	}
+	for target, want := range cases {
+		got, err := matchTransition(transitions, target)
+		if err != nil || got.ID != want {
+			t.Fatalf("matchTransition(%q) = %v, %v; want %s", target, got, err, want)
+		}
+	}
+
+	if _, err := matchTransition(transitions, "in re"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected an ambiguous match, got %v", err)
+	}
+	_, err := matchTransition(transitions, "Blocked")
+	if err == nil || !strings.Contains(err.Error(), `no transition to "Blocked"`) || !strings.Contains(err.Error(), `In Review (via "Request review")`) {
+		t.Fatalf("expected the available transitions to be listed, got %v", err)
+	}
+}
+
+func TestTransitionFieldEncoding(t *testing.T) {
+	fields := testTransitions(t)[3].Fields
+	if missing := missingRequiredFields(fields, nil); len(missing) != 1 || missing[0] != "Resolution (one of: Fixed, Won't Do)" {
+		t.Fatalf("unexpected missing fields: %v", missing)
+	}
+
+	id, meta, ok := findFieldMeta(fields, "root cause")
+	if !ok || id != "customfield_10050" {
+		t.Fatalf("expected to find Root Cause by name, got %q, %v", id, ok)
+	}
+	value, err := encodeFieldValue(meta, "config, Code", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, _ := json.Marshal(value)
+	if string(encoded) != `[{"id":"7"},{"id":"8"}]` {
+		t.Fatalf("unexpected array encoding: %s", encoded)
+	}
+	if _, err := encodeFieldValue(fields["resolution"], "Duplicate", nil); err == nil || !strings.Contains(err.Error(), "allowed: Fixed, Won't Do") {
+		t.Fatalf("expected an invalid option error, got %v", err)
+	}
+	if value, err := encodeFieldValue(fields["customfield_10016"], "3.5", nil); err != nil || value != 3.5 {
+		t.Fatalf("expected a number, got %v, %v", value, err)
+	}
+}
+
+func TestMoveTaskCmd(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"},
+	})
+
+	var payload map[string]interface{}
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/3/issue/ABC-1/transitions":
+			_, _ = w.Write([]byte(`{"transitions":[
+				{"id":"21","name":"Request review","to":{"name":"In Review"}},
+				{"id":"31","name":"Done","to":{"name":"Done"},"fields":{"resolution":{"name":"Resolution","required":true,"schema":{"type":"resolution"},"allowedValues":[{"id":"1","name":"Fixed"}]}}}
+			]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/3/issue/ABC-1/transitions":
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	origComment, origResolution, origFields := moveComment, moveResolution, moveFields
+	t.Cleanup(func() { moveComment, moveResolution, moveFields = origComment, origResolution, origFields })
+
+	moveComment, moveResolution, moveFields = "Shipped", "fixed", nil
+	out := captureStdout(func() {
+		moveTaskCmd.Run(moveTaskCmd, []string{"ABC-1", "done"})
+	})
+	if !strings.Contains(out, "Moved ABC-1 to Done") {
+		t.Fatalf("unexpected move output: %q", out)
+	}
+	encoded, _ := json.Marshal(payload)
+	if !strings.Contains(string(encoded), `"transition":{"id":"31"}`) || !strings.Contains(string(encoded), `"resolution":{"id":"1"}`) ||
+		!strings.Contains(string(encoded), `"text":"Shipped"`) {
+		t.Fatalf("unexpected transition payload: %s", encoded)
+	}
+
+	moveComment, moveResolution = "", ""
+	out = captureStdout(func() {
+		moveTaskCmd.Run(moveTaskCmd, []string{"ABC-1"})
+	})
+	if !strings.Contains(out, `In Review (via "Request review")`) || !strings.Contains(out, "requires: Resolution (one of: Fixed)") {
+		t.Fatalf("unexpected transition list: %q", out)
+	}
+}
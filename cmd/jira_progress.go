@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"devflow/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var (
+	progressPullRequests bool
+	progressMaxIssues    int
+)
+
+// Status categories, as Jira groups workflow statuses.
+const (
+	categoryToDo       = "To Do"
+	categoryInProgress = "In Progress"
+	categoryDone       = "Done"
+)
+
+// progressBarWidth is the number of cells in the progress bar.
+const progressBarWidth = 40
+
+var progressCmd = &cobra.Command{
+	Use:   "progress [issue-key]",
+	Short: "Roll up the progress of an epic or initiative",
+	Long: `Roll up the progress of every descendant of an epic or initiative: issue
+counts per status category (To Do, In Progress, Done), story points done and
+remaining, the percentage complete, open issues nobody is assigned to or
+that have no estimate, and linked pull requests that are still open, e.g.
+
+  devflow tasks progress EPIC-1
+  devflow tasks progress INIT-7 --format json
+
+The percentage is by story points when any issue is estimated, otherwise by
+issue count. An estimate covers the issues below it, so a sized story's
+sub-tasks are neither counted again nor reported as unestimated.
+
+A tree larger than --max-issues is cut short; the report then covers only the
+loaded issues and says so, with "truncated": true in JSON.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		issueKey := args[0]
+		if progressMaxIssues < 0 {
+			fatalError("Invalid limits", fmt.Errorf("--max-issues must be 0 or more"))
+		}
+
+		client := newJiraClient()
+		issue, err := client.GetIssueDetailsContext(ctx, issueKey)
+		if err != nil {
+			fatalError("Error fetching issue details", err)
+		}
+		tree, truncated, err := buildIssueTree(ctx, client, issueKey, issueTreeOptions{PullRequests: progressPullRequests, MaxIssues: progressMaxIssues})
+		if err != nil {
+			fatalError("Error fetching child issues", err)
+		}
+		var keys []string
+		walkIssueTree(tree, func(node issueTreeNode) { keys = append(keys, node.Issue.Key) })
+		points, err := client.StoryPointsContext(ctx, keys)
+		if err != nil {
+			fatalError("Error fetching story points", err)
+		}
+		var pullRequests []jira.PullRequestRef
+		if progressPullRequests {
+			pullRequests, err = client.GetIssuePullRequestsContext(ctx, issue.ID)
+			if err != nil {
+				fatalError("Error fetching pull requests", err)
+			}
+		}
+		report := buildProgressReport(issue, pullRequests, tree, points)
+		report.Truncated = truncated
+
+		if wantsJSON(cmd) {
+			if err := printJSON(report); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			renderKeyValueTable([][2]string{
+				{"Issue", report.Key + " " + report.Summary},
+				{"Complete", fmt.Sprintf("%s%% by %s", formatPoints(report.PercentComplete), strings.ReplaceAll(report.Basis, "_", " "))},
+				{categoryToDo, fmt.Sprint(report.Counts.ToDo)},
+				{categoryInProgress, fmt.Sprint(report.Counts.InProgress)},
+				{categoryDone, fmt.Sprint(report.Counts.Done)},
+				{"Points done", formatPoints(report.Points.Done)},
+				{"Points remaining", formatPoints(report.Points.Remaining)},
+				{"Truncated", fmt.Sprint(report.Truncated)},
+			})
+			rows := make([][]any, 0, len(report.Unassigned)+len(report.Unestimated))
+			for _, item := range report.Unassigned {
+				rows = append(rows, []any{"No assignee", item.Key, item.Summary, item.Status})
+			}
+			for _, item := range report.Unestimated {
+				rows = append(rows, []any{"No estimate", item.Key, item.Summary, item.Status})
+			}
+			if len(rows) > 0 {
+				fmt.Println()
+				renderTable([]string{"Attention", "Ticket", "Name", "Status"}, rows)
+			}
+			if len(report.OpenPullRequests) > 0 {
+				fmt.Println()
+				rows = make([][]any, 0, len(report.OpenPullRequests))
+				for _, pr := range report.OpenPullRequests {
+					rows = append(rows, []any{pr.Issue, pr.Name, pr.Author, pr.URL})
+				}
+				renderTable([]string{"Ticket", "Pull request", "Author", "URL"}, rows)
+			}
+			return
+		}
+		displayProgressReport(report)
+	},
+}
+
+func init() {
+	progressCmd.Flags().BoolVar(&progressPullRequests, "pull-requests", true, "List linked pull requests that are still open")
+	progressCmd.Flags().IntVar(&progressMaxIssues, "max-issues", 1000, "Stop loading descendants after this many issues (0 for no limit)")
+}
+
+type progressReport struct {
+	Key              string                `json:"key"`
+	Summary          string                `json:"summary"`
+	Status           string                `json:"status"`
+	Issues           int                   `json:"issues"`
+	Counts           progressCounts        `json:"status_categories"`
+	Points           progressPoints        `json:"story_points"`
+	PercentComplete  float64               `json:"percent_complete"`
+	Basis            string                `json:"basis"`     // story_points or issues
+	Truncated        bool                  `json:"truncated"` // --max-issues left issues out
+	Unassigned       []progressIssue       `json:"unassigned"`
+	Unestimated      []progressIssue       `json:"unestimated"`
+	OpenPullRequests []progressPullRequest `json:"open_pull_requests"`
+}
+
+type progressCounts struct {
+	ToDo       int `json:"to_do"`
+	InProgress int `json:"in_progress"`
+	Done       int `json:"done"`
+}
+
+type progressPoints struct {
+	Done      float64 `json:"done"`
+	Remaining float64 `json:"remaining"`
+	Total     float64 `json:"total"`
+}
+
+type progressIssue struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	Status  string `json:"status"`
+}
+
+type progressPullRequest struct {
+	Issue  string `json:"issue"`
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Author string `json:"author,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+// buildProgressReport rolls up the descendants of issue. An issue's story
+// points count when no issue above it is estimated; only open issues are
+// reported as unassigned or, when nothing covers them, unestimated.
+func buildProgressReport(issue *jira.IssueDetails, pullRequests []jira.PullRequestRef, tree []issueTreeNode, points map[string]float64) progressReport {
+	report := progressReport{
+		Key:              issue.Key,
+		Summary:          issue.Fields.Summary,
+		Status:           issue.Fields.Status.Name,
+		Unassigned:       []progressIssue{},
+		Unestimated:      []progressIssue{},
+		OpenPullRequests: []progressPullRequest{},
+	}
+	addPullRequests := func(key string, pullRequests []jira.PullRequestRef) {
+		for _, pr := range pullRequests {
+			if strings.EqualFold(pr.Status, "OPEN") {
+				report.OpenPullRequests = append(report.OpenPullRequests, progressPullRequest{
+					Issue: key, ID: pr.ID, Name: pr.Name, Author: pr.Author.Name, URL: pr.URL,
+				})
+			}
+		}
+	}
+	addPullRequests(issue.Key, pullRequests)
+
+	var walk func(nodes []issueTreeNode, covered bool)
+	walk = func(nodes []issueTreeNode, covered bool) {
+		for _, node := range nodes {
+			child := node.Issue
+			category := statusCategory(child)
+			report.Issues++
+			switch category {
+			case categoryDone:
+				report.Counts.Done++
+			case categoryInProgress:
+				report.Counts.InProgress++
+			default:
+				report.Counts.ToDo++
+			}
+
+			estimate, estimated := points[child.Key]
+			if estimated && !covered {
+				if category == categoryDone {
+					report.Points.Done += estimate
+				} else {
+					report.Points.Remaining += estimate
+				}
+			}
+			if category != categoryDone {
+				item := progressIssue{Key: child.Key, Summary: child.Fields.Summary, Status: child.Fields.Status.Name}
+				if child.Fields.Assignee.DisplayName == "" {
+					report.Unassigned = append(report.Unassigned, item)
+				}
+				if !estimated && !covered && len(node.Children) == 0 {
+					report.Unestimated = append(report.Unestimated, item)
+				}
+			}
+			addPullRequests(child.Key, node.PullRequests)
+			walk(node.Children, covered || estimated)
+		}
+	}
+	walk(tree, false)
+
+	report.Points.Total = report.Points.Done + report.Points.Remaining
+	switch {
+	case report.Points.Total > 0:
+		report.Basis = "story_points"
+		report.PercentComplete = percentOf(report.Points.Done, report.Points.Total)
+	default:
+		report.Basis = "issues"
+		report.PercentComplete = percentOf(float64(report.Counts.Done), float64(report.Issues))
+	}
+	return report
+}
+
+// statusCategory returns the status category of an issue, guessing it from
+// the status name when the search did not include it.
+func statusCategory(issue jira.Issue) string {
+	switch issue.Fields.Status.StatusCategory.Key {
+	case "done":
+		return categoryDone
+	case "indeterminate":
+		return categoryInProgress
+	case "new":
+		return categoryToDo
+	}
+	switch strings.ToLower(issue.Fields.Status.Name) {
+	case "done", "closed", "resolved", "cancelled":
+		return categoryDone
+	case "", "to do", "open", "backlog", "new", "selected for development":
+		return categoryToDo
+	}
+	return categoryInProgress
+}
+
+// walkIssueTree calls visit on every node of the tree, parents first.
+func walkIssueTree(nodes []issueTreeNode, visit func(issueTreeNode)) {
+	for _, node := range nodes {
+		visit(node)
+		walkIssueTree(node.Children, visit)
+	}
+}
+
+// percentOf returns part as a percentage of whole, to one decimal place.
+func percentOf(part, whole float64) float64 {
+	if whole <= 0 {
+		return 0
+	}
+	return math.Round(part/whole*1000) / 10
+}
+
+// formatPoints renders a number without trailing zeros, e.g. "3" or "2.5".
+func formatPoints(value float64) string {
+	return fmt.Sprintf("%g", value)
+}
+
+// progressBar draws percent as "[#######-------]".
+func progressBar(percent float64) string {
+	filled := int(math.Round(percent / 100 * progressBarWidth))
+	filled = min(max(filled, 0), progressBarWidth)
+	return "[" + strings.Repeat("#", filled) + strings.Repeat("-", progressBarWidth-filled) + "]"
+}
+
+func displayProgressReport(report progressReport) {
+	fmt.Printf("📈 %s: %s\n", report.Key, report.Summary)
+	fmt.Println(strings.Repeat("=", 80))
+	if report.Truncated {
+		fmt.Printf("⚠️  Incomplete: only the first %d issues were loaded (raise --max-issues)\n", report.Issues)
+	}
+	if report.Issues == 0 {
+		fmt.Println("No child items found.")
+		return
+	}
+
+	if report.Basis == "story_points" {
+		fmt.Printf("%s %5s%%  %s of %s story points done, %s remaining\n", progressBar(report.PercentComplete), formatPoints(report.PercentComplete),
+			formatPoints(report.Points.Done), formatPoints(report.Points.Total), formatPoints(report.Points.Remaining))
+	} else {
+		fmt.Printf("%s %5s%%  %d of %d issues done (no story points)\n", progressBar(report.PercentComplete), formatPoints(report.PercentComplete),
+			report.Counts.Done, report.Issues)
+	}
+	fmt.Println()
+	width := len(categoryInProgress)
+	for _, category := range []struct {
+		name  string
+		count int
+	}{{categoryToDo, report.Counts.ToDo}, {categoryInProgress, report.Counts.InProgress}, {categoryDone, report.Counts.Done}} {
+		fmt.Printf("  %-*s  %s %d\n", width, category.name, progressBar(percentOf(float64(category.count), float64(report.Issues))), category.count)
+	}
+
+	displayProgressIssues("👤 No assignee", report.Unassigned)
+	displayProgressIssues("📏 No estimate", report.Unestimated)
+	if len(report.OpenPullRequests) > 0 {
+		fmt.Printf("\n🔀 Open pull requests (%d):\n", len(report.OpenPullRequests))
+		for _, pr := range report.OpenPullRequests {
+			fmt.Printf("  %s  %s", pr.Issue, pr.Name)
+			if pr.URL != "" {
+				fmt.Printf(" 🔗 %s", pr.URL)
+			}
+			fmt.Println()
+		}
+	}
+}
+
+func displayProgressIssues(title string, issues []progressIssue) {
+	if len(issues) == 0 {
+		return
+	}
+	fmt.Printf("\n%s (%d):\n", title, len(issues))
+	for _, issue := range issues {
+		fmt.Printf("  %s %s - %s\n", getStatusIcon(issue.Status), issue.Key, issue.Summary)
+	}
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"devflow/internal/config"
+	"devflow/internal/jira"
+)
+
+func progressIssueFixture(key, status, category, assignee string) jira.Issue {
+	issue := jira.Issue{Key: key}
+	issue.Fields.Summary = "Summary of " + key
+	issue.Fields.Status.Name = status
+	issue.Fields.Status.StatusCategory.Key = category
+	issue.Fields.Assignee.DisplayName = assignee
+	return issue
+}
+
+func TestBuildProgressReport(t *testing.T) {
+	epic := &jira.IssueDetails{Key: "EPIC-1"}
+	epic.Fields.Summary = "Checkout"
+	tree := []issueTreeNode{
+		{Issue: progressIssueFixture("ENG-1", "Done", "done", "alice")},
+		{
+			Issue: progressIssueFixture("ENG-2", "In Review", "indeterminate", "bob"),
+			// The story's estimate covers its sub-tasks.
+			Children: []issueTreeNode{{Issue: progressIssueFixture("ENG-5", "To Do", "new", "")}},
+			PullRequests: []jira.PullRequestRef{
+				{ID: "1", Name: "Add checkout", Status: "OPEN", URL: "https://bitbucket.example/pr/1"},
+				{ID: "2", Name: "Old attempt", Status: "DECLINED"},
+			},
+		},
+		{Issue: progressIssueFixture("ENG-3", "Backlog", "", "")},
+		{Issue: progressIssueFixture("ENG-4", "Closed", "", "")},
+	}
+	points := map[string]float64{"ENG-1": 3, "ENG-2": 5, "ENG-5": 8}
+
+	report := buildProgressReport(epic, nil, tree, points)
+	if report.Issues != 5 || report.Counts != (progressCounts{ToDo: 2, InProgress: 1, Done: 2}) {
+		t.Fatalf("unexpected counts: %d issues, %+v", report.Issues, report.Counts)
+	}
+	if report.Points != (progressPoints{Done: 3, Remaining: 5, Total: 8}) || report.PercentComplete != 37.5 || report.Basis != "story_points" {
+		t.Fatalf("unexpected points: %+v, %v%% by %s", report.Points, report.PercentComplete, report.Basis)
+	}
+	if len(report.Unassigned) != 2 || report.Unassigned[0].Key != "ENG-5" || report.Unassigned[1].Key != "ENG-3" {
+		t.Fatalf("unexpected unassigned issues: %+v", report.Unassigned)
+	}
+	if len(report.Unestimated) != 1 || report.Unestimated[0].Key != "ENG-3" {
+		t.Fatalf("unexpected unestimated issues: %+v", report.Unestimated)
+	}
+	if len(report.OpenPullRequests) != 1 || report.OpenPullRequests[0].Issue != "ENG-2" {
+		t.Fatalf("unexpected open pull requests: %+v", report.OpenPullRequests)
+	}
+
+	byCount := buildProgressReport(epic, nil, tree, nil)
+	if byCount.Basis != "issues" || byCount.PercentComplete != 40 {
+		t.Fatalf("expected progress by issue count without estimates, got %v%% by %s", byCount.PercentComplete, byCount.Basis)
+	}
+	if got := progressBar(37.5); got != "["+strings.Repeat("#", 15)+strings.Repeat("-", 25)+"]" {
+		t.Fatalf("unexpected progress bar %q", got)
+	}
+}
+
+func TestProgressCmd(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"},
+	})
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/issue/EPIC-1":
+			_, _ = w.Write([]byte(`{"id":"1","key":"EPIC-1","fields":{"summary":"Checkout","status":{"name":"In Progress"}}}`))
+		case "/rest/api/3/field":
+			_, _ = w.Write([]byte(`[{"id":"customfield_10016","name":"Story point estimate","custom":true}]`))
+		case "/rest/api/3/search/jql":
+			jql := r.URL.Query().Get("jql")
+			switch {
+			case strings.HasPrefix(jql, "parent in (EPIC-1)"):
+				_, _ = w.Write([]byte(`{"issues":[
+					{"id":"11","key":"ENG-1","fields":{"summary":"Cart","status":{"name":"Done","statusCategory":{"key":"done"}},"assignee":{"displayName":"Alice"},"parent":{"key":"EPIC-1"}}},
+					{"id":"12","key":"ENG-2","fields":{"summary":"Payment","status":{"name":"To Do","statusCategory":{"key":"new"}},"parent":{"key":"EPIC-1"}}}],"isLast":true}`))
+			case strings.HasPrefix(jql, "key in (ENG-1,ENG-2)"):
+				if r.URL.Query().Get("fields") != "customfield_10016" {
+					t.Errorf("expected the story points field to be requested, got %q", r.URL.Query().Get("fields"))
+				}
+				_, _ = w.Write([]byte(`{"issues":[{"key":"ENG-1","fields":{"customfield_10016":2}},{"key":"ENG-2","fields":{"customfield_10016":6}}]}`))
+			default:
+				_, _ = w.Write([]byte(`{"issues":[],"isLast":true}`))
+			}
+		case "/rest/dev-status/1.0/issue/detail":
+			if r.URL.Query().Get("issueId") == "12" {
+				_, _ = w.Write([]byte(`{"detail":[{"pullRequests":[{"id":"7","name":"Payment form","status":"OPEN","url":"https://bitbucket.example/pr/7"}]}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"detail":[]}`))
+		default:
+			t.Errorf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	out := captureStdout(func() { progressCmd.Run(commandWithFormat(formatJSON), []string{"EPIC-1"}) })
+	var report progressReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if report.PercentComplete != 25 || report.Points.Remaining != 6 || len(report.Unassigned) != 1 || len(report.OpenPullRequests) != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	if report.Truncated {
+		t.Fatalf("expected a complete report, got %+v", report)
+	}
+
+	origMax := progressMaxIssues
+	t.Cleanup(func() { progressMaxIssues = origMax })
+	progressMaxIssues = 1
+	out = captureStdout(func() { progressCmd.Run(commandWithFormat(formatJSON), []string{"EPIC-1"}) })
+	report = progressReport{}
+	if err := json.Unmarshal([]byte(out), &report); err != nil || !report.Truncated || report.Issues != 1 {
+		t.Fatalf("expected a truncated report of one issue, got %q, %v", out, err)
+	}
+	progressMaxIssues = origMax
+
+	out = captureStdout(func() { progressCmd.Run(commandWithFormat(formatDetailed), []string{"EPIC-1"}) })
+	for _, want := range []string{"EPIC-1: Checkout", "[##########------------------------------]", "2 of 8 story points done, 6 remaining", "ENG-2 - Payment", "Payment form"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("progress view missing %q:\n%s", want, out)
+		}
+	}
+}
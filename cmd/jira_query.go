@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"devflow/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var queryImportOverwrite bool
+
+var queryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Save, list and delete named JQL queries",
+	Long: `Keep JQL you run often under a name in jira.saved_queries and run it with
+"devflow tasks list --query <name>", e.g.
+
+  devflow tasks query save my-bugs 'project = {{.Project}} AND type = Bug AND assignee = {{.Me}}'
+  devflow tasks query save stale 'updated < "{{date "-14d"}}" AND statusCategory != Done'
+  devflow tasks list --query stale
+
+The JQL is a template expanded when the query runs:
+
+  {{.Me}}          currentUser()
+  {{.Project}}     the jira.project_key setting
+  {{.Today}}       today's date, YYYY-MM-DD
+  {{date "-7d"}}   a date relative to today; units are d, w, m (months) and y`,
+}
+
+var querySaveCmd = &cobra.Command{
+	Use:   "save [name] [jql]",
+	Short: "Save a JQL query under a name",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		name, jql := args[0], strings.TrimSpace(args[1])
+		if err := validateQueryName(name); err != nil {
+			fatalError("Invalid query name", err)
+		}
+		if _, err := parseQueryTemplate(name, jql); err != nil {
+			fatalError("Invalid query", err)
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			fatalError("Error loading config", err)
+		}
+		_, replaced := cfg.Jira.SavedQueries[name]
+		if cfg.Jira.SavedQueries == nil {
+			cfg.Jira.SavedQueries = make(map[string]string)
+		}
+		cfg.Jira.SavedQueries[name] = jql
+		if err := saveConfig(cfg); err != nil {
+			fatalError("Error saving config", err)
+		}
+
+		if wantsJSON(cmd) {
+			if err := printJSON(map[string]any{"name": name, "jql": jql, "replaced": replaced}); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			renderKeyValueTable([][2]string{{"Name", name}, {"JQL", jql}, {"Replaced", strconv.FormatBool(replaced)}})
+			return
+		}
+		if replaced {
+			fmt.Printf("✅ Updated query %s\n", name)
+			return
+		}
+		fmt.Printf("✅ Saved query %s\n", name)
+	},
+}
+
+var queryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved queries",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := loadConfig()
+		if err != nil {
+			fatalError("Error loading config", err)
+		}
+		queries := savedQueryList(cfg.Jira.SavedQueries)
+
+		if wantsJSON(cmd) {
+			if err := printJSON(queries); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			rows := make([][]any, 0, len(queries))
+			for _, query := range queries {
+				rows = append(rows, []any{query.Name, query.JQL})
+			}
+			renderTable([]string{"Name", "JQL"}, rows)
+			return
+		}
+		if len(queries) == 0 {
+			fmt.Println("No saved queries. Save one with: devflow tasks query save <name> <jql>")
+			return
+		}
+		width := 0
+		for _, query := range queries {
+			width = max(width, len(query.Name))
+		}
+		for _, query := range queries {
+			fmt.Printf("%-*s  %s\n", width, query.Name, query.JQL)
+		}
+	},
+}
+
+var queryDeleteCmd = &cobra.Command{
+	Use:   "delete [name]",
+	Short: "Delete a saved query",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		cfg, err := loadConfig()
+		if err != nil {
+			fatalError("Error loading config", err)
+		}
+		if _, ok := cfg.Jira.SavedQueries[name]; !ok {
+			fatalError("Cannot delete query", fmt.Errorf("no saved query named %q", name))
+		}
+		delete(cfg.Jira.SavedQueries, name)
+		if err := saveConfig(cfg); err != nil {
+			fatalError("Error saving config", err)
+		}
+
+		if wantsJSON(cmd) {
+			if err := printJSON(map[string]any{"name": name, "deleted": true}); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			renderKeyValueTable([][2]string{{"Name", name}, {"Deleted", "true"}})
+			return
+		}
+		fmt.Printf("🗑️  Deleted query %s\n", name)
+	},
+}
+
+var queryImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import your favourite Jira filters as saved queries",
+	Long: `Save each filter starred in Jira under a name derived from the filter name,
+e.g. "My open bugs" becomes my-open-bugs. Existing queries are kept unless
+--overwrite is given.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, client := loadJiraClient()
+		filters, err := client.FavouriteFiltersContext(commandContext(cmd))
+		if err != nil {
+			fatalError("Failed to fetch favourite filters", err)
+		}
+
+		type importedQuery struct {
+			Name     string `json:"name"`
+			Filter   string `json:"filter"`
+			JQL      string `json:"jql"`
+			Imported bool   `json:"imported"`
+		}
+		results := make([]importedQuery, 0, len(filters))
+		imported := 0
+		for _, filter := range filters {
+			name := savedQueryName(filter.Name)
+			if name == "" {
+				name = "filter-" + filter.ID
+			}
+			result := importedQuery{Name: name, Filter: filter.Name, JQL: filter.JQL}
+			if _, exists := cfg.Jira.SavedQueries[name]; !exists || queryImportOverwrite {
+				if cfg.Jira.SavedQueries == nil {
+					cfg.Jira.SavedQueries = make(map[string]string)
+				}
+				cfg.Jira.SavedQueries[name] = filter.JQL
+				result.Imported = true
+				imported++
+			}
+			results = append(results, result)
+		}
+		if imported > 0 {
+			if err := saveConfig(cfg); err != nil {
+				fatalError("Error saving config", err)
+			}
+		}
+
+		if wantsJSON(cmd) {
+			if err := printJSON(results); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			rows := make([][]any, 0, len(results))
+			for _, result := range results {
+				rows = append(rows, []any{result.Name, result.Filter, result.Imported})
+			}
+			renderTable([]string{"Name", "Filter", "Imported"}, rows)
+			return
+		}
+		if len(results) == 0 {
+			fmt.Println("No favourite filters found")
+			return
+		}
+		for _, result := range results {
+			if result.Imported {
+				fmt.Printf("✅ %s  (%s)\n", result.Name, result.Filter)
+			} else {
+				fmt.Printf("⏭️  %s  already saved; use --overwrite to replace it\n", result.Name)
+			}
+		}
+		fmt.Printf("\nImported %d of %d favourite filters\n", imported, len(results))
+	},
+}
+
+func init() {
+	queryImportCmd.Flags().BoolVar(&queryImportOverwrite, "overwrite", false, "Replace saved queries that have the same name")
+
+	queryCmd.AddCommand(querySaveCmd)
+	queryCmd.AddCommand(queryListCmd)
+	queryCmd.AddCommand(queryDeleteCmd)
+	queryCmd.AddCommand(queryImportCmd)
+}
+
+type savedQuery struct {
+	Name string `json:"name"`
+	JQL  string `json:"jql"`
+}
+
+func savedQueryList(queries map[string]string) []savedQuery {
+	list := make([]savedQuery, 0, len(queries))
+	for name, jql := range queries {
+		list = append(list, savedQuery{Name: name, JQL: jql})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+var queryNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+func validateQueryName(name string) error {
+	if !queryNamePattern.MatchString(name) {
+		return fmt.Errorf("%q must start with a letter or digit and contain only letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+var queryNameSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// savedQueryName turns a Jira filter name into a saved query name, e.g.
+// "My open bugs" into "my-open-bugs".
+func savedQueryName(filterName string) string {
+	return strings.Trim(queryNameSeparators.ReplaceAllString(strings.ToLower(filterName), "-"), "-")
+}
+
+// queryTemplateData is what saved query templates can refer to.
+type queryTemplateData struct {
+	Me    string
+	Today string
+
+	project string
+}
+
+// Project returns the configured project key, failing the expansion when
+// none is set.
+func (d queryTemplateData) Project() (string, error) {
+	if d.project == "" {
+		return "", fmt.Errorf("the query uses {{.Project}} but jira.project_key is not set")
+	}
+	return d.project, nil
+}
+
+func parseQueryTemplate(name, jql string) (*template.Template, error) {
+	return template.New(name).Funcs(template.FuncMap{
+		"date": queryDate,
+	}).Parse(jql)
+}
+
+// expandSavedQuery runs the saved query name through the template engine.
+func expandSavedQuery(cfg *config.Config, name string) (string, error) {
+	jql, ok := cfg.Jira.SavedQueries[name]
+	if !ok {
+		return "", fmt.Errorf("no saved query named %q", name)
+	}
+	tmpl, err := parseQueryTemplate(name, jql)
+	if err != nil {
+		return "", err
+	}
+	data := queryTemplateData{
+		Me:      "currentUser()",
+		Today:   now().Format(time.DateOnly),
+		project: cfg.Jira.ProjectKey,
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+var dateOffsetPattern = regexp.MustCompile(`^([+-]?\d+)\s*([dwmy])$`)
+
+// queryDate formats today shifted by an offset such as "-7d", "+2w", "-1m"
+// or "1y" as YYYY-MM-DD.
+func queryDate(offset string) (string, error) {
+	today := startOfDay(now())
+	offset = strings.ToLower(strings.TrimSpace(offset))
+	if offset == "" || offset == "0" || offset == "today" {
+		return today.Format(time.DateOnly), nil
+	}
+	match := dateOffsetPattern.FindStringSubmatch(offset)
+	if match == nil {
+		return "", fmt.Errorf("invalid date offset %q; use e.g. -7d, +2w, -1m or -1y", offset)
+	}
+	n, _ := strconv.Atoi(match[1])
+	switch match[2] {
+	case "d":
+		today = today.AddDate(0, 0, n)
+	case "w":
+		today = today.AddDate(0, 0, 7*n)
+	case "m":
+		today = today.AddDate(0, n, 0)
+	case "y":
+		today = today.AddDate(n, 0, 0)
+	}
+	return today.Format(time.DateOnly), nil
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"devflow/internal/config"
+)
+
+func TestExpandSavedQuery(t *testing.T) {
+	origNow := now
+	t.Cleanup(func() { now = origNow })
+	now = func() time.Time { return time.Date(2024, 5, 9, 15, 0, 0, 0, time.Local) }
+
+	cfg := &config.Config{Jira: config.JiraConfig{ProjectKey: "ENG", SavedQueries: map[string]string{
+		"mine":    `project = {{.Project}} AND assignee = {{.Me}}`,
+		"recent":  `updated >= "{{date "-7d"}}" AND created < "{{.Today}}" AND duedate <= "{{date "+1m"}}"`,
+		"bad":     `updated >= "{{date "soon"}}"`,
+		"unknown": `assignee = {{.Someone}}`,
+	}}}
+
+	if jql, err := expandSavedQuery(cfg, "mine"); err != nil || jql != "project = ENG AND assignee = currentUser()" {
+		t.Fatalf("unexpected expansion %q, %v", jql, err)
+	}
+	if jql, err := expandSavedQuery(cfg, "recent"); err != nil || jql != `updated >= "2024-05-02" AND created < "2024-05-09" AND duedate <= "2024-06-09"` {
+		t.Fatalf("unexpected expansion %q, %v", jql, err)
+	}
+	for _, name := range []string{"bad", "unknown", "missing"} {
+		if _, err := expandSavedQuery(cfg, name); err == nil {
+			t.Fatalf("expected %q to fail", name)
+		}
+	}
+	cfg.Jira.ProjectKey = ""
+	if _, err := expandSavedQuery(cfg, "mine"); err == nil || !strings.Contains(err.Error(), "jira.project_key is not set") {
+		t.Fatalf("expected a missing project key to fail, got %v", err)
+	}
+	if got := savedQueryName("  My open bugs (Q3)!"); got != "my-open-bugs-q3" {
+		t.Fatalf("savedQueryName = %q", got)
+	}
+}
+
+func TestQuerySaveDeleteAndImport(t *testing.T) {
+	cfg := &config.Config{Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud",
+		SavedQueries: map[string]string{"my-open-bugs": "type = Bug"}}}
+	setJiraCmdConfig(t, cfg)
+	saves := 0
+	origSave := saveConfig
+	t.Cleanup(func() { saveConfig = origSave })
+	saveConfig = func(*config.Config) error { saves++; return nil }
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/filter/favourite" {
+			t.Fatalf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`[
+			{"id":"1","name":"My open bugs","jql":"type = Bug AND resolution is EMPTY"},
+			{"id":"2","name":"Team board","jql":"project = ENG"}
+		]`))
+	})
+
+	out := captureStdout(func() { querySaveCmd.Run(querySaveCmd, []string{"stale", `updated < "{{date "-14d"}}"`}) })
+	if !strings.Contains(out, "Saved query stale") || cfg.Jira.SavedQueries["stale"] == "" || saves != 1 {
+		t.Fatalf("unexpected save: %q, %v", out, cfg.Jira.SavedQueries)
+	}
+
+	out = captureStdout(func() { queryImportCmd.Run(commandWithFormat(formatJSON), nil) })
+	var results []map[string]any
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(results) != 2 || results[0]["imported"] != false || results[1]["name"] != "team-board" || results[1]["imported"] != true {
+		t.Fatalf("unexpected import results: %v", results)
+	}
+	if cfg.Jira.SavedQueries["my-open-bugs"] != "type = Bug" || cfg.Jira.SavedQueries["team-board"] != "project = ENG" {
+		t.Fatalf("unexpected saved queries: %v", cfg.Jira.SavedQueries)
+	}
+
+	out = captureStdout(func() { queryListCmd.Run(queryListCmd, nil) })
+	if !strings.Contains(out, "team-board    project = ENG") {
+		t.Fatalf("unexpected list output %q", out)
+	}
+	captureStdout(func() { queryDeleteCmd.Run(queryDeleteCmd, []string{"stale"}) })
+	if _, ok := cfg.Jira.SavedQueries["stale"]; ok || saves != 3 {
+		t.Fatalf("expected stale to be deleted, got %v after %d saves", cfg.Jira.SavedQueries, saves)
+	}
+}
+
+func TestListRunsSavedQuery(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud",
+		ProjectKey: "ENG", SavedQueries: map[string]string{"mine": "project = {{.Project}} AND assignee = {{.Me}}"}}})
+	var searched []string
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		searched = append(searched, r.URL.Query().Get("jql"))
+		_, _ = w.Write([]byte(`{"isLast":true,"issues":[{"key":"ENG-1","fields":{"summary":"Mine","status":{"name":"To Do"}}}]}`))
+	})
+	origQuery, origJQL := searchQuery, searchJQL
+	t.Cleanup(func() { searchQuery, searchJQL = origQuery, origJQL })
+	searchJQL = ""
+
+	searchQuery = "mine"
+	out := captureStdout(func() { listTasksCmd.Run(listTasksCmd, nil) })
+	if !strings.Contains(out, "ENG-1") || len(searched) != 1 || !strings.HasPrefix(searched[0], "project = ENG AND assignee = currentUser()") {
+		t.Fatalf("unexpected search %v, output %q", searched, out)
+	}
+	searchQuery = "login error"
+	captureStdout(func() { listTasksCmd.Run(listTasksCmd, nil) })
+	if len(searched) != 2 || !strings.HasPrefix(searched[1], `text ~ "login error"`) {
+		t.Fatalf("expected free text to be a text search, got %v", searched)
+	}
+
+	cfg, _ := loadConfig()
+	if _, _, err := listSearch(cfg, "mnie", ""); err == nil || !strings.Contains(err.Error(), `no saved query named "mnie"`) {
+		t.Fatalf("expected an unknown query name to fail, got %v", err)
+	}
+	if query, jql, err := listSearch(cfg, "mnie", "project = ENG"); err != nil || query != "mnie" || jql != "project = ENG" {
+		t.Fatalf("expected --jql to take precedence, got %q, %q, %v", query, jql, err)
+	}
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"devflow/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var relateRemove bool
+
+var relateCmd = &cobra.Command{
+	Use:   "relate [from-key] [link-type] [to-key]",
+	Short: "Link two Jira issues (blocks, is blocked by, duplicates, ...)",
+	Long: `Create an issue link so that the first issue relates to the second, e.g.
+
+  devflow tasks relate ENG-12 blocks ENG-40
+  devflow tasks relate ENG-40 is-blocked-by ENG-12
+  devflow tasks relate ENG-7 duplicates ENG-3 --remove
+
+The link type is matched against the outward and inward phrases of the link
+types configured in Jira ("blocks", "is blocked by") and against their names
+("Blocks"), ignoring case, spaces and hyphens. With --remove the matching
+link is deleted instead.`,
+	Args: cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		from, phrase, to := args[0], args[1], args[2]
+
+		cfg, err := loadConfig()
+		if err != nil {
+			fatalError("Error loading config", err)
+		}
+		if cfg.Jira.URL == "" || jiraUsernameMissing(cfg.Jira) || cfg.Jira.Token == "" {
+			log.Fatal("Jira configuration incomplete. Run: devflow config set jira.url|jira.username|jira.token ...")
+		}
+
+		client := jira.NewClient(&cfg.Jira)
+		types, err := client.ListIssueLinkTypesContext(ctx)
+		if err != nil {
+			fatalError("Failed to list issue link types", err)
+		}
+		linkType, inward, err := matchLinkType(types, phrase)
+		if err != nil {
+			fatalError("Cannot relate "+from+" and "+to, err)
+		}
+		relation := linkType.Outward
+		if inward {
+			relation = linkType.Inward
+		}
+
+		if relateRemove {
+			issue, err := client.GetIssueDetailsContext(ctx, from)
+			if err != nil {
+				fatalError("Error fetching issue details", err)
+			}
+			link := findIssueLink(issue.Fields.IssueLinks, linkType, relation, to)
+			if link == nil {
+				fatalError("Cannot remove link", fmt.Errorf("%s does not %s %s", from, relation, to))
+			}
+			if err := client.DeleteIssueLinkContext(ctx, link.ID); err != nil {
+				fatalError("Failed to remove link", err)
+			}
+		} else {
+			// Jira always describes a link from its inward issue with the
+			// outward phrase, so inward phrases swap the ends.
+			inwardKey, outwardKey := from, to
+			if inward {
+				inwardKey, outwardKey = to, from
+			}
+			if err := client.CreateIssueLinkContext(ctx, linkType.Name, inwardKey, outwardKey); err != nil {
+				fatalError("Failed to link issues", err)
+			}
+		}
+
+		if wantsJSON(cmd) {
+			result := map[string]any{"from": from, "relation": relation, "to": to, "type": linkType.Name}
+			if relateRemove {
+				result["removed"] = true
+			}
+			if err := printJSON(result); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			rows := [][2]string{{"From", from}, {"Relation", relation}, {"To", to}, {"Type", linkType.Name}}
+			if relateRemove {
+				rows = append(rows, [2]string{"Removed", "true"})
+			}
+			renderKeyValueTable(rows)
+			return
+		}
+		if relateRemove {
+			fmt.Printf("✂️  Removed link: %s %s %s\n", from, relation, to)
+			return
+		}
+		fmt.Printf("🔗 %s %s %s\n", from, relation, to)
+	},
+}
+
+func init() {
+	relateCmd.Flags().BoolVar(&relateRemove, "remove", false, "Remove the link instead of creating it")
+}
+
+// matchLinkType finds the link type whose outward phrase, inward phrase or
+// name matches phrase, and reports whether the inward phrase matched. Link
+// types such as "relates to" use the same phrase both ways; they count as
+// outward.
+func matchLinkType(types []jira.IssueLinkType, phrase string) (jira.IssueLinkType, bool, error) {
+	want := normalizeStatusName(phrase)
+	if want == "" {
+		return jira.IssueLinkType{}, false, fmt.Errorf("empty link type")
+	}
+
+	type match struct {
+		linkType jira.IssueLinkType
+		inward   bool
+	}
+	var found []match
+	for _, t := range types {
+		switch want {
+		case normalizeStatusName(t.Outward):
+			found = append(found, match{t, false})
+		case normalizeStatusName(t.Inward):
+			found = append(found, match{t, true})
+		case normalizeStatusName(t.Name):
+			found = append(found, match{t, false})
+		}
+	}
+	switch len(found) {
+	case 1:
+		return found[0].linkType, found[0].inward, nil
+	case 0:
+		return jira.IssueLinkType{}, false, fmt.Errorf("unknown link type %q; available: %s", phrase, describeLinkTypes(types))
+	default:
+		names := make([]string, 0, len(found))
+		for _, m := range found {
+			names = append(names, m.linkType.Name)
+		}
+		return jira.IssueLinkType{}, false, fmt.Errorf("%q is ambiguous; it matches the link types %s", phrase, strings.Join(names, ", "))
+	}
+}
+
+func describeLinkTypes(types []jira.IssueLinkType) string {
+	phrases := make([]string, 0, 2*len(types))
+	seen := make(map[string]bool)
+	for _, t := range types {
+		for _, p := range []string{t.Outward, t.Inward} {
+			if p != "" && !seen[p] {
+				seen[p] = true
+				phrases = append(phrases, p)
+			}
+		}
+	}
+	sort.Strings(phrases)
+	return strings.Join(phrases, ", ")
+}
+
+// findIssueLink returns the link of the given type through which the issue
+// holding links relates to key with the given phrase.
+func findIssueLink(links []jira.IssueLink, linkType jira.IssueLinkType, relation, key string) *jira.IssueLink {
+	for i, link := range links {
+		if link.Type.ID != linkType.ID && link.Type.Name != linkType.Name {
+			continue
+		}
+		phrase, other := link.Direction()
+		if other != nil && strings.EqualFold(other.Key, key) && phrase == relation {
+			return &links[i]
+		}
+	}
+	return nil
+}
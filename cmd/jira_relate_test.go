@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"devflow/internal/config"
+	"devflow/internal/jira"
+)
+
+var testLinkTypes = []jira.IssueLinkType{
+	{ID: "1", Name: "Blocks", Inward: "is blocked by", Outward: "blocks"},
+	{ID: "2", Name: "Duplicate", Inward: "is duplicated by", Outward: "duplicates"},
+	{ID: "3", Name: "Relates", Inward: "relates to", Outward: "relates to"},
+}
+
+func TestMatchLinkType(t *testing.T) {
+	cases := []struct {
+		phrase string
+		want   string
+		inward bool
+	}{
+		{"blocks", "Blocks", false},
+		{"is-blocked-by", "Blocks", true},
+		{"Is Blocked By", "Blocks", true},
+		{"duplicate", "Duplicate", false},
+		{"relates-to", "Relates", false},
+	}
+	for _, c := range cases {
+		got, inward, err := matchLinkType(testLinkTypes, c.phrase)
+		if err != nil || got.Name != c.want || inward != c.inward {
+			t.Fatalf("matchLinkType(%q) = %s, %v, %v; want %s, %v", c.phrase, got.Name, inward, err, c.want, c.inward)
+		}
+	}
+
+	if _, _, err := matchLinkType(testLinkTypes, "clones"); err == nil || !strings.Contains(err.Error(), "available: blocks, duplicates") {
+		t.Fatalf("expected an unknown link type error listing phrases, got %v", err)
+	}
+}
+
+func TestRelateCmd(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"},
+	})
+
+	var created map[string]map[string]string
+	var deleted string
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/3/issueLinkType":
+			_ = json.NewEncoder(w).Encode(map[string]any{"issueLinkTypes": testLinkTypes})
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/3/issueLink":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/3/field":
+			_, _ = w.Write([]byte(`[]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/3/issue/ENG-40":
+			_, _ = w.Write([]byte(`{"key":"ENG-40","fields":{"issuelinks":[
+				{"id":"77","type":{"id":"1","name":"Blocks","inward":"is blocked by","outward":"blocks"},"inwardIssue":{"key":"ENG-12"}}
+			]}}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/rest/api/3/issueLink/77":
+			deleted = "77"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	origRemove := relateRemove
+	t.Cleanup(func() { relateRemove = origRemove })
+
+	relateRemove = false
+	out := captureStdout(func() {
+		relateCmd.Run(relateCmd, []string{"ENG-40", "is-blocked-by", "ENG-12"})
+	})
+	if !strings.Contains(out, "ENG-40 is blocked by ENG-12") {
+		t.Fatalf("unexpected relate output: %q", out)
+	}
+	if created["type"]["name"] != "Blocks" || created["inwardIssue"]["key"] != "ENG-12" || created["outwardIssue"]["key"] != "ENG-40" {
+		t.Fatalf("expected the inward phrase to swap the link ends, got %+v", created)
+	}
+
+	relateRemove = true
+	out = captureStdout(func() {
+		relateCmd.Run(relateCmd, []string{"ENG-40", "is blocked by", "ENG-12"})
+	})
+	if deleted != "77" || !strings.Contains(out, "Removed link: ENG-40 is blocked by ENG-12") {
+		t.Fatalf("expected link 77 to be removed, got %q (deleted %q)", out, deleted)
+	}
+}
+
+func TestShowRendersIssueLinks(t *testing.T) {
+	var issue jira.IssueDetails
+	_ = json.Unmarshal([]byte(`{"key":"ENG-12","fields":{"summary":"Auth","issuelinks":[
+		{"id":"1","type":{"name":"Blocks","inward":"is blocked by","outward":"blocks"},"outwardIssue":{"key":"ENG-40","fields":{"summary":"Ship it","status":{"name":"To Do"}}}},
+		{"id":"2","type":{"name":"Duplicate","inward":"is duplicated by","outward":"duplicates"},"inwardIssue":{"key":"ENG-9","fields":{"summary":"Old auth","status":{"name":"Done"}}}}
+	]}}`), &issue)
+
+	normalized := normalizedIssueJSON(&issue, nil, false, nil, false)
+	if len(normalized.Links) != 2 {
+		t.Fatalf("expected two links, got %+v", normalized.Links)
+	}
+	if link := normalized.Links[0]; link.Direction != "outward" || link.Relation != "blocks" || link.Key != "ENG-40" || link.Status != "To Do" {
+		t.Fatalf("unexpected outward link: %+v", link)
+	}
+	if link := normalized.Links[1]; link.Direction != "inward" || link.Relation != "is duplicated by" || link.Key != "ENG-9" {
+		t.Fatalf("unexpected inward link: %+v", link)
+	}
+
+	out := captureStdout(func() { displayIssueDetails(&issue) })
+	if !strings.Contains(out, "Links (2)") || !strings.Contains(out, "blocks") || !strings.Contains(out, "ENG-40 - Ship it") ||
+		!strings.Contains(out, "is duplicated by ✅ ENG-9 - Old auth") {
+		t.Fatalf("unexpected detailed output: %q", out)
+	}
+
+	out = captureStdout(func() { displayLinkTable(&issue) })
+	if !strings.Contains(out, "RELATION") || !strings.Contains(out, "is duplicated by") || !strings.Contains(out, "Ship it") {
+		t.Fatalf("unexpected link table: %q", out)
+	}
+}
@@ -0,0 +1,369 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"devflow/internal/config"
+	"devflow/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var (
+	releaseProject      string
+	releaseVersion      string
+	releasePullRequests bool
+	releaseDescription  string
+	releaseStartDate    string
+	releaseDate         string
+)
+
+// issueTypeOrder is the order release notes list the usual issue types in;
+// other types follow alphabetically.
+var issueTypeOrder = []string{"Epic", "New Feature", "Feature", "Story", "Improvement", "Bug", "Task", "Sub-task", "Subtask"}
+
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Jira versions and release notes",
+	Long: `Manage the versions of a Jira project and generate release notes from the
+issues fixed in them.
+
+The project is taken from --project, then jira.project_key.
+
+Subcommands:
+  list        List the project's versions
+  create      Create a version
+  publish     Mark a version as released
+  notes       Generate release notes for a version`,
+}
+
+var releaseListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the versions of a project",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, client := loadJiraClient()
+		project := releaseProjectKey(cfg)
+		versions, err := client.ListVersionsContext(commandContext(cmd), project)
+		if err != nil {
+			fatalError("Failed to list versions", err)
+		}
+
+		if wantsJSON(cmd) {
+			if err := printJSON(versions); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			rows := make([][]any, 0, len(versions))
+			for _, version := range versions {
+				rows = append(rows, []any{version.ID, version.Name, version.Released, version.ReleaseDate, version.Description})
+			}
+			renderTable([]string{"ID", "Name", "Released", "Release date", "Description"}, rows)
+			return
+		}
+		if len(versions) == 0 {
+			fmt.Printf("Project %s has no versions\n", project)
+			return
+		}
+		fmt.Printf("🏷️  Versions of %s (%d):\n", project, len(versions))
+		for _, version := range versions {
+			icon := "⏳"
+			if version.Released {
+				icon = "✅"
+			}
+			fmt.Printf("%s %s", icon, version.Name)
+			if version.ReleaseDate != "" {
+				fmt.Printf(" (%s)", version.ReleaseDate)
+			}
+			if version.Archived {
+				fmt.Print(" [archived]")
+			}
+			fmt.Println()
+		}
+	},
+}
+
+var releaseCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "Create a version",
+	Long: `Create an unreleased version in the project, e.g.
+
+  devflow release create 2.5.0 --release-date 2024-07-01`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, client := loadJiraClient()
+		project := releaseProjectKey(cfg)
+		version, err := client.CreateVersionContext(commandContext(cmd), project, jira.VersionInput{
+			Name:        args[0],
+			Description: releaseDescription,
+			StartDate:   releaseStartDate,
+			ReleaseDate: releaseDate,
+		})
+		if err != nil {
+			fatalError("Failed to create the version", err)
+		}
+		reportVersion(cmd, project, version, "Created")
+	},
+}
+
+var releasePublishCmd = &cobra.Command{
+	Use:   "publish [name]",
+	Short: "Mark a version as released",
+	Long: `Mark a version as released, on --release-date or the date already set on
+the version, e.g.
+
+  devflow release publish 2.4.0 --release-date 2024-06-01`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		cfg, client := loadJiraClient()
+		project := releaseProjectKey(cfg)
+		version, err := client.FindVersionContext(ctx, project, args[0])
+		if err != nil {
+			fatalError("Cannot find the version", err)
+		}
+		version, err = client.ReleaseVersionContext(ctx, version.ID, releaseDate)
+		if err != nil {
+			fatalError("Failed to release the version", err)
+		}
+		reportVersion(cmd, project, version, "Released")
+	},
+}
+
+var releaseNotesCmd = &cobra.Command{
+	Use:   "notes --version <name>",
+	Short: "Generate release notes for a version",
+	Long: `Generate release notes from the issues whose fix versions include a
+version, grouped by issue type, with the titles and URLs of their linked pull
+requests, e.g.
+
+  devflow release notes --version 2.4.0 > notes.md
+  devflow release notes --version 2.4.0 --format json
+
+Declined pull requests are left out. The default output is Markdown; use
+--format json for changelog pipelines.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		cfg, client := loadJiraClient()
+		project := releaseProjectKey(cfg)
+
+		version, err := client.FindVersionContext(ctx, project, releaseVersion)
+		if err != nil {
+			fatalError("Cannot find the version", err)
+		}
+		issues, err := client.VersionIssuesContext(ctx, project, version.Name)
+		if err != nil {
+			fatalError("Failed to search the issues of "+version.Name, err)
+		}
+		var pullRequests map[string][]jira.PullRequestRef
+		if releasePullRequests {
+			pullRequests, err = fetchTreePullRequests(ctx, client, issues)
+			if err != nil {
+				fatalError("Error fetching pull requests", err)
+			}
+		}
+		notes := buildReleaseNotes(project, *version, issues, pullRequests, cfg.Jira.URL)
+
+		if wantsJSON(cmd) {
+			if err := printJSON(notes); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			var rows [][]any
+			for _, group := range notes.Groups {
+				for _, issue := range group.Issues {
+					rows = append(rows, []any{group.Type, issue.Key, issue.Summary, issue.Status, len(issue.PullRequests)})
+				}
+			}
+			renderTable([]string{"Type", "Ticket", "Name", "Status", "PRs"}, rows)
+			return
+		}
+		fmt.Print(renderReleaseNotes(notes))
+	},
+}
+
+func init() {
+	releaseCmd.PersistentFlags().StringVarP(&releaseProject, "project", "p", "", "Jira project key (defaults to jira.project_key)")
+	releaseCreateCmd.Flags().StringVar(&releaseDescription, "description", "", "Version description")
+	releaseCreateCmd.Flags().StringVar(&releaseStartDate, "start-date", "", "Start date, YYYY-MM-DD")
+	releaseCreateCmd.Flags().StringVar(&releaseDate, "release-date", "", "Planned release date, YYYY-MM-DD")
+	releasePublishCmd.Flags().StringVar(&releaseDate, "release-date", "", "Release date, YYYY-MM-DD (defaults to the version's)")
+	releaseNotesCmd.Flags().StringVar(&releaseVersion, "version", "", "Version name or ID")
+	releaseNotesCmd.Flags().BoolVar(&releasePullRequests, "pull-requests", true, "Include linked pull requests")
+	_ = releaseNotesCmd.MarkFlagRequired("version")
+	releaseCmd.AddCommand(releaseListCmd)
+	releaseCmd.AddCommand(releaseCreateCmd)
+	releaseCmd.AddCommand(releasePublishCmd)
+	releaseCmd.AddCommand(releaseNotesCmd)
+}
+
+// releaseProjectKey returns --project, falling back to jira.project_key.
+func releaseProjectKey(cfg *config.Config) string {
+	project := strings.TrimSpace(releaseProject)
+	if project == "" {
+		project = strings.TrimSpace(cfg.Jira.ProjectKey)
+	}
+	if project == "" {
+		log.Fatal("--project is required (Jira project key), or set jira.project_key")
+	}
+	return project
+}
+
+func reportVersion(cmd *cobra.Command, project string, version *jira.Version, action string) {
+	if wantsJSON(cmd) {
+		if err := printJSON(version); err != nil {
+			fatalError("Error encoding JSON", err)
+		}
+		return
+	}
+	if wantsTabular(cmd) {
+		renderKeyValueTable([][2]string{{"Project", project}, {"Version", version.Name}, {"ID", version.ID}, {"Release date", version.ReleaseDate}})
+		return
+	}
+	fmt.Printf("✅ %s %s %s\n", action, project, version.Name)
+}
+
+type releaseNotes struct {
+	Project     string         `json:"project"`
+	Version     string         `json:"version"`
+	Released    bool           `json:"released"`
+	ReleaseDate string         `json:"release_date,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Issues      int            `json:"issues"`
+	Groups      []releaseGroup `json:"groups"`
+}
+
+type releaseGroup struct {
+	Type   string         `json:"type"`
+	Issues []releaseIssue `json:"issues"`
+}
+
+type releaseIssue struct {
+	Key          string               `json:"key"`
+	Summary      string               `json:"summary"`
+	Status       string               `json:"status"`
+	URL          string               `json:"url,omitempty"`
+	PullRequests []releasePullRequest `json:"pull_requests"`
+}
+
+type releasePullRequest struct {
+	Title      string `json:"title"`
+	URL        string `json:"url,omitempty"`
+	Status     string `json:"status,omitempty"`
+	Repository string `json:"repository,omitempty"`
+}
+
+// buildReleaseNotes groups the issues of a version by type, in
+// issueTypeOrder, with their pull requests other than declined ones.
+func buildReleaseNotes(project string, version jira.Version, issues []jira.Issue, pullRequests map[string][]jira.PullRequestRef, baseURL string) releaseNotes {
+	notes := releaseNotes{
+		Project:     project,
+		Version:     version.Name,
+		Released:    version.Released,
+		ReleaseDate: version.ReleaseDate,
+		Description: version.Description,
+		Issues:      len(issues),
+		Groups:      []releaseGroup{},
+	}
+	index := make(map[string]int)
+	for _, issue := range issues {
+		issueType := issue.Fields.IssueType.Name
+		if issueType == "" {
+			issueType = "Other"
+		}
+		if _, ok := index[issueType]; !ok {
+			index[issueType] = len(notes.Groups)
+			notes.Groups = append(notes.Groups, releaseGroup{Type: issueType})
+		}
+		item := releaseIssue{Key: issue.Key, Summary: issue.Fields.Summary, Status: issue.Fields.Status.Name, PullRequests: []releasePullRequest{}}
+		if baseURL != "" {
+			item.URL = strings.TrimSuffix(baseURL, "/") + "/browse/" + issue.Key
+		}
+		for _, pr := range pullRequests[issue.Key] {
+			if strings.EqualFold(pr.Status, "DECLINED") {
+				continue
+			}
+			repository := pr.Source.Repository.Name
+			if repository == "" {
+				repository = pr.Destination.Repository.Name
+			}
+			item.PullRequests = append(item.PullRequests, releasePullRequest{Title: pr.Name, URL: pr.URL, Status: pr.Status, Repository: repository})
+		}
+		group := &notes.Groups[index[issueType]]
+		group.Issues = append(group.Issues, item)
+	}
+	sort.SliceStable(notes.Groups, func(i, j int) bool {
+		ri, rj := issueTypeRank(notes.Groups[i].Type), issueTypeRank(notes.Groups[j].Type)
+		if ri != rj {
+			return ri < rj
+		}
+		return notes.Groups[i].Type < notes.Groups[j].Type
+	})
+	return notes
+}
+
+func issueTypeRank(issueType string) int {
+	for i, known := range issueTypeOrder {
+		if strings.EqualFold(known, issueType) {
+			return i
+		}
+	}
+	return len(issueTypeOrder)
+}
+
+// renderReleaseNotes renders the notes as Markdown.
+func renderReleaseNotes(notes releaseNotes) string {
+	var b strings.Builder
+	date := "unreleased"
+	if notes.ReleaseDate != "" {
+		date = notes.ReleaseDate
+	}
+	fmt.Fprintf(&b, "## %s (%s)\n\n", notes.Version, date)
+	if notes.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", notes.Description)
+	}
+	if len(notes.Groups) == 0 {
+		b.WriteString("No issues are fixed in this version.\n")
+		return b.String()
+	}
+	for _, group := range notes.Groups {
+		fmt.Fprintf(&b, "### %s\n\n", pluralIssueType(group.Type))
+		for _, issue := range group.Issues {
+			key := issue.Key
+			if issue.URL != "" {
+				key = fmt.Sprintf("[%s](%s)", issue.Key, issue.URL)
+			}
+			fmt.Fprintf(&b, "- %s %s\n", key, issue.Summary)
+			for _, pr := range issue.PullRequests {
+				if pr.URL != "" {
+					fmt.Fprintf(&b, "  - [%s](%s)\n", pr.Title, pr.URL)
+				} else {
+					fmt.Fprintf(&b, "  - %s\n", pr.Title)
+				}
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// pluralIssueType turns an issue type into a section heading, e.g. "Story"
+// into "Stories".
+func pluralIssueType(issueType string) string {
+	lower := strings.ToLower(issueType)
+	switch {
+	case strings.HasSuffix(lower, "s"):
+		return issueType
+	case strings.HasSuffix(lower, "y") && !strings.HasSuffix(lower, "ay") && !strings.HasSuffix(lower, "ey") && !strings.HasSuffix(lower, "oy"):
+		return issueType[:len(issueType)-1] + "ies"
+	default:
+		return issueType + "s"
+	}
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"devflow/internal/config"
+	"devflow/internal/jira"
+)
+
+func releaseIssueFixture(key, issueType, summary string) jira.Issue {
+	issue := jira.Issue{ID: strings.TrimPrefix(key, "ENG-"), Key: key}
+	issue.Fields.Summary = summary
+	issue.Fields.Status.Name = "Done"
+	issue.Fields.IssueType.Name = issueType
+	return issue
+}
+
+func TestBuildReleaseNotes(t *testing.T) {
+	version := jira.Version{ID: "101", Name: "2.4.0", Released: true, ReleaseDate: "2024-06-01"}
+	issues := []jira.Issue{
+		releaseIssueFixture("ENG-1", "Bug", "Fix crash on save"),
+		releaseIssueFixture("ENG-2", "Chore", "Bump dependencies"),
+		releaseIssueFixture("ENG-3", "Story", "Search by label"),
+	}
+	pullRequests := map[string][]jira.PullRequestRef{
+		"ENG-3": {
+			{ID: "1", Name: "Add label search", Status: "MERGED", URL: "https://bitbucket.example/pr/1"},
+			{ID: "2", Name: "Label search v1", Status: "DECLINED", URL: "https://bitbucket.example/pr/2"},
+		},
+	}
+
+	notes := buildReleaseNotes("ENG", version, issues, pullRequests, "https://jira.example/")
+	var types []string
+	for _, group := range notes.Groups {
+		types = append(types, group.Type)
+	}
+	if strings.Join(types, ",") != "Story,Bug,Chore" || notes.Issues != 3 {
+		t.Fatalf("unexpected groups %v", types)
+	}
+	if prs := notes.Groups[0].Issues[0].PullRequests; len(prs) != 1 || prs[0].Title != "Add label search" {
+		t.Fatalf("expected declined pull requests to be left out, got %+v", prs)
+	}
+
+	markdown := renderReleaseNotes(notes)
+	for _, want := range []string{
+		"## 2.4.0 (2024-06-01)",
+		"### Stories\n\n- [ENG-3](https://jira.example/browse/ENG-3) Search by label\n  - [Add label search](https://bitbucket.example/pr/1)\n",
+		"### Bugs",
+		"### Chores",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Fatalf("release notes missing %q:\n%s", want, markdown)
+		}
+	}
+	if got := pluralIssueType("Sub-task"); got != "Sub-tasks" {
+		t.Fatalf("unexpected heading %q", got)
+	}
+}
+
+func TestReleaseNotesCmd(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", ProjectKey: "ENG", Deployment: "cloud"},
+	})
+	origVersion, origPullRequests := releaseVersion, releasePullRequests
+	t.Cleanup(func() { releaseVersion, releasePullRequests = origVersion, origPullRequests })
+	releaseVersion, releasePullRequests = "2.4.0", true
+
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/project/ENG/versions":
+			_, _ = w.Write([]byte(`[{"id":"101","name":"2.4.0","description":"Spring release"}]`))
+		case "/rest/api/3/search/jql":
+			if jql := r.URL.Query().Get("jql"); !strings.Contains(jql, `fixVersion = "2.4.0"`) {
+				t.Errorf("unexpected search %q", jql)
+			}
+			_, _ = w.Write([]byte(`{"issues":[{"id":"1","key":"ENG-1","fields":{"summary":"Fix crash","status":{"name":"Done"},"issuetype":{"name":"Bug"}}}],"isLast":true}`))
+		case "/rest/dev-status/1.0/issue/detail":
+			_, _ = w.Write([]byte(`{"detail":[{"pullRequests":[{"id":"7","name":"Guard nil save","status":"MERGED","url":"https://bitbucket.example/pr/7"}]}]}`))
+		default:
+			t.Errorf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	out := captureStdout(func() { releaseNotesCmd.Run(commandWithFormat(formatJSON), nil) })
+	var notes releaseNotes
+	if err := json.Unmarshal([]byte(out), &notes); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if notes.Project != "ENG" || len(notes.Groups) != 1 || notes.Groups[0].Type != "Bug" || notes.Groups[0].Issues[0].PullRequests[0].URL != "https://bitbucket.example/pr/7" {
+		t.Fatalf("unexpected notes: %+v", notes)
+	}
+
+	out = captureStdout(func() { releaseNotesCmd.Run(commandWithFormat(formatDetailed), nil) })
+	for _, want := range []string{"## 2.4.0 (unreleased)", "Spring release", "- [ENG-1](https://jira.example/browse/ENG-1) Fix crash", "[Guard nil save](https://bitbucket.example/pr/7)"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("release notes missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 
+	"devflow/internal/adf"
 	"devflow/internal/config"
 	"devflow/internal/jira"
 	"github.com/spf13/cobra"
@@ -13,6 +16,8 @@ import (
 var showChildren bool
 var showPullRequests bool
 var recursive bool
+var showDepth int
+var showMaxIssues int
 
 var showIssueCmd = &cobra.Command{
 	Use:   "show [issue-key]",
@@ -20,19 +25,20 @@ var showIssueCmd = &cobra.Command{
 	Long:  `Display comprehensive details about a specific Jira issue including description, status, priority, assignee, and more`,
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
 		issueKey := args[0]
 
 		// Load configuration
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 
 		// Validate required config
 		if cfg.Jira.URL == "" {
 			log.Fatal("Jira URL not configured. Run: devflow config set jira.url <url>")
 		}
-		if cfg.Jira.Username == "" {
+		if jiraUsernameMissing(cfg.Jira) {
 			log.Fatal("Jira username not configured. Run: devflow config set jira.username <username>")
 		}
 		if cfg.Jira.Token == "" {
@@ -43,20 +49,27 @@ var showIssueCmd = &cobra.Command{
 		client := jira.NewClient(&cfg.Jira)
 
 		// Get issue details
-		issue, err := client.GetIssueDetails(issueKey)
+		issue, err := client.GetIssueDetailsContext(ctx, issueKey)
 		if err != nil {
-			log.Fatalf("Error fetching issue details: %v", err)
+			fatalError("Error fetching issue details", err)
 		}
-		if recursive {
-			children, err := buildIssueTree(client, issueKey, showPullRequests)
+		if showDepth < 0 || showMaxIssues < 0 {
+			fatalError("Invalid limits", fmt.Errorf("--depth and --max-issues must be 0 or more"))
+		}
+		if recursive || showDepth > 0 {
+			children, _, err := buildIssueTree(ctx, client, issueKey, issueTreeOptions{
+				PullRequests: showPullRequests,
+				MaxDepth:     showDepth,
+				MaxIssues:    showMaxIssues,
+			})
 			if err != nil {
-				log.Fatalf("Error fetching recursive child issues: %v", err)
+				fatalError("Error fetching recursive child issues", err)
 			}
 			var pullRequests []jira.PullRequestRef
 			if showPullRequests {
-				pullRequests, err = client.GetIssuePullRequests(issue.ID)
+				pullRequests, err = client.GetIssuePullRequestsContext(ctx, issue.ID)
 				if err != nil {
-					log.Fatalf("Error fetching pull requests: %v", err)
+					fatalError("Error fetching pull requests", err)
 				}
 			}
 			if wantsJSON(cmd) {
@@ -67,26 +80,27 @@ var showIssueCmd = &cobra.Command{
 					output = normalizedIssueJSONWithTree(issue, pullRequests, showPullRequests, children)
 				}
 				if err := printJSON(output); err != nil {
-					log.Fatalf("Error encoding JSON: %v", err)
+					fatalError("Error encoding JSON", err)
 				}
 				return
 			}
 			displayRecursiveTable(issue, pullRequests, showPullRequests, children)
+			displayLinkTable(issue)
 			return
 		}
 		if wantsJSON(cmd) {
 			var pullRequests []jira.PullRequestRef
 			var children []jira.Issue
 			if showPullRequests {
-				pullRequests, err = client.GetIssuePullRequests(issue.ID)
+				pullRequests, err = client.GetIssuePullRequestsContext(ctx, issue.ID)
 				if err != nil {
-					log.Fatalf("Error fetching pull requests: %v", err)
+					fatalError("Error fetching pull requests", err)
 				}
 			}
 			if showChildren {
-				children, err = fetchChildIssues(client, issueKey)
+				children, err = fetchChildIssues(ctx, client, issueKey)
 				if err != nil {
-					log.Fatalf("Error fetching child issues: %v", err)
+					fatalError("Error fetching child issues", err)
 				}
 			}
 			var output any
@@ -96,7 +110,7 @@ var showIssueCmd = &cobra.Command{
 				output = normalizedIssueJSON(issue, pullRequests, showPullRequests, children, showChildren)
 			}
 			if err := printJSON(output); err != nil {
-				log.Fatalf("Error encoding JSON: %v", err)
+				fatalError("Error encoding JSON", err)
 			}
 			return
 		}
@@ -104,15 +118,15 @@ var showIssueCmd = &cobra.Command{
 			var pullRequests []jira.PullRequestRef
 			var children []jira.Issue
 			if showPullRequests {
-				pullRequests, err = client.GetIssuePullRequests(issue.ID)
+				pullRequests, err = client.GetIssuePullRequestsContext(ctx, issue.ID)
 				if err != nil {
-					log.Fatalf("Error fetching pull requests: %v", err)
+					fatalError("Error fetching pull requests", err)
 				}
 			}
 			if showChildren {
-				children, err = fetchChildIssues(client, issueKey)
+				children, err = fetchChildIssues(ctx, client, issueKey)
 				if err != nil {
-					log.Fatalf("Error fetching child issues: %v", err)
+					fatalError("Error fetching child issues", err)
 				}
 			}
 			tree := make([]issueTreeNode, 0, len(children))
@@ -120,6 +134,7 @@ var showIssueCmd = &cobra.Command{
 				tree = append(tree, issueTreeNode{Issue: child})
 			}
 			displayRecursiveTable(issue, pullRequests, showPullRequests, tree)
+			displayLinkTable(issue)
 			return
 		}
 
@@ -127,11 +142,11 @@ var showIssueCmd = &cobra.Command{
 		displayIssueDetails(issue)
 
 		if showChildren {
-			displayChildIssues(client, cfg, issueKey)
+			displayChildIssues(ctx, client, cfg, issueKey)
 		}
 
 		if showPullRequests {
-			displayPullRequests(client, issue)
+			displayPullRequests(ctx, client, issue)
 		}
 	},
 }
@@ -150,6 +165,7 @@ type normalizedIssue struct {
 	Description  string                   `json:"description,omitempty"`
 	Comments     []normalizedComment      `json:"comments"`
 	Attachments  []normalizedAttachment   `json:"attachments"`
+	Links        []normalizedLink         `json:"links"`
 	Children     *[]normalizedChild       `json:"children,omitempty"`
 	PullRequests *[]normalizedPullRequest `json:"pull_requests,omitempty"`
 }
@@ -166,11 +182,24 @@ type normalizedComment struct {
 }
 
 type normalizedAttachment struct {
+	ID       string `json:"id,omitempty"`
 	Filename string `json:"filename"`
 	Size     int64  `json:"size"`
+	MimeType string `json:"mime_type,omitempty"`
+	URL      string `json:"url,omitempty"`
 	Created  string `json:"created,omitempty"`
 }
 
+type normalizedLink struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Direction string `json:"direction"`
+	Relation  string `json:"relation"`
+	Key       string `json:"key"`
+	Summary   string `json:"summary,omitempty"`
+	Status    string `json:"status,omitempty"`
+}
+
 type normalizedChild struct {
 	Key          string                   `json:"key"`
 	Summary      string                   `json:"summary"`
@@ -199,16 +228,33 @@ func normalizedIssueJSON(issue *jira.IssueDetails, pullRequests []jira.PullReque
 		comments = append(comments, normalizedComment{
 			Author:  comment.Author.DisplayName,
 			Created: comment.Created,
-			Body:    normalizedText(comment.Body),
+			Body:    renderedText(comment.Body),
 		})
 	}
 
 	attachments := make([]normalizedAttachment, 0, len(issue.Fields.Attachment))
 	for _, attachment := range issue.Fields.Attachment {
-		attachments = append(attachments, normalizedAttachment{
-			Filename: attachment.Filename,
-			Size:     attachment.Size,
-			Created:  attachment.Created,
+		attachments = append(attachments, normalizedAttachmentFrom(attachment))
+	}
+
+	links := make([]normalizedLink, 0, len(issue.Fields.IssueLinks))
+	for _, link := range issue.Fields.IssueLinks {
+		relation, other := link.Direction()
+		if other == nil {
+			continue
+		}
+		direction := "inward"
+		if link.OutwardIssue != nil {
+			direction = "outward"
+		}
+		links = append(links, normalizedLink{
+			ID:        link.ID,
+			Type:      link.Type.Name,
+			Direction: direction,
+			Relation:  relation,
+			Key:       other.Key,
+			Summary:   other.Fields.Summary,
+			Status:    other.Fields.Status.Name,
 		})
 	}
 
@@ -222,9 +268,10 @@ func normalizedIssueJSON(issue *jira.IssueDetails, pullRequests []jira.PullReque
 		Reporter:    issue.Fields.Reporter.DisplayName,
 		Created:     issue.Fields.Created,
 		Updated:     issue.Fields.Updated,
-		Description: normalizedText(issue.Fields.Description),
+		Description: renderedText(issue.Fields.Description),
 		Comments:    comments,
 		Attachments: attachments,
+		Links:       links,
 	}
 	if issue.Fields.TeamAssigned.ID != "" || issue.Fields.TeamAssigned.Name != "" {
 		output.Team = &normalizedTeam{ID: issue.Fields.TeamAssigned.ID, Name: issue.Fields.TeamAssigned.Name}
@@ -376,47 +423,12 @@ func rawIssueTreeNodeFromTree(node issueTreeNode, includePullRequests bool) rawI
 	return output
 }
 
-func normalizedText(value any) string {
-	if value == nil {
-		return ""
-	}
-	if text, ok := value.(string); ok {
-		return cleanDescription(text)
-	}
-
-	var parts []string
-	var walk func(any)
-	walk = func(current any) {
-		switch item := current.(type) {
-		case map[string]any:
-			if text, ok := item["text"].(string); ok {
-				parts = append(parts, text)
-			}
-			if attrs, ok := item["attrs"].(map[string]any); ok {
-				if url, ok := attrs["url"].(string); ok {
-					parts = append(parts, url)
-				}
-			}
-			if content, ok := item["content"]; ok {
-				walk(content)
-			}
-		case []any:
-			for _, child := range item {
-				walk(child)
-			}
-		}
-	}
-	walk(value)
-	if len(parts) > 0 {
-		return strings.Join(strings.Fields(strings.Join(parts, " ")), " ")
-	}
-	return cleanDescription(fmt.Sprintf("%v", value))
-}
-
 func init() {
 	showIssueCmd.Flags().BoolVar(&showChildren, "children", false, "List the ticket's child items")
 	showIssueCmd.Flags().BoolVar(&showPullRequests, "pull-requests", false, "List the ticket's linked pull requests")
 	showIssueCmd.Flags().BoolVar(&recursive, "recursive", false, "Include all descendant child items")
+	showIssueCmd.Flags().IntVar(&showDepth, "depth", 0, "Levels of descendants to include; implies --recursive (0 for all)")
+	showIssueCmd.Flags().IntVar(&showMaxIssues, "max-issues", 1000, "Stop loading descendants after this many issues (0 for no limit)")
 }
 
 func issueJSONValue(issue *jira.IssueDetails, pullRequests []jira.PullRequestRef, includePullRequests bool) any {
@@ -456,10 +468,10 @@ func issueJSONValueWithChildren(issue *jira.IssueDetails, pullRequests []jira.Pu
 
 // displayPullRequests fetches and prints the pull requests linked to the
 // given issue via the Jira dev-status integration.
-func displayPullRequests(client *jira.Client, issue *jira.IssueDetails) {
-	prs, err := client.GetIssuePullRequests(issue.ID)
+func displayPullRequests(ctx context.Context, client *jira.Client, issue *jira.IssueDetails) {
+	prs, err := client.GetIssuePullRequestsContext(ctx, issue.ID)
 	if err != nil {
-		log.Fatalf("Error fetching pull requests: %v", err)
+		fatalError("Error fetching pull requests", err)
 	}
 
 	fmt.Println()
@@ -485,10 +497,10 @@ func displayPullRequests(client *jira.Client, issue *jira.IssueDetails) {
 
 // displayChildIssues fetches and prints the child issues (e.g. subtasks or
 // items whose parent is issueKey) for the given ticket.
-func displayChildIssues(client *jira.Client, cfg *config.Config, issueKey string) {
-	children, err := fetchChildIssues(client, issueKey)
+func displayChildIssues(ctx context.Context, client *jira.Client, cfg *config.Config, issueKey string) {
+	children, err := fetchChildIssues(ctx, client, issueKey)
 	if err != nil {
-		log.Fatalf("Error fetching child issues: %v", err)
+		fatalError("Error fetching child issues", err)
 	}
 
 	fmt.Println()
@@ -513,41 +525,140 @@ func displayChildIssues(client *jira.Client, cfg *config.Config, issueKey string
 	}
 }
 
-func fetchChildIssues(client *jira.Client, issueKey string) ([]jira.Issue, error) {
+func fetchChildIssues(ctx context.Context, client *jira.Client, issueKey string) ([]jira.Issue, error) {
 	jql := fmt.Sprintf("parent = %s ORDER BY status ASC, priority DESC", issueKey)
-	return client.Search(jql, true, 0, 0)
+	return client.SearchContext(ctx, jql, true, 0, 0)
 }
 
-func buildIssueTree(client *jira.Client, issueKey string, includePullRequests bool) ([]issueTreeNode, error) {
-	children, err := fetchChildIssues(client, issueKey)
-	if err != nil {
-		return nil, err
-	}
+// childBatchSize is how many parents one children search asks about, which
+// keeps the JQL well within URL length limits.
+const childBatchSize = 50
 
-	tree := make([]issueTreeNode, 0, len(children))
-	for _, child := range children {
-		node := issueTreeNode{Issue: child}
-		if includePullRequests {
-			issueID := child.ID
-			if issueID == "" {
-				details, err := client.GetIssueDetails(child.Key)
-				if err != nil {
-					return nil, fmt.Errorf("resolve child issue %s: %w", child.Key, err)
-				}
-				issueID = details.ID
+// treePullRequestWorkers bounds the pull request lookups running at a time.
+const treePullRequestWorkers = 8
+
+// issueTreeOptions select what buildIssueTree loads.
+type issueTreeOptions struct {
+	PullRequests bool
+	MaxDepth     int // Levels below the root; 0 loads all of them
+	MaxIssues    int // Descendants to load; 0 loads all of them
+}
+
+// buildIssueTree loads the descendants of issueKey breadth first, asking for
+// the children of a whole level in batched "parent in (...)" searches. An
+// issue reached twice, which only a cycle or a moved issue can cause, is
+// kept where it was first seen. Pull requests are fetched afterwards, a few
+// issues at a time. truncated reports whether MaxIssues left issues out.
+func buildIssueTree(ctx context.Context, client *jira.Client, issueKey string, opts issueTreeOptions) (tree []issueTreeNode, truncated bool, err error) {
+	children := make(map[string][]jira.Issue)
+	seen := map[string]bool{issueKey: true}
+	var loaded []jira.Issue
+
+	level := []string{issueKey}
+	for depth := 1; len(level) > 0 && !truncated; depth++ {
+		if opts.MaxDepth > 0 && depth > opts.MaxDepth {
+			break
+		}
+		var next []string
+		for start := 0; start < len(level) && !truncated; start += childBatchSize {
+			batch := level[start:min(start+childBatchSize, len(level))]
+			limit := 0
+			if opts.MaxIssues > 0 {
+				// One more than fits tells whether anything was left out.
+				limit = opts.MaxIssues - len(loaded) + 1
 			}
-			node.PullRequests, err = client.GetIssuePullRequests(issueID)
+			found, err := client.SearchChildrenContext(ctx, batch, limit)
 			if err != nil {
-				return nil, fmt.Errorf("fetch pull requests for %s: %w", child.Key, err)
+				return nil, false, fmt.Errorf("fetch children of %s: %w", strings.Join(batch, ", "), err)
+			}
+			for _, child := range found {
+				if opts.MaxIssues > 0 && len(loaded) == opts.MaxIssues {
+					truncated = true
+					break
+				}
+				parent := ""
+				if child.Fields.Parent != nil {
+					parent = child.Fields.Parent.Key
+				} else if len(batch) == 1 {
+					parent = batch[0]
+				}
+				if parent == "" {
+					continue
+				}
+				if seen[child.Key] {
+					fmt.Fprintf(errorOutput, "Skipping %s under %s: it is already in the tree.\n", child.Key, parent)
+					continue
+				}
+				seen[child.Key] = true
+				children[parent] = append(children[parent], child)
+				loaded = append(loaded, child)
+				next = append(next, child.Key)
 			}
 		}
-		node.Children, err = buildIssueTree(client, child.Key, includePullRequests)
+		level = next
+	}
+	if truncated {
+		fmt.Fprintf(errorOutput, "Stopped at --max-issues %d; the tree is incomplete.\n", opts.MaxIssues)
+	}
+
+	var pullRequests map[string][]jira.PullRequestRef
+	if opts.PullRequests {
+		pullRequests, err = fetchTreePullRequests(ctx, client, loaded)
 		if err != nil {
-			return nil, err
+			return nil, false, err
+		}
+	}
+
+	var assemble func(string) []issueTreeNode
+	assemble = func(key string) []issueTreeNode {
+		nodes := make([]issueTreeNode, 0, len(children[key]))
+		for _, child := range children[key] {
+			nodes = append(nodes, issueTreeNode{
+				Issue:        child,
+				PullRequests: pullRequests[child.Key],
+				Children:     assemble(child.Key),
+			})
 		}
-		tree = append(tree, node)
+		return nodes
 	}
-	return tree, nil
+	return assemble(issueKey), truncated, nil
+}
+
+// fetchTreePullRequests looks up the pull requests of the issues,
+// treePullRequestWorkers at a time, by the ids their search returned.
+func fetchTreePullRequests(ctx context.Context, client *jira.Client, issues []jira.Issue) (map[string][]jira.PullRequestRef, error) {
+	results := make([][]jira.PullRequestRef, len(issues))
+	errs := make([]error, len(issues))
+	sem := make(chan struct{}, treePullRequestWorkers)
+	var wg sync.WaitGroup
+	for i, issue := range issues {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, issue jira.Issue) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if issue.ID == "" {
+				errs[i] = fmt.Errorf("fetch pull requests for %s: the search returned no issue id", issue.Key)
+				return
+			}
+			prs, err := client.GetIssuePullRequestsContext(ctx, issue.ID)
+			if err != nil {
+				errs[i] = fmt.Errorf("fetch pull requests for %s: %w", issue.Key, err)
+				return
+			}
+			results[i] = prs
+		}(i, issue)
+	}
+	wg.Wait()
+
+	pullRequests := make(map[string][]jira.PullRequestRef, len(issues))
+	for i, issue := range issues {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		pullRequests[issue.Key] = results[i]
+	}
+	return pullRequests, nil
 }
 
 func displayRecursiveTable(issue *jira.IssueDetails, pullRequests []jira.PullRequestRef, includePullRequests bool, children []issueTreeNode) {
@@ -575,6 +686,23 @@ func displayRecursiveTable(issue *jira.IssueDetails, pullRequests []jira.PullReq
 	}
 }
 
+// displayLinkTable lists the issue's links below the issue table, if it has
+// any.
+func displayLinkTable(issue *jira.IssueDetails) {
+	rows := make([][]any, 0, len(issue.Fields.IssueLinks))
+	for _, link := range issue.Fields.IssueLinks {
+		relation, other := link.Direction()
+		if other == nil {
+			continue
+		}
+		rows = append(rows, []any{relation, other.Key, other.Fields.Summary, other.Fields.Status.Name})
+	}
+	if len(rows) == 0 {
+		return
+	}
+	renderTable([]string{"Relation", "Ticket", "Name", "Status"}, rows)
+}
+
 func displayIssueDetails(issue *jira.IssueDetails) {
 	// Load config to get the base URL
 	cfg, _ := loadConfig()
@@ -627,10 +755,21 @@ func displayIssueDetails(issue *jira.IssueDetails) {
 	if issue.Fields.Description != nil {
 		fmt.Println("📄 Description:")
 		fmt.Println("─────────────")
-		// Convert interface{} to string and clean up
-		descStr := fmt.Sprintf("%v", issue.Fields.Description)
-		cleanDescription := cleanDescription(descStr)
-		fmt.Println(cleanDescription)
+		fmt.Println(renderedText(issue.Fields.Description))
+		fmt.Println()
+	}
+
+	// Issue links
+	if len(issue.Fields.IssueLinks) > 0 {
+		fmt.Printf("🔗 Links (%d):\n", len(issue.Fields.IssueLinks))
+		fmt.Println("──────────")
+		for _, link := range issue.Fields.IssueLinks {
+			relation, other := link.Direction()
+			if other == nil {
+				continue
+			}
+			fmt.Printf("• %s %s %s - %s\n", relation, getStatusIcon(other.Fields.Status.Name), other.Key, other.Fields.Summary)
+		}
 		fmt.Println()
 	}
 
@@ -640,9 +779,8 @@ func displayIssueDetails(issue *jira.IssueDetails) {
 		fmt.Println("────────────")
 		for i, comment := range issue.Fields.Comment.Comments {
 			fmt.Printf("%d. %s - %s\n", i+1, comment.Author.DisplayName, comment.Created)
-			// Handle comment body as interface{} in case it's complex
-			bodyStr := fmt.Sprintf("%v", comment.Body)
-			fmt.Printf("   %s\n\n", cleanDescription(bodyStr))
+			body := renderedText(comment.Body)
+			fmt.Printf("   %s\n\n", strings.ReplaceAll(body, "\n", "\n   "))
 		}
 	}
 
@@ -657,6 +795,22 @@ func displayIssueDetails(issue *jira.IssueDetails) {
 	}
 }
 
+// renderedText renders a description, comment or worklog body for the
+// terminal and JSON output. ADF documents become Markdown; anything else is
+// cleaned up as text.
+func renderedText(value any) string {
+	if value == nil {
+		return ""
+	}
+	if text, ok := value.(string); ok {
+		return cleanDescription(text)
+	}
+	if markdown := adf.Render(value); markdown != "" {
+		return markdown
+	}
+	return cleanDescription(fmt.Sprintf("%v", value))
+}
+
 func cleanDescription(description string) string {
 	// Handle Atlassian Document Format (ADF) - extract text content
 	if strings.Contains(description, "type:") && strings.Contains(description, "content:") {
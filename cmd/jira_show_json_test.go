@@ -139,9 +139,20 @@ func TestNormalizedIssueJSONFlattensFieldsAndADF(t *testing.T) {
 		"type": "doc",
 		"content": []any{
 			map[string]any{
-				"type":    "paragraph",
+				"type":    "heading",
+				"attrs":   map[string]any{"level": 2},
 				"content": []any{map[string]any{"type": "text", "text": "Hello"}},
 			},
+			map[string]any{
+				"type": "bulletList",
+				"content": []any{map[string]any{
+					"type": "listItem",
+					"content": []any{map[string]any{
+						"type":    "paragraph",
+						"content": []any{map[string]any{"type": "text", "text": "item"}},
+					}},
+				}},
+			},
 		},
 	}
 
@@ -161,7 +172,7 @@ func TestNormalizedIssueJSONFlattensFieldsAndADF(t *testing.T) {
 	if err := json.Unmarshal(output["description"], &description); err != nil {
 		t.Fatalf("decode normalized description: %v", err)
 	}
-	if description != "Hello" {
-		t.Fatalf("normalized description = %q, want %q", description, "Hello")
+	if want := "## Hello\n\n- item"; description != want {
+		t.Fatalf("normalized description = %q, want %q", description, want)
 	}
 }
@@ -8,39 +8,6 @@ import (
 	"devflow/internal/jira"
 )
 
-func TestNormalizedText(t *testing.T) {
-	tests := []struct {
-		name  string
-		value any
-		want  string
-	}{
-		{name: "plain text", value: "  hello\nworld  ", want: "hello\nworld"},
-		{
-			name: "ADF text and link",
-			value: map[string]any{
-				"type": "doc",
-				"content": []any{map[string]any{
-					"content": []any{
-						map[string]any{"text": "See"},
-						map[string]any{"attrs": map[string]any{"url": "https://example.com"}},
-					},
-				}},
-			},
-			want: "See https://example.com",
-		},
-		{name: "nil", value: nil, want: ""},
-		{name: "fallback", value: 42, want: "42"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := normalizedText(tt.value); got != tt.want {
-				t.Fatalf("normalizedText() = %q, want %q", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestNormalizedChildFromTreeIncludesNestedChildrenAndPullRequests(t *testing.T) {
 	grandchild := jira.Issue{Key: "TASK-2"}
 	grandchild.Fields.Summary = "Grandchild"
@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
 
 	"devflow/internal/config"
@@ -18,9 +21,9 @@ func TestJiraChildAndRecursiveHelpers(t *testing.T) {
 		case "/rest/api/3/search/jql":
 			jql := r.URL.Query().Get("jql")
 			switch {
-			case strings.Contains(jql, "parent = ROOT-1"):
+			case strings.Contains(jql, "parent = ROOT-1"), strings.Contains(jql, "parent in (ROOT-1)"):
 				_ = json.NewEncoder(w).Encode(jira.SearchResponse{Issues: []jira.Issue{{ID: "2", Key: "CHILD-1"}}})
-			case strings.Contains(jql, "parent = CHILD-1"):
+			case strings.Contains(jql, "parent in (CHILD-1)"):
 				_ = json.NewEncoder(w).Encode(jira.SearchResponse{Issues: []jira.Issue{{ID: "3", Key: "GRAND-1"}}})
 			default:
 				_ = json.NewEncoder(w).Encode(jira.SearchResponse{Issues: []jira.Issue{}})
@@ -36,16 +39,16 @@ func TestJiraChildAndRecursiveHelpers(t *testing.T) {
 		}
 	}))
 	defer server.Close()
-	client := jira.NewClient(&config.JiraConfig{URL: server.URL})
+	client := jira.NewClient(&config.JiraConfig{URL: server.URL, Deployment: "cloud"})
 
-	children, err := fetchChildIssues(client, "ROOT-1")
+	children, err := fetchChildIssues(context.Background(), client, "ROOT-1")
 	if err != nil || len(children) != 1 || children[0].Key != "CHILD-1" {
-		t.Fatalf("fetchChildIssues() = %+v, err=%v", children, err)
+		t.Fatalf("fetchChildIssues(context.Background(), ) = %+v, err=%v", children, err)
 	}
 
-	tree, err := buildIssueTree(client, "ROOT-1", true)
+	tree, _, err := buildIssueTree(context.Background(), client, "ROOT-1", issueTreeOptions{PullRequests: true})
 	if err != nil {
-		t.Fatalf("buildIssueTree() failed: %v", err)
+		t.Fatalf("buildIssueTree(context.Background(), ) failed: %v", err)
 	}
 	if len(tree) != 1 || len(tree[0].Children) != 1 || len(tree[0].PullRequests) != 1 {
 		t.Fatalf("unexpected issue tree: %+v", tree)
@@ -54,17 +57,93 @@ func TestJiraChildAndRecursiveHelpers(t *testing.T) {
 	root := &jira.IssueDetails{ID: "1", Key: "ROOT-1"}
 	root.Fields.Summary = "Root"
 	out := captureStdout(func() {
-		displayPullRequests(client, root)
+		displayPullRequests(context.Background(), client, root)
 	})
 	if !strings.Contains(out, "Implement child") {
 		t.Fatalf("pull-request display missing result: %s", out)
 	}
 
-	cfg := &config.Config{Jira: config.JiraConfig{URL: server.URL}}
+	cfg := &config.Config{Jira: config.JiraConfig{URL: server.URL, Deployment: "cloud"}}
 	out = captureStdout(func() {
-		displayChildIssues(client, cfg, "ROOT-1")
+		displayChildIssues(context.Background(), client, cfg, "ROOT-1")
 	})
 	if !strings.Contains(out, "CHILD-1") || !strings.Contains(out, "Child") {
 		t.Fatalf("child display missing result: %s", out)
 	}
 }
+
+func TestBuildIssueTreeBatchesLevels(t *testing.T) {
+	var mu sync.Mutex
+	var searches []string
+	prLookups := 0
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/3/search/jql":
+			jql := r.URL.Query().Get("jql")
+			if fields := r.URL.Query().Get("fields"); !strings.Contains(fields, "id") || !strings.Contains(fields, "parent") {
+				t.Errorf("expected the id and parent fields to be requested, got %q", fields)
+			}
+			mu.Lock()
+			searches = append(searches, jql)
+			mu.Unlock()
+			switch {
+			case strings.HasPrefix(jql, "parent in (EPIC-1)"):
+				_, _ = w.Write([]byte(`{"issues":[
+					{"id":"11","key":"ENG-1","fields":{"summary":"One","parent":{"key":"EPIC-1"}}},
+					{"id":"12","key":"ENG-2","fields":{"summary":"Two","parent":{"key":"EPIC-1"}}}],"isLast":true}`))
+			case strings.HasPrefix(jql, "parent in (ENG-1,ENG-2)"):
+				_, _ = w.Write([]byte(`{"issues":[
+					{"id":"21","key":"ENG-3","fields":{"summary":"Three","parent":{"key":"ENG-1"}}},
+					{"id":"22","key":"ENG-4","fields":{"summary":"Four","parent":{"key":"ENG-2"}}},
+					{"id":"1","key":"EPIC-1","fields":{"summary":"Cycle","parent":{"key":"ENG-2"}}}],"isLast":true}`))
+			default:
+				_, _ = w.Write([]byte(`{"issues":[],"isLast":true}`))
+			}
+		case "/rest/dev-status/1.0/issue/detail":
+			mu.Lock()
+			prLookups++
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"detail":[{"pullRequests":[{"id":"pr-` + r.URL.Query().Get("issueId") + `"}]}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	client := jira.NewClient(&config.JiraConfig{URL: server.URL, Deployment: "cloud"})
+
+	var stderr bytes.Buffer
+	origErr := errorOutput
+	errorOutput = &stderr
+	t.Cleanup(func() { errorOutput = origErr })
+
+	tree, _, err := buildIssueTree(context.Background(), client, "EPIC-1", issueTreeOptions{PullRequests: true})
+	if err != nil {
+		t.Fatalf("buildIssueTree failed: %v", err)
+	}
+	if len(searches) != 3 {
+		t.Fatalf("expected one search per level, got %q", searches)
+	}
+	if len(tree) != 2 || tree[0].Children[0].Issue.Key != "ENG-3" || len(tree[1].Children) != 1 || tree[1].Children[0].Issue.Key != "ENG-4" {
+		t.Fatalf("unexpected issue tree: %+v", tree)
+	}
+	if prLookups != 4 || tree[1].Children[0].PullRequests[0].ID != "pr-22" {
+		t.Fatalf("expected pull requests by search id without details calls, got %d lookups, %+v", prLookups, tree[1].Children[0].PullRequests)
+	}
+	if !strings.Contains(stderr.String(), "Skipping EPIC-1 under ENG-2") {
+		t.Fatalf("expected the cycle to be reported, got %q", stderr.String())
+	}
+
+	searches, stderr = nil, bytes.Buffer{}
+	tree, _, err = buildIssueTree(context.Background(), client, "EPIC-1", issueTreeOptions{MaxDepth: 1})
+	if err != nil || len(searches) != 1 || len(tree) != 2 || len(tree[0].Children) != 0 {
+		t.Fatalf("expected --depth 1 to load only the children, got %+v after %q, %v", tree, searches, err)
+	}
+	tree, truncated, err := buildIssueTree(context.Background(), client, "EPIC-1", issueTreeOptions{MaxIssues: 3})
+	if err != nil || !truncated || len(tree) != 2 || len(tree[0].Children) != 1 || len(tree[1].Children) != 0 {
+		t.Fatalf("expected --max-issues 3 to stop after three issues, got %+v, %v", tree, err)
+	}
+	if !strings.Contains(stderr.String(), "Stopped at --max-issues 3") {
+		t.Fatalf("expected the truncation to be reported, got %q", stderr.String())
+	}
+}
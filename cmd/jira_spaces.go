@@ -17,14 +17,14 @@ var spacesCmd = &cobra.Command{
 		// Load configuration
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 
 		// Validate required config
 		if cfg.Jira.URL == "" {
 			log.Fatal("Jira URL not configured. Run: devflow config set jira.url <url>")
 		}
-		if cfg.Jira.Username == "" {
+		if jiraUsernameMissing(cfg.Jira) {
 			log.Fatal("Jira username not configured. Run: devflow config set jira.username <username>")
 		}
 		if cfg.Jira.Token == "" {
@@ -35,15 +35,15 @@ var spacesCmd = &cobra.Command{
 		client := jira.NewClient(&cfg.Jira)
 
 		// Get projects
-		projects, err := client.ListProjects()
+		projects, err := client.ListProjectsContext(commandContext(cmd))
 		if err != nil {
-			log.Fatalf("Error listing Jira projects: %v", err)
+			fatalError("Error listing Jira projects", err)
 		}
 
 		// Display results
 		if wantsJSON(cmd) {
 			if err := printJSON(projects); err != nil {
-				log.Fatalf("Error encoding JSON: %v", err)
+				fatalError("Error encoding JSON", err)
 			}
 			return
 		}
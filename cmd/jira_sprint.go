@@ -0,0 +1,420 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"devflow/internal/config"
+	"devflow/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sprintBoard  string
+	sprintTarget string
+	rankBefore   string
+	rankAfter    string
+)
+
+var sprintCmd = &cobra.Command{
+	Use:   "sprint",
+	Short: "Sprints of a Jira Software board",
+	Long: `Show and plan the sprints of a Jira Software board.
+
+The board is taken from --board (an ID or a name), then jira.board_id, then
+the only scrum board of jira.project_key.
+
+Subcommands:
+  show        Show the active sprint grouped by board column
+  move        Move issues into a sprint or back to the backlog`,
+}
+
+var showSprintCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the active sprint grouped by board column",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		cfg, client := loadJiraClient()
+
+		board, err := resolveBoard(ctx, client, cfg, sprintBoard)
+		if err != nil {
+			fatalError("Cannot choose a board", err)
+		}
+		sprints, err := client.ListSprintsContext(ctx, board.ID, "active")
+		if err != nil {
+			fatalError("Failed to list sprints", err)
+		}
+		if len(sprints) == 0 {
+			if wantsJSON(cmd) {
+				if err := printJSON([]sprintView{}); err != nil {
+					fatalError("Error encoding JSON", err)
+				}
+				return
+			}
+			fmt.Printf("No active sprint on board %s\n", board.Name)
+			return
+		}
+		columns, err := client.BoardColumnsContext(ctx, board.ID)
+		if err != nil {
+			fatalError("Failed to read the board columns", err)
+		}
+
+		views := make([]sprintView, 0, len(sprints))
+		for _, sprint := range sprints {
+			issues, err := client.SprintIssuesContext(ctx, sprint.ID)
+			if err != nil {
+				fatalError("Failed to list the issues of "+sprint.Name, err)
+			}
+			views = append(views, buildSprintView(board, sprint, columns, issues))
+		}
+
+		if wantsJSON(cmd) {
+			if err := printJSON(views); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			var rows [][]any
+			for _, view := range views {
+				for _, column := range view.Columns {
+					for _, issue := range column.Issues {
+						rows = append(rows, []any{view.Sprint.Name, column.Name, issue.Key, issue.Summary, issue.Status, issue.Assignee})
+					}
+				}
+			}
+			renderTable([]string{"Sprint", "Column", "Key", "Summary", "Status", "Assignee"}, rows)
+			return
+		}
+		for i, view := range views {
+			if i > 0 {
+				fmt.Println()
+			}
+			displaySprintView(view, cfg.Jira.URL)
+		}
+	},
+}
+
+var moveSprintCmd = &cobra.Command{
+	Use:   "move [issue-key...] --to next|active|backlog|<sprint>",
+	Short: "Move issues into a sprint or back to the backlog",
+	Long: `Move issues into a sprint, e.g.
+
+  devflow sprint move ENG-1 ENG-2 --to next
+  devflow sprint move ENG-3 --to active
+  devflow sprint move ENG-4 --to "Sprint 14"
+  devflow sprint move ENG-5 --to backlog
+
+--to accepts "next" (the first future sprint of the board), "active",
+"backlog", a sprint ID, or the name of an active or future sprint.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		cfg, client := loadJiraClient()
+
+		if strings.EqualFold(sprintTarget, "backlog") {
+			if err := client.MoveIssuesToBacklogContext(ctx, args); err != nil {
+				fatalError("Failed to move issues to the backlog", err)
+			}
+			reportSprintMove(cmd, args, nil)
+			return
+		}
+
+		sprint, err := resolveSprintTarget(ctx, client, cfg, sprintTarget)
+		if err != nil {
+			fatalError("Cannot choose a sprint", err)
+		}
+		if err := client.MoveIssuesToSprintContext(ctx, sprint.ID, args); err != nil {
+			fatalError("Failed to move issues to "+sprint.Name, err)
+		}
+		reportSprintMove(cmd, args, sprint)
+	},
+}
+
+var backlogCmd = &cobra.Command{
+	Use:   "backlog",
+	Short: "Order the Jira Software backlog",
+}
+
+var rankBacklogCmd = &cobra.Command{
+	Use:   "rank [issue-key...] --before|--after <issue-key>",
+	Short: "Rank issues before or after another issue",
+	Long: `Rank issues directly before or after another issue, e.g.
+
+  devflow backlog rank ENG-5 --before ENG-3
+  devflow backlog rank ENG-7 ENG-8 --after ENG-2
+
+Several issues keep their order relative to each other.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if rankBefore == "" && rankAfter == "" {
+			log.Fatal("Specify where to rank the issues with --before or --after")
+		}
+		if err := newJiraClient().RankIssuesContext(commandContext(cmd), args, rankBefore, rankAfter); err != nil {
+			fatalError("Failed to rank issues", err)
+		}
+
+		position, other := "before", rankBefore
+		if rankAfter != "" {
+			position, other = "after", rankAfter
+		}
+		if wantsJSON(cmd) {
+			if err := printJSON(map[string]any{"issues": args, "position": position, "issue": other}); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			renderKeyValueTable([][2]string{{"Issues", strings.Join(args, ", ")}, {"Position", position}, {"Issue", other}})
+			return
+		}
+		fmt.Printf("✅ Ranked %s %s %s\n", strings.Join(args, ", "), position, other)
+	},
+}
+
+func init() {
+	sprintCmd.PersistentFlags().StringVar(&sprintBoard, "board", "", "Board ID or name (defaults to jira.board_id)")
+	moveSprintCmd.Flags().StringVar(&sprintTarget, "to", "", `Target sprint: "next", "active", "backlog", a sprint ID or name`)
+	_ = moveSprintCmd.MarkFlagRequired("to")
+	sprintCmd.AddCommand(showSprintCmd)
+	sprintCmd.AddCommand(moveSprintCmd)
+
+	rankBacklogCmd.Flags().StringVar(&rankBefore, "before", "", "Rank the issues directly before this issue")
+	rankBacklogCmd.Flags().StringVar(&rankAfter, "after", "", "Rank the issues directly after this issue")
+	rankBacklogCmd.MarkFlagsMutuallyExclusive("before", "after")
+	backlogCmd.AddCommand(rankBacklogCmd)
+}
+
+// resolveBoard picks the board named by flag (an ID or a name), then
+// jira.board_id, then the only scrum board of jira.project_key.
+func resolveBoard(ctx context.Context, client *jira.Client, cfg *config.Config, flag string) (jira.Board, error) {
+	ref := strings.TrimSpace(flag)
+	if ref == "" {
+		ref = strings.TrimSpace(cfg.Jira.BoardID)
+	}
+	if id, err := strconv.Atoi(ref); err == nil {
+		return jira.Board{ID: id, Name: "board " + ref}, nil
+	}
+
+	boards, err := client.ListBoardsContext(ctx, cfg.Jira.ProjectKey)
+	if err != nil {
+		return jira.Board{}, err
+	}
+	if ref != "" {
+		for _, board := range boards {
+			if strings.EqualFold(board.Name, ref) {
+				return board, nil
+			}
+		}
+		return jira.Board{}, fmt.Errorf("no board named %q (boards: %s)", ref, describeBoards(boards))
+	}
+
+	var scrum []jira.Board
+	for _, board := range boards {
+		if board.Type == "scrum" {
+			scrum = append(scrum, board)
+		}
+	}
+	switch {
+	case len(scrum) == 1:
+		return scrum[0], nil
+	case len(boards) == 0:
+		return jira.Board{}, fmt.Errorf("no boards found; pass --board or set jira.board_id")
+	default:
+		return jira.Board{}, fmt.Errorf("several boards match (%s); pass --board or set jira.board_id", describeBoards(boards))
+	}
+}
+
+func describeBoards(boards []jira.Board) string {
+	names := make([]string, 0, len(boards))
+	for _, board := range boards {
+		names = append(names, fmt.Sprintf("%d %s", board.ID, board.Name))
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
+
+// resolveSprintTarget turns a --to value other than "backlog" into a sprint.
+// A numeric value is used as the sprint ID without asking for the board.
+func resolveSprintTarget(ctx context.Context, client *jira.Client, cfg *config.Config, target string) (*jira.Sprint, error) {
+	return findSprint(ctx, client, cfg, sprintBoard, target)
+}
+
+// resolveSprintFlag turns the --sprint value of create, update and bulk
+// update into a sprint ID, looking names up on the board in jira.board_id or
+// the only scrum board of projectKey.
+func resolveSprintFlag(ctx context.Context, client *jira.Client, cfg *config.Config, projectKey, value string) (string, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	scoped := *cfg
+	if projectKey != "" {
+		scoped.Jira.ProjectKey = projectKey
+	}
+	sprint, err := findSprint(ctx, client, &scoped, "", value)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(sprint.ID), nil
+}
+
+// findSprint picks target from the open sprints of the board named by
+// boardFlag; see resolveBoard and pickSprint.
+func findSprint(ctx context.Context, client *jira.Client, cfg *config.Config, boardFlag, target string) (*jira.Sprint, error) {
+	target = strings.TrimSpace(target)
+	if id, err := strconv.Atoi(target); err == nil {
+		return &jira.Sprint{ID: id, Name: "sprint " + target}, nil
+	}
+
+	board, err := resolveBoard(ctx, client, cfg, boardFlag)
+	if err != nil {
+		return nil, err
+	}
+	sprints, err := client.ListSprintsContext(ctx, board.ID, "active", "future")
+	if err != nil {
+		return nil, err
+	}
+	return pickSprint(sprints, target)
+}
+
+// pickSprint chooses "next" (the first future sprint), "active" or a sprint
+// by name from the open sprints of a board.
+func pickSprint(sprints []jira.Sprint, target string) (*jira.Sprint, error) {
+	state := ""
+	switch strings.ToLower(target) {
+	case "next":
+		state = "future"
+	case "active", "current":
+		state = "active"
+	}
+	for i, sprint := range sprints {
+		if state != "" && sprint.State == state {
+			return &sprints[i], nil
+		}
+		if state == "" && strings.EqualFold(sprint.Name, target) {
+			return &sprints[i], nil
+		}
+	}
+	if state != "" {
+		return nil, fmt.Errorf("the board has no %s sprint", state)
+	}
+	names := make([]string, 0, len(sprints))
+	for _, sprint := range sprints {
+		names = append(names, sprint.Name)
+	}
+	return nil, fmt.Errorf("no open sprint named %q (open sprints: %s)", target, strings.Join(names, ", "))
+}
+
+func reportSprintMove(cmd *cobra.Command, keys []string, sprint *jira.Sprint) {
+	target := "backlog"
+	if sprint != nil {
+		target = sprint.Name
+	}
+	if wantsJSON(cmd) {
+		result := map[string]any{"issues": keys, "to": target}
+		if sprint != nil {
+			result["sprint_id"] = sprint.ID
+		}
+		if err := printJSON(result); err != nil {
+			fatalError("Error encoding JSON", err)
+		}
+		return
+	}
+	if wantsTabular(cmd) {
+		renderKeyValueTable([][2]string{{"Issues", strings.Join(keys, ", ")}, {"To", target}})
+		return
+	}
+	if sprint == nil {
+		fmt.Printf("✅ Moved %s to the backlog\n", strings.Join(keys, ", "))
+		return
+	}
+	fmt.Printf("✅ Moved %s to %s\n", strings.Join(keys, ", "), target)
+}
+
+type sprintView struct {
+	Board   string         `json:"board"`
+	Sprint  jira.Sprint    `json:"sprint"`
+	Columns []sprintColumn `json:"columns"`
+}
+
+type sprintColumn struct {
+	Name   string        `json:"name"`
+	Issues []sprintIssue `json:"issues"`
+}
+
+type sprintIssue struct {
+	Key      string `json:"key"`
+	Summary  string `json:"summary"`
+	Status   string `json:"status"`
+	Assignee string `json:"assignee,omitempty"`
+	Priority string `json:"priority,omitempty"`
+}
+
+// buildSprintView groups the issues of a sprint by the board column their
+// status is mapped to, keeping the sprint's rank order within a column.
+// Issues in statuses no column shows are grouped under "Unmapped".
+func buildSprintView(board jira.Board, sprint jira.Sprint, columns []jira.BoardColumn, issues []jira.Issue) sprintView {
+	view := sprintView{Board: board.Name, Sprint: sprint, Columns: make([]sprintColumn, 0, len(columns))}
+	columnOf := make(map[string]int)
+	for i, column := range columns {
+		view.Columns = append(view.Columns, sprintColumn{Name: column.Name, Issues: []sprintIssue{}})
+		for _, id := range column.StatusIDs {
+			columnOf[id] = i
+		}
+	}
+
+	var unmapped []sprintIssue
+	for _, issue := range issues {
+		item := sprintIssue{
+			Key:      issue.Key,
+			Summary:  issue.Fields.Summary,
+			Status:   issue.Fields.Status.Name,
+			Assignee: issue.Fields.Assignee.DisplayName,
+			Priority: issue.Fields.Priority.Name,
+		}
+		if i, ok := columnOf[issue.Fields.Status.ID]; ok {
+			view.Columns[i].Issues = append(view.Columns[i].Issues, item)
+			continue
+		}
+		unmapped = append(unmapped, item)
+	}
+	if len(unmapped) > 0 {
+		view.Columns = append(view.Columns, sprintColumn{Name: "Unmapped", Issues: unmapped})
+	}
+	return view
+}
+
+func displaySprintView(view sprintView, baseURL string) {
+	fmt.Printf("🏃 %s (%s)", view.Sprint.Name, view.Board)
+	if view.Sprint.StartDate != "" && view.Sprint.EndDate != "" {
+		fmt.Printf(" %s → %s", sprintDate(view.Sprint.StartDate), sprintDate(view.Sprint.EndDate))
+	}
+	fmt.Println()
+	if view.Sprint.Goal != "" {
+		fmt.Printf("🎯 Goal: %s\n", view.Sprint.Goal)
+	}
+	for _, column := range view.Columns {
+		fmt.Printf("\n%s (%d)\n", column.Name, len(column.Issues))
+		for _, issue := range column.Issues {
+			fmt.Printf("  %s %s - %s", getStatusIcon(issue.Status), issue.Key, issue.Summary)
+			if issue.Assignee != "" {
+				fmt.Printf(" 👤 %s", issue.Assignee)
+			}
+			fmt.Printf(" 🔗 %s/browse/%s\n", strings.TrimSuffix(baseURL, "/"), issue.Key)
+		}
+	}
+}
+
+// sprintDate shortens an Agile API timestamp to its date.
+func sprintDate(value string) string {
+	if len(value) >= len("2006-01-02") {
+		return value[:len("2006-01-02")]
+	}
+	return value
+}
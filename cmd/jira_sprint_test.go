@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"devflow/internal/config"
+	"devflow/internal/jira"
+)
+
+func TestPickSprint(t *testing.T) {
+	sprints := []jira.Sprint{
+		{ID: 10, Name: "Sprint 10", State: "active"},
+		{ID: 11, Name: "Sprint 11", State: "future"},
+		{ID: 12, Name: "Sprint 12", State: "future"},
+	}
+	for target, want := range map[string]int{"next": 11, "active": 10, "sprint 12": 12} {
+		sprint, err := pickSprint(sprints, target)
+		if err != nil || sprint.ID != want {
+			t.Fatalf("pickSprint(%q) = %+v, %v; want sprint %d", target, sprint, err, want)
+		}
+	}
+	if _, err := pickSprint(sprints[:1], "next"); err == nil || !strings.Contains(err.Error(), "no future sprint") {
+		t.Fatalf("expected no future sprint, got %v", err)
+	}
+	if _, err := pickSprint(sprints, "Sprint 9"); err == nil || !strings.Contains(err.Error(), "Sprint 10, Sprint 11") {
+		t.Fatalf("expected the open sprints to be listed, got %v", err)
+	}
+}
+
+func TestResolveBoard(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"},
+	})
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":1,"name":"ENG board","type":"scrum"},{"id":2,"name":"Ops","type":"kanban"}]}`))
+	})
+	client := jira.NewClient(&config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"})
+	cfg := &config.Config{Jira: config.JiraConfig{ProjectKey: "ENG"}}
+
+	if board, err := resolveBoard(context.Background(), client, cfg, ""); err != nil || board.ID != 1 {
+		t.Fatalf("expected the only scrum board, got %+v, %v", board, err)
+	}
+	if board, err := resolveBoard(context.Background(), client, cfg, "ops"); err != nil || board.ID != 2 {
+		t.Fatalf("expected the board named Ops, got %+v, %v", board, err)
+	}
+	cfg.Jira.BoardID = "42"
+	if board, err := resolveBoard(context.Background(), client, cfg, ""); err != nil || board.ID != 42 {
+		t.Fatalf("expected jira.board_id, got %+v, %v", board, err)
+	}
+	if _, err := resolveBoard(context.Background(), client, cfg, "Missing"); err == nil || !strings.Contains(err.Error(), "1 ENG board, 2 Ops") {
+		t.Fatalf("expected an unknown board to list the boards, got %v", err)
+	}
+}
+
+func TestSprintShowGroupsByColumn(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", BoardID: "1", Deployment: "cloud"},
+	})
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/agile/1.0/board/1/sprint":
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":10,"name":"Sprint 10","state":"active","startDate":"2024-05-06T09:00:00.000Z","endDate":"2024-05-20T09:00:00.000Z","goal":"Parser"}]}`))
+		case "/rest/agile/1.0/board/1/configuration":
+			_, _ = w.Write([]byte(`{"columnConfig":{"columns":[{"name":"To Do","statuses":[{"id":"1"}]},{"name":"Doing","statuses":[{"id":"3"}]},{"name":"Done","statuses":[{"id":"5"}]}]}}`))
+		case "/rest/agile/1.0/sprint/10/issue":
+			_, _ = w.Write([]byte(`{"total":3,"issues":[
+				{"key":"ENG-2","fields":{"summary":"Lexer","status":{"id":"3","name":"In Progress"},"assignee":{"displayName":"Alice"}}},
+				{"key":"ENG-1","fields":{"summary":"Grammar","status":{"id":"1","name":"To Do"}}},
+				{"key":"ENG-9","fields":{"summary":"Triage","status":{"id":"77","name":"Blocked"}}}
+			]}`))
+		default:
+			t.Fatalf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	out := captureStdout(func() { showSprintCmd.Run(commandWithFormat(formatJSON), nil) })
+	var views []sprintView
+	if err := json.Unmarshal([]byte(out), &views); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(views) != 1 || len(views[0].Columns) != 4 {
+		t.Fatalf("unexpected sprint views: %+v", views)
+	}
+	columns := views[0].Columns
+	if columns[0].Issues[0].Key != "ENG-1" || columns[1].Issues[0].Assignee != "Alice" || len(columns[2].Issues) != 0 ||
+		columns[3].Name != "Unmapped" || columns[3].Issues[0].Key != "ENG-9" {
+		t.Fatalf("unexpected columns: %+v", columns)
+	}
+
+	out = captureStdout(func() { showSprintCmd.Run(showSprintCmd, nil) })
+	for _, want := range []string{"Sprint 10", "2024-05-06 → 2024-05-20", "🎯 Goal: Parser", "Doing (1)", "Done (0)"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output %q", want, out)
+		}
+	}
+}
+
+func TestSprintMoveAndBacklogRank(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", BoardID: "1", Deployment: "cloud"},
+	})
+	var requests []string
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.URL.Path == "/rest/agile/1.0/board/1/sprint" {
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":10,"name":"Sprint 10","state":"active"},{"id":11,"name":"Sprint 11","state":"future"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	origTarget, origBefore, origAfter := sprintTarget, rankBefore, rankAfter
+	t.Cleanup(func() { sprintTarget, rankBefore, rankAfter = origTarget, origBefore, origAfter })
+
+	sprintTarget = "next"
+	out := captureStdout(func() { moveSprintCmd.Run(moveSprintCmd, []string{"ENG-1", "ENG-2"}) })
+	if !strings.Contains(out, "Moved ENG-1, ENG-2 to Sprint 11") || requests[len(requests)-1] != "POST /rest/agile/1.0/sprint/11/issue" {
+		t.Fatalf("unexpected move: %q %v", out, requests)
+	}
+
+	sprintTarget = "backlog"
+	out = captureStdout(func() { moveSprintCmd.Run(moveSprintCmd, []string{"ENG-3"}) })
+	if !strings.Contains(out, "Moved ENG-3 to the backlog") || requests[len(requests)-1] != "POST /rest/agile/1.0/backlog/issue" {
+		t.Fatalf("unexpected backlog move: %q %v", out, requests)
+	}
+
+	rankBefore, rankAfter = "ENG-3", ""
+	out = captureStdout(func() { rankBacklogCmd.Run(rankBacklogCmd, []string{"ENG-5"}) })
+	if !strings.Contains(out, "Ranked ENG-5 before ENG-3") || requests[len(requests)-1] != "PUT /rest/agile/1.0/issue/rank" {
+		t.Fatalf("unexpected rank: %q %v", out, requests)
+	}
+}
@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"devflow/internal/jira"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultSubtaskType is the issue type of template sub-tasks that name none.
+const defaultSubtaskType = "Sub-task"
+
+// issueTemplate describes an issue, and the sub-tasks to create under it,
+// in a YAML or JSON file. String values are Go templates expanded with the
+// template's vars and the --var values, e.g. "{{.component}}".
+type issueTemplate struct {
+	Project     string            `json:"project" yaml:"project"`
+	Type        string            `json:"type" yaml:"type"`
+	Summary     string            `json:"summary" yaml:"summary"`
+	Description string            `json:"description" yaml:"description"`
+	Priority    string            `json:"priority" yaml:"priority"`
+	Assignee    string            `json:"assignee" yaml:"assignee"`
+	Labels      []string          `json:"labels" yaml:"labels"`
+	Epic        string            `json:"epic" yaml:"epic"`
+	StoryPoints float64           `json:"story_points" yaml:"story_points"`
+	Sprint      string            `json:"sprint" yaml:"sprint"`
+	Team        string            `json:"team" yaml:"team"`
+	Fields      map[string]any    `json:"fields" yaml:"fields"`
+	Vars        map[string]string `json:"vars" yaml:"vars"` // Defaults for --var
+	Subtasks    []issueTemplate   `json:"subtasks" yaml:"subtasks"`
+}
+
+// loadIssueTemplate reads the template called name from the templates
+// directory, trying the .yaml, .yml and .json extensions. A name that is a
+// path to an existing file is read as is.
+func loadIssueTemplate(name string) (*issueTemplate, error) {
+	path := ""
+	if info, err := os.Stat(name); err == nil && !info.IsDir() && (strings.ContainsRune(name, os.PathSeparator) || filepath.Ext(name) != "") {
+		path = name
+	} else {
+		for _, ext := range []string{".yaml", ".yml", ".json"} {
+			candidate := filepath.Join(templatesDir(), name+ext)
+			if _, err := os.Stat(candidate); err == nil {
+				path = candidate
+				break
+			}
+		}
+	}
+	if path == "" {
+		available := listIssueTemplates()
+		if len(available) == 0 {
+			return nil, fmt.Errorf("no template named %q; templates are read from %s", name, templatesDir())
+		}
+		return nil, fmt.Errorf("no template named %q in %s (available: %s)", name, templatesDir(), strings.Join(available, ", "))
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	var tmpl issueTemplate
+	if filepath.Ext(path) == ".json" {
+		decoder := json.NewDecoder(strings.NewReader(string(data)))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&tmpl)
+	} else {
+		decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&tmpl)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for _, subtask := range tmpl.Subtasks {
+		if len(subtask.Subtasks) > 0 || len(subtask.Vars) > 0 {
+			return nil, fmt.Errorf("%s: sub-tasks cannot have their own subtasks or vars", path)
+		}
+	}
+	return &tmpl, nil
+}
+
+// listIssueTemplates returns the names of the templates in the templates
+// directory.
+func listIssueTemplates() []string {
+	entries, err := os.ReadDir(templatesDir())
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if !entry.IsDir() && (ext == ".yaml" || ext == ".yml" || ext == ".json") {
+			names = append(names, strings.TrimSuffix(entry.Name(), ext))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseTemplateVars parses --var "name=value" assignments.
+func parseTemplateVars(assignments []string) (map[string]string, error) {
+	vars := make(map[string]string, len(assignments))
+	for _, assignment := range assignments {
+		name, value, ok := strings.Cut(assignment, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid variable %q: expected name=value", assignment)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+// render expands every string of the template with vars. Referring to a
+// variable that is not set is an error.
+func (t issueTemplate) render(vars map[string]string) (issueTemplate, error) {
+	var errs []error
+	expand := func(field, text string) string {
+		if !strings.Contains(text, "{{") {
+			return text
+		}
+		tmpl, err := template.New(field).Option("missingkey=error").Parse(text)
+		if err != nil {
+			errs = append(errs, err)
+			return text
+		}
+		var b strings.Builder
+		if err := tmpl.Execute(&b, vars); err != nil {
+			errs = append(errs, fmt.Errorf("%w; set it with --var name=value", err))
+			return text
+		}
+		return b.String()
+	}
+
+	out := t
+	out.Project = expand("project", t.Project)
+	out.Type = expand("type", t.Type)
+	out.Summary = expand("summary", t.Summary)
+	out.Description = expand("description", t.Description)
+	out.Priority = expand("priority", t.Priority)
+	out.Assignee = expand("assignee", t.Assignee)
+	out.Epic = expand("epic", t.Epic)
+	out.Sprint = expand("sprint", t.Sprint)
+	out.Team = expand("team", t.Team)
+	out.Labels = nil
+	for _, label := range t.Labels {
+		if label = strings.TrimSpace(expand("labels", label)); label != "" {
+			out.Labels = append(out.Labels, label)
+		}
+	}
+	out.Fields = make(map[string]any, len(t.Fields))
+	for name, value := range t.Fields {
+		out.Fields[name] = expand("fields."+name, templateFieldValue(value))
+	}
+	out.Subtasks = nil
+	for _, subtask := range t.Subtasks {
+		rendered, err := subtask.render(vars)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		out.Subtasks = append(out.Subtasks, rendered)
+	}
+	return out, errors.Join(errs...)
+}
+
+// templateFieldValue turns a field value from a template file into the
+// "Name=value" form of --field: lists become comma-separated.
+func templateFieldValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, templateFieldValue(item))
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// fieldAssignments returns the template's fields as --field assignments.
+func (t issueTemplate) fieldAssignments() map[string]string {
+	values := make(map[string]string, len(t.Fields))
+	for name, value := range t.Fields {
+		values[name] = templateFieldValue(value)
+	}
+	return values
+}
+
+// createdIssue is an issue created from a template.
+type createdIssue struct {
+	Key      string         `json:"key"`
+	Title    string         `json:"title"`
+	URL      string         `json:"url"`
+	Subtasks []createdIssue `json:"subtasks,omitempty"`
+}
+
+// createTemplateIssue creates one issue of a rendered template, resolving
+// its fields against the create screen of the project and issue type.
+func createTemplateIssue(ctx context.Context, client *jira.Client, projectKey, parentKey string, t issueTemplate) (*jira.Issue, error) {
+	var fields map[string]interface{}
+	if assignments := t.fieldAssignments(); len(assignments) > 0 {
+		screen, err := client.CreateMetaContext(ctx, projectKey, t.Type)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the create screen: %w", err)
+		}
+		fields, err = resolveFieldValues(ctx, client, screen, assignments, fmt.Sprintf("create screen of %s %s", projectKey, t.Type))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return client.CreateIssueContext(ctx, jira.CreateIssueOptions{
+		ProjectKey:  projectKey,
+		Summary:     t.Summary,
+		Description: t.Description,
+		IssueType:   t.Type,
+		Priority:    t.Priority,
+		Assignee:    t.Assignee,
+		Labels:      t.Labels,
+		Epic:        t.Epic,
+		StoryPoints: t.StoryPoints,
+		Sprint:      t.Sprint,
+		Team:        t.Team,
+		Parent:      parentKey,
+		Fields:      fields,
+	})
+}
+
+// createIssueTree creates the issue of a rendered template and then its
+// sub-tasks under it. When a sub-task fails, the issues created so far are
+// returned with the error.
+func createIssueTree(ctx context.Context, client *jira.Client, baseURL, projectKey string, t issueTemplate) (createdIssue, error) {
+	issue, err := createTemplateIssue(ctx, client, projectKey, "", t)
+	if err != nil {
+		return createdIssue{}, err
+	}
+	created := createdIssue{Key: issue.Key, Title: t.Summary, URL: baseURL + "/browse/" + issue.Key}
+	for _, subtask := range t.Subtasks {
+		if subtask.Type == "" {
+			subtask.Type = defaultSubtaskType
+		}
+		if subtask.Summary == "" {
+			return created, fmt.Errorf("a sub-task of %s has no summary", issue.Key)
+		}
+		child, err := createTemplateIssue(ctx, client, projectKey, issue.Key, subtask)
+		if err != nil {
+			return created, fmt.Errorf("sub-task %q: %w", subtask.Summary, err)
+		}
+		created.Subtasks = append(created.Subtasks, createdIssue{Key: child.Key, Title: subtask.Summary, URL: baseURL + "/browse/" + child.Key})
+	}
+	return created, nil
+}
+
+// createdKeys lists the keys of an issue tree, parent first.
+func createdKeys(created createdIssue) []string {
+	var keys []string
+	if created.Key != "" {
+		keys = append(keys, created.Key)
+	}
+	for _, subtask := range created.Subtasks {
+		keys = append(keys, subtask.Key)
+	}
+	return keys
+}
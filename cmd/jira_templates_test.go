@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"devflow/internal/config"
+)
+
+const bugReportTemplate = `project: ENG
+type: Bug
+summary: "[{{.component}}] {{.title}}"
+labels: [bug, "{{.component}}"]
+description: |
+  ## Steps to reproduce
+  Seen in {{.env}}.
+vars:
+  env: staging
+fields:
+  Severity: High
+subtasks:
+  - summary: Reproduce in {{.env}}
+  - summary: Write a regression test
+    type: Subtask
+    labels: [testing]
+`
+
+func setTemplatesDir(t *testing.T, files map[string]string) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	orig := templatesDir
+	t.Cleanup(func() { templatesDir = orig })
+	templatesDir = func() string { return dir }
+}
+
+func TestLoadAndRenderIssueTemplate(t *testing.T) {
+	setTemplatesDir(t, map[string]string{
+		"bug-report.yaml": bugReportTemplate,
+		"chore.json":      `{"type":"Task","summary":"Chore","fields":{"Story Points":3,"Components":["api","web"]}}`,
+		"typo.json":       `{"summry":"Oops"}`,
+	})
+
+	tmpl, err := loadIssueTemplate("bug-report")
+	if err != nil {
+		t.Fatalf("loadIssueTemplate failed: %v", err)
+	}
+	rendered, err := tmpl.render(map[string]string{"component": "api", "title": "Login fails", "env": "prod"})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if rendered.Summary != "[api] Login fails" || strings.Join(rendered.Labels, ",") != "bug,api" || !strings.Contains(rendered.Description, "Seen in prod.") {
+		t.Fatalf("unexpected rendering: %+v", rendered)
+	}
+	if len(rendered.Subtasks) != 2 || rendered.Subtasks[0].Summary != "Reproduce in prod" || rendered.Subtasks[1].Type != "Subtask" {
+		t.Fatalf("unexpected sub-tasks: %+v", rendered.Subtasks)
+	}
+	if _, err := tmpl.render(map[string]string{"title": "Login fails"}); err == nil || !strings.Contains(err.Error(), `"component"`) {
+		t.Fatalf("expected a missing variable to fail, got %v", err)
+	}
+
+	chore, err := loadIssueTemplate("chore")
+	if err != nil {
+		t.Fatalf("loading a JSON template failed: %v", err)
+	}
+	if fields := chore.fieldAssignments(); fields["Story Points"] != "3" || fields["Components"] != "api, web" {
+		t.Fatalf("unexpected field assignments: %v", fields)
+	}
+	if _, err := loadIssueTemplate("typo"); err == nil || !strings.Contains(err.Error(), "summry") {
+		t.Fatalf("expected an unknown key to fail, got %v", err)
+	}
+	if _, err := loadIssueTemplate("feature"); err == nil || !strings.Contains(err.Error(), "available: bug-report, chore, typo") {
+		t.Fatalf("expected the available templates to be listed, got %v", err)
+	}
+}
+
+func TestCreateFromTemplate(t *testing.T) {
+	setTemplatesDir(t, map[string]string{"bug-report.yaml": bugReportTemplate})
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"},
+	})
+	var mu sync.Mutex
+	var created []map[string]any
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/issue/createmeta/ENG/issuetypes":
+			_, _ = w.Write([]byte(`{"issueTypes":[{"id":"1","name":"Bug"}]}`))
+		case "/rest/api/3/issue/createmeta/ENG/issuetypes/1":
+			_, _ = w.Write([]byte(`{"total":1,"fields":[{"fieldId":"customfield_10050","name":"Severity","schema":{"type":"option"},"allowedValues":[{"id":"7","value":"High"}]}]}`))
+		case "/rest/api/3/issue":
+			var body map[string]map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			created = append(created, body["fields"])
+			key := fmt.Sprintf("ENG-%d", 9+len(created))
+			mu.Unlock()
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"key":"` + key + `"}`))
+		default:
+			t.Fatalf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	origTemplate, origVars, origLabels, origPriority := createTemplate, createVars, createLabels, createPriority
+	t.Cleanup(func() {
+		createTemplate, createVars, createLabels, createPriority = origTemplate, origVars, origLabels, origPriority
+	})
+	createTemplate, createVars, createLabels, createPriority = "bug-report", []string{"component=api"}, "triage", "High"
+
+	out := captureStdout(func() { createTaskCmd.Run(createTaskCmd, []string{"Login fails"}) })
+	if !strings.Contains(out, "Created Jira issue ENG-10: [api] Login fails") || !strings.Contains(out, "└─ ENG-11: Reproduce in staging") ||
+		!strings.Contains(out, "└─ ENG-12: Write a regression test") {
+		t.Fatalf("unexpected output %q", out)
+	}
+	if len(created) != 3 {
+		t.Fatalf("expected 3 issues to be created, got %d", len(created))
+	}
+	parent := created[0]
+	if severity, _ := parent["customfield_10050"].(map[string]any); severity["id"] != "7" {
+		t.Fatalf("expected the template field to be resolved, got %+v", parent)
+	}
+	if labels := fmt.Sprint(parent["labels"]); labels != "[bug api triage]" || parent["priority"].(map[string]any)["name"] != "High" {
+		t.Fatalf("unexpected parent fields: %+v", parent)
+	}
+	for i, want := range []string{"Sub-task", "Subtask"} {
+		subtask := created[i+1]
+		if subtask["parent"].(map[string]any)["key"] != "ENG-10" || subtask["issuetype"].(map[string]any)["name"] != want {
+			t.Fatalf("unexpected sub-task fields: %+v", subtask)
+		}
+	}
+}
@@ -3,7 +3,7 @@ package cmd
 import (
 	"fmt"
 	"log"
-	"strconv"
+	"maps"
 	"strings"
 
 	"devflow/internal/jira"
@@ -22,20 +22,34 @@ var (
 	updateStoryPoints     float64
 	updateSprint          string
 	updateTeam            string
+	updateFields          []string
+	updateFixVersions     []string
 )
 
 var updateTaskCmd = &cobra.Command{
 	Use:   "update [issue-key]",
 	Short: "Update fields on a Jira issue",
-	Long:  "Update one or more fields on an existing Jira issue (assignee, priority, labels, summary, description, epic, story-points, sprint, team).",
-	Args:  cobra.ExactArgs(1),
+	Long: `Update one or more fields on an existing Jira issue (assignee, priority,
+labels, summary, description, epic, story-points, sprint, team, fix versions).
+
+--epic, --story-points, --sprint and --team are set on the custom fields the
+issue's edit screen names "Epic Link", "Story Points", "Sprint" and "Team".
+Any other editable field is set with --field "Name=value"; an empty value
+clears the field, e.g.
+
+  devflow tasks update ENG-123 --field "Story Points=5" --field "Due date="
+
+--fix-version replaces the issue's fix versions with the named ones; pass
+--fix-version none to clear them.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		issueKey := args[0]
 
 		// At least one flag must be provided
 		if updateAssignee == "" && updatePriority == "" && updateLabels == "" && updateSummary == "" &&
 			updateDescription == "" && updateDescriptionFile == "" && updateEpic == "" &&
-			updateStoryPoints == 0 && updateSprint == "" && updateTeam == "" && updateTitle == "" {
+			updateStoryPoints == 0 && updateSprint == "" && updateTeam == "" && updateTitle == "" && len(updateFields) == 0 &&
+			len(updateFixVersions) == 0 {
 			log.Fatal("Provide at least one field to update (use --help for flags)")
 		}
 
@@ -47,19 +61,25 @@ var updateTaskCmd = &cobra.Command{
 		// Resolve description (mutually exclusive)
 		description, err := resolveDescription(updateDescription, updateDescriptionFile)
 		if err != nil {
-			log.Fatalf("Failed to read description: %v", err)
+			fatalError("Failed to read description", err)
+		}
+
+		fieldValues, err := parseFieldAssignments(updateFields)
+		if err != nil {
+			fatalError("Invalid --field", err)
 		}
 
 		// Load config
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
-		if cfg.Jira.URL == "" || cfg.Jira.Username == "" || cfg.Jira.Token == "" {
+		if cfg.Jira.URL == "" || jiraUsernameMissing(cfg.Jira) || cfg.Jira.Token == "" {
 			log.Fatal("Jira configuration incomplete. Run: devflow config set jira.url|jira.username|jira.token ...")
 		}
 
 		client := jira.NewClient(&cfg.Jira)
+		ctx := commandContext(cmd)
 
 		fields := make(map[string]interface{})
 
@@ -73,35 +93,48 @@ var updateTaskCmd = &cobra.Command{
 			fields["priority"] = map[string]string{"name": updatePriority}
 		}
 		if updateAssignee != "" {
-			fields["assignee"] = map[string]string{"name": updateAssignee}
+			assignee, err := client.UserFieldContext(ctx, updateAssignee)
+			if err != nil {
+				fatalError("Cannot resolve assignee", err)
+			}
+			fields["assignee"] = assignee
 		}
 		if strings.TrimSpace(updateLabels) != "" {
 			fields["labels"] = parseLabels(updateLabels)
 		}
-		if updateStoryPoints > 0 {
-			fields["customfield_10016"] = updateStoryPoints
+		if len(updateFixVersions) > 0 {
+			fields["fixVersions"] = fixVersionsField(updateFixVersions)
 		}
-		if updateEpic != "" {
-			fields["customfield_10014"] = updateEpic
-		}
-		if updateSprint != "" {
-			fields["customfield_10020"] = updateSprint
+
+		projectKey, _, _ := strings.Cut(issueKey, "-")
+		sprint, err := resolveSprintFlag(ctx, client, cfg, projectKey, updateSprint)
+		if err != nil {
+			fatalError("Cannot resolve --sprint", err)
 		}
-		if updateTeam != "" {
-			// mimic CreateIssue behavior: accept numeric id or string name
-			if _, err := strconv.Atoi(updateTeam); err == nil {
-				fields["customfield_11887"] = map[string]string{"id": updateTeam}
-			} else {
-				fields["customfield_11887"] = map[string]string{"name": updateTeam}
+		planning := jira.PlanningFields{Epic: updateEpic, StoryPoints: updateStoryPoints, Sprint: sprint, Team: updateTeam}
+		if !planning.IsZero() || len(fieldValues) > 0 {
+			screen, err := client.EditMetaContext(ctx, issueKey)
+			if err != nil {
+				fatalError("Failed to read the edit screen", err)
+			}
+			values, omitted := planning.Resolve(screen)
+			if len(omitted) > 0 {
+				fatalError("Cannot update "+issueKey, fmt.Errorf("the edit screen has no %s field", strings.Join(omitted, " or ")))
 			}
+			custom, err := resolveFieldValues(ctx, client, screen, fieldValues, "edit screen of "+issueKey)
+			if err != nil {
+				fatalError("Cannot update "+issueKey, err)
+			}
+			maps.Copy(fields, values)
+			maps.Copy(fields, custom)
 		}
 
-		if err := client.UpdateIssue(issueKey, fields); err != nil {
-			log.Fatalf("Failed to update issue: %v", err)
+		if err := client.UpdateIssueContext(ctx, issueKey, fields); err != nil {
+			fatalError("Failed to update issue", err)
 		}
 		if wantsJSON(cmd) {
 			if err := printJSON(map[string]any{"issue": issueKey, "updated": true, "fields": fields}); err != nil {
-				log.Fatalf("Error encoding JSON: %v", err)
+				fatalError("Error encoding JSON", err)
 			}
 			return
 		}
@@ -114,15 +147,31 @@ var updateTaskCmd = &cobra.Command{
 }
 
 func init() {
-	updateTaskCmd.Flags().StringVar(&updateAssignee, "assignee", "", "Assignee username (may require accountId in some instances)")
+	updateTaskCmd.Flags().StringVar(&updateAssignee, "assignee", "", "Assignee: me, an email address, display name or accountId; none to unassign")
 	updateTaskCmd.Flags().StringVar(&updatePriority, "priority", "", "Priority (Highest, High, Medium, Low, Lowest)")
 	updateTaskCmd.Flags().StringVar(&updateLabels, "labels", "", "Comma-separated labels (e.g. backend,api,urgent)")
 	updateTaskCmd.Flags().StringVar(&updateSummary, "summary", "", "New summary/title")
 	updateTaskCmd.Flags().StringVar(&updateTitle, "title", "", "Alias for --summary (for ergonomics)")
-	updateTaskCmd.Flags().StringVar(&updateDescription, "description", "", "Issue description text")
-	updateTaskCmd.Flags().StringVar(&updateDescriptionFile, "description-file", "", "Path to file for description body")
+	updateTaskCmd.Flags().StringVar(&updateDescription, "description", "", "Issue description (Markdown)")
+	updateTaskCmd.Flags().StringVar(&updateDescriptionFile, "description-file", "", "Path to a Markdown file for the description body")
 	updateTaskCmd.Flags().StringVar(&updateEpic, "epic", "", "Epic key to link")
 	updateTaskCmd.Flags().Float64Var(&updateStoryPoints, "story-points", 0, "Story points value")
-	updateTaskCmd.Flags().StringVar(&updateSprint, "sprint", "", "Sprint name or ID")
+	updateTaskCmd.Flags().StringVar(&updateSprint, "sprint", "", "Sprint ID or name, or active or next, of the project's board")
 	updateTaskCmd.Flags().StringVar(&updateTeam, "team", "", "Team id or name for Team Assigned custom field")
+	updateTaskCmd.Flags().StringArrayVar(&updateFields, "field", nil, "Editable field as \"Name=value\"; an empty value clears it (repeatable)")
+	updateTaskCmd.Flags().StringArrayVar(&updateFixVersions, "fix-version", nil, "Fix version name, replacing the current ones; none clears them (repeatable)")
+}
+
+// fixVersionsField returns the fixVersions value naming versions. "none"
+// alone clears the field.
+func fixVersionsField(versions []string) []map[string]string {
+	value := make([]map[string]string, 0, len(versions))
+	for _, version := range versions {
+		version = strings.TrimSpace(version)
+		if version == "" || (len(versions) == 1 && strings.EqualFold(version, "none")) {
+			continue
+		}
+		value = append(value, map[string]string{"name": version})
+	}
+	return value
 }
@@ -0,0 +1,553 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"devflow/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var (
+	worklogStarted  string
+	worklogAdjust   string
+	worklogEstimate string
+	worklogEdit     string
+	worklogDelete   string
+
+	timesheetFrom string
+	timesheetTo   string
+)
+
+var logWorkCmd = &cobra.Command{
+	Use:   "log [issue-key] [duration] [comment]",
+	Short: "Log time against a Jira issue, or list its worklogs",
+	Long: `Log time spent on an issue, e.g.
+
+  devflow tasks log ENG-123 1h30m "pairing on parser"
+  devflow tasks log ENG-123 2h --started "2024-05-06 09:00" --estimate 1d
+  devflow tasks log ENG-123 45m --edit 10230
+  devflow tasks log ENG-123 --delete 10230 --adjust-estimate leave
+
+Durations use Jira units: w, d, h and m, as in "1h30m", "1d 4h" or "1.5h",
+with 8-hour days and 5-day weeks. The comment is Markdown. Without a
+duration, the issue's worklogs are listed.
+
+By default Jira reduces the remaining estimate by the time logged. Use
+--adjust-estimate leave to keep it, --estimate 1d to set a new remaining
+estimate, or --adjust-estimate manual --estimate 2h to reduce it (or, when
+deleting, increase it) by a fixed amount.`,
+	Args: cobra.RangeArgs(1, 3),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		issueKey := args[0]
+
+		adjust, err := estimateAdjustment(worklogAdjust, worklogEstimate)
+		if err != nil {
+			fatalError("Invalid estimate adjustment", err)
+		}
+		if worklogDelete != "" && len(args) > 1 {
+			fatalError("Invalid arguments", fmt.Errorf("--delete takes no duration or comment"))
+		}
+		if worklogEdit != "" && len(args) == 1 {
+			fatalError("Invalid arguments", fmt.Errorf("--edit needs the new duration"))
+		}
+
+		var input jira.WorklogInput
+		if len(args) > 1 {
+			if input.TimeSpentSeconds, err = parseWorkDuration(args[1]); err != nil {
+				fatalError("Invalid duration", err)
+			}
+			if len(args) > 2 {
+				input.Comment = args[2]
+			}
+			if worklogStarted != "" {
+				if input.Started, err = parseLocalTime(worklogStarted); err != nil {
+					fatalError("Invalid --started", err)
+				}
+			}
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			fatalError("Error loading config", err)
+		}
+		if cfg.Jira.URL == "" || jiraUsernameMissing(cfg.Jira) || cfg.Jira.Token == "" {
+			log.Fatal("Jira configuration incomplete. Run: devflow config set jira.url|jira.username|jira.token ...")
+		}
+		client := jira.NewClient(&cfg.Jira)
+
+		switch {
+		case worklogDelete != "":
+			if err := client.DeleteWorklogContext(ctx, issueKey, worklogDelete, adjust); err != nil {
+				fatalError("Failed to delete worklog", err)
+			}
+			if wantsJSON(cmd) {
+				if err := printJSON(map[string]any{"issue": issueKey, "id": worklogDelete, "deleted": true}); err != nil {
+					fatalError("Error encoding JSON", err)
+				}
+				return
+			}
+			if wantsTabular(cmd) {
+				renderKeyValueTable([][2]string{{"Issue", issueKey}, {"Worklog", worklogDelete}, {"Deleted", "true"}})
+				return
+			}
+			fmt.Printf("🗑️  Deleted worklog %s from %s\n", worklogDelete, issueKey)
+		case len(args) == 1:
+			worklogs, err := client.ListWorklogsContext(ctx, issueKey, time.Time{})
+			if err != nil {
+				fatalError("Failed to list worklogs", err)
+			}
+			displayWorklogs(cmd, issueKey, worklogs)
+		default:
+			var worklog *jira.Worklog
+			if worklogEdit != "" {
+				worklog, err = client.UpdateWorklogContext(ctx, issueKey, worklogEdit, input, adjust)
+			} else {
+				worklog, err = client.AddWorklogContext(ctx, issueKey, input, adjust)
+			}
+			if err != nil {
+				fatalError("Failed to log work", err)
+			}
+			entry := normalizedWorklogFrom(issueKey, *worklog)
+			if wantsJSON(cmd) {
+				if err := printJSON(entry); err != nil {
+					fatalError("Error encoding JSON", err)
+				}
+				return
+			}
+			if wantsTabular(cmd) {
+				renderKeyValueTable([][2]string{{"Issue", issueKey}, {"Worklog", entry.ID}, {"Time Spent", entry.TimeSpent}, {"Started", entry.Started}})
+				return
+			}
+			verb := "Logged"
+			if worklogEdit != "" {
+				verb = "Updated worklog " + entry.ID + " to"
+			}
+			fmt.Printf("⏱️  %s %s on %s\n", verb, entry.TimeSpent, issueKey)
+		}
+	},
+}
+
+var timesheetCmd = &cobra.Command{
+	Use:   "timesheet",
+	Short: "Report the time you logged per day and per issue",
+	Long: `Summarize your worklogs between --from and --to (inclusive), grouped by
+day and by issue. Dates are YYYY-MM-DD, "today" or "yesterday"; by default
+the report covers the current week up to today.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+
+		from, to, err := timesheetRange(timesheetFrom, timesheetTo)
+		if err != nil {
+			fatalError("Invalid date range", err)
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			fatalError("Error loading config", err)
+		}
+		if cfg.Jira.URL == "" || jiraUsernameMissing(cfg.Jira) || cfg.Jira.Token == "" {
+			log.Fatal("Jira configuration incomplete. Run: devflow config set jira.url|jira.username|jira.token ...")
+		}
+		client := jira.NewClient(&cfg.Jira)
+
+		me, err := client.GetMyselfContext(ctx)
+		if err != nil {
+			fatalError("Failed to identify the current user", err)
+		}
+		jql := fmt.Sprintf(`worklogAuthor = currentUser() AND worklogDate >= "%s" AND worklogDate <= "%s" ORDER BY key ASC`,
+			from.Format(time.DateOnly), to.Format(time.DateOnly))
+		issues, err := client.SearchAllContext(ctx, jql, true, 100, 0)
+		if err != nil {
+			fatalError("Failed to search worklogs", err)
+		}
+
+		end := to.AddDate(0, 0, 1)
+		var entries []timesheetEntry
+		for _, issue := range issues {
+			worklogs, err := client.ListWorklogsContext(ctx, issue.Key, from)
+			if err != nil {
+				fatalError("Failed to list worklogs of "+issue.Key, err)
+			}
+			for _, worklog := range worklogs {
+				started, err := worklog.StartedAt()
+				if err != nil || worklog.Author.ID() != me.ID() || started.Before(from) || !started.Before(end) {
+					continue
+				}
+				entries = append(entries, timesheetEntry{Key: issue.Key, Summary: issue.Fields.Summary, Started: started, Seconds: worklog.TimeSpentSeconds})
+			}
+		}
+
+		sheet := buildTimesheet(from, to, entries)
+		if wantsJSON(cmd) {
+			if err := printJSON(sheet); err != nil {
+				fatalError("Error encoding JSON", err)
+			}
+			return
+		}
+		if wantsTabular(cmd) {
+			displayTimesheetTable(sheet)
+			return
+		}
+		displayTimesheet(sheet)
+	},
+}
+
+func init() {
+	logWorkCmd.Flags().StringVar(&worklogStarted, "started", "", `When the work started ("2024-05-06 09:00", "2024-05-06", "yesterday"); defaults to now, or unchanged with --edit`)
+	logWorkCmd.Flags().StringVar(&worklogAdjust, "adjust-estimate", "", "How to adjust the remaining estimate: auto, leave, new or manual")
+	logWorkCmd.Flags().StringVar(&worklogEstimate, "estimate", "", "New remaining estimate, or the manual adjustment amount (e.g. 1d, 3h)")
+	logWorkCmd.Flags().StringVar(&worklogEdit, "edit", "", "ID of a worklog to update instead of adding one")
+	logWorkCmd.Flags().StringVar(&worklogDelete, "delete", "", "ID of a worklog to delete")
+	logWorkCmd.MarkFlagsMutuallyExclusive("edit", "delete")
+
+	timesheetCmd.Flags().StringVar(&timesheetFrom, "from", "", "First day of the report (default: Monday of this week)")
+	timesheetCmd.Flags().StringVar(&timesheetTo, "to", "", "Last day of the report (default: today)")
+}
+
+type normalizedWorklog struct {
+	Issue            string `json:"issue"`
+	ID               string `json:"id"`
+	Author           string `json:"author,omitempty"`
+	Started          string `json:"started"`
+	TimeSpent        string `json:"time_spent"`
+	TimeSpentSeconds int    `json:"time_spent_seconds"`
+	Comment          string `json:"comment,omitempty"`
+}
+
+func normalizedWorklogFrom(issueKey string, worklog jira.Worklog) normalizedWorklog {
+	started := worklog.Started
+	if at, err := worklog.StartedAt(); err == nil {
+		started = at.Local().Format("2006-01-02 15:04")
+	}
+	entry := normalizedWorklog{
+		Issue:            issueKey,
+		ID:               worklog.ID,
+		Author:           worklog.Author.DisplayName,
+		Started:          started,
+		TimeSpent:        formatWorkDuration(worklog.TimeSpentSeconds),
+		TimeSpentSeconds: worklog.TimeSpentSeconds,
+	}
+	if worklog.Comment != nil {
+		entry.Comment = renderedText(worklog.Comment)
+	}
+	return entry
+}
+
+func displayWorklogs(cmd *cobra.Command, issueKey string, worklogs []jira.Worklog) {
+	entries := make([]normalizedWorklog, 0, len(worklogs))
+	total := 0
+	for _, worklog := range worklogs {
+		entries = append(entries, normalizedWorklogFrom(issueKey, worklog))
+		total += worklog.TimeSpentSeconds
+	}
+	if wantsJSON(cmd) {
+		if err := printJSON(entries); err != nil {
+			fatalError("Error encoding JSON", err)
+		}
+		return
+	}
+	if wantsTabular(cmd) {
+		rows := make([][]any, 0, len(entries))
+		for _, e := range entries {
+			rows = append(rows, []any{e.ID, e.Started, e.Author, e.TimeSpent, e.Comment})
+		}
+		renderTable([]string{"ID", "Started", "Author", "Time", "Comment"}, rows)
+		return
+	}
+	if len(entries) == 0 {
+		fmt.Printf("No work logged on %s\n", issueKey)
+		return
+	}
+	fmt.Printf("⏱️  Worklogs for %s (%s total):\n", issueKey, formatWorkDuration(total))
+	for _, e := range entries {
+		fmt.Printf("  %s  %-8s %s [%s]", e.Started, e.TimeSpent, e.Author, e.ID)
+		if e.Comment != "" {
+			fmt.Printf(" - %s", strings.ReplaceAll(e.Comment, "\n", "\n    "))
+		}
+		fmt.Println()
+	}
+}
+
+// timesheetEntry is one worklog counted in a timesheet.
+type timesheetEntry struct {
+	Key     string
+	Summary string
+	Started time.Time
+	Seconds int
+}
+
+type timesheet struct {
+	From         string           `json:"from"`
+	To           string           `json:"to"`
+	TotalSeconds int              `json:"total_seconds"`
+	Total        string           `json:"total"`
+	Days         []timesheetDay   `json:"days"`
+	Issues       []timesheetIssue `json:"issues"`
+}
+
+type timesheetDay struct {
+	Date         string              `json:"date"`
+	TotalSeconds int                 `json:"total_seconds"`
+	Total        string              `json:"total"`
+	Issues       []timesheetDayIssue `json:"issues"`
+}
+
+type timesheetDayIssue struct {
+	Key     string `json:"key"`
+	Seconds int    `json:"seconds"`
+}
+
+type timesheetIssue struct {
+	Key          string         `json:"key"`
+	Summary      string         `json:"summary"`
+	TotalSeconds int            `json:"total_seconds"`
+	Total        string         `json:"total"`
+	Days         map[string]int `json:"days"`
+}
+
+// buildTimesheet aggregates entries per local day and per issue. Only days
+// with logged time are listed.
+func buildTimesheet(from, to time.Time, entries []timesheetEntry) timesheet {
+	sheet := timesheet{From: from.Format(time.DateOnly), To: to.Format(time.DateOnly), Days: []timesheetDay{}, Issues: []timesheetIssue{}}
+	days := make(map[string]map[string]int)
+	issues := make(map[string]*timesheetIssue)
+	for _, e := range entries {
+		day := e.Started.In(from.Location()).Format(time.DateOnly)
+		if days[day] == nil {
+			days[day] = make(map[string]int)
+		}
+		days[day][e.Key] += e.Seconds
+		issue := issues[e.Key]
+		if issue == nil {
+			issue = &timesheetIssue{Key: e.Key, Summary: e.Summary, Days: make(map[string]int)}
+			issues[e.Key] = issue
+		}
+		issue.Days[day] += e.Seconds
+		issue.TotalSeconds += e.Seconds
+		sheet.TotalSeconds += e.Seconds
+	}
+
+	for date, perIssue := range days {
+		day := timesheetDay{Date: date}
+		for key, seconds := range perIssue {
+			day.Issues = append(day.Issues, timesheetDayIssue{Key: key, Seconds: seconds})
+			day.TotalSeconds += seconds
+		}
+		sort.Slice(day.Issues, func(i, j int) bool { return day.Issues[i].Key < day.Issues[j].Key })
+		day.Total = formatWorkDuration(day.TotalSeconds)
+		sheet.Days = append(sheet.Days, day)
+	}
+	sort.Slice(sheet.Days, func(i, j int) bool { return sheet.Days[i].Date < sheet.Days[j].Date })
+
+	for _, issue := range issues {
+		issue.Total = formatWorkDuration(issue.TotalSeconds)
+		sheet.Issues = append(sheet.Issues, *issue)
+	}
+	sort.Slice(sheet.Issues, func(i, j int) bool { return sheet.Issues[i].Key < sheet.Issues[j].Key })
+	sheet.Total = formatWorkDuration(sheet.TotalSeconds)
+	return sheet
+}
+
+// displayTimesheetTable renders the timesheet as an issue-by-day grid.
+func displayTimesheetTable(sheet timesheet) {
+	headers := []string{"Issue", "Summary"}
+	for _, day := range sheet.Days {
+		headers = append(headers, dayLabel(day.Date))
+	}
+	headers = append(headers, "Total")
+
+	rows := make([][]any, 0, len(sheet.Issues)+1)
+	for _, issue := range sheet.Issues {
+		row := []any{issue.Key, issue.Summary}
+		for _, day := range sheet.Days {
+			row = append(row, formatOptionalDuration(issue.Days[day.Date]))
+		}
+		rows = append(rows, append(row, issue.Total))
+	}
+	totals := []any{"Total", ""}
+	for _, day := range sheet.Days {
+		totals = append(totals, day.Total)
+	}
+	rows = append(rows, append(totals, sheet.Total))
+	renderTable(headers, rows)
+}
+
+func displayTimesheet(sheet timesheet) {
+	fmt.Printf("🗓️  Timesheet %s – %s: %s\n", sheet.From, sheet.To, sheet.Total)
+	if len(sheet.Days) == 0 {
+		fmt.Println("No work logged in this period.")
+		return
+	}
+	summaries := make(map[string]string, len(sheet.Issues))
+	for _, issue := range sheet.Issues {
+		summaries[issue.Key] = issue.Summary
+	}
+	for _, day := range sheet.Days {
+		fmt.Printf("\n📅 %s — %s\n", dayLabel(day.Date), day.Total)
+		for _, issue := range day.Issues {
+			fmt.Printf("   %-8s %s - %s\n", formatWorkDuration(issue.Seconds), issue.Key, summaries[issue.Key])
+		}
+	}
+	fmt.Println("\n📋 Per issue:")
+	for _, issue := range sheet.Issues {
+		fmt.Printf("   %-8s %s - %s\n", issue.Total, issue.Key, issue.Summary)
+	}
+}
+
+func dayLabel(date string) string {
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return date
+	}
+	return day.Format("Mon 01-02")
+}
+
+// timesheetRange resolves the --from and --to flags to local midnights. The
+// default range runs from Monday of the current week to today.
+func timesheetRange(fromFlag, toFlag string) (time.Time, time.Time, error) {
+	today := startOfDay(now())
+	from := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	to := today
+	var err error
+	if fromFlag != "" {
+		if from, err = parseDay(fromFlag); err != nil {
+			return from, to, err
+		}
+	}
+	if toFlag != "" {
+		if to, err = parseDay(toFlag); err != nil {
+			return from, to, err
+		}
+	}
+	if to.Before(from) {
+		return from, to, fmt.Errorf("--to %s is before --from %s", to.Format(time.DateOnly), from.Format(time.DateOnly))
+	}
+	return from, to, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// parseDay parses YYYY-MM-DD, "today" or "yesterday" as a local midnight.
+func parseDay(value string) (time.Time, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "today":
+		return startOfDay(now()), nil
+	case "yesterday":
+		return startOfDay(now()).AddDate(0, 0, -1), nil
+	}
+	day, err := time.ParseInLocation(time.DateOnly, strings.TrimSpace(value), time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q; use YYYY-MM-DD", value)
+	}
+	return day, nil
+}
+
+// parseLocalTime parses a local date with an optional time of day, or an
+// RFC 3339 timestamp.
+func parseLocalTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02 15:04:05"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	if day, err := parseDay(value); err == nil {
+		return day.Add(9 * time.Hour), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q; use YYYY-MM-DD [HH:MM]", value)
+}
+
+var workDurationPart = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*([wdhm])`)
+
+// Jira's default time tracking settings: 8-hour days and 5-day weeks.
+var workUnitSeconds = map[string]float64{"w": 5 * 8 * 3600, "d": 8 * 3600, "h": 3600, "m": 60}
+
+// parseWorkDuration converts a Jira duration such as "1h30m", "1d 4h" or
+// "1.5h" to seconds.
+func parseWorkDuration(value string) (int, error) {
+	text := strings.ToLower(strings.TrimSpace(value))
+	matches := workDurationPart.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return 0, fmt.Errorf("invalid duration %q; use units w, d, h and m, e.g. 1h30m", value)
+	}
+	var seconds float64
+	pos := 0
+	for _, m := range matches {
+		if strings.TrimSpace(text[pos:m[0]]) != "" {
+			return 0, fmt.Errorf("invalid duration %q; use units w, d, h and m, e.g. 1h30m", value)
+		}
+		amount, _ := strconv.ParseFloat(text[m[2]:m[3]], 64)
+		seconds += amount * workUnitSeconds[text[m[4]:m[5]]]
+		pos = m[1]
+	}
+	if strings.TrimSpace(text[pos:]) != "" {
+		return 0, fmt.Errorf("invalid duration %q; use units w, d, h and m, e.g. 1h30m", value)
+	}
+	if seconds < 60 {
+		return 0, fmt.Errorf("duration %q is shorter than a minute", value)
+	}
+	return int(seconds + 0.5), nil
+}
+
+// formatWorkDuration renders seconds as hours and minutes, e.g. "1h 30m".
+func formatWorkDuration(seconds int) string {
+	minutes := (seconds + 30) / 60
+	hours, minutes := minutes/60, minutes%60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+}
+
+func formatOptionalDuration(seconds int) string {
+	if seconds == 0 {
+		return ""
+	}
+	return formatWorkDuration(seconds)
+}
+
+// estimateAdjustment builds the remaining-estimate adjustment from the
+// --adjust-estimate and --estimate flags. An estimate on its own sets a new
+// remaining estimate.
+func estimateAdjustment(mode, estimate string) (jira.EstimateAdjustment, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" && estimate != "" {
+		mode = jira.AdjustNew
+	}
+	switch mode {
+	case "", jira.AdjustAuto, jira.AdjustLeave:
+		if estimate != "" {
+			return jira.EstimateAdjustment{}, fmt.Errorf("--estimate cannot be used with --adjust-estimate %s", mode)
+		}
+		return jira.EstimateAdjustment{Mode: mode}, nil
+	case jira.AdjustNew, jira.AdjustManual:
+		if estimate == "" {
+			return jira.EstimateAdjustment{}, fmt.Errorf("--adjust-estimate %s needs --estimate", mode)
+		}
+		if strings.TrimSpace(estimate) != "0" {
+			if _, err := parseWorkDuration(estimate); err != nil {
+				return jira.EstimateAdjustment{}, err
+			}
+		}
+		return jira.EstimateAdjustment{Mode: mode, Value: strings.Join(strings.Fields(estimate), " ")}, nil
+	default:
+		return jira.EstimateAdjustment{}, fmt.Errorf("unknown --adjust-estimate %q; use auto, leave, new or manual", mode)
+	}
+}
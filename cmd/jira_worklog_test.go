@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"devflow/internal/config"
+	"devflow/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+func TestParseWorkDuration(t *testing.T) {
+	cases := map[string]int{
+		"1h30m":  5400,
+		"1h 30m": 5400,
+		"90m":    5400,
+		"1.5h":   5400,
+		"1d":     8 * 3600,
+		"1w 2d":  7 * 8 * 3600,
+		"2H":     7200,
+	}
+	for in, want := range cases {
+		if got, err := parseWorkDuration(in); err != nil || got != want {
+			t.Fatalf("parseWorkDuration(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "abc", "1x", "1h foo", "30s", "0m"} {
+		if _, err := parseWorkDuration(in); err == nil {
+			t.Fatalf("expected parseWorkDuration(%q) to fail", in)
+		}
+	}
+
+	for seconds, want := range map[int]string{0: "0m", 2700: "45m", 7200: "2h", 5400: "1h 30m", 36000: "10h"} {
+		if got := formatWorkDuration(seconds); got != want {
+			t.Fatalf("formatWorkDuration(%d) = %q, want %q", seconds, got, want)
+		}
+	}
+}
+
+func TestEstimateAdjustment(t *testing.T) {
+	cases := []struct {
+		mode, estimate string
+		want           jira.EstimateAdjustment
+	}{
+		{"", "", jira.EstimateAdjustment{}},
+		{"leave", "", jira.EstimateAdjustment{Mode: jira.AdjustLeave}},
+		{"", "1d  4h", jira.EstimateAdjustment{Mode: jira.AdjustNew, Value: "1d 4h"}},
+		{"new", "0", jira.EstimateAdjustment{Mode: jira.AdjustNew, Value: "0"}},
+		{"manual", "2h", jira.EstimateAdjustment{Mode: jira.AdjustManual, Value: "2h"}},
+	}
+	for _, c := range cases {
+		if got, err := estimateAdjustment(c.mode, c.estimate); err != nil || got != c.want {
+			t.Fatalf("estimateAdjustment(%q, %q) = %+v, %v; want %+v", c.mode, c.estimate, got, err, c.want)
+		}
+	}
+	for _, bad := range [][2]string{{"manual", ""}, {"leave", "1h"}, {"sometimes", ""}, {"new", "soon"}} {
+		if _, err := estimateAdjustment(bad[0], bad[1]); err == nil {
+			t.Fatalf("expected estimateAdjustment(%q, %q) to fail", bad[0], bad[1])
+		}
+	}
+}
+
+func TestTimesheetRange(t *testing.T) {
+	origNow := now
+	t.Cleanup(func() { now = origNow })
+	now = func() time.Time { return time.Date(2024, 5, 9, 15, 0, 0, 0, time.Local) } // a Thursday
+
+	from, to, err := timesheetRange("", "")
+	if err != nil || from.Format(time.DateOnly) != "2024-05-06" || to.Format(time.DateOnly) != "2024-05-09" {
+		t.Fatalf("unexpected default range %v – %v (%v)", from, to, err)
+	}
+	from, to, err = timesheetRange("2024-04-29", "yesterday")
+	if err != nil || from.Format(time.DateOnly) != "2024-04-29" || to.Format(time.DateOnly) != "2024-05-08" {
+		t.Fatalf("unexpected explicit range %v – %v (%v)", from, to, err)
+	}
+	if _, _, err := timesheetRange("2024-05-10", "2024-05-01"); err == nil {
+		t.Fatal("expected an inverted range to fail")
+	}
+}
+
+func TestTimesheetCmd(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"},
+	})
+	origNow, origFrom, origTo := now, timesheetFrom, timesheetTo
+	t.Cleanup(func() { now, timesheetFrom, timesheetTo = origNow, origFrom, origTo })
+	now = func() time.Time { return time.Date(2024, 5, 9, 15, 0, 0, 0, time.Local) }
+	timesheetFrom, timesheetTo = "", ""
+
+	day := func(d, hour int) string {
+		return time.Date(2024, 5, d, hour, 0, 0, 0, time.Local).Format(jira.WorklogTimeLayout)
+	}
+	var jql string
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/myself":
+			_, _ = w.Write([]byte(`{"accountId":"me-1","displayName":"Alice"}`))
+		case "/rest/api/3/search/jql":
+			jql = r.URL.Query().Get("jql")
+			_, _ = w.Write([]byte(`{"issues":[{"key":"ENG-1","fields":{"summary":"Parser"}},{"key":"ENG-2","fields":{"summary":"Review"}}],"isLast":true}`))
+		case "/rest/api/3/issue/ENG-1/worklog":
+			_ = json.NewEncoder(w).Encode(map[string]any{"total": 3, "worklogs": []map[string]any{
+				{"id": "1", "author": map[string]string{"accountId": "me-1"}, "started": day(6, 9), "timeSpentSeconds": 5400},
+				{"id": "2", "author": map[string]string{"accountId": "bob"}, "started": day(6, 10), "timeSpentSeconds": 3600},
+				{"id": "3", "author": map[string]string{"accountId": "me-1"}, "started": day(7, 9), "timeSpentSeconds": 3600},
+			}})
+		case "/rest/api/3/issue/ENG-2/worklog":
+			_ = json.NewEncoder(w).Encode(map[string]any{"total": 2, "worklogs": []map[string]any{
+				{"id": "4", "author": map[string]string{"accountId": "me-1"}, "started": day(6, 14), "timeSpentSeconds": 1800},
+				{"id": "5", "author": map[string]string{"accountId": "me-1"}, "started": day(10, 9), "timeSpentSeconds": 1800},
+			}})
+		default:
+			t.Fatalf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	cmd := commandWithFormat(formatJSON)
+	out := captureStdout(func() { timesheetCmd.Run(cmd, nil) })
+	if !strings.Contains(jql, `worklogDate >= "2024-05-06" AND worklogDate <= "2024-05-09"`) {
+		t.Fatalf("unexpected JQL %q", jql)
+	}
+	var sheet timesheet
+	if err := json.Unmarshal([]byte(out), &sheet); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if sheet.TotalSeconds != 5400+3600+1800 || sheet.Total != "3h" || len(sheet.Days) != 2 || len(sheet.Issues) != 2 {
+		t.Fatalf("unexpected timesheet: %+v", sheet)
+	}
+	if d := sheet.Days[0]; d.Date != "2024-05-06" || d.Total != "2h" || len(d.Issues) != 2 || d.Issues[0].Key != "ENG-1" {
+		t.Fatalf("unexpected first day: %+v", d)
+	}
+	if i := sheet.Issues[0]; i.Key != "ENG-1" || i.Total != "2h 30m" || i.Days["2024-05-07"] != 3600 {
+		t.Fatalf("unexpected first issue: %+v", i)
+	}
+
+	cmd = commandWithFormat(formatTabular)
+	out = captureStdout(func() { timesheetCmd.Run(cmd, nil) })
+	if !strings.Contains(out, "MON 05-06") || !strings.Contains(out, "1h 30m") || !strings.Contains(out, "2h 30m") {
+		t.Fatalf("unexpected tabular timesheet: %q", out)
+	}
+}
+
+func TestTimesheetCmdOnServer(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "server"},
+	})
+	origNow, origFrom, origTo := now, timesheetFrom, timesheetTo
+	t.Cleanup(func() { now, timesheetFrom, timesheetTo = origNow, origFrom, origTo })
+	now = func() time.Time { return time.Date(2024, 5, 9, 15, 0, 0, 0, time.Local) }
+	timesheetFrom, timesheetTo = "", ""
+
+	started := time.Date(2024, 5, 6, 9, 0, 0, 0, time.Local).Format(jira.WorklogTimeLayout)
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/myself":
+			_, _ = w.Write([]byte(`{"name":"alice","key":"alice","displayName":"Alice"}`))
+		case "/rest/api/2/search":
+			_, _ = w.Write([]byte(`{"issues":[{"key":"ENG-1","fields":{"summary":"Parser"}}],"startAt":0,"maxResults":100,"total":1}`))
+		case "/rest/api/2/issue/ENG-1/worklog":
+			_ = json.NewEncoder(w).Encode(map[string]any{"total": 2, "worklogs": []map[string]any{
+				{"id": "1", "author": map[string]string{"name": "alice", "key": "alice"}, "started": started, "timeSpentSeconds": 5400},
+				{"id": "2", "author": map[string]string{"name": "bob", "key": "bob"}, "started": started, "timeSpentSeconds": 3600},
+			}})
+		default:
+			t.Fatalf("unexpected jira request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	out := captureStdout(func() { timesheetCmd.Run(commandWithFormat(formatJSON), nil) })
+	var sheet timesheet
+	if err := json.Unmarshal([]byte(out), &sheet); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if sheet.TotalSeconds != 5400 {
+		t.Fatalf("expected only alice's worklog to count, got %+v", sheet)
+	}
+}
+
+func TestLogWorkCmd(t *testing.T) {
+	setJiraCmdConfig(t, &config.Config{
+		Jira: config.JiraConfig{URL: "https://jira.example", Username: "alice", Token: "token", Deployment: "cloud"},
+	})
+	var request string
+	var payload map[string]any
+	registerHost(t, "jira.example", func(w http.ResponseWriter, r *http.Request) {
+		request = r.Method + " " + r.URL.Path + "?" + r.URL.RawQuery
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"10230","timeSpentSeconds":5400,"started":"2024-05-06T09:00:00.000+0000"}`))
+	})
+
+	orig := []string{worklogStarted, worklogAdjust, worklogEstimate, worklogEdit, worklogDelete}
+	t.Cleanup(func() {
+		worklogStarted, worklogAdjust, worklogEstimate, worklogEdit, worklogDelete = orig[0], orig[1], orig[2], orig[3], orig[4]
+	})
+	worklogStarted, worklogAdjust, worklogEstimate, worklogEdit, worklogDelete = "2024-05-06 09:00", "", "1d", "", ""
+
+	out := captureStdout(func() {
+		logWorkCmd.Run(logWorkCmd, []string{"ENG-123", "1h30m", "pairing on parser"})
+	})
+	if !strings.Contains(out, "Logged 1h 30m on ENG-123") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	if request != "POST /rest/api/3/issue/ENG-123/worklog?adjustEstimate=new&newEstimate=1d" {
+		t.Fatalf("unexpected request %q", request)
+	}
+	if payload["timeSpentSeconds"] != float64(5400) || !strings.HasPrefix(payload["started"].(string), "2024-05-06T09:00:00.000") {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+}
+
+// commandWithFormat returns a bare command whose --format flag is set, for
+// calling Run functions with a non-default output format.
+func commandWithFormat(format string) *cobra.Command {
+	command := &cobra.Command{}
+	command.Flags().String("format", formatDetailed, "")
+	_ = command.Flags().Set("format", format)
+	return command
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -56,7 +57,11 @@ func wantsTabular(cmd *cobra.Command) bool {
 }
 
 func printJSON(value any) error {
-	encoder := json.NewEncoder(os.Stdout)
+	return encodeJSON(os.Stdout, value)
+}
+
+func encodeJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(value)
 }
@@ -20,7 +20,7 @@ var showRepoCmd = &cobra.Command{
 		// Load configuration
 		cfg, err := loadConfig()
 		if err != nil {
-			log.Fatalf("Error loading config: %v", err)
+			fatalError("Error loading config", err)
 		}
 
 		// Validate required config
@@ -38,13 +38,13 @@ var showRepoCmd = &cobra.Command{
 		client := bitbucket.NewClient(&cfg.Bitbucket)
 
 		// Get repository details
-		repo, err := client.GetRepository(repoSlug)
+		repo, err := client.GetRepositoryContext(commandContext(cmd), repoSlug)
 		if err != nil {
-			log.Fatalf("Error fetching repository details: %v", err)
+			fatalError("Error fetching repository details", err)
 		}
 		if wantsJSON(cmd) {
 			if err := printJSON(repo); err != nil {
-				log.Fatalf("Error encoding JSON: %v", err)
+				fatalError("Error encoding JSON", err)
 			}
 			return
 		}
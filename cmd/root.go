@@ -1,27 +1,110 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"devflow/internal/config"
+	"devflow/internal/httpx"
+	"devflow/internal/jira"
 	"github.com/spf13/cobra"
 )
 
+var profileName string
+var commandTimeout time.Duration
+var noCache bool
+var refreshCache bool
+
+// cancelTimeout releases the --timeout deadline once the command returns.
+var cancelTimeout context.CancelFunc = func() {}
+
 var rootCmd = &cobra.Command{
 	Use:   "devflow",
 	Short: "CLI tool for development workflow management",
 	Long: `A command-line interface tool for streamlining development workflows with Jira and Bitbucket.
 Perfect for developers who want to manage tasks and repositories from the terminal.`,
-	PersistentPreRunE: validateFormat,
+	PersistentPreRunE: persistentPreRun,
+	// Errors are printed by Execute so --format json gets an error object.
+	SilenceErrors: true,
 }
 
+// Execute runs the command line and reports any error it returns. Callers
+// should exit with ExitCode(err).
 func Execute() error {
-	return rootCmd.Execute()
+	// The first interrupt cancels in-flight requests; once the context is
+	// done the default handling is restored so a second one exits at once.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	cmd, err := rootCmd.ExecuteContextC(ctx)
+	cancelTimeout()
+	var reported reportedError
+	if err != nil && !errors.As(err, &reported) {
+		reportError(cmd, "", err)
+	}
+	return err
+}
+
+// persistentPreRun applies global flags before any subcommand runs.
+func persistentPreRun(cmd *cobra.Command, args []string) error {
+	currentCmd = cmd
+	config.SelectProfile(profileName)
+	if commandTimeout > 0 {
+		ctx, cancel := context.WithTimeout(commandContext(cmd), commandTimeout)
+		cmd.SetContext(ctx)
+		cancelTimeout = cancel
+	}
+	httpx.ConfigureCache(cacheDir(), cacheMode())
+	jira.ConfigureUserCache(filepath.Join(cacheDir(), "jira-users.json"), cacheMode())
+	return validateFormat(cmd, args)
+}
+
+// cacheMode maps --no-cache and --refresh to the HTTP cache mode.
+func cacheMode() httpx.CacheMode {
+	switch {
+	case noCache:
+		return httpx.CacheOff
+	case refreshCache:
+		return httpx.CacheRefresh
+	default:
+		return httpx.CacheNormal
+	}
+}
+
+// commandContext returns the context of cmd, cancelled on interrupt or when
+// --timeout expires. Commands run directly (as in tests) get a background
+// context.
+func commandContext(cmd *cobra.Command) context.Context {
+	if cmd != nil && cmd.Context() != nil {
+		return cmd.Context()
+	}
+	return context.Background()
 }
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", formatDetailed, "Output format: json, raw, tabular, or detailed")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Configuration profile to use (overrides "+config.ProfileEnvVar+")")
+	rootCmd.PersistentFlags().DurationVar(&commandTimeout, "timeout", 0, "Abort the command after this long, e.g. 30s or 2m (0 disables)")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Do not read or write the HTTP response cache")
+	rootCmd.PersistentFlags().BoolVar(&refreshCache, "refresh", false, "Ignore cached HTTP responses and store fresh ones")
+	rootCmd.MarkFlagsMutuallyExclusive("no-cache", "refresh")
 	rootCmd.AddCommand(authCmd)
 	rootCmd.AddCommand(tasksCmd)
+	rootCmd.AddCommand(sprintCmd)
+	rootCmd.AddCommand(backlogCmd)
+	rootCmd.AddCommand(releaseCmd)
 	rootCmd.AddCommand(repoCmd)
 	rootCmd.AddCommand(pullrequestCmd)
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(jenkinsCmd)
+	rootCmd.AddCommand(cacheCmd)
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"os"
 	"strings"
 	"testing"
@@ -86,7 +87,7 @@ func TestDisplayIssueDetailsWithURL(t *testing.T) {
 	defer func() { loadConfig = origLoad }()
 
 	loadConfig = func() (*config.Config, error) {
-		return &config.Config{Jira: config.JiraConfig{URL: "https://jira.example"}}, nil
+		return &config.Config{Jira: config.JiraConfig{URL: "https://jira.example", Deployment: "cloud"}}, nil
 	}
 
 	issue := &jira.IssueDetails{Key: "ABC-1"}
@@ -106,7 +107,7 @@ type fakeWorkspacePRLister struct {
 	prs []bitbucket.PullRequestWithReviewers
 }
 
-func (f fakeWorkspacePRLister) GetWorkspacePullRequestsForUser(username string) ([]bitbucket.PullRequestWithReviewers, error) {
+func (f fakeWorkspacePRLister) GetWorkspacePullRequestsForUserContext(ctx context.Context, username string) ([]bitbucket.PullRequestWithReviewers, error) {
 	return f.prs, nil
 }
 
@@ -118,7 +119,7 @@ type pagedRepositoryPager struct {
 	lastSize   int
 }
 
-func (p *pagedRepositoryPager) GetRepositoriesPaged(page, size int) ([]bitbucket.Repository, int, error) {
+func (p *pagedRepositoryPager) GetRepositoriesPagedContext(ctx context.Context, page, size int) ([]bitbucket.Repository, int, error) {
 	p.pageCount++
 	p.lastPage = page
 	p.lastSize = size
@@ -138,7 +139,7 @@ func TestGetPRsToReviewFromAllRepos(t *testing.T) {
 		}, nil
 	}
 
-	prs, err := getPRsToReviewFromAllRepos(fakeWorkspacePRLister{prs: []bitbucket.PullRequestWithReviewers{{
+	prs, err := getPRsToReviewFromAllRepos(context.Background(), fakeWorkspacePRLister{prs: []bitbucket.PullRequestWithReviewers{{
 		ID:    1,
 		Title: "Example",
 		Source: struct {
@@ -220,7 +221,7 @@ func TestRunInteractiveMode_Quits(t *testing.T) {
 		totalCount: 11,
 	}
 	captureStdout(func() {
-		runInteractiveMode(pager, "workspace")
+		runInteractiveMode(context.Background(), pager, "workspace")
 	})
 	if pager.pageCount < 3 {
 		t.Fatalf("expected paging interactions, got %d fetches", pager.pageCount)
@@ -1,6 +1,38 @@
 package cmd
 
-import "devflow/internal/config"
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"time"
 
-var loadConfig = config.Load
+	"devflow/internal/config"
+)
+
+var loadConfig = loadConfigAndApply
+
+// loadConfigForEdit is used by config set and setup, which may create the
+// selected profile.
+var loadConfigForEdit = loadConfigForEditAndApply
 var saveConfig = config.Save
+
+var listProfiles = config.ListProfiles
+var activeProfile = config.ActiveProfile
+var useProfile = config.UseProfile
+var copyProfile = config.CopyProfile
+var deleteProfile = config.DeleteProfile
+
+// cacheDir is where the HTTP response cache lives.
+var cacheDir = func() string { return filepath.Join(config.Dir(), "cache") }
+
+// templatesDir holds the issue templates used by tasks create --template.
+var templatesDir = func() string { return filepath.Join(config.Dir(), "templates") }
+
+// snapshotPath is the offline snapshot store of tasks list --sync.
+var snapshotPath = func() string { return filepath.Join(config.Dir(), "snapshots.json") }
+
+// now is the clock used for relative dates such as "today".
+var now = time.Now
+
+// stdin answers confirmation prompts.
+var stdin io.Reader = os.Stdin
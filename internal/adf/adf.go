@@ -0,0 +1,148 @@
+// Package adf converts between Markdown and the Atlassian Document Format
+// (ADF), the JSON document model Jira Cloud uses for descriptions and
+// comments.
+//
+// FromMarkdown encodes the Markdown people write in terminals and files:
+// headings, paragraphs, emphasis, code spans and fences, links, bullet and
+// ordered lists (nested by indentation), block quotes, rules, GitHub-style
+// pipe tables, panels written as GitHub alerts ("> [!NOTE]") and mentions
+// written as @[Name](accountid:ID). ToMarkdown renders ADF back to the same
+// dialect, so documents in that subset survive a round trip; nodes Markdown
+// cannot express (statuses, dates, media) are rendered as readable text.
+package adf
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// Node is an ADF node. Documents, blocks and inline content all share this
+// shape; which fields are used depends on Type.
+type Node struct {
+	Type    string         `json:"type"`
+	Version int            `json:"version,omitempty"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+	Content []*Node        `json:"content,omitempty"`
+	Text    string         `json:"text,omitempty"`
+	Marks   []Mark         `json:"marks,omitempty"`
+}
+
+// Mark is a formatting mark on a text node, such as strong or link.
+type Mark struct {
+	Type  string         `json:"type"`
+	Attrs map[string]any `json:"attrs,omitempty"`
+}
+
+// Document returns an ADF document holding blocks.
+func Document(blocks ...*Node) *Node {
+	return &Node{Type: "doc", Version: 1, Content: blocks}
+}
+
+// Decode converts a decoded JSON value, as found in the interface{} fields of
+// Jira responses, into a Node. It also accepts a *Node, a Node, raw JSON
+// bytes or a json.RawMessage.
+func Decode(value any) (*Node, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, fmt.Errorf("empty ADF value")
+	case *Node:
+		return v, nil
+	case Node:
+		return &v, nil
+	case json.RawMessage:
+		return decodeJSON(v)
+	case []byte:
+		return decodeJSON(v)
+	case map[string]any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return decodeJSON(data)
+	default:
+		return nil, fmt.Errorf("unsupported ADF value of type %T", value)
+	}
+}
+
+func decodeJSON(data []byte) (*Node, error) {
+	var node Node
+	if err := json.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("invalid ADF: %w", err)
+	}
+	if node.Type == "" {
+		return nil, fmt.Errorf("invalid ADF: missing node type")
+	}
+	return &node, nil
+}
+
+// Walk calls fn for node and each of its descendants in document order. It
+// stops descending into a node when fn returns false.
+func Walk(node *Node, fn func(*Node) bool) {
+	if node == nil || !fn(node) {
+		return
+	}
+	for _, child := range node.Content {
+		Walk(child, fn)
+	}
+}
+
+// wikiMentionPattern matches wiki markup mentions: [~username] on Server and
+// [~accountid:ID] in Cloud wiki renderings.
+var wikiMentionPattern = regexp.MustCompile(`\[~(?:accountid:)?([^\]\s]+)\]`)
+
+// Mentions returns the IDs of the users a description or comment body
+// mentions: the accountIds of ADF mention nodes or, when value is a wiki
+// markup string, the usernames or accountIds between "[~" and "]".
+func Mentions(value any) []string {
+	var ids []string
+	if text, ok := value.(string); ok {
+		for _, match := range wikiMentionPattern.FindAllStringSubmatch(text, -1) {
+			ids = append(ids, match[1])
+		}
+		return ids
+	}
+	node, err := Decode(value)
+	if err != nil {
+		return nil
+	}
+	Walk(node, func(n *Node) bool {
+		if n.Type == "mention" {
+			if id := n.attr("id"); id != "" {
+				ids = append(ids, id)
+			}
+		}
+		return true
+	})
+	return ids
+}
+
+func (n *Node) attr(name string) string {
+	if n.Attrs == nil {
+		return ""
+	}
+	switch v := n.Attrs[name].(type) {
+	case string:
+		return v
+	case float64:
+		return fmt.Sprintf("%g", v)
+	case int:
+		return fmt.Sprint(v)
+	default:
+		return ""
+	}
+}
+
+func (n *Node) intAttr(name string, fallback int) int {
+	if n.Attrs == nil {
+		return fallback
+	}
+	switch v := n.Attrs[name].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return fallback
+	}
+}
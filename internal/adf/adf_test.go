@@ -0,0 +1,287 @@
+package adf
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func mustJSON(t *testing.T, node *Node) string {
+	t.Helper()
+	data, err := json.Marshal(node)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	// Normalize numbers and attribute maps through a decode/encode cycle.
+	var value any
+	_ = json.Unmarshal(data, &value)
+	data, _ = json.Marshal(value)
+	return string(data)
+}
+
+// roundTripCases are Markdown documents in the canonical form ToMarkdown
+// produces, so encoding and rendering them must give the same text back.
+var roundTripCases = map[string]string{
+	"paragraphs and breaks":     "First line\nsecond line of the same paragraph\n\nAnother paragraph",
+	"headings":                  "# Title\n\n### Section with `code`",
+	"inline marks":              "Some **bold**, _italic_, ~~struck~~ and `code` text with a [link](https://example.com/a_b) and **bold _both_ bold**",
+	"intraword":                 "snake_case_name and a*b*c stay as they are",
+	"escapes":                   `Literal \*stars\*, \_underscores\_, \[brackets\] and a back\\slash`,
+	"line starts":               "\\# not a heading\n\\- not a list\n1\\. not ordered\n\\> not a quote",
+	"mentions and cards":        "Ping @[Alice Smith](accountid:5b10ac8d82e05b22cc7d4ef5) about <https://jira.example/browse/ENG-1>",
+	"code fence":                "Before\n\n```go\nfunc main() {\n\tfmt.Println(\"*not emphasis*\")\n}\n```\n\nAfter",
+	"code fence with backticks": "````\nuse ``` fences\n````",
+	"bullet list":               "- one\n- two\n- three",
+	"nested lists":              "- parent\n  - child with **bold**\n    1. grandchild\n    2. second grandchild\n  - second child\n- sibling",
+	"ordered start":             "3. three\n4. four\n   - nested bullet",
+	"multi-paragraph item":      "- first paragraph\n\n  second paragraph\n- next item",
+	"list item code":            "1. step one\n\n   ```sh\n   make build\n   ```\n2. step two",
+	"blockquote":                "> quoted text\n>\n> - quoted list",
+	"panels":                    "> [!NOTE]\n> Informational panel\n\n> [!WARNING]\n> Be careful with **this**\n\n> [!CAUTION]\n> - error item",
+	"table":                     "| Name | Value |\n| --- | --- |\n| `a\\|b` | **bold** |\n| plain | [link](https://example.com) |",
+	"rule":                      "Above\n\n---\n\nBelow",
+}
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	for name, markdown := range roundTripCases {
+		t.Run(name, func(t *testing.T) {
+			doc := FromMarkdown(markdown)
+			got := ToMarkdown(doc)
+			if got != markdown {
+				t.Fatalf("round trip changed the Markdown\nwant:\n%s\ngot:\n%s\nADF: %s", markdown, got, mustJSON(t, doc))
+			}
+			again := FromMarkdown(got)
+			if mustJSON(t, again) != mustJSON(t, doc) {
+				t.Fatalf("re-encoding changed the ADF\nfirst:  %s\nsecond: %s", mustJSON(t, doc), mustJSON(t, again))
+			}
+		})
+	}
+}
+
+func TestFromMarkdownStructure(t *testing.T) {
+	doc := FromMarkdown("## Plan\n\n- [x] **done** item\n  1. nested\n\n| A | B |\n|---|:-:|\n| 1 |\n\n> [!TIP]\n> Use `make`")
+	got := mustJSON(t, doc)
+	var decoded struct {
+		Content []struct {
+			Type    string `json:"type"`
+			Attrs   map[string]any
+			Content []json.RawMessage `json:"content"`
+		} `json:"content"`
+	}
+	if err := json.Unmarshal([]byte(got), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	var types []string
+	for _, block := range decoded.Content {
+		types = append(types, block.Type)
+	}
+	if strings.Join(types, ",") != "heading,bulletList,table,panel" {
+		t.Fatalf("unexpected block types %v in %s", types, got)
+	}
+
+	list := doc.Content[1].Content[0]
+	if list.Type != "listItem" || list.Content[0].Type != "paragraph" || list.Content[1].Type != "orderedList" {
+		t.Fatalf("unexpected list item: %s", mustJSON(t, list))
+	}
+	if text := list.Content[0].Content[1]; text.Text != "done" || text.Marks[0].Type != "strong" {
+		t.Fatalf("expected bold text in the item, got %s", mustJSON(t, list.Content[0]))
+	}
+
+	table := doc.Content[2]
+	if len(table.Content) != 2 || table.Content[0].Content[0].Type != "tableHeader" || table.Content[1].Content[1].Type != "tableCell" {
+		t.Fatalf("unexpected table: %s", mustJSON(t, table))
+	}
+	if panel := doc.Content[3]; panel.attr("panelType") != "success" {
+		t.Fatalf("expected a success panel, got %s", mustJSON(t, panel))
+	}
+}
+
+func TestFromMarkdownPlainText(t *testing.T) {
+	if got := mustJSON(t, FromMarkdown("")); got != `{"content":[{"type":"paragraph"}],"type":"doc","version":1}` {
+		t.Fatalf("unexpected empty document: %s", got)
+	}
+
+	doc := FromMarkdown("In 2024. we shipped\nprice * quantity = 3 * 4\nfile_name_here")
+	if len(doc.Content) != 1 || doc.Content[0].Type != "paragraph" {
+		t.Fatalf("expected a single paragraph, got %s", mustJSON(t, doc))
+	}
+	if got := ToMarkdown(doc); got != "In 2024. we shipped\nprice \\* quantity = 3 \\* 4\nfile_name_here" {
+		t.Fatalf("unexpected rendering: %q", got)
+	}
+}
+
+// jiraDocument is a description as returned by Jira Cloud, using nodes and
+// attributes the encoder never produces.
+const jiraDocument = `{
+  "type": "doc", "version": 1,
+  "content": [
+    {"type": "heading", "attrs": {"level": 2}, "content": [{"type": "text", "text": "Context"}]},
+    {"type": "paragraph", "content": [
+      {"type": "text", "text": "Owner: "},
+      {"type": "mention", "attrs": {"id": "abc-123", "text": "@Bob", "accessLevel": ""}},
+      {"type": "text", "text": " status "},
+      {"type": "status", "attrs": {"text": "IN PROGRESS", "color": "blue"}},
+      {"type": "text", "text": " due "},
+      {"type": "date", "attrs": {"timestamp": "1767225600000"}},
+      {"type": "text", "text": " "},
+      {"type": "emoji", "attrs": {"shortName": ":tada:", "text": "🎉"}},
+      {"type": "hardBreak"},
+      {"type": "text", "text": "underlined", "marks": [{"type": "underline"}]}
+    ]},
+    {"type": "panel", "attrs": {"panelType": "note"}, "content": [
+      {"type": "bulletList", "content": [
+        {"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "in a panel"}]}]}
+      ]}
+    ]},
+    {"type": "table", "attrs": {"isNumberColumnEnabled": false, "layout": "default"}, "content": [
+      {"type": "tableRow", "content": [
+        {"type": "tableHeader", "attrs": {}, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Key", "marks": [{"type": "strong"}]}]}]},
+        {"type": "tableHeader", "attrs": {}, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Notes"}]}]}
+      ]},
+      {"type": "tableRow", "content": [
+        {"type": "tableCell", "attrs": {}, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "ENG-1"}]}]},
+        {"type": "tableCell", "attrs": {}, "content": [
+          {"type": "paragraph", "content": [{"type": "text", "text": "a | b"}]},
+          {"type": "paragraph", "content": [{"type": "text", "text": "second"}]}
+        ]}
+      ]}
+    ]},
+    {"type": "codeBlock", "attrs": {"language": "json"}, "content": [{"type": "text", "text": "{\"a\": 1}"}]},
+    {"type": "mediaSingle", "content": [{"type": "media", "attrs": {"alt": "screenshot.png", "type": "file", "id": "x"}}]},
+    {"type": "expand", "attrs": {"title": "Details"}, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "hidden"}]}]}
+  ]
+}`
+
+func TestRenderJiraDocument(t *testing.T) {
+	var value map[string]any
+	if err := json.Unmarshal([]byte(jiraDocument), &value); err != nil {
+		t.Fatal(err)
+	}
+	got := Render(value)
+	want := strings.Join([]string{
+		"## Context",
+		"",
+		"Owner: @[Bob](accountid:abc-123) status [IN PROGRESS] due 2026-01-01 🎉",
+		"underlined",
+		"",
+		"> [!IMPORTANT]",
+		"> - in a panel",
+		"",
+		"| **Key** | Notes |",
+		"| --- | --- |",
+		"| ENG-1 | a \\| b second |",
+		"",
+		"```json",
+		`{"a": 1}`,
+		"```",
+		"",
+		"[attachment: screenshot.png]",
+		"",
+		"**Details**",
+		"",
+		"hidden",
+	}, "\n")
+	if got != want {
+		t.Fatalf("unexpected rendering\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	// The parts Markdown can express survive re-encoding.
+	doc := FromMarkdown(got)
+	mention := doc.Content[1].Content[1]
+	if mention.Type != "mention" || mention.attr("id") != "abc-123" || mention.attr("text") != "@Bob" {
+		t.Fatalf("expected the mention to survive, got %s", mustJSON(t, mention))
+	}
+	if panel := doc.Content[2]; panel.Type != "panel" || panel.attr("panelType") != "note" || panel.Content[0].Type != "bulletList" {
+		t.Fatalf("expected the panel to survive, got %s", mustJSON(t, panel))
+	}
+	if cell := doc.Content[3].Content[1].Content[1]; plainText(cell) != "a | b second" {
+		t.Fatalf("expected the escaped pipe to survive, got %s", mustJSON(t, cell))
+	}
+}
+
+func TestRenderAndDecode(t *testing.T) {
+	if got := Render("plain *server* text"); got != "plain *server* text" {
+		t.Fatalf("expected strings to pass through, got %q", got)
+	}
+	if got := Render(42); got != "" {
+		t.Fatalf("expected non-ADF values to render empty, got %q", got)
+	}
+	if _, err := Decode(map[string]any{"content": []any{}}); err == nil {
+		t.Fatal("expected a node without a type to be rejected")
+	}
+	node, err := Decode(json.RawMessage(`{"type":"paragraph","content":[{"type":"text","text":"hi","marks":[{"type":"em"}]}]}`))
+	if err != nil || ToMarkdown(node) != "_hi_" {
+		t.Fatalf("unexpected decode: %v, %q", err, ToMarkdown(node))
+	}
+
+	var mentions []string
+	Walk(FromMarkdown("@[A](accountid:1) and [~accountid:2]"), func(n *Node) bool {
+		if n.Type == "mention" {
+			mentions = append(mentions, n.attr("id"))
+		}
+		return true
+	})
+	if strings.Join(mentions, ",") != "1,2" {
+		t.Fatalf("unexpected mentions: %v", mentions)
+	}
+	raw := json.RawMessage(`{"type":"doc","content":[{"type":"paragraph","content":[{"type":"mention","attrs":{"id":"acc-1"}}]}]}`)
+	if got := Mentions(raw); strings.Join(got, ",") != "acc-1" {
+		t.Fatalf("unexpected ADF mentions: %v", got)
+	}
+	if got := Mentions("ping [~alice] and [~bob]"); strings.Join(got, ",") != "alice,bob" {
+		t.Fatalf("unexpected wiki mentions: %v", got)
+	}
+}
+
+func TestToWiki(t *testing.T) {
+	markdown := strings.Join([]string{
+		"## Release *1.2*",
+		"",
+		"Run `make deploy` with **care**, see [the runbook](https://wiki.example/run) and @[Alice](accountid:alice). Don't re-run {it} [twice]!",
+		"",
+		"- first",
+		"  1. nested",
+		"- second",
+		"",
+		"> [!WARNING]",
+		"> Downtime expected",
+		"",
+		"| Env | Owner |",
+		"| --- | --- |",
+		"| prod | ops |",
+		"",
+		"```go",
+		"fmt.Println(\"*\")",
+		"```",
+		"",
+		"---",
+	}, "\n")
+	want := strings.Join([]string{
+		"h2. Release _1.2_",
+		"",
+		"Run {{make deploy}} with *care*, see [the runbook|https://wiki.example/run] and [~alice]. Don't re-run \\{it\\} \\[twice\\]\\!",
+		"",
+		"* first",
+		"*# nested",
+		"* second",
+		"",
+		"{warning}",
+		"Downtime expected",
+		"{warning}",
+		"",
+		"||Env||Owner||",
+		"|prod|ops|",
+		"",
+		"{code:go}",
+		"fmt.Println(\"*\")",
+		"{code}",
+		"",
+		"----",
+	}, "\n")
+	if got := MarkdownToWiki(markdown); got != want {
+		t.Fatalf("unexpected wiki markup\nwant:\n%s\ngot:\n%s", want, got)
+	}
+	if got := MarkdownToWiki("#42 is fixed"); got != `\#42 is fixed` {
+		t.Fatalf("expected a leading # to be escaped, got %q", got)
+	}
+}
@@ -0,0 +1,759 @@
+package adf
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	headingPattern        = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?[ \t]*$`)
+	headingClosePattern   = regexp.MustCompile(`(^|[ \t]+)#+$`)
+	fencePattern          = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^`]*)$")
+	rulePattern           = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	bulletPattern         = regexp.MustCompile(`^( *)([-*+])(?:([ \t]+)(.*))?$`)
+	orderedPattern        = regexp.MustCompile(`^( *)(\d{1,9})([.)])(?:([ \t]+)(.*))?$`)
+	quotePattern          = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+	tableDelimiterPattern = regexp.MustCompile(`^ *\|? *:?-+:? *(\| *:?-+:? *)*\|? *$`)
+	alertPattern          = regexp.MustCompile(`(?i)^\[!(NOTE|TIP|IMPORTANT|WARNING|CAUTION)\][ \t]*$`)
+	autolinkPattern       = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^\s<>]*)>`)
+)
+
+// alertPanels maps GitHub alert kinds to ADF panel types.
+var alertPanels = map[string]string{
+	"NOTE":      "info",
+	"IMPORTANT": "note",
+	"TIP":       "success",
+	"WARNING":   "warning",
+	"CAUTION":   "error",
+}
+
+// FromMarkdown encodes Markdown as an ADF document. Text that is not
+// Markdown comes out as plain paragraphs, with line breaks kept as hard
+// breaks. An empty input yields a document with one empty paragraph, which
+// Jira accepts as a blank description.
+func FromMarkdown(markdown string) *Node {
+	markdown = strings.ReplaceAll(markdown, "\r\n", "\n")
+	markdown = strings.ReplaceAll(markdown, "\r", "\n")
+	lines := strings.Split(markdown, "\n")
+	// Tabs only matter for indentation outside code fences; inside them
+	// they are content.
+	fence := ""
+	for i, line := range lines {
+		if fence != "" {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+				fence = ""
+			}
+			continue
+		}
+		lines[i] = expandLeadingTabs(line)
+		if match := fencePattern.FindStringSubmatch(lines[i]); match != nil {
+			fence = match[2]
+		}
+	}
+	blocks := parseBlocks(lines)
+	if len(blocks) == 0 {
+		blocks = []*Node{{Type: "paragraph"}}
+	}
+	return Document(blocks...)
+}
+
+func parseBlocks(lines []string) []*Node {
+	var blocks []*Node
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case isBlank(line):
+			i++
+		case fencePattern.MatchString(line):
+			var block *Node
+			block, i = parseFence(lines, i)
+			blocks = append(blocks, block)
+		case headingPattern.MatchString(line):
+			blocks = append(blocks, parseHeading(line))
+			i++
+		case rulePattern.MatchString(line):
+			blocks = append(blocks, &Node{Type: "rule"})
+			i++
+		case quotePattern.MatchString(line):
+			var block *Node
+			block, i = parseQuote(lines, i)
+			blocks = append(blocks, block)
+		case matchListMarker(line) != nil:
+			var block *Node
+			block, i = parseList(lines, i)
+			blocks = append(blocks, block)
+		case isTableStart(lines, i):
+			var block *Node
+			block, i = parseTable(lines, i)
+			blocks = append(blocks, block)
+		default:
+			var block *Node
+			block, i = parseParagraph(lines, i)
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// interruptsParagraph reports whether line starts a block that ends the
+// paragraph before it.
+func interruptsParagraph(lines []string, i int) bool {
+	line := lines[i]
+	if isBlank(line) || fencePattern.MatchString(line) || headingPattern.MatchString(line) ||
+		rulePattern.MatchString(line) || quotePattern.MatchString(line) || isTableStart(lines, i) {
+		return true
+	}
+	marker := matchListMarker(line)
+	if marker == nil || marker.content == "" {
+		return false
+	}
+	// As in CommonMark, only lists starting at 1 interrupt a paragraph, so
+	// prose such as "2024. was a good year" stays prose.
+	return !marker.ordered || marker.number == 1
+}
+
+func parseParagraph(lines []string, start int) (*Node, int) {
+	parts := []string{strings.TrimSpace(lines[start])}
+	i := start + 1
+	for i < len(lines) && !interruptsParagraph(lines, i) {
+		parts = append(parts, strings.TrimSpace(lines[i]))
+		i++
+	}
+	return &Node{Type: "paragraph", Content: parseInline(strings.Join(parts, "\n"))}, i
+}
+
+func parseHeading(line string) *Node {
+	match := headingPattern.FindStringSubmatch(line)
+	text := headingClosePattern.ReplaceAllString(match[2], "")
+	return &Node{
+		Type:    "heading",
+		Attrs:   map[string]any{"level": len(match[1])},
+		Content: parseInline(strings.TrimSpace(text)),
+	}
+}
+
+func parseFence(lines []string, start int) (*Node, int) {
+	match := fencePattern.FindStringSubmatch(lines[start])
+	indent, fence := len(match[1]), match[2]
+	node := &Node{Type: "codeBlock"}
+	if language := strings.Fields(match[3]); len(language) > 0 {
+		node.Attrs = map[string]any{"language": language[0]}
+	}
+
+	var body []string
+	i := start + 1
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" && leadingSpaces(lines[i]) <= 3 {
+			i++
+			break
+		}
+		line := lines[i]
+		line = line[min(indent, leadingSpaces(line)):]
+		body = append(body, line)
+	}
+	if text := strings.Join(body, "\n"); text != "" {
+		node.Content = []*Node{{Type: "text", Text: text}}
+	}
+	return node, i
+}
+
+func parseQuote(lines []string, start int) (*Node, int) {
+	var inner []string
+	i := start
+	for ; i < len(lines); i++ {
+		match := quotePattern.FindStringSubmatch(lines[i])
+		if match == nil {
+			break
+		}
+		inner = append(inner, match[1])
+	}
+
+	if alert := alertPattern.FindStringSubmatch(strings.TrimSpace(inner[0])); alert != nil {
+		content := parseBlocks(inner[1:])
+		if len(content) == 0 {
+			content = []*Node{{Type: "paragraph"}}
+		}
+		return &Node{
+			Type:    "panel",
+			Attrs:   map[string]any{"panelType": alertPanels[strings.ToUpper(alert[1])]},
+			Content: content,
+		}, i
+	}
+	content := parseBlocks(inner)
+	if len(content) == 0 {
+		content = []*Node{{Type: "paragraph"}}
+	}
+	return &Node{Type: "blockquote", Content: content}, i
+}
+
+type listMarker struct {
+	ordered bool
+	number  int
+	indent  int
+	// offset is the column where the item's content starts; continuation
+	// lines indented at least this far belong to the item.
+	offset  int
+	content string
+}
+
+func matchListMarker(line string) *listMarker {
+	if match := bulletPattern.FindStringSubmatch(line); match != nil {
+		if rulePattern.MatchString(line) {
+			return nil
+		}
+		return newListMarker(false, 0, match[1], match[2], match[3], match[4])
+	}
+	if match := orderedPattern.FindStringSubmatch(line); match != nil {
+		number, _ := strconv.Atoi(match[2])
+		return newListMarker(true, number, match[1], match[2]+match[3], match[4], match[5])
+	}
+	return nil
+}
+
+func newListMarker(ordered bool, number int, indent, marker, spacing, content string) *listMarker {
+	// A marker followed by five or more spaces starts indented content; as
+	// in CommonMark the content then begins one space after the marker.
+	width := len(spacing)
+	if width == 0 || width > 4 || content == "" {
+		width = 1
+	}
+	return &listMarker{
+		ordered: ordered,
+		number:  number,
+		indent:  len(indent),
+		offset:  len(indent) + len(marker) + width,
+		content: content,
+	}
+}
+
+func parseList(lines []string, start int) (*Node, int) {
+	first := matchListMarker(lines[start])
+	list := &Node{Type: "bulletList"}
+	if first.ordered {
+		list.Type = "orderedList"
+		if first.number != 1 {
+			list.Attrs = map[string]any{"order": first.number}
+		}
+	}
+
+	i := start
+	for i < len(lines) {
+		marker := matchListMarker(lines[i])
+		if marker == nil || marker.ordered != first.ordered {
+			break
+		}
+		itemLines := []string{marker.content}
+		i++
+		for i < len(lines) {
+			line := lines[i]
+			if isBlank(line) {
+				next := i
+				for next < len(lines) && isBlank(lines[next]) {
+					next++
+				}
+				if next < len(lines) && leadingSpaces(lines[next]) >= marker.offset {
+					for ; i < next; i++ {
+						itemLines = append(itemLines, "")
+					}
+					continue
+				}
+				// A blank line between items keeps the list going.
+				if next < len(lines) {
+					if sibling := matchListMarker(lines[next]); sibling != nil && sibling.ordered == first.ordered && sibling.indent < marker.offset {
+						i = next
+					}
+				}
+				break
+			}
+			if leadingSpaces(line) >= marker.offset {
+				itemLines = append(itemLines, line[marker.offset:])
+				i++
+				continue
+			}
+			// Lazy continuation: an unindented line continues the item's
+			// paragraph unless it starts a block of its own.
+			if matchListMarker(line) == nil && !interruptsParagraph(lines, i) && !isBlank(itemLines[len(itemLines)-1]) {
+				itemLines = append(itemLines, strings.TrimSpace(line))
+				i++
+				continue
+			}
+			break
+		}
+
+		content := parseBlocks(itemLines)
+		// ADF list items must start with a paragraph or code block.
+		if len(content) == 0 || (content[0].Type != "paragraph" && content[0].Type != "codeBlock") {
+			content = append([]*Node{{Type: "paragraph"}}, content...)
+		}
+		list.Content = append(list.Content, &Node{Type: "listItem", Content: content})
+	}
+	return list, i
+}
+
+func isTableStart(lines []string, i int) bool {
+	return i+1 < len(lines) && strings.Contains(lines[i], "|") &&
+		strings.Contains(lines[i+1], "|") && tableDelimiterPattern.MatchString(lines[i+1])
+}
+
+func parseTable(lines []string, start int) (*Node, int) {
+	header := splitTableRow(lines[start])
+	columns := len(header)
+	table := &Node{Type: "table", Content: []*Node{tableRow(header, "tableHeader", columns)}}
+
+	i := start + 2
+	for ; i < len(lines) && !isBlank(lines[i]) && strings.Contains(lines[i], "|"); i++ {
+		table.Content = append(table.Content, tableRow(splitTableRow(lines[i]), "tableCell", columns))
+	}
+	return table, i
+}
+
+func tableRow(cells []string, cellType string, columns int) *Node {
+	row := &Node{Type: "tableRow"}
+	for c := 0; c < columns; c++ {
+		var text string
+		if c < len(cells) {
+			text = cells[c]
+		}
+		row.Content = append(row.Content, &Node{
+			Type:    cellType,
+			Content: []*Node{{Type: "paragraph", Content: parseInline(text)}},
+		})
+	}
+	return row
+}
+
+// splitTableRow splits a pipe table row into trimmed cells, leaving escaped
+// pipes and pipes inside code spans alone.
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			cell.WriteByte(line[i])
+			if i+1 < len(line) {
+				i++
+				cell.WriteByte(line[i])
+			}
+		case '`':
+			if end, ok := codeSpanEnd(line, i); ok {
+				cell.WriteString(line[i:end])
+				i = end - 1
+			} else {
+				cell.WriteByte(line[i])
+			}
+		case '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// parseInline parses the inline Markdown of a paragraph, heading or table
+// cell. Newlines become hard breaks.
+func parseInline(text string) []*Node {
+	var nodes []*Node
+	appendInline(&nodes, text, nil)
+	for _, node := range nodes {
+		sortMarks(node.Marks)
+	}
+	return mergeText(nodes)
+}
+
+func appendInline(out *[]*Node, s string, marks []Mark) {
+	var buf strings.Builder
+	flush := func() {
+		if buf.Len() > 0 {
+			*out = append(*out, &Node{Type: "text", Text: buf.String(), Marks: marks})
+			buf.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && s[i+1] == '\n':
+			flush()
+			*out = append(*out, &Node{Type: "hardBreak"})
+			i += 2
+			continue
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			buf.WriteByte(s[i+1])
+			i += 2
+			continue
+		case c == '\n':
+			text := strings.TrimRight(buf.String(), " \t")
+			buf.Reset()
+			buf.WriteString(text)
+			flush()
+			*out = append(*out, &Node{Type: "hardBreak"})
+			i++
+			continue
+		case c == '`':
+			if end, ok := codeSpanEnd(s, i); ok {
+				flush()
+				*out = append(*out, &Node{Type: "text", Text: codeSpanText(s[i:end]), Marks: codeMarks(marks)})
+				i = end
+				continue
+			}
+			run := runLength(s, i, '`')
+			buf.WriteString(s[i : i+run])
+			i += run
+			continue
+		case c == '@' && i+1 < len(s) && s[i+1] == '[':
+			if label, href, end, ok := linkAt(s, i+1); ok && strings.HasPrefix(strings.ToLower(href), "accountid:") {
+				flush()
+				*out = append(*out, mentionNode(href[len("accountid:"):], "@"+unescape(label)))
+				i = end
+				continue
+			}
+		case c == '[':
+			if strings.HasPrefix(strings.ToLower(s[i:]), "[~accountid:") {
+				if end := strings.IndexByte(s[i:], ']'); end > 0 {
+					flush()
+					*out = append(*out, mentionNode(s[i+len("[~accountid:"):i+end], ""))
+					i += end + 1
+					continue
+				}
+			}
+			if label, href, end, ok := linkAt(s, i); ok {
+				flush()
+				appendInline(out, label, withMark(marks, Mark{Type: "link", Attrs: map[string]any{"href": href}}))
+				i = end
+				continue
+			}
+		case c == '<':
+			if match := autolinkPattern.FindStringSubmatch(s[i:]); match != nil {
+				flush()
+				*out = append(*out, &Node{Type: "inlineCard", Attrs: map[string]any{"url": match[1]}})
+				i += len(match[0])
+				continue
+			}
+		case c == '*' || c == '_' || c == '~':
+			if end, inner, markTypes, ok := emphasisAt(s, i); ok {
+				flush()
+				next := marks
+				for _, markType := range markTypes {
+					next = withMark(next, Mark{Type: markType})
+				}
+				appendInline(out, inner, next)
+				i = end
+				continue
+			}
+			run := runLength(s, i, c)
+			buf.WriteString(s[i : i+run])
+			i += run
+			continue
+		}
+		buf.WriteByte(c)
+		i++
+	}
+	flush()
+}
+
+// emphasisAt parses a strong, em or strike span opening at s[i]. It returns
+// the index after the closing delimiter, the enclosed text and the marks to
+// apply.
+func emphasisAt(s string, i int) (int, string, []string, bool) {
+	c := s[i]
+	run := runLength(s, i, c)
+	if c == '_' && i > 0 && isAlnum(s[i-1]) {
+		return 0, "", nil, false
+	}
+
+	var width int
+	var markTypes []string
+	switch {
+	case c == '~' && run == 2:
+		width, markTypes = 2, []string{"strike"}
+	case c == '~':
+		return 0, "", nil, false
+	case run >= 3:
+		width, markTypes = 3, []string{"strong", "em"}
+	case run == 2:
+		width, markTypes = 2, []string{"strong"}
+	default:
+		width, markTypes = 1, []string{"em"}
+	}
+
+	start := i + width
+	if start >= len(s) || isSpace(s[start]) {
+		return 0, "", nil, false
+	}
+	closer := findCloser(s, start, c, width)
+	if closer < 0 {
+		return 0, "", nil, false
+	}
+	return closer + width, s[start:closer], markTypes, true
+}
+
+// findCloser finds the closing delimiter of width characters c for an
+// emphasis span whose content starts at from. Code spans, links and escaped
+// characters are skipped, and runs longer than the delimiter close with
+// their last characters so "**a *b***" nests correctly.
+func findCloser(s string, from int, c byte, width int) int {
+	for j := from; j < len(s); {
+		switch s[j] {
+		case '\\':
+			j += 2
+			continue
+		case '`':
+			if end, ok := codeSpanEnd(s, j); ok {
+				j = end
+				continue
+			}
+		case '[':
+			if _, _, end, ok := linkAt(s, j); ok {
+				j = end
+				continue
+			}
+		}
+		if s[j] != c {
+			j++
+			continue
+		}
+
+		run := runLength(s, j, c)
+		closeAt := j + run - width
+		valid := run >= width && closeAt > from && !isSpace(s[closeAt-1])
+		if width == 1 && run != 1 {
+			valid = false
+		}
+		if c == '_' && j+run < len(s) && isAlnum(s[j+run]) {
+			valid = false
+		}
+		if valid {
+			return closeAt
+		}
+		j += run
+	}
+	return -1
+}
+
+// linkAt parses "[label](href)" at s[i].
+func linkAt(s string, i int) (string, string, int, bool) {
+	if i >= len(s) || s[i] != '[' {
+		return "", "", 0, false
+	}
+	depth := 0
+	labelEnd := -1
+	for j := i; j < len(s) && labelEnd < 0; j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '`':
+			if end, ok := codeSpanEnd(s, j); ok {
+				j = end - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				labelEnd = j
+			}
+		}
+	}
+	if labelEnd < 0 || labelEnd+1 >= len(s) || s[labelEnd+1] != '(' {
+		return "", "", 0, false
+	}
+
+	depth = 0
+	for j := labelEnd + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				href := strings.TrimSpace(s[labelEnd+2 : j])
+				if href == "" || strings.ContainsAny(href, " \n") {
+					return "", "", 0, false
+				}
+				return s[i+1 : labelEnd], unescape(href), j + 1, true
+			}
+		case '\n':
+			return "", "", 0, false
+		}
+	}
+	return "", "", 0, false
+}
+
+// codeSpanEnd returns the index after the code span opening at s[i], whose
+// closing backtick run must match the opening one.
+func codeSpanEnd(s string, i int) (int, bool) {
+	run := runLength(s, i, '`')
+	for j := i + run; j < len(s); {
+		if s[j] != '`' {
+			j++
+			continue
+		}
+		closing := runLength(s, j, '`')
+		if closing == run {
+			return j + closing, true
+		}
+		j += closing
+	}
+	return 0, false
+}
+
+func codeSpanText(span string) string {
+	run := runLength(span, 0, '`')
+	text := span[run : len(span)-run]
+	text = strings.ReplaceAll(text, "\n", " ")
+	if len(text) >= 2 && text[0] == ' ' && text[len(text)-1] == ' ' && strings.TrimSpace(text) != "" {
+		text = text[1 : len(text)-1]
+	}
+	return text
+}
+
+// codeMarks keeps the marks ADF allows together with code: only links.
+func codeMarks(marks []Mark) []Mark {
+	out := []Mark{{Type: "code"}}
+	for _, mark := range marks {
+		if mark.Type == "link" {
+			out = append(out, mark)
+		}
+	}
+	return out
+}
+
+func mentionNode(id, text string) *Node {
+	attrs := map[string]any{"id": id}
+	if text != "" {
+		attrs["text"] = text
+	}
+	return &Node{Type: "mention", Attrs: attrs}
+}
+
+func withMark(marks []Mark, mark Mark) []Mark {
+	out := make([]Mark, 0, len(marks)+1)
+	for _, existing := range marks {
+		if existing.Type != mark.Type {
+			out = append(out, existing)
+		}
+	}
+	return append(out, mark)
+}
+
+// markRank orders marks canonically, outermost first, so equal formatting
+// always compares equal.
+var markRank = map[string]int{"link": 0, "strong": 1, "em": 2, "strike": 3, "code": 4}
+
+func sortMarks(marks []Mark) {
+	sort.SliceStable(marks, func(a, b int) bool {
+		return rank(marks[a].Type) < rank(marks[b].Type)
+	})
+}
+
+func rank(markType string) int {
+	if r, ok := markRank[markType]; ok {
+		return r
+	}
+	return len(markRank)
+}
+
+func mergeText(nodes []*Node) []*Node {
+	var out []*Node
+	for _, node := range nodes {
+		if n := len(out); n > 0 && node.Type == "text" && out[n-1].Type == "text" && sameMarks(out[n-1].Marks, node.Marks) {
+			out[n-1] = &Node{Type: "text", Text: out[n-1].Text + node.Text, Marks: node.Marks}
+			continue
+		}
+		out = append(out, node)
+	}
+	return out
+}
+
+func sameMarks(a, b []Mark) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !sameMark(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameMark(a, b Mark) bool {
+	if a.Type != b.Type {
+		return false
+	}
+	if a.Type == "link" {
+		return linkHref(a) == linkHref(b)
+	}
+	return true
+}
+
+func linkHref(mark Mark) string {
+	href, _ := mark.Attrs["href"].(string)
+	return href
+}
+
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func runLength(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func expandLeadingTabs(line string) string {
+	trimmed := strings.TrimLeft(line, " \t")
+	prefix := line[:len(line)-len(trimmed)]
+	if !strings.Contains(prefix, "\t") {
+		return line
+	}
+	return strings.ReplaceAll(prefix, "\t", "    ") + trimmed
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
@@ -0,0 +1,473 @@
+package adf
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// panelAlerts maps ADF panel types back to GitHub alert kinds.
+var panelAlerts = map[string]string{
+	"info":    "NOTE",
+	"note":    "IMPORTANT",
+	"success": "TIP",
+	"warning": "WARNING",
+	"error":   "CAUTION",
+}
+
+// lineStartPattern matches text that would start a block if it began a line.
+var lineStartPattern = regexp.MustCompile(`^(#{1,6}(\s|$)|>|[-+](\s|$)|\d{1,9}[.)](\s|$))`)
+
+// Render renders a description or comment body as Markdown. ADF values
+// (decoded JSON maps or Nodes) are converted; strings, as returned by Jira
+// Server, are returned unchanged. It returns "" when value is not ADF.
+func Render(value any) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+	node, err := Decode(value)
+	if err != nil {
+		return ""
+	}
+	return ToMarkdown(node)
+}
+
+// ToMarkdown renders an ADF node, usually a document, as Markdown.
+func ToMarkdown(node *Node) string {
+	if node == nil {
+		return ""
+	}
+	if isInline(node) {
+		return renderInline([]*Node{node}, false)
+	}
+	return strings.Trim(renderBlock(node), "\n")
+}
+
+func renderBlocks(nodes []*Node) string {
+	var parts []string
+	for _, node := range nodes {
+		if rendered := renderBlock(node); rendered != "" {
+			parts = append(parts, rendered)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func renderBlock(node *Node) string {
+	switch node.Type {
+	case "doc":
+		return renderBlocks(node.Content)
+	case "paragraph":
+		return renderInline(node.Content, false)
+	case "heading":
+		level := min(max(node.intAttr("level", 1), 1), 6)
+		return strings.Repeat("#", level) + " " + strings.ReplaceAll(renderInline(node.Content, false), "\n", " ")
+	case "bulletList", "orderedList":
+		return renderList(node)
+	case "codeBlock":
+		text := plainText(node)
+		fence := strings.Repeat("`", max(3, longestRun(text, '`')+1))
+		if text == "" {
+			return fence + node.attr("language") + "\n" + fence
+		}
+		return fence + node.attr("language") + "\n" + text + "\n" + fence
+	case "blockquote":
+		return quoteLines(renderBlocks(node.Content))
+	case "panel":
+		kind, ok := panelAlerts[node.attr("panelType")]
+		if !ok {
+			kind = "NOTE"
+		}
+		return quoteLines("[!" + kind + "]\n" + renderBlocks(node.Content))
+	case "rule":
+		return "---"
+	case "table":
+		return renderTable(node)
+	case "mediaSingle", "mediaGroup":
+		var parts []string
+		for _, media := range node.Content {
+			parts = append(parts, renderMedia(media))
+		}
+		return strings.Join(parts, "\n")
+	case "media":
+		return renderMedia(node)
+	case "expand", "nestedExpand":
+		title := node.attr("title")
+		if title == "" {
+			return renderBlocks(node.Content)
+		}
+		return "**" + escapeText(title, false, false) + "**\n\n" + renderBlocks(node.Content)
+	case "blockCard", "embedCard":
+		return "<" + node.attr("url") + ">"
+	case "taskList":
+		var lines []string
+		for _, item := range node.Content {
+			box := "[ ]"
+			if item.attr("state") == "DONE" {
+				box = "[x]"
+			}
+			lines = append(lines, indentItem("- "+box+" ", renderInline(item.Content, false)))
+		}
+		return strings.Join(lines, "\n")
+	case "decisionList":
+		var lines []string
+		for _, item := range node.Content {
+			lines = append(lines, indentItem("- ", renderInline(item.Content, false)))
+		}
+		return strings.Join(lines, "\n")
+	default:
+		if isInline(node) {
+			return renderInline([]*Node{node}, false)
+		}
+		return renderBlocks(node.Content)
+	}
+}
+
+func renderList(node *Node) string {
+	number := node.intAttr("order", 1)
+	var items []string
+	for _, item := range node.Content {
+		marker := "- "
+		if node.Type == "orderedList" {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+
+		var body strings.Builder
+		for i, child := range item.Content {
+			if i > 0 {
+				if child.Type == "bulletList" || child.Type == "orderedList" {
+					body.WriteString("\n")
+				} else {
+					body.WriteString("\n\n")
+				}
+			}
+			body.WriteString(renderBlock(child))
+		}
+		items = append(items, indentItem(marker, body.String()))
+	}
+	return strings.Join(items, "\n")
+}
+
+// indentItem prefixes the first line of body with marker and indents the
+// following lines to the marker's width so they stay inside the item.
+func indentItem(marker, body string) string {
+	lines := strings.Split(body, "\n")
+	indent := strings.Repeat(" ", len(marker))
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = indent + lines[i]
+		}
+	}
+	if lines[0] == "" {
+		lines[0] = strings.TrimRight(marker, " ")
+	} else {
+		lines[0] = marker + lines[0]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func quoteLines(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func renderTable(node *Node) string {
+	columns := 0
+	for _, row := range node.Content {
+		columns = max(columns, len(row.Content))
+	}
+	if columns == 0 {
+		return ""
+	}
+
+	var lines []string
+	for r, row := range node.Content {
+		cells := make([]string, columns)
+		for c, cell := range row.Content {
+			var parts []string
+			for _, block := range cell.Content {
+				if text := strings.TrimSpace(renderCell(block)); text != "" {
+					parts = append(parts, text)
+				}
+			}
+			cells[c] = strings.Join(parts, " ")
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+		// Markdown tables need a header row; the first row serves as one.
+		if r == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func renderCell(block *Node) string {
+	if block.Type == "paragraph" || block.Type == "heading" {
+		return renderInline(block.Content, true)
+	}
+	return strings.ReplaceAll(strings.ReplaceAll(renderBlock(block), "\n", " "), "|", `\|`)
+}
+
+func renderMedia(node *Node) string {
+	if alt := node.attr("alt"); alt != "" {
+		return "[attachment: " + alt + "]"
+	}
+	return "[attachment]"
+}
+
+func isInline(node *Node) bool {
+	switch node.Type {
+	case "text", "hardBreak", "mention", "emoji", "inlineCard", "status", "date", "placeholder", "mediaInline":
+		return true
+	}
+	return false
+}
+
+// renderInline renders inline nodes. Marks are opened and closed as the
+// formatting changes between nodes, so "**bold _both_ bold**" is not split
+// into separate spans, and whitespace is kept outside delimiters.
+func renderInline(nodes []*Node, inTable bool) string {
+	var b strings.Builder
+	var open []Mark
+	var delimiters []string
+	pending := ""
+	lineStart := true
+
+	closeTo := func(keep []Mark) {
+		k := 0
+		for k < len(open) && containsMark(keep, open[k]) {
+			k++
+		}
+		for i := len(open) - 1; i >= k; i-- {
+			if open[i].Type == "link" {
+				b.WriteString("](" + linkHref(open[i]) + ")")
+			} else {
+				b.WriteString(delimiters[i])
+			}
+		}
+		open, delimiters = open[:k], delimiters[:k]
+	}
+
+	for _, node := range nodes {
+		if node.Type != "text" {
+			closeTo(nil)
+			b.WriteString(pending)
+			pending = ""
+			if node.Type == "hardBreak" {
+				if inTable {
+					b.WriteString(" ")
+				} else {
+					b.WriteString("\n")
+					lineStart = true
+				}
+				continue
+			}
+			b.WriteString(renderInlineNode(node))
+			lineStart = false
+			continue
+		}
+
+		marks := renderableMarks(node.Marks)
+		code := hasMark(marks, "code")
+		outer := withoutMark(marks, "code")
+		text := node.Text
+		if strings.TrimSpace(text) == "" && !code {
+			closeTo(outer)
+			pending += text
+			continue
+		}
+
+		closeTo(outer)
+		b.WriteString(pending)
+		pending = ""
+		if len(outer) > len(open) && !code {
+			trimmed := strings.TrimLeft(text, " \t")
+			b.WriteString(text[:len(text)-len(trimmed)])
+			text = trimmed
+		}
+		for _, mark := range outer {
+			if !containsMark(open, mark) {
+				delimiter := openingDelimiter(mark)
+				// "_" does not open emphasis inside a word; "*" does.
+				if current := b.String(); mark.Type == "em" && current != "" && isAlnum(current[len(current)-1]) {
+					delimiter = "*"
+				}
+				b.WriteString(delimiter)
+				open, delimiters = append(open, mark), append(delimiters, delimiter)
+				lineStart = false
+			}
+		}
+
+		if code {
+			b.WriteString(codeSpan(text))
+			lineStart = false
+			continue
+		}
+		trimmed := strings.TrimRight(text, " \t")
+		if len(open) > 0 {
+			pending = text[len(trimmed):]
+			text = trimmed
+		}
+		b.WriteString(escapeText(text, lineStart, inTable))
+		lineStart = false
+	}
+	closeTo(nil)
+	b.WriteString(pending)
+	return b.String()
+}
+
+func renderInlineNode(node *Node) string {
+	switch node.Type {
+	case "mention":
+		name := strings.TrimPrefix(node.attr("text"), "@")
+		if name == "" {
+			return "[~accountid:" + node.attr("id") + "]"
+		}
+		return "@[" + escapeText(name, false, false) + "](accountid:" + node.attr("id") + ")"
+	case "emoji":
+		if text := node.attr("text"); text != "" {
+			return text
+		}
+		return node.attr("shortName")
+	case "inlineCard":
+		return "<" + node.attr("url") + ">"
+	case "status":
+		return "[" + node.attr("text") + "]"
+	case "date":
+		if ms, err := strconv.ParseInt(node.attr("timestamp"), 10, 64); err == nil {
+			return time.UnixMilli(ms).UTC().Format(time.DateOnly)
+		}
+		return node.attr("timestamp")
+	case "placeholder":
+		return node.attr("text")
+	case "mediaInline":
+		return renderMedia(node)
+	default:
+		return renderInline(node.Content, false)
+	}
+}
+
+func renderableMarks(marks []Mark) []Mark {
+	var out []Mark
+	for _, mark := range marks {
+		if _, ok := markRank[mark.Type]; ok {
+			out = append(out, mark)
+		}
+	}
+	sortMarks(out)
+	return out
+}
+
+func openingDelimiter(mark Mark) string {
+	switch mark.Type {
+	case "link":
+		return "["
+	case "strong":
+		return "**"
+	case "em":
+		return "_"
+	case "strike":
+		return "~~"
+	}
+	return ""
+}
+
+func codeSpan(text string) string {
+	fence := strings.Repeat("`", longestRun(text, '`')+1)
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") ||
+		(strings.HasPrefix(text, " ") && strings.HasSuffix(text, " ") && strings.TrimSpace(text) != "") {
+		return fence + " " + text + " " + fence
+	}
+	return fence + text + fence
+}
+
+// escapeText backslash-escapes the characters of text that the encoder
+// would otherwise read as Markdown. Underscores inside words and lone
+// tildes are left alone to keep identifiers readable.
+func escapeText(text string, lineStart, inTable bool) string {
+	var b strings.Builder
+	if lineStart {
+		if match := lineStartPattern.FindString(text); match != "" {
+			if match[0] >= '0' && match[0] <= '9' {
+				digits := strings.TrimRight(match, ".) \t")
+				b.WriteString(digits + `\`)
+				text = text[len(digits):]
+			} else {
+				b.WriteString(`\`)
+			}
+		}
+	}
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == '\\' || c == '*' || c == '`' || c == '[' || c == ']':
+			b.WriteByte('\\')
+		case c == '_' && !(i > 0 && isAlnum(text[i-1]) && i+1 < len(text) && isAlnum(text[i+1])):
+			b.WriteByte('\\')
+		case c == '~' && (i+1 < len(text) && text[i+1] == '~' || i > 0 && text[i-1] == '~'):
+			b.WriteByte('\\')
+		case c == '<' && autolinkPattern.MatchString(text[i:]):
+			b.WriteByte('\\')
+		case c == '|' && inTable:
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func plainText(node *Node) string {
+	var b strings.Builder
+	Walk(node, func(n *Node) bool {
+		b.WriteString(n.Text)
+		return true
+	})
+	return b.String()
+}
+
+func longestRun(text string, c byte) int {
+	longest := 0
+	for i := 0; i < len(text); i++ {
+		if text[i] == c {
+			longest = max(longest, runLength(text, i, c))
+		}
+	}
+	return longest
+}
+
+func hasMark(marks []Mark, markType string) bool {
+	for _, mark := range marks {
+		if mark.Type == markType {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutMark(marks []Mark, markType string) []Mark {
+	var out []Mark
+	for _, mark := range marks {
+		if mark.Type != markType {
+			out = append(out, mark)
+		}
+	}
+	return out
+}
+
+func containsMark(marks []Mark, mark Mark) bool {
+	for _, m := range marks {
+		if sameMark(m, mark) {
+			return true
+		}
+	}
+	return false
+}
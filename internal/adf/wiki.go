@@ -0,0 +1,289 @@
+package adf
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// panelMacros maps ADF panel types to the wiki markup macros closest to them.
+var panelMacros = map[string]string{
+	"info":    "info",
+	"note":    "note",
+	"success": "tip",
+	"warning": "warning",
+	"error":   "warning",
+}
+
+// wikiMarks maps ADF marks to the wiki markup delimiters around marked text.
+var wikiMarks = map[string]string{
+	"strong":    "*",
+	"em":        "_",
+	"strike":    "-",
+	"underline": "+",
+}
+
+// ToWiki renders an ADF node, usually a document, as the wiki markup Jira
+// Server and Data Center use for descriptions and comments. Nodes wiki markup
+// cannot express are rendered as readable text, as ToMarkdown does.
+func ToWiki(node *Node) string {
+	if node == nil {
+		return ""
+	}
+	if isInline(node) {
+		return wikiInline([]*Node{node}, false)
+	}
+	return strings.Trim(wikiBlock(node, ""), "\n")
+}
+
+// MarkdownToWiki converts the Markdown FromMarkdown accepts to wiki markup.
+func MarkdownToWiki(markdown string) string {
+	return ToWiki(FromMarkdown(markdown))
+}
+
+func wikiBlocks(nodes []*Node) string {
+	var parts []string
+	for _, node := range nodes {
+		if rendered := wikiBlock(node, ""); rendered != "" {
+			parts = append(parts, rendered)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// wikiBlock renders a block. listPrefix holds the markers of the enclosing
+// lists, since wiki markup nests lists by repeating them ("**", "*#").
+func wikiBlock(node *Node, listPrefix string) string {
+	switch node.Type {
+	case "doc":
+		return wikiBlocks(node.Content)
+	case "paragraph":
+		text := wikiInline(node.Content, false)
+		// A leading "#" would start a numbered list.
+		if strings.HasPrefix(text, "#") {
+			return `\` + text
+		}
+		return text
+	case "heading":
+		level := min(max(node.intAttr("level", 1), 1), 6)
+		return "h" + strconv.Itoa(level) + ". " + strings.ReplaceAll(wikiInline(node.Content, false), "\n", " ")
+	case "bulletList", "orderedList":
+		return wikiList(node, listPrefix)
+	case "codeBlock":
+		if language := node.attr("language"); language != "" {
+			return "{code:" + language + "}\n" + plainText(node) + "\n{code}"
+		}
+		return "{code}\n" + plainText(node) + "\n{code}"
+	case "blockquote":
+		return "{quote}\n" + wikiBlocks(node.Content) + "\n{quote}"
+	case "panel":
+		macro, ok := panelMacros[node.attr("panelType")]
+		if !ok {
+			macro = "info"
+		}
+		return "{" + macro + "}\n" + wikiBlocks(node.Content) + "\n{" + macro + "}"
+	case "rule":
+		return "----"
+	case "table":
+		return wikiTable(node)
+	case "mediaSingle", "mediaGroup":
+		var parts []string
+		for _, media := range node.Content {
+			parts = append(parts, wikiMedia(media))
+		}
+		return strings.Join(parts, "\n")
+	case "media":
+		return wikiMedia(node)
+	case "expand", "nestedExpand":
+		title := node.attr("title")
+		if title == "" {
+			return wikiBlocks(node.Content)
+		}
+		return "*" + escapeWiki(title) + "*\n\n" + wikiBlocks(node.Content)
+	case "blockCard", "embedCard":
+		return "[" + node.attr("url") + "]"
+	case "taskList", "decisionList":
+		var lines []string
+		for _, item := range node.Content {
+			box := ""
+			if node.Type == "taskList" && item.attr("state") == "DONE" {
+				box = "(/) "
+			}
+			lines = append(lines, listPrefix+"* "+box+wikiInline(item.Content, false))
+		}
+		return strings.Join(lines, "\n")
+	default:
+		if isInline(node) {
+			return wikiInline([]*Node{node}, false)
+		}
+		return wikiBlocks(node.Content)
+	}
+}
+
+func wikiList(node *Node, listPrefix string) string {
+	marker := listPrefix + "*"
+	if node.Type == "orderedList" {
+		marker = listPrefix + "#"
+	}
+	var lines []string
+	for _, item := range node.Content {
+		var text []string
+		var nested []string
+		for _, child := range item.Content {
+			switch child.Type {
+			case "bulletList", "orderedList":
+				nested = append(nested, wikiList(child, marker))
+			default:
+				// Wiki list items are single lines; later paragraphs
+				// continue the item after a line break.
+				text = append(text, strings.ReplaceAll(wikiBlock(child, marker), "\n", " \\\\ "))
+			}
+		}
+		lines = append(lines, marker+" "+strings.Join(text, " \\\\ "))
+		lines = append(lines, nested...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func wikiTable(node *Node) string {
+	var lines []string
+	for _, row := range node.Content {
+		var b strings.Builder
+		delimiter := "|"
+		for _, cell := range row.Content {
+			delimiter = "|"
+			if cell.Type == "tableHeader" {
+				delimiter = "||"
+			}
+			var parts []string
+			for _, block := range cell.Content {
+				if text := strings.TrimSpace(wikiCell(block)); text != "" {
+					parts = append(parts, text)
+				}
+			}
+			content := strings.Join(parts, " ")
+			if content == "" {
+				content = " "
+			}
+			b.WriteString(delimiter + content)
+		}
+		if b.Len() > 0 {
+			b.WriteString(delimiter)
+		}
+		lines = append(lines, b.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func wikiCell(block *Node) string {
+	if block.Type == "paragraph" || block.Type == "heading" {
+		return wikiInline(block.Content, true)
+	}
+	return strings.ReplaceAll(wikiBlock(block, ""), "\n", " ")
+}
+
+func wikiMedia(node *Node) string {
+	if alt := node.attr("alt"); alt != "" {
+		return "!" + alt + "!"
+	}
+	return "[attachment]"
+}
+
+// wikiInline renders inline nodes. Unlike Markdown, wiki markup only opens
+// formatting at word boundaries, so each text node is wrapped on its own
+// with the surrounding whitespace kept outside the delimiters.
+func wikiInline(nodes []*Node, inTable bool) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		switch node.Type {
+		case "text":
+			b.WriteString(wikiText(node))
+		case "hardBreak":
+			if inTable {
+				b.WriteString(" ")
+			} else {
+				b.WriteString("\n")
+			}
+		default:
+			b.WriteString(wikiInlineNode(node))
+		}
+	}
+	return b.String()
+}
+
+func wikiText(node *Node) string {
+	marks := renderableMarks(node.Marks)
+	core := strings.TrimSpace(node.Text)
+	if core == "" {
+		return node.Text
+	}
+	start := strings.Index(node.Text, core)
+	lead, trail := node.Text[:start], node.Text[start+len(core):]
+
+	if hasMark(marks, "code") {
+		core = "{{" + core + "}}"
+	} else {
+		core = escapeWiki(core)
+	}
+	for _, mark := range node.Marks {
+		if delimiter, ok := wikiMarks[mark.Type]; ok {
+			core = delimiter + core + delimiter
+		}
+	}
+	for _, mark := range marks {
+		if mark.Type == "link" {
+			core = "[" + core + "|" + linkHref(mark) + "]"
+		}
+	}
+	return lead + core + trail
+}
+
+func wikiInlineNode(node *Node) string {
+	switch node.Type {
+	case "mention":
+		// Server and Data Center identify users by name, which is what
+		// mentions written for them carry as their ID.
+		return "[~" + node.attr("id") + "]"
+	case "emoji":
+		if text := node.attr("text"); text != "" {
+			return text
+		}
+		return node.attr("shortName")
+	case "inlineCard":
+		return "[" + node.attr("url") + "]"
+	case "status":
+		return "*" + escapeWiki("["+node.attr("text")+"]") + "*"
+	case "date":
+		if ms, err := strconv.ParseInt(node.attr("timestamp"), 10, 64); err == nil {
+			return time.UnixMilli(ms).UTC().Format(time.DateOnly)
+		}
+		return node.attr("timestamp")
+	case "placeholder":
+		return escapeWiki(node.attr("text"))
+	case "mediaInline":
+		return wikiMedia(node)
+	default:
+		return wikiInline(node.Content, false)
+	}
+}
+
+// escapeWiki backslash-escapes the characters of text that wiki markup would
+// read as formatting. Brackets, braces, pipes and bangs are always escaped;
+// the emphasis characters only where they could open or close a span, so
+// "re-run" and snake_case stay readable.
+func escapeWiki(text string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch c {
+		case '\\', '[', ']', '{', '}', '|', '!':
+			b.WriteByte('\\')
+		case '*', '_', '-', '+', '^', '~':
+			if !(i > 0 && isAlnum(text[i-1]) && i+1 < len(text) && isAlnum(text[i+1])) {
+				b.WriteByte('\\')
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -2,6 +2,7 @@ package bitbucket
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -156,74 +157,69 @@ func NewClient(cfg *config.BitbucketConfig) *Client {
 	}
 }
 
-func (c *Client) makeRequest(method, endpoint string, body interface{}) (*http.Response, error) {
-	return c.makeRequestWithRetry(method, endpoint, body, 3)
-}
-
-func (c *Client) makeRequestWithRetry(method, endpoint string, body interface{}, maxRetries int) (*http.Response, error) {
+// makeRequest sends an authenticated request. Transient failures and 429
+// responses are retried by the shared httpx transport; a request that is
+// still rate limited after those retries is reported as an error.
+func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
 	url := fmt.Sprintf("%s/%s", c.baseURL, endpoint)
 
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		// Rate limiting
-		if c.rateLimiter != nil {
-			<-c.rateLimiter
-		}
-
-		var reqBody io.Reader
-		if body != nil {
-			jsonBody, err := json.Marshal(body)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal request body: %w", err)
-			}
-			reqBody = bytes.NewReader(jsonBody)
-		}
+	// Rate limiting applies only to requests that reach the network, so
+	// responses served from the httpx cache are not throttled.
+	if c.rateLimiter != nil {
+		ctx = httpx.WithGate(ctx, c.waitForSlot)
+	}
 
-		req, err := http.NewRequest(method, url, reqBody)
+	var reqBody io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
+		reqBody = bytes.NewReader(jsonBody)
+	}
 
-		// Authentication selection:
-		// - If a username (email) is configured, prefer Basic (personal API token)
-		// - If no username, assume a resource access token and use Bearer
-		// No automatic fallback to avoid masking misconfiguration.
-		httpx.ApplyAuth(req, c.config.Username, c.config.Token)
-		req.Header.Set("Accept", "application/json")
-		req.Header.Set("Content-Type", "application/json")
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
 
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("failed to make request: %w", err)
-		}
+	// Authentication selection:
+	// - If a username (email) is configured, prefer Basic (personal API token)
+	// - If no username, assume a resource access token and use Bearer
+	// No automatic fallback to avoid masking misconfiguration.
+	httpx.ApplyAuth(req, c.config.Username, c.config.Token)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
 
-		// Handle rate limiting
-		if resp.StatusCode == 429 {
-			if err := resp.Body.Close(); err != nil {
-				fmt.Printf("warning: failed to close response body: %v\n", err)
-			}
-			if attempt < maxRetries-1 {
-				// Exponential backoff: wait longer between retries
-				waitTime := time.Duration(attempt+1) * 2 * time.Second
-				fmt.Printf("Rate limited, waiting %v before retry %d/%d...\n", waitTime, attempt+1, maxRetries-1)
-				time.Sleep(waitTime)
-				continue
-			}
-			return nil, fmt.Errorf("rate limit exceeded after %d retries", maxRetries)
-		}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
 
-		// Return early on HTTP errors (leave body for caller to inspect)
-		if resp.StatusCode >= 400 {
-			return resp, nil
+	if resp.StatusCode == http.StatusTooManyRequests {
+		body, _ := io.ReadAll(resp.Body)
+		if err := resp.Body.Close(); err != nil {
+			fmt.Printf("warning: failed to close response body: %v\n", err)
 		}
-
-		return resp, nil
+		return nil, fmt.Errorf("rate limit exceeded: %w", httpx.NewAPIError(httpx.ServiceBitbucket, resp, body))
 	}
 
-	return nil, fmt.Errorf("max retries exceeded")
+	// HTTP errors are returned as is; the caller inspects the body.
+	return resp, nil
 }
 
-// TestAuth tests basic authentication with a simple API call
-func (c *Client) TestAuth() error {
+// waitForSlot blocks until the rate limiter allows another request.
+func (c *Client) waitForSlot(ctx context.Context) error {
+	select {
+	case <-c.rateLimiter:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TestAuthContext tests basic authentication with a simple API call
+func (c *Client) TestAuthContext(ctx context.Context) error {
 	// Try endpoints that match the user's scopes
 	endpoints := []string{"workspaces"}
 	if c.config.Workspace != "" {
@@ -231,7 +227,7 @@ func (c *Client) TestAuth() error {
 	}
 
 	for _, endpoint := range endpoints {
-		resp, err := c.makeRequest("GET", endpoint, nil)
+		resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 		if err != nil {
 			continue
 		}
@@ -249,11 +245,64 @@ func (c *Client) TestAuth() error {
 	return fmt.Errorf("authentication test failed")
 }
 
-// TestBasicAuth tests authentication using Basic auth instead of Bearer
-func (c *Client) TestBasicAuth() error {
+// TestAuth calls TestAuthContext with a background context.
+func (c *Client) TestAuth() error {
+	return c.TestAuthContext(context.Background())
+}
+
+// CheckAuthContext verifies the configured credentials and reports the
+// authenticated user. Workspace and repository access tokens cannot read
+// /user, so they fall back to TestAuth and report the missing account scope.
+func (c *Client) CheckAuthContext(ctx context.Context) (*httpx.AuthReport, error) {
+	report := &httpx.AuthReport{Scheme: httpx.AuthScheme(c.config.Username, c.config.Token)}
+
+	resp, err := c.makeRequest(ctx, "GET", "user", nil)
+	if err != nil {
+		return report, err
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if err := resp.Body.Close(); err != nil {
+		fmt.Printf("warning: failed to close response body: %v\n", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var user struct {
+			DisplayName string `json:"display_name"`
+			Nickname    string `json:"nickname"`
+		}
+		if err := json.Unmarshal(body, &user); err != nil {
+			return report, fmt.Errorf("failed to decode response: %w", err)
+		}
+		report.Identity = user.DisplayName
+		if user.Nickname != "" && user.Nickname != user.DisplayName {
+			report.Identity = fmt.Sprintf("%s (%s)", user.DisplayName, user.Nickname)
+		}
+		return report, nil
+	case http.StatusForbidden:
+		authErr := httpx.NewAuthError(resp, body)
+		if err := c.TestAuthContext(ctx); err != nil {
+			return report, authErr
+		}
+		report.MissingScopes = authErr.MissingScopes
+		return report, nil
+	case http.StatusUnauthorized:
+		return report, httpx.NewAuthError(resp, body)
+	default:
+		return report, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
+	}
+}
+
+// CheckAuth calls CheckAuthContext with a background context.
+func (c *Client) CheckAuth() (*httpx.AuthReport, error) {
+	return c.CheckAuthContext(context.Background())
+}
+
+// TestBasicAuthContext tests authentication using Basic auth instead of Bearer
+func (c *Client) TestBasicAuthContext(ctx context.Context) error {
 	// Create a separate request with Basic auth
 	url := fmt.Sprintf("%s/workspaces", c.baseURL)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to make request: %w", err)
 	}
@@ -284,3 +333,8 @@ func (c *Client) TestBasicAuth() error {
 
 	return fmt.Errorf("basic auth test failed with status: %d", resp.StatusCode)
 }
+
+// TestBasicAuth calls TestBasicAuthContext with a background context.
+func (c *Client) TestBasicAuth() error {
+	return c.TestBasicAuthContext(context.Background())
+}
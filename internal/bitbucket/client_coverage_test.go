@@ -1,6 +1,7 @@
 package bitbucket
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -30,7 +31,7 @@ func TestMakeRequest_WithBody(t *testing.T) {
 	c.baseURL = server.URL
 
 	payload := map[string]string{"key": "value"}
-	resp, err := c.makeRequest("POST", "test", payload)
+	resp, err := c.makeRequest(context.Background(), "POST", "test", payload)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -63,7 +64,7 @@ func TestMakeRequest_BasicAuth(t *testing.T) {
 	c.rateLimiter = nil
 	c.baseURL = server.URL
 
-	resp, err := c.makeRequest("GET", "test", nil)
+	resp, err := c.makeRequest(context.Background(), "GET", "test", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -97,7 +98,7 @@ func TestMakeRequest_BearerAuth(t *testing.T) {
 	c.rateLimiter = nil
 	c.baseURL = server.URL
 
-	resp, err := c.makeRequest("GET", "test", nil)
+	resp, err := c.makeRequest(context.Background(), "GET", "test", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -130,7 +131,7 @@ func TestMakeRequest_HTTPError_4xx(t *testing.T) {
 	c.rateLimiter = nil
 	c.baseURL = server.URL
 
-	resp, err := c.makeRequest("GET", "test", nil)
+	resp, err := c.makeRequest(context.Background(), "GET", "test", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -860,7 +861,7 @@ func TestGetFirstPageWithTotal_WithNext(t *testing.T) {
 	c.rateLimiter = nil
 	c.baseURL = server.URL
 
-	repos, total, err := c.getFirstPageWithTotal(10)
+	repos, total, err := c.getFirstPageWithTotal(context.Background(), 10)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -884,7 +885,7 @@ func TestGetFirstPageWithTotal_Error(t *testing.T) {
 	c.rateLimiter = nil
 	c.baseURL = server.URL
 
-	_, _, err := c.getFirstPageWithTotal(10)
+	_, _, err := c.getFirstPageWithTotal(context.Background(), 10)
 	if err == nil {
 		t.Fatalf("expected error for 500 response")
 	}
@@ -902,8 +903,56 @@ func TestGetTotalRepositoryCount_Error(t *testing.T) {
 	c.rateLimiter = nil
 	c.baseURL = server.URL
 
-	_, err := c.getTotalRepositoryCount(10)
+	_, err := c.getTotalRepositoryCount(context.Background(), 10)
 	if err == nil {
 		t.Fatalf("expected error for 500 response")
 	}
 }
+
+func TestCheckAuth_ReportsUser(t *testing.T) {
+	server := newTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"display_name":"Alice","nickname":"alice"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(&config.BitbucketConfig{Username: "alice@example.com", Token: "token"})
+	c.rateLimiter = nil
+	c.baseURL = server.URL
+
+	report, err := c.CheckAuth()
+	if err != nil {
+		t.Fatalf("CheckAuth failed: %v", err)
+	}
+	if report.Identity != "Alice (alice)" || report.Scheme != "Basic" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestCheckAuth_AccessTokenWithoutAccountScope(t *testing.T) {
+	server := newTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/user" {
+			w.Header().Set("X-Accepted-OAuth-Scopes", "account")
+			w.Header().Set("X-OAuth-Scopes", "repository, pullrequest")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"type":"error","error":{"message":"Access token lacks scope"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(&config.BitbucketConfig{Workspace: "w", Token: "token"})
+	c.rateLimiter = nil
+	c.baseURL = server.URL
+
+	report, err := c.CheckAuth()
+	if err != nil {
+		t.Fatalf("CheckAuth failed: %v", err)
+	}
+	if report.Scheme != "Bearer" || len(report.MissingScopes) != 1 || report.MissingScopes[0] != "account" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
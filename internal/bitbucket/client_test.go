@@ -1,6 +1,7 @@
 package bitbucket
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 	"testing"
 
 	"devflow/internal/config"
+	"devflow/internal/httpx"
 )
 
 // fakeTransport allows test code to return custom responses per request.
@@ -30,7 +32,7 @@ func (f *fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	return &http.Response{StatusCode: 500, Body: io.NopCloser(strings.NewReader("")), Header: http.Header{}}, nil
 }
 
-func TestMakeRequestWithRetry_RateLimitExceeded(t *testing.T) {
+func TestMakeRequest_RateLimitExceeded(t *testing.T) {
 	cfg := &config.BitbucketConfig{Workspace: "w"}
 	c := NewClient(cfg)
 	// disable rate limiter for tests
@@ -40,15 +42,18 @@ func TestMakeRequestWithRetry_RateLimitExceeded(t *testing.T) {
 		// always return 429
 		return &http.Response{StatusCode: 429, Body: io.NopCloser(strings.NewReader("")), Header: http.Header{}}
 	}}
-	c.httpClient = &http.Client{Transport: ft}
+	c.httpClient = &http.Client{Transport: httpx.NewRetryTransport(ft, httpx.RetryPolicy{MaxRetries: 1})}
 
-	_, err := c.makeRequestWithRetry("GET", "some/endpoint", nil, 2)
+	_, err := c.makeRequest(context.Background(), "GET", "some/endpoint", nil)
 	if err == nil || !strings.Contains(err.Error(), "rate limit exceeded") {
 		t.Fatalf("expected rate limit exceeded error, got: %v", err)
 	}
+	if ft.calls != 2 {
+		t.Fatalf("expected one retry, got %d calls", ft.calls)
+	}
 }
 
-func TestMakeRequestWithRetry_SucceedsAfterRetry(t *testing.T) {
+func TestMakeRequest_SucceedsAfterRetry(t *testing.T) {
 	cfg := &config.BitbucketConfig{Workspace: "w"}
 	c := NewClient(cfg)
 	c.rateLimiter = nil
@@ -59,9 +64,9 @@ func TestMakeRequestWithRetry_SucceedsAfterRetry(t *testing.T) {
 		}
 		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("ok")), Header: http.Header{"Content-Type": {"application/json"}}}
 	}}
-	c.httpClient = &http.Client{Transport: ft}
+	c.httpClient = &http.Client{Transport: httpx.NewRetryTransport(ft, httpx.RetryPolicy{MaxRetries: 1})}
 
-	resp, err := c.makeRequestWithRetry("GET", "some/endpoint", nil, 2)
+	resp, err := c.makeRequest(context.Background(), "GET", "some/endpoint", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -175,7 +180,7 @@ func TestGetTotalRepositoryCount_NoNext(t *testing.T) {
 	}}
 	c.httpClient = &http.Client{Transport: ft}
 
-	count, err := c.getTotalRepositoryCount(10)
+	count, err := c.getTotalRepositoryCount(context.Background(), 10)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -195,7 +200,7 @@ func TestGetTotalRepositoryCount_WithNext(t *testing.T) {
 	}}
 	c.httpClient = &http.Client{Transport: ft}
 
-	count, err := c.getTotalRepositoryCount(10)
+	count, err := c.getTotalRepositoryCount(context.Background(), 10)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1,20 +1,23 @@
 package bitbucket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+
+	"devflow/internal/httpx"
 )
 
-// GetPullRequestComments retrieves all comments for a given pull request.
-func (c *Client) GetPullRequestComments(repoSlug string, prID int) ([]Comment, error) {
+// GetPullRequestCommentsContext retrieves all comments for a given pull request.
+func (c *Client) GetPullRequestCommentsContext(ctx context.Context, repoSlug string, prID int) ([]Comment, error) {
 	endpoint := fmt.Sprintf("repositories/%s/%s/pullrequests/%d/comments", c.config.Workspace, repoSlug, prID)
 
 	var allComments []Comment
 	for endpoint != "" {
-		resp, err := c.makeRequest("GET", endpoint, nil)
+		resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to make request: %w", err)
 		}
@@ -26,7 +29,7 @@ func (c *Client) GetPullRequestComments(repoSlug string, prID int) ([]Comment, e
 
 		if resp.StatusCode != http.StatusOK {
 			body, _ := io.ReadAll(resp.Body)
-			return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+			return nil, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 		}
 
 		var commentsResp CommentsResponse
@@ -46,8 +49,13 @@ func (c *Client) GetPullRequestComments(repoSlug string, prID int) ([]Comment, e
 	return allComments, nil
 }
 
-// CreatePullRequestComment adds a new comment to a pull request.
-func (c *Client) CreatePullRequestComment(repoSlug string, prID int, content string) (*Comment, error) {
+// GetPullRequestComments calls GetPullRequestCommentsContext with a background context.
+func (c *Client) GetPullRequestComments(repoSlug string, prID int) ([]Comment, error) {
+	return c.GetPullRequestCommentsContext(context.Background(), repoSlug, prID)
+}
+
+// CreatePullRequestCommentContext adds a new comment to a pull request.
+func (c *Client) CreatePullRequestCommentContext(ctx context.Context, repoSlug string, prID int, content string) (*Comment, error) {
 	endpoint := fmt.Sprintf("repositories/%s/%s/pullrequests/%d/comments", c.config.Workspace, repoSlug, prID)
 
 	payload := map[string]interface{}{
@@ -56,7 +64,7 @@ func (c *Client) CreatePullRequestComment(repoSlug string, prID int, content str
 		},
 	}
 
-	resp, err := c.makeRequest("POST", endpoint, payload)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -70,7 +78,7 @@ func (c *Client) CreatePullRequestComment(repoSlug string, prID int, content str
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+		return nil, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 	}
 
 	var comment Comment
@@ -81,8 +89,13 @@ func (c *Client) CreatePullRequestComment(repoSlug string, prID int, content str
 	return &comment, nil
 }
 
-// CreatePullRequestInlineComment adds an inline comment anchored to a specific file and line in a pull request diff.
-func (c *Client) CreatePullRequestInlineComment(repoSlug string, prID int, content string, filePath string, line int) (*Comment, error) {
+// CreatePullRequestComment calls CreatePullRequestCommentContext with a background context.
+func (c *Client) CreatePullRequestComment(repoSlug string, prID int, content string) (*Comment, error) {
+	return c.CreatePullRequestCommentContext(context.Background(), repoSlug, prID, content)
+}
+
+// CreatePullRequestInlineCommentContext adds an inline comment anchored to a specific file and line in a pull request diff.
+func (c *Client) CreatePullRequestInlineCommentContext(ctx context.Context, repoSlug string, prID int, content string, filePath string, line int) (*Comment, error) {
 	endpoint := fmt.Sprintf("repositories/%s/%s/pullrequests/%d/comments", c.config.Workspace, repoSlug, prID)
 
 	payload := map[string]interface{}{
@@ -95,7 +108,7 @@ func (c *Client) CreatePullRequestInlineComment(repoSlug string, prID int, conte
 		},
 	}
 
-	resp, err := c.makeRequest("POST", endpoint, payload)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -107,7 +120,7 @@ func (c *Client) CreatePullRequestInlineComment(repoSlug string, prID int, conte
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+		return nil, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 	}
 
 	var comment Comment
@@ -118,8 +131,13 @@ func (c *Client) CreatePullRequestInlineComment(repoSlug string, prID int, conte
 	return &comment, nil
 }
 
-// ReplyToPullRequestComment replies to a specific comment thread.
-func (c *Client) ReplyToPullRequestComment(repoSlug string, prID int, parentCommentID int, content string) (*Comment, error) {
+// CreatePullRequestInlineComment calls CreatePullRequestInlineCommentContext with a background context.
+func (c *Client) CreatePullRequestInlineComment(repoSlug string, prID int, content string, filePath string, line int) (*Comment, error) {
+	return c.CreatePullRequestInlineCommentContext(context.Background(), repoSlug, prID, content, filePath, line)
+}
+
+// ReplyToPullRequestCommentContext replies to a specific comment thread.
+func (c *Client) ReplyToPullRequestCommentContext(ctx context.Context, repoSlug string, prID int, parentCommentID int, content string) (*Comment, error) {
 	endpoint := fmt.Sprintf("repositories/%s/%s/pullrequests/%d/comments", c.config.Workspace, repoSlug, prID)
 
 	payload := map[string]interface{}{
@@ -131,7 +149,7 @@ func (c *Client) ReplyToPullRequestComment(repoSlug string, prID int, parentComm
 		},
 	}
 
-	resp, err := c.makeRequest("POST", endpoint, payload)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -145,7 +163,7 @@ func (c *Client) ReplyToPullRequestComment(repoSlug string, prID int, parentComm
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+		return nil, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 	}
 
 	var comment Comment
@@ -156,3 +174,7 @@ func (c *Client) ReplyToPullRequestComment(repoSlug string, prID int, parentComm
 	return &comment, nil
 }
 
+// ReplyToPullRequestComment calls ReplyToPullRequestCommentContext with a background context.
+func (c *Client) ReplyToPullRequestComment(repoSlug string, prID int, parentCommentID int, content string) (*Comment, error) {
+	return c.ReplyToPullRequestCommentContext(context.Background(), repoSlug, prID, parentCommentID, content)
+}
@@ -1,6 +1,7 @@
 package bitbucket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,14 +11,14 @@ import (
 	"devflow/internal/httpx"
 )
 
-// GetPipelines returns a list of pipelines for the given repository.
+// GetPipelinesContext returns a list of pipelines for the given repository.
 // Results are sorted by build number descending (newest first) and limited by the pagelen param.
-func (c *Client) GetPipelines(repoSlug string, limit int) ([]Pipeline, error) {
+func (c *Client) GetPipelinesContext(ctx context.Context, repoSlug string, limit int) ([]Pipeline, error) {
 	endpoint := fmt.Sprintf("repositories/%s/%s/pipelines/?sort=-created_on&pagelen=%d", c.config.Workspace, repoSlug, limit)
 
 	var allPipelines []Pipeline
 	for endpoint != "" {
-		resp, err := c.makeRequest("GET", endpoint, nil)
+		resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to make request: %w", err)
 		}
@@ -27,7 +28,7 @@ func (c *Client) GetPipelines(repoSlug string, limit int) ([]Pipeline, error) {
 			if err := resp.Body.Close(); err != nil {
 				fmt.Printf("warning: failed to close response body: %v\n", err)
 			}
-			return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+			return nil, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 		}
 
 		var pipelinesResp PipelinesResponse
@@ -56,11 +57,16 @@ func (c *Client) GetPipelines(repoSlug string, limit int) ([]Pipeline, error) {
 	return allPipelines, nil
 }
 
-// GetPipeline returns details for a single pipeline by its UUID or build number string.
-func (c *Client) GetPipeline(repoSlug, pipelineUUID string) (*Pipeline, error) {
+// GetPipelines calls GetPipelinesContext with a background context.
+func (c *Client) GetPipelines(repoSlug string, limit int) ([]Pipeline, error) {
+	return c.GetPipelinesContext(context.Background(), repoSlug, limit)
+}
+
+// GetPipelineContext returns details for a single pipeline by its UUID or build number string.
+func (c *Client) GetPipelineContext(ctx context.Context, repoSlug, pipelineUUID string) (*Pipeline, error) {
 	endpoint := fmt.Sprintf("repositories/%s/%s/pipelines/%s", c.config.Workspace, repoSlug, pipelineUUID)
 
-	resp, err := c.makeRequest("GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -72,7 +78,7 @@ func (c *Client) GetPipeline(repoSlug, pipelineUUID string) (*Pipeline, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+		return nil, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 	}
 
 	var pipeline Pipeline
@@ -83,13 +89,18 @@ func (c *Client) GetPipeline(repoSlug, pipelineUUID string) (*Pipeline, error) {
 	return &pipeline, nil
 }
 
-// GetPipelineSteps returns the steps for a pipeline.
-func (c *Client) GetPipelineSteps(repoSlug, pipelineUUID string) ([]PipelineStep, error) {
+// GetPipeline calls GetPipelineContext with a background context.
+func (c *Client) GetPipeline(repoSlug, pipelineUUID string) (*Pipeline, error) {
+	return c.GetPipelineContext(context.Background(), repoSlug, pipelineUUID)
+}
+
+// GetPipelineStepsContext returns the steps for a pipeline.
+func (c *Client) GetPipelineStepsContext(ctx context.Context, repoSlug, pipelineUUID string) ([]PipelineStep, error) {
 	endpoint := fmt.Sprintf("repositories/%s/%s/pipelines/%s/steps/", c.config.Workspace, repoSlug, pipelineUUID)
 
 	var allSteps []PipelineStep
 	for endpoint != "" {
-		resp, err := c.makeRequest("GET", endpoint, nil)
+		resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to make request: %w", err)
 		}
@@ -99,7 +110,7 @@ func (c *Client) GetPipelineSteps(repoSlug, pipelineUUID string) ([]PipelineStep
 			if err := resp.Body.Close(); err != nil {
 				fmt.Printf("warning: failed to close response body: %v\n", err)
 			}
-			return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+			return nil, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 		}
 
 		var stepsResp PipelineStepsResponse
@@ -125,14 +136,19 @@ func (c *Client) GetPipelineSteps(repoSlug, pipelineUUID string) ([]PipelineStep
 	return allSteps, nil
 }
 
-// GetPipelineStepLog returns the raw log output for a pipeline step.
+// GetPipelineSteps calls GetPipelineStepsContext with a background context.
+func (c *Client) GetPipelineSteps(repoSlug, pipelineUUID string) ([]PipelineStep, error) {
+	return c.GetPipelineStepsContext(context.Background(), repoSlug, pipelineUUID)
+}
+
+// GetPipelineStepLogContext returns the raw log output for a pipeline step.
 // The Bitbucket log endpoint returns text/plain, so this method sets
 // Accept: */* explicitly to avoid a 406 Not Acceptable response.
-func (c *Client) GetPipelineStepLog(repoSlug, pipelineUUID, stepUUID string) (string, error) {
+func (c *Client) GetPipelineStepLogContext(ctx context.Context, repoSlug, pipelineUUID, stepUUID string) (string, error) {
 	endpoint := fmt.Sprintf("repositories/%s/%s/pipelines/%s/steps/%s/log", c.config.Workspace, repoSlug, pipelineUUID, stepUUID)
 	rawURL := fmt.Sprintf("%s/%s", c.baseURL, endpoint)
 
-	req, err := http.NewRequest("GET", rawURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
@@ -152,7 +168,7 @@ func (c *Client) GetPipelineStepLog(repoSlug, pipelineUUID, stepUUID string) (st
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+		return "", httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 	}
 
 	logBytes, err := io.ReadAll(resp.Body)
@@ -162,3 +178,8 @@ func (c *Client) GetPipelineStepLog(repoSlug, pipelineUUID, stepUUID string) (st
 
 	return string(logBytes), nil
 }
+
+// GetPipelineStepLog calls GetPipelineStepLogContext with a background context.
+func (c *Client) GetPipelineStepLog(repoSlug, pipelineUUID, stepUUID string) (string, error) {
+	return c.GetPipelineStepLogContext(context.Background(), repoSlug, pipelineUUID, stepUUID)
+}
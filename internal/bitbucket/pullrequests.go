@@ -1,21 +1,24 @@
 package bitbucket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+
+	"devflow/internal/httpx"
 )
 
-// GetPullRequests retrieves pull requests for a repository.
-func (c *Client) GetPullRequests(repoSlug string) ([]PullRequest, error) {
+// GetPullRequestsContext retrieves pull requests for a repository.
+func (c *Client) GetPullRequestsContext(ctx context.Context, repoSlug string) ([]PullRequest, error) {
 	endpoint := fmt.Sprintf("repositories/%s/%s/pullrequests", c.config.Workspace, repoSlug)
 
 	var allPRs []PullRequest
 	for endpoint != "" {
-		resp, err := c.makeRequest("GET", endpoint, nil)
+		resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to make request: %w", err)
 		}
@@ -25,7 +28,7 @@ func (c *Client) GetPullRequests(repoSlug string) ([]PullRequest, error) {
 			if err := resp.Body.Close(); err != nil {
 				fmt.Printf("warning: failed to close response body: %v\n", err)
 			}
-			return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+			return nil, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 		}
 
 		var prResp PullRequestsResponse
@@ -54,14 +57,19 @@ func (c *Client) GetPullRequests(repoSlug string) ([]PullRequest, error) {
 	return allPRs, nil
 }
 
-// GetParticipatingPullRequests retrieves pull requests where the user participates (author, reviewer, etc.).
-func (c *Client) GetParticipatingPullRequests(repoSlug, username string) ([]PullRequest, error) {
+// GetPullRequests calls GetPullRequestsContext with a background context.
+func (c *Client) GetPullRequests(repoSlug string) ([]PullRequest, error) {
+	return c.GetPullRequestsContext(context.Background(), repoSlug)
+}
+
+// GetParticipatingPullRequestsContext retrieves pull requests where the user participates (author, reviewer, etc.).
+func (c *Client) GetParticipatingPullRequestsContext(ctx context.Context, repoSlug, username string) ([]PullRequest, error) {
 	query := url.QueryEscape(fmt.Sprintf("participants.username=\"%s\"", username))
 	endpoint := fmt.Sprintf("repositories/%s/%s/pullrequests?q=%s", c.config.Workspace, repoSlug, query)
 
 	var allPRs []PullRequest
 	for endpoint != "" {
-		resp, err := c.makeRequest("GET", endpoint, nil)
+		resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to make request: %w", err)
 		}
@@ -71,7 +79,7 @@ func (c *Client) GetParticipatingPullRequests(repoSlug, username string) ([]Pull
 			if err := resp.Body.Close(); err != nil {
 				fmt.Printf("warning: failed to close response body: %v\n", err)
 			}
-			return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+			return nil, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 		}
 
 		var prResp PullRequestsResponse
@@ -100,9 +108,14 @@ func (c *Client) GetParticipatingPullRequests(repoSlug, username string) ([]Pull
 	return allPRs, nil
 }
 
-// GetPullRequestsWithReviewers retrieves pull requests with reviewer information for a specific repository.
-func (c *Client) GetPullRequestsWithReviewers(repoSlug string) ([]PullRequestWithReviewers, error) {
-	basicPRs, err := c.GetPullRequests(repoSlug)
+// GetParticipatingPullRequests calls GetParticipatingPullRequestsContext with a background context.
+func (c *Client) GetParticipatingPullRequests(repoSlug, username string) ([]PullRequest, error) {
+	return c.GetParticipatingPullRequestsContext(context.Background(), repoSlug, username)
+}
+
+// GetPullRequestsWithReviewersContext retrieves pull requests with reviewer information for a specific repository.
+func (c *Client) GetPullRequestsWithReviewersContext(ctx context.Context, repoSlug string) ([]PullRequestWithReviewers, error) {
+	basicPRs, err := c.GetPullRequestsContext(ctx, repoSlug)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get basic PR list: %w", err)
 	}
@@ -119,7 +132,7 @@ func (c *Client) GetPullRequestsWithReviewers(repoSlug string) ([]PullRequestWit
 			fmt.Printf("Processed %d/%d PRs...\n", i, len(basicPRs))
 		}
 
-		details, err := c.GetPullRequestDetails(repoSlug, basicPR.ID)
+		details, err := c.GetPullRequestDetailsContext(ctx, repoSlug, basicPR.ID)
 		if err != nil {
 			fmt.Printf("Warning: Failed to get details for PR #%d: %v\n", basicPR.ID, err)
 			continue
@@ -199,11 +212,16 @@ func (c *Client) GetPullRequestsWithReviewers(repoSlug string) ([]PullRequestWit
 	return prsWithReviewers, nil
 }
 
-// GetWorkspacePullRequestsForUser retrieves all PRs where the user is a reviewer across the entire workspace.
-func (c *Client) GetWorkspacePullRequestsForUser(username string) ([]PullRequestWithReviewers, error) {
+// GetPullRequestsWithReviewers calls GetPullRequestsWithReviewersContext with a background context.
+func (c *Client) GetPullRequestsWithReviewers(repoSlug string) ([]PullRequestWithReviewers, error) {
+	return c.GetPullRequestsWithReviewersContext(context.Background(), repoSlug)
+}
+
+// GetWorkspacePullRequestsForUserContext retrieves all PRs where the user is a reviewer across the entire workspace.
+func (c *Client) GetWorkspacePullRequestsForUserContext(ctx context.Context, username string) ([]PullRequestWithReviewers, error) {
 	endpoint := fmt.Sprintf("workspaces/%s/pullrequests/%s", c.config.Workspace, username)
 
-	resp, err := c.makeRequest("GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -215,7 +233,7 @@ func (c *Client) GetWorkspacePullRequestsForUser(username string) ([]PullRequest
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+		return nil, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 	}
 
 	var prResp PullRequestsWithReviewersResponse
@@ -226,11 +244,16 @@ func (c *Client) GetWorkspacePullRequestsForUser(username string) ([]PullRequest
 	return prResp.Values, nil
 }
 
-// GetPullRequestDetails retrieves detailed information about a specific pull request.
-func (c *Client) GetPullRequestDetails(repoSlug string, prID int) (*PullRequestDetails, error) {
+// GetWorkspacePullRequestsForUser calls GetWorkspacePullRequestsForUserContext with a background context.
+func (c *Client) GetWorkspacePullRequestsForUser(username string) ([]PullRequestWithReviewers, error) {
+	return c.GetWorkspacePullRequestsForUserContext(context.Background(), username)
+}
+
+// GetPullRequestDetailsContext retrieves detailed information about a specific pull request.
+func (c *Client) GetPullRequestDetailsContext(ctx context.Context, repoSlug string, prID int) (*PullRequestDetails, error) {
 	endpoint := fmt.Sprintf("repositories/%s/%s/pullrequests/%d", c.config.Workspace, repoSlug, prID)
 
-	resp, err := c.makeRequest("GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -242,7 +265,7 @@ func (c *Client) GetPullRequestDetails(repoSlug string, prID int) (*PullRequestD
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+		return nil, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 	}
 
 	var pr PullRequestDetails
@@ -253,11 +276,16 @@ func (c *Client) GetPullRequestDetails(repoSlug string, prID int) (*PullRequestD
 	return &pr, nil
 }
 
-// GetPullRequestDiff retrieves the diff for a specific pull request.
-func (c *Client) GetPullRequestDiff(repoSlug string, prID int) (string, error) {
+// GetPullRequestDetails calls GetPullRequestDetailsContext with a background context.
+func (c *Client) GetPullRequestDetails(repoSlug string, prID int) (*PullRequestDetails, error) {
+	return c.GetPullRequestDetailsContext(context.Background(), repoSlug, prID)
+}
+
+// GetPullRequestDiffContext retrieves the diff for a specific pull request.
+func (c *Client) GetPullRequestDiffContext(ctx context.Context, repoSlug string, prID int) (string, error) {
 	endpoint := fmt.Sprintf("repositories/%s/%s/pullrequests/%d/diff", c.config.Workspace, repoSlug, prID)
 
-	resp, err := c.makeRequest("GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to make request: %w", err)
 	}
@@ -269,7 +297,7 @@ func (c *Client) GetPullRequestDiff(repoSlug string, prID int) (string, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+		return "", httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 	}
 
 	diff, err := io.ReadAll(resp.Body)
@@ -280,13 +308,18 @@ func (c *Client) GetPullRequestDiff(repoSlug string, prID int) (string, error) {
 	return string(diff), nil
 }
 
-// GetPullRequestCommits retrieves commits for a given pull request.
-func (c *Client) GetPullRequestCommits(repoSlug string, prID int) ([]Commit, error) {
+// GetPullRequestDiff calls GetPullRequestDiffContext with a background context.
+func (c *Client) GetPullRequestDiff(repoSlug string, prID int) (string, error) {
+	return c.GetPullRequestDiffContext(context.Background(), repoSlug, prID)
+}
+
+// GetPullRequestCommitsContext retrieves commits for a given pull request.
+func (c *Client) GetPullRequestCommitsContext(ctx context.Context, repoSlug string, prID int) ([]Commit, error) {
 	endpoint := fmt.Sprintf("repositories/%s/%s/pullrequests/%d/commits", c.config.Workspace, repoSlug, prID)
 
 	var allCommits []Commit
 	for endpoint != "" {
-		resp, err := c.makeRequest("GET", endpoint, nil)
+		resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to make request: %w", err)
 		}
@@ -296,7 +329,7 @@ func (c *Client) GetPullRequestCommits(repoSlug string, prID int) ([]Commit, err
 			if err := resp.Body.Close(); err != nil {
 				fmt.Printf("warning: failed to close response body: %v\n", err)
 			}
-			return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+			return nil, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 		}
 
 		var commitsResp CommitsResponse
@@ -325,3 +358,7 @@ func (c *Client) GetPullRequestCommits(repoSlug string, prID int) ([]Commit, err
 	return allCommits, nil
 }
 
+// GetPullRequestCommits calls GetPullRequestCommitsContext with a background context.
+func (c *Client) GetPullRequestCommits(repoSlug string, prID int) ([]Commit, error) {
+	return c.GetPullRequestCommitsContext(context.Background(), repoSlug, prID)
+}
@@ -1,21 +1,24 @@
 package bitbucket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+
+	"devflow/internal/httpx"
 )
 
-// GetRepositories retrieves all repositories in the workspace with pagination support.
-func (c *Client) GetRepositories() ([]Repository, error) {
+// GetRepositoriesContext retrieves all repositories in the workspace with pagination support.
+func (c *Client) GetRepositoriesContext(ctx context.Context) ([]Repository, error) {
 	var allRepos []Repository
 	endpoint := fmt.Sprintf("repositories/%s?pagelen=100", c.config.Workspace)
 
 	for endpoint != "" {
-		resp, err := c.makeRequest("GET", endpoint, nil)
+		resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to make request: %w", err)
 		}
@@ -25,7 +28,7 @@ func (c *Client) GetRepositories() ([]Repository, error) {
 			if err := resp.Body.Close(); err != nil {
 				fmt.Printf("warning: failed to close response body: %v\n", err)
 			}
-			return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+			return nil, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 		}
 
 		var repoResp RepositoriesResponse
@@ -54,25 +57,30 @@ func (c *Client) GetRepositories() ([]Repository, error) {
 	return allRepos, nil
 }
 
-// GetRepositoriesPaged retrieves repositories for a specific page with total count.
-func (c *Client) GetRepositoriesPaged(page, size int) ([]Repository, int, error) {
+// GetRepositories calls GetRepositoriesContext with a background context.
+func (c *Client) GetRepositories() ([]Repository, error) {
+	return c.GetRepositoriesContext(context.Background())
+}
+
+// GetRepositoriesPagedContext retrieves repositories for a specific page with total count.
+func (c *Client) GetRepositoriesPagedContext(ctx context.Context, page, size int) ([]Repository, int, error) {
 	apiPage := page
 	if apiPage < 0 {
 		apiPage = 0
 	}
 
 	if apiPage == 0 {
-		return c.getFirstPageWithTotal(size)
+		return c.getFirstPageWithTotal(ctx, size)
 	}
 
-	totalCount, err := c.getTotalRepositoryCount(size)
+	totalCount, err := c.getTotalRepositoryCount(ctx, size)
 	if err != nil {
 		totalCount = 1000
 	}
 
 	endpoint := fmt.Sprintf("repositories/%s?page=%d&pagelen=%d", c.config.Workspace, apiPage+1, size)
 
-	resp, err := c.makeRequest("GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -84,7 +92,7 @@ func (c *Client) GetRepositoriesPaged(page, size int) ([]Repository, int, error)
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, 0, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+		return nil, 0, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 	}
 
 	var repoResp RepositoriesResponse
@@ -95,11 +103,16 @@ func (c *Client) GetRepositoriesPaged(page, size int) ([]Repository, int, error)
 	return repoResp.Values, totalCount, nil
 }
 
+// GetRepositoriesPaged calls GetRepositoriesPagedContext with a background context.
+func (c *Client) GetRepositoriesPaged(page, size int) ([]Repository, int, error) {
+	return c.GetRepositoriesPagedContext(context.Background(), page, size)
+}
+
 // getFirstPageWithTotal gets the first page and calculates total count.
-func (c *Client) getFirstPageWithTotal(size int) ([]Repository, int, error) {
+func (c *Client) getFirstPageWithTotal(ctx context.Context, size int) ([]Repository, int, error) {
 	endpoint := fmt.Sprintf("repositories/%s?page=1&pagelen=%d", c.config.Workspace, size)
 
-	resp, err := c.makeRequest("GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -111,7 +124,7 @@ func (c *Client) getFirstPageWithTotal(size int) ([]Repository, int, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, 0, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+		return nil, 0, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 	}
 
 	var repoResp RepositoriesResponse
@@ -128,10 +141,10 @@ func (c *Client) getFirstPageWithTotal(size int) ([]Repository, int, error) {
 }
 
 // getTotalRepositoryCount attempts to get the total repository count.
-func (c *Client) getTotalRepositoryCount(size int) (int, error) {
+func (c *Client) getTotalRepositoryCount(ctx context.Context, size int) (int, error) {
 	endpoint := fmt.Sprintf("repositories/%s?page=1&pagelen=1", c.config.Workspace)
 
-	resp, err := c.makeRequest("GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -142,7 +155,8 @@ func (c *Client) getTotalRepositoryCount(size int) (int, error) {
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("failed to get repository count")
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("failed to get repository count: %w", httpx.NewAPIError(httpx.ServiceBitbucket, resp, body))
 	}
 
 	var repoResp RepositoriesResponse
@@ -156,11 +170,11 @@ func (c *Client) getTotalRepositoryCount(size int) (int, error) {
 	return len(repoResp.Values), nil
 }
 
-// GetRepository retrieves detailed information about a repository by slug or UUID.
-func (c *Client) GetRepository(repoIdentifier string) (*Repository, error) {
+// GetRepositoryContext retrieves detailed information about a repository by slug or UUID.
+func (c *Client) GetRepositoryContext(ctx context.Context, repoIdentifier string) (*Repository, error) {
 	repositoryID := strings.Trim(repoIdentifier, "{}")
 	if isRepositoryUUID(repositoryID) {
-		if repositories, err := c.GetRepositories(); err == nil {
+		if repositories, err := c.GetRepositoriesContext(ctx); err == nil {
 			for _, repository := range repositories {
 				if strings.EqualFold(strings.Trim(repository.UUID, "{}"), repositoryID) {
 					return &repository, nil
@@ -169,7 +183,7 @@ func (c *Client) GetRepository(repoIdentifier string) (*Repository, error) {
 		}
 	}
 	endpoint := fmt.Sprintf("repositories/%s/%s", c.config.Workspace, url.PathEscape(repositoryID))
-	resp, err := c.makeRequest("GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -180,7 +194,7 @@ func (c *Client) GetRepository(repoIdentifier string) (*Repository, error) {
 	}()
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed for repository %s with status: %d, response: %s", repoIdentifier, resp.StatusCode, string(body))
+		return nil, fmt.Errorf("repository %s: %w", repoIdentifier, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body))
 	}
 	var repo Repository
 	if err := json.NewDecoder(resp.Body).Decode(&repo); err != nil {
@@ -189,6 +203,11 @@ func (c *Client) GetRepository(repoIdentifier string) (*Repository, error) {
 	return &repo, nil
 }
 
+// GetRepository calls GetRepositoryContext with a background context.
+func (c *Client) GetRepository(repoIdentifier string) (*Repository, error) {
+	return c.GetRepositoryContext(context.Background(), repoIdentifier)
+}
+
 func isRepositoryUUID(value string) bool {
 	if len(value) != 36 {
 		return false
@@ -207,9 +226,9 @@ func isRepositoryUUID(value string) bool {
 	return true
 }
 
-// GetRepositoryMainBranch returns the repository's main branch name or a fallback.
-func (c *Client) GetRepositoryMainBranch(repoSlug string) (string, error) {
-	repo, err := c.GetRepository(repoSlug)
+// GetRepositoryMainBranchContext returns the repository's main branch name or a fallback.
+func (c *Client) GetRepositoryMainBranchContext(ctx context.Context, repoSlug string) (string, error) {
+	repo, err := c.GetRepositoryContext(ctx, repoSlug)
 	if err != nil {
 		return "", err
 	}
@@ -219,16 +238,21 @@ func (c *Client) GetRepositoryMainBranch(repoSlug string) (string, error) {
 	return "main", nil
 }
 
-// GetRepositoryReadme fetches README file content if present.
+// GetRepositoryMainBranch calls GetRepositoryMainBranchContext with a background context.
+func (c *Client) GetRepositoryMainBranch(repoSlug string) (string, error) {
+	return c.GetRepositoryMainBranchContext(context.Background(), repoSlug)
+}
+
+// GetRepositoryReadmeContext fetches README file content if present.
 // Returns the matched filename and its contents.
-func (c *Client) GetRepositoryReadme(repoSlug string) (string, string, error) {
+func (c *Client) GetRepositoryReadmeContext(ctx context.Context, repoSlug string) (string, string, error) {
 	candidates := []string{
 		"README.md", "README.MD", "Readme.md", "readme.md",
 		"README.markdown", "README.txt", "README", "readme", "Readme",
 	}
 	for _, name := range candidates {
 		endpoint := fmt.Sprintf("repositories/%s/%s/src/HEAD/%s", c.config.Workspace, repoSlug, name)
-		resp, err := c.makeRequest("GET", endpoint, nil)
+		resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 		if err != nil {
 			continue
 		}
@@ -248,8 +272,13 @@ func (c *Client) GetRepositoryReadme(repoSlug string) (string, string, error) {
 	return "", "", fmt.Errorf("no README found in repository %s", repoSlug)
 }
 
-// CreatePullRequest creates a new pull request with description and reviewers.
-func (c *Client) CreatePullRequest(repoSlug, title, description, sourceBranch, destinationBranch string, reviewers []string) (*PullRequest, error) {
+// GetRepositoryReadme calls GetRepositoryReadmeContext with a background context.
+func (c *Client) GetRepositoryReadme(repoSlug string) (string, string, error) {
+	return c.GetRepositoryReadmeContext(context.Background(), repoSlug)
+}
+
+// CreatePullRequestContext creates a new pull request with description and reviewers.
+func (c *Client) CreatePullRequestContext(ctx context.Context, repoSlug, title, description, sourceBranch, destinationBranch string, reviewers []string) (*PullRequest, error) {
 	endpoint := fmt.Sprintf("repositories/%s/%s/pullrequests", c.config.Workspace, repoSlug)
 
 	var reviewerObjs []map[string]string
@@ -274,7 +303,7 @@ func (c *Client) CreatePullRequest(repoSlug, title, description, sourceBranch, d
 		body["reviewers"] = reviewerObjs
 	}
 
-	resp, err := c.makeRequest("POST", endpoint, body)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -286,7 +315,7 @@ func (c *Client) CreatePullRequest(repoSlug, title, description, sourceBranch, d
 
 	if resp.StatusCode != http.StatusCreated {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(respBody))
+		return nil, httpx.NewAPIError(httpx.ServiceBitbucket, resp, respBody)
 	}
 
 	var pr PullRequest
@@ -296,3 +325,8 @@ func (c *Client) CreatePullRequest(repoSlug, title, description, sourceBranch, d
 
 	return &pr, nil
 }
+
+// CreatePullRequest calls CreatePullRequestContext with a background context.
+func (c *Client) CreatePullRequest(repoSlug, title, description, sourceBranch, destinationBranch string, reviewers []string) (*PullRequest, error) {
+	return c.CreatePullRequestContext(context.Background(), repoSlug, title, description, sourceBranch, destinationBranch, reviewers)
+}
@@ -1,20 +1,23 @@
 package bitbucket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+
+	"devflow/internal/httpx"
 )
 
-// GetCommitStatuses retrieves build/status information for a given commit hash.
-func (c *Client) GetCommitStatuses(repoSlug, commitHash string) ([]CommitStatus, error) {
+// GetCommitStatusesContext retrieves build/status information for a given commit hash.
+func (c *Client) GetCommitStatusesContext(ctx context.Context, repoSlug, commitHash string) ([]CommitStatus, error) {
 	endpoint := fmt.Sprintf("repositories/%s/%s/commit/%s/statuses", c.config.Workspace, repoSlug, commitHash)
 
 	var allStatuses []CommitStatus
 	for endpoint != "" {
-		resp, err := c.makeRequest("GET", endpoint, nil)
+		resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to make request: %w", err)
 		}
@@ -24,7 +27,7 @@ func (c *Client) GetCommitStatuses(repoSlug, commitHash string) ([]CommitStatus,
 			if err := resp.Body.Close(); err != nil {
 				fmt.Printf("warning: failed to close response body: %v\n", err)
 			}
-			return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+			return nil, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 		}
 
 		var statusesResp CommitStatusesResponse
@@ -53,9 +56,14 @@ func (c *Client) GetCommitStatuses(repoSlug, commitHash string) ([]CommitStatus,
 	return allStatuses, nil
 }
 
-// SetCommitStatus creates or updates a build/status for a commit.
+// GetCommitStatuses calls GetCommitStatusesContext with a background context.
+func (c *Client) GetCommitStatuses(repoSlug, commitHash string) ([]CommitStatus, error) {
+	return c.GetCommitStatusesContext(context.Background(), repoSlug, commitHash)
+}
+
+// SetCommitStatusContext creates or updates a build/status for a commit.
 // Bitbucket upserts a status when the same key is reused.
-func (c *Client) SetCommitStatus(repoSlug, commitHash, state, key, name, urlStr, description string) (*CommitStatus, error) {
+func (c *Client) SetCommitStatusContext(ctx context.Context, repoSlug, commitHash, state, key, name, urlStr, description string) (*CommitStatus, error) {
 	endpoint := fmt.Sprintf("repositories/%s/%s/commit/%s/statuses/build", c.config.Workspace, repoSlug, commitHash)
 	payload := map[string]string{
 		"state":       state,
@@ -64,7 +72,7 @@ func (c *Client) SetCommitStatus(repoSlug, commitHash, state, key, name, urlStr,
 		"url":         urlStr,
 		"description": description,
 	}
-	resp, err := c.makeRequest("POST", endpoint, payload)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -77,7 +85,7 @@ func (c *Client) SetCommitStatus(repoSlug, commitHash, state, key, name, urlStr,
 	}()
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+		return nil, httpx.NewAPIError(httpx.ServiceBitbucket, resp, body)
 	}
 	var status CommitStatus
 	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
@@ -86,3 +94,7 @@ func (c *Client) SetCommitStatus(repoSlug, commitHash, state, key, name, urlStr,
 	return &status, nil
 }
 
+// SetCommitStatus calls SetCommitStatusContext with a background context.
+func (c *Client) SetCommitStatus(repoSlug, commitHash, state, key, name, urlStr, description string) (*CommitStatus, error) {
+	return c.SetCommitStatusContext(context.Background(), repoSlug, commitHash, state, key, name, urlStr, description)
+}
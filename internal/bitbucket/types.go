@@ -138,4 +138,3 @@ type PipelineStepsResponse struct {
 	Values []PipelineStep `json:"values"`
 	Next   string         `json:"next"`
 }
-
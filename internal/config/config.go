@@ -5,32 +5,94 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
+// DefaultProfile is the name of the profile stored at the top level of the
+// configuration file.
+const DefaultProfile = "default"
+
+// ProfileEnvVar selects the active profile when no --profile flag is given.
+const ProfileEnvVar = "DEVFLOW_PROFILE"
+
 type Config struct {
 	Jira      JiraConfig      `json:"jira"`
 	Bitbucket BitbucketConfig `json:"bitbucket"`
 	Jenkins   JenkinsConfig   `json:"jenkins"`
+	HTTP      HTTPConfig      `json:"http"`
+
+	// Profile is the name of the profile these settings were loaded from.
+	// Save writes the settings back to the same profile.
+	Profile string `json:"-"`
+	// Secrets selects where tokens are stored. It is shared by all profiles.
+	Secrets SecretsConfig `json:"-"`
+
+	// resolvedTokens records the secrets Load read through token_ref values.
+	resolvedTokens map[string]string
+	// origins, base and overlay track which layer supplied each key so Save
+	// only persists values that belong in the global file.
+	origins map[string]string
+	base    layerValues
+	overlay layerValues
+}
+
+// Profile holds the per-integration settings of a named profile.
+type Profile struct {
+	Jira      JiraConfig      `json:"jira"`
+	Bitbucket BitbucketConfig `json:"bitbucket"`
+	Jenkins   JenkinsConfig   `json:"jenkins"`
+	HTTP      HTTPConfig      `json:"http,omitzero"`
 }
 
 type JiraConfig struct {
-	URL      string `json:"url"`
-	Username string `json:"username"`
-	Token    string `json:"token"`
+	URL        string `json:"url"`
+	Username   string `json:"username"`
+	Token      string `json:"token"`
+	TokenRef   string `json:"token_ref,omitempty"`   // Secret store reference, e.g. vault:default/jira
+	ProjectKey string `json:"project_key,omitempty"` // Default project for new issues
+	BoardID    string `json:"board_id,omitempty"`    // Default board for sprint and backlog commands
+	Deployment string `json:"deployment,omitempty"`  // cloud, server or auto (the default)
+
+	SavedQueries map[string]string `json:"saved_queries,omitempty"` // Named JQL templates for tasks list --query
 }
 
 type BitbucketConfig struct {
-	Workspace     string   `json:"workspace"`
-	Username      string   `json:"username"`       // Email address for authentication
-	BitbucketUser string   `json:"bitbucket_user"` // Username for API calls
-	Token         string   `json:"token"`
-	WatchedRepos  []string `json:"watched_repos"` // List of watched repository slugs
+	Workspace        string   `json:"workspace"`
+	Username         string   `json:"username"`       // Email address for authentication
+	BitbucketUser    string   `json:"bitbucket_user"` // Username for API calls
+	Token            string   `json:"token"`
+	TokenRef         string   `json:"token_ref,omitempty"`         // Secret store reference, e.g. helper:bitbucket
+	WatchedRepos     []string `json:"watched_repos"`               // List of watched repository slugs
+	DefaultReviewers []string `json:"default_reviewers,omitempty"` // Reviewers added to new pull requests
 }
 
 type JenkinsConfig struct {
 	URL      string `json:"url"`
 	Username string `json:"username"`
 	Token    string `json:"token"`
+	TokenRef string `json:"token_ref,omitempty"` // Secret store reference
+}
+
+// HTTPConfig tunes the retrying transport shared by all API clients. Zero
+// values select the built-in defaults.
+type HTTPConfig struct {
+	MaxRetries     int    `json:"max_retries,omitempty"`      // Retries per request; negative disables retries
+	RetryBaseDelay string `json:"retry_base_delay,omitempty"` // First backoff ceiling, e.g. "500ms"
+	RetryMaxDelay  string `json:"retry_max_delay,omitempty"`  // Longest wait between attempts, e.g. "30s"
+	RetryBudget    int    `json:"retry_budget,omitempty"`     // Retries each host may consume; negative is unlimited
+}
+
+// document is the on-disk layout of config.json. The default profile lives
+// at the top level so files written before profiles existed keep working.
+type document struct {
+	Jira          JiraConfig         `json:"jira"`
+	Bitbucket     BitbucketConfig    `json:"bitbucket"`
+	Jenkins       JenkinsConfig      `json:"jenkins"`
+	HTTP          HTTPConfig         `json:"http,omitzero"`
+	ActiveProfile string             `json:"active_profile,omitempty"`
+	Profiles      map[string]Profile `json:"profiles,omitempty"`
+	Secrets       SecretsConfig      `json:"secrets,omitempty"`
 }
 
 var configPath = func() string {
@@ -40,10 +102,250 @@ var configPath = func() string {
 	return filepath.Join(".devflow", "config.json")
 }()
 
-// Load reads the configuration from disk
+// Dir returns the directory holding config.json, where DevFlow also keeps
+// its other state such as the HTTP cache.
+func Dir() string {
+	return filepath.Dir(configPath)
+}
+
+// selectedProfile is the process-wide profile override set from --profile.
+var selectedProfile string
+
+// SelectProfile overrides the active profile for the current process. An
+// empty name clears the override.
+func SelectProfile(name string) {
+	selectedProfile = strings.TrimSpace(name)
+}
+
+// Load reads the configuration of the active profile, layering built-in
+// defaults, the global file, a repo-local .devflow.json and DEVFLOW_*
+// environment variables, then resolves token_ref values through the
+// configured secret store. A profile that does not exist is an error.
+// Command flags such as --project are applied by the commands themselves on
+// top of the loaded values and are not tracked by Origin.
 func Load() (*Config, error) {
+	return load(false)
+}
+
+// LoadForEdit is Load for config set and setup, where a profile that does
+// not exist yet starts out empty and is created by Save.
+func LoadForEdit() (*Config, error) {
+	return load(true)
+}
+
+func load(allowNewProfile bool) (*Config, error) {
+	doc, err := readDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	name := activeProfileName(doc)
+	config := &Config{Profile: name, Secrets: doc.Secrets}
+	if profile, ok := doc.profile(name); ok {
+		config.setProfile(profile)
+	} else if !allowNewProfile {
+		return nil, fmt.Errorf("profile %q does not exist (see: devflow config profile list)", name)
+	}
+	if err := applyLayers(config); err != nil {
+		return nil, err
+	}
+	if err := resolveTokens(config); err != nil {
+		return nil, err
+	}
+	for _, field := range tokenFields(config) {
+		refKey := field.name + ".token_ref"
+		if _, resolved := config.resolvedTokens[field.name]; !resolved {
+			continue
+		}
+		config.origins[field.name+".token"] = config.Origin(refKey)
+		// A token reached through an overridden reference is itself an
+		// override and must never be written to the global file.
+		if _, overridden := config.overlay[refKey]; overridden {
+			config.overlay[field.name+".token"], _ = json.Marshal(*field.token)
+		}
+	}
+
+	return config, nil
+}
+
+// Save writes the configuration to the profile it was loaded from, leaving
+// the other profiles untouched. Tokens are moved into the selected secret
+// store when one is configured
+func Save(config *Config) error {
+	doc, err := readDocument()
+	if err != nil {
+		return err
+	}
+
+	profile, err := stripLayers(config)
+	if err != nil {
+		return fmt.Errorf("failed to separate config overrides: %w", err)
+	}
+	persist := *config
+	persist.setProfile(profile)
+	stored, err := storeTokens(&persist)
+	if err != nil {
+		return err
+	}
+	for i, field := range tokenFields(config) {
+		if _, overridden := config.overlay[field.name+".token"]; !overridden {
+			*field.ref = *tokenFields(&persist)[i].ref
+		}
+	}
+	config.resolvedTokens = persist.resolvedTokens
+	doc.Secrets = config.Secrets
+
+	name := config.Profile
+	if name == "" {
+		name = DefaultProfile
+	}
+	if err := validateProfileName(name); err != nil {
+		return err
+	}
+	doc.setProfile(name, stored.profile())
+
+	return writeDocument(doc)
+}
+
+// ActiveProfile returns the name of the profile Load will use.
+func ActiveProfile() (string, error) {
+	doc, err := readDocument()
+	if err != nil {
+		return "", err
+	}
+	return activeProfileName(doc), nil
+}
+
+// ListProfiles returns the names of all stored profiles, sorted, with the
+// default profile first.
+func ListProfiles() ([]string, error) {
+	doc, err := readDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(doc.Profiles)+1)
+	for name := range doc.Profiles {
+		if name != DefaultProfile {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return append([]string{DefaultProfile}, names...), nil
+}
+
+// UseProfile persists name as the active profile.
+func UseProfile(name string) error {
+	doc, err := readDocument()
+	if err != nil {
+		return err
+	}
+	if _, ok := doc.profile(name); !ok {
+		return fmt.Errorf("profile %q does not exist", name)
+	}
+
+	doc.ActiveProfile = name
+	if name == DefaultProfile {
+		doc.ActiveProfile = ""
+	}
+	return writeDocument(doc)
+}
+
+// CopyProfile duplicates the settings of src into a new profile named dst.
+func CopyProfile(src, dst string) error {
+	if err := validateProfileName(dst); err != nil {
+		return err
+	}
+
+	doc, err := readDocument()
+	if err != nil {
+		return err
+	}
+	profile, ok := doc.profile(src)
+	if !ok {
+		return fmt.Errorf("profile %q does not exist", src)
+	}
+	if _, exists := doc.profile(dst); exists {
+		return fmt.Errorf("profile %q already exists", dst)
+	}
+
+	profile.Bitbucket.WatchedRepos = append([]string(nil), profile.Bitbucket.WatchedRepos...)
+	doc.setProfile(dst, profile)
+	return writeDocument(doc)
+}
+
+// DeleteProfile removes a named profile. The default profile cannot be
+// deleted; deleting the active profile makes the default profile active.
+func DeleteProfile(name string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("the %s profile cannot be deleted", DefaultProfile)
+	}
+
+	doc, err := readDocument()
+	if err != nil {
+		return err
+	}
+	if _, ok := doc.Profiles[name]; !ok {
+		return fmt.Errorf("profile %q does not exist", name)
+	}
+
+	delete(doc.Profiles, name)
+	if doc.ActiveProfile == name {
+		doc.ActiveProfile = ""
+	}
+	return writeDocument(doc)
+}
+
+// activeProfileName resolves the profile in precedence order: --profile,
+// DEVFLOW_PROFILE, the persisted active profile, then the default.
+func activeProfileName(doc *document) string {
+	if selectedProfile != "" {
+		return selectedProfile
+	}
+	if env := strings.TrimSpace(os.Getenv(ProfileEnvVar)); env != "" {
+		return env
+	}
+	if doc.ActiveProfile != "" {
+		return doc.ActiveProfile
+	}
+	return DefaultProfile
+}
+
+func validateProfileName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("profile name cannot be empty")
+	}
+	if strings.ContainsAny(name, " \t./\\") {
+		return fmt.Errorf("invalid profile name %q", name)
+	}
+	return nil
+}
+
+func (d *document) profile(name string) (Profile, bool) {
+	if name == DefaultProfile {
+		return Profile{Jira: d.Jira, Bitbucket: d.Bitbucket, Jenkins: d.Jenkins, HTTP: d.HTTP}, true
+	}
+	profile, ok := d.Profiles[name]
+	return profile, ok
+}
+
+func (d *document) setProfile(name string, profile Profile) {
+	if name == DefaultProfile {
+		d.Jira = profile.Jira
+		d.Bitbucket = profile.Bitbucket
+		d.Jenkins = profile.Jenkins
+		d.HTTP = profile.HTTP
+		return
+	}
+	if d.Profiles == nil {
+		d.Profiles = make(map[string]Profile)
+	}
+	d.Profiles[name] = profile
+}
+
+func readDocument() (*document, error) {
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return &Config{}, nil
+		return &document{}, nil
 	}
 
 	data, err := os.ReadFile(configPath)
@@ -51,23 +353,22 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	var config Config
-	if err := json.Unmarshal(data, &config); err != nil {
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
-	return &config, nil
+	return &doc, nil
 }
 
-// Save writes the configuration to disk
-func Save(config *Config) error {
+func writeDocument(doc *document) error {
 	// Create config directory if it doesn't exist
 	configDir := filepath.Dir(configPath)
 	if err := os.MkdirAll(configDir, 0700); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	data, err := json.MarshalIndent(config, "", "  ")
+	data, err := json.MarshalIndent(doc, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -70,3 +71,135 @@ func TestLoadInvalidJSON(t *testing.T) {
 		t.Fatal("expected invalid JSON error")
 	}
 }
+
+func useTempConfig(t *testing.T) {
+	t.Helper()
+	originalPath := configPath
+	originalWorkingDir := workingDir
+	configPath = filepath.Join(t.TempDir(), "config.json")
+	cwd := t.TempDir()
+	workingDir = func() (string, error) { return cwd, nil }
+	t.Setenv(ProfileEnvVar, "")
+	t.Cleanup(func() {
+		configPath = originalPath
+		workingDir = originalWorkingDir
+		SelectProfile("")
+	})
+}
+
+func TestProfilesLoadAndSave(t *testing.T) {
+	useTempConfig(t)
+
+	if err := Save(&Config{Jira: JiraConfig{URL: "https://prod.atlassian.net"}}); err != nil {
+		t.Fatalf("save default: %v", err)
+	}
+	if err := Save(&Config{Profile: "sandbox", Jira: JiraConfig{URL: "https://sandbox.atlassian.net"}}); err != nil {
+		t.Fatalf("save sandbox: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Profile != DefaultProfile || cfg.Jira.URL != "https://prod.atlassian.net" {
+		t.Fatalf("expected default profile, got %+v", cfg)
+	}
+
+	t.Setenv(ProfileEnvVar, "sandbox")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Profile != "sandbox" || cfg.Jira.URL != "https://sandbox.atlassian.net" {
+		t.Fatalf("expected sandbox profile from env, got %+v", cfg)
+	}
+
+	SelectProfile(DefaultProfile)
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Profile != DefaultProfile {
+		t.Fatalf("expected --profile to override env, got %q", cfg.Profile)
+	}
+}
+
+func TestLoadUnknownProfile(t *testing.T) {
+	useTempConfig(t)
+
+	if err := Save(&Config{Jira: JiraConfig{URL: "https://prod.atlassian.net"}}); err != nil {
+		t.Fatalf("save default: %v", err)
+	}
+	SelectProfile("sandbx")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), `profile "sandbx" does not exist`) {
+		t.Fatalf("expected an unknown profile to fail, got %v", err)
+	}
+
+	cfg, err := LoadForEdit()
+	if err != nil {
+		t.Fatalf("load for edit: %v", err)
+	}
+	if cfg.Profile != "sandbx" || cfg.Jira.URL != "" {
+		t.Fatalf("expected an empty new profile, got %+v", cfg)
+	}
+	cfg.Jira.URL = "https://sandbox.atlassian.net"
+	if err := Save(cfg); err != nil {
+		t.Fatalf("save new profile: %v", err)
+	}
+	if cfg, err = Load(); err != nil || cfg.Jira.URL != "https://sandbox.atlassian.net" {
+		t.Fatalf("expected the saved profile to load, got %+v, %v", cfg, err)
+	}
+
+	if err := Save(&Config{Profile: "bad name"}); err == nil {
+		t.Fatal("expected saving an invalid profile name to fail")
+	}
+}
+
+func TestProfileManagement(t *testing.T) {
+	useTempConfig(t)
+
+	if err := Save(&Config{Bitbucket: BitbucketConfig{Workspace: "prod", WatchedRepos: []string{"api"}}}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := CopyProfile(DefaultProfile, "sandbox"); err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+	if err := CopyProfile(DefaultProfile, "sandbox"); err == nil {
+		t.Fatal("expected copying onto an existing profile to fail")
+	}
+	if err := UseProfile("missing"); err == nil {
+		t.Fatal("expected using a missing profile to fail")
+	}
+	if err := UseProfile("sandbox"); err != nil {
+		t.Fatalf("use: %v", err)
+	}
+
+	names, err := ListProfiles()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(names) != 2 || names[0] != DefaultProfile || names[1] != "sandbox" {
+		t.Fatalf("unexpected profiles: %v", names)
+	}
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Profile != "sandbox" || cfg.Bitbucket.Workspace != "prod" {
+		t.Fatalf("expected copied sandbox profile to be active, got %+v", cfg)
+	}
+
+	if err := DeleteProfile(DefaultProfile); err == nil {
+		t.Fatal("expected deleting the default profile to fail")
+	}
+	if err := DeleteProfile("sandbox"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	active, err := ActiveProfile()
+	if err != nil {
+		t.Fatalf("active: %v", err)
+	}
+	if active != DefaultProfile {
+		t.Fatalf("expected default profile after deleting the active one, got %q", active)
+	}
+}
@@ -0,0 +1,386 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ProjectConfigName is the repo-local configuration file looked up from the
+// working directory upwards.
+const ProjectConfigName = ".devflow.json"
+
+// EnvPrefix prefixes the environment variables that override configuration
+// keys, e.g. DEVFLOW_JIRA_URL for jira.url.
+const EnvPrefix = "DEVFLOW_"
+
+// Origin values reported by Config.Origin. File and environment origins are
+// suffixed with the path or variable name, e.g. "env:DEVFLOW_JIRA_URL".
+const (
+	OriginDefault = "default"
+	OriginGlobal  = "global"
+	OriginProject = "project"
+	OriginEnv     = "env"
+)
+
+// projectDeniedFields are never taken from a repo-local file, so a cloned
+// repository cannot redirect credentials to another server.
+var projectDeniedFields = map[string]bool{
+	"url":       true,
+	"username":  true,
+	"token":     true,
+	"token_ref": true,
+}
+
+var workingDir = os.Getwd
+
+// layerValues maps "section.field" keys to raw JSON values.
+type layerValues map[string]json.RawMessage
+
+func flatten(profile Profile) (layerValues, error) {
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return nil, err
+	}
+	var sections map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return nil, err
+	}
+	values := layerValues{}
+	for section, fields := range sections {
+		for field, raw := range fields {
+			values[section+"."+field] = raw
+		}
+	}
+	return values, nil
+}
+
+func unflatten(values layerValues) (Profile, error) {
+	sections := map[string]map[string]json.RawMessage{}
+	for key, raw := range values {
+		section, field, _ := strings.Cut(key, ".")
+		if sections[section] == nil {
+			sections[section] = map[string]json.RawMessage{}
+		}
+		sections[section][field] = raw
+	}
+	var profile Profile
+	data, err := json.Marshal(sections)
+	if err != nil {
+		return profile, err
+	}
+	err = json.Unmarshal(data, &profile)
+	return profile, err
+}
+
+// isZeroJSON reports whether raw encodes an unset value.
+func isZeroJSON(raw json.RawMessage) bool {
+	switch strings.TrimSpace(string(raw)) {
+	case "", "null", `""`, "0", "false", "[]", "{}":
+		return true
+	}
+	return false
+}
+
+// findProjectConfig walks up from the working directory looking for a
+// repo-local configuration file.
+func findProjectConfig() string {
+	dir, err := workingDir()
+	if err != nil {
+		return ""
+	}
+	for {
+		candidate := filepath.Join(dir, ProjectConfigName)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func readProjectLayer(path string) (layerValues, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var sections map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	values := layerValues{}
+	for section, fields := range sections {
+		for field, raw := range fields {
+			if projectDeniedFields[field] {
+				fmt.Fprintf(os.Stderr, "warning: ignoring %s.%s in %s; credentials and server URLs must come from the global config or environment\n", section, field, path)
+				continue
+			}
+			values[section+"."+field] = raw
+		}
+	}
+	return values, nil
+}
+
+// EnvVarName returns the environment variable that overrides key.
+func EnvVarName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// knownKeys lists every "section.field" key of a profile with its Go kind,
+// including fields omitted from JSON when empty.
+func knownKeys() map[string]reflect.Kind {
+	keys := map[string]reflect.Kind{}
+	profileType := reflect.TypeOf(Profile{})
+	for i := 0; i < profileType.NumField(); i++ {
+		section := profileType.Field(i)
+		sectionName := jsonName(section)
+		for j := 0; j < section.Type.NumField(); j++ {
+			field := section.Type.Field(j)
+			if name := jsonName(field); name != "" {
+				keys[sectionName+"."+name] = field.Type.Kind()
+			}
+		}
+	}
+	return keys
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// envLayer reads DEVFLOW_<SECTION>_<FIELD> overrides for every known key.
+// List values are comma-separated; numbers and booleans are parsed as JSON.
+func envLayer() layerValues {
+	values := layerValues{}
+	for key, kind := range knownKeys() {
+		text, ok := os.LookupEnv(EnvVarName(key))
+		if !ok {
+			continue
+		}
+		switch kind {
+		case reflect.Slice:
+			items := []string{}
+			for _, item := range strings.Split(text, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			values[key], _ = json.Marshal(items)
+		case reflect.String:
+			values[key], _ = json.Marshal(text)
+		default:
+			if json.Valid([]byte(text)) {
+				values[key] = json.RawMessage(text)
+			} else {
+				fmt.Fprintf(os.Stderr, "warning: ignoring %s: %q is not a valid value\n", EnvVarName(key), text)
+			}
+		}
+	}
+	return values
+}
+
+// secretsKeys reports for every "secrets.field" key whether it is set. The
+// secrets section is shared by all profiles and only read from the global
+// file, so it takes no part in the repo-local and environment layers.
+func secretsKeys(secrets SecretsConfig) map[string]bool {
+	keys := map[string]bool{}
+	value := reflect.ValueOf(secrets)
+	for i := 0; i < value.NumField(); i++ {
+		if name := jsonName(value.Type().Field(i)); name != "" {
+			keys["secrets."+name] = !value.Field(i).IsZero()
+		}
+	}
+	return keys
+}
+
+// applyLayers overlays the repo-local file and environment variables on top
+// of the global profile, recording where each value came from. Map values
+// such as jira.saved_queries are merged entry by entry.
+func applyLayers(cfg *Config) error {
+	base, err := flatten(cfg.profile())
+	if err != nil {
+		return fmt.Errorf("failed to flatten config: %w", err)
+	}
+
+	cfg.origins = map[string]string{}
+	for key, raw := range base {
+		if isZeroJSON(raw) {
+			cfg.origins[key] = OriginDefault
+		} else {
+			cfg.origins[key] = OriginGlobal + ":" + configPath
+		}
+	}
+	for key, set := range secretsKeys(cfg.Secrets) {
+		if set {
+			cfg.origins[key] = OriginGlobal + ":" + configPath
+		} else {
+			cfg.origins[key] = OriginDefault
+		}
+	}
+	cfg.base = base
+	cfg.overlay = layerValues{}
+
+	merged := layerValues{}
+	for key, raw := range base {
+		merged[key] = raw
+	}
+	known := knownKeys()
+	overlay := func(key string, raw json.RawMessage, origin string) {
+		if known[key] == reflect.Map {
+			merged[key] = mergeEntries(merged[key], raw)
+			cfg.overlay[key] = mergeEntries(cfg.overlay[key], raw)
+		} else {
+			merged[key] = raw
+			cfg.overlay[key] = raw
+		}
+		cfg.origins[key] = origin
+	}
+	if path := findProjectConfig(); path != "" {
+		project, err := readProjectLayer(path)
+		if err != nil {
+			return err
+		}
+		for key, raw := range project {
+			overlay(key, raw, OriginProject+":"+path)
+		}
+	}
+	for key, raw := range envLayer() {
+		overlay(key, raw, OriginEnv+":"+EnvVarName(key))
+	}
+
+	if len(cfg.overlay) == 0 {
+		return nil
+	}
+	profile, err := unflatten(merged)
+	if err != nil {
+		return fmt.Errorf("failed to apply config overrides: %w", err)
+	}
+	cfg.setProfile(profile)
+	return nil
+}
+
+// stripLayers returns the profile to persist in the global file: values that
+// still hold what a repo-local file or environment variable supplied are
+// replaced by the global value they shadowed. Map values are compared entry
+// by entry, so only the entries added, changed or removed are persisted.
+func stripLayers(cfg *Config) (Profile, error) {
+	if len(cfg.overlay) == 0 {
+		return cfg.profile(), nil
+	}
+	current, err := flatten(cfg.profile())
+	if err != nil {
+		return Profile{}, err
+	}
+	known := knownKeys()
+	for key, raw := range cfg.overlay {
+		if known[key] == reflect.Map {
+			current[key] = stripEntries(current[key], raw, cfg.base[key])
+			continue
+		}
+		if jsonEqual(current[key], raw) {
+			if original, ok := cfg.base[key]; ok {
+				current[key] = original
+			} else {
+				delete(current, key)
+			}
+		}
+	}
+	return unflatten(current)
+}
+
+// mergeEntries overlays the entries of the JSON object layer on base. A
+// layer that is not an object replaces base.
+func mergeEntries(base, layer json.RawMessage) json.RawMessage {
+	var entries, layerEntries map[string]json.RawMessage
+	if json.Unmarshal(layer, &layerEntries) != nil || layerEntries == nil {
+		return layer
+	}
+	if json.Unmarshal(base, &entries) != nil || entries == nil {
+		entries = map[string]json.RawMessage{}
+	}
+	for name, raw := range layerEntries {
+		entries[name] = raw
+	}
+	merged, _ := json.Marshal(entries)
+	return merged
+}
+
+// stripEntries returns the entries of the JSON object current to persist:
+// entries still holding the overlay's value fall back to base, or are
+// dropped when base has none, and entries missing from current stay
+// removed.
+func stripEntries(current, overlay, base json.RawMessage) json.RawMessage {
+	var entries, overlayEntries, baseEntries map[string]json.RawMessage
+	if json.Unmarshal(current, &entries) != nil || json.Unmarshal(overlay, &overlayEntries) != nil {
+		if jsonEqual(current, overlay) {
+			return base
+		}
+		return current
+	}
+	_ = json.Unmarshal(base, &baseEntries)
+	kept := map[string]json.RawMessage{}
+	for name, raw := range entries {
+		layered, ok := overlayEntries[name]
+		if !ok || !jsonEqual(raw, layered) {
+			kept[name] = raw
+		} else if original, ok := baseEntries[name]; ok {
+			kept[name] = original
+		}
+	}
+	stripped, _ := json.Marshal(kept)
+	return stripped
+}
+
+func jsonEqual(a, b json.RawMessage) bool {
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return bytes.Equal(a, b)
+	}
+	ca, _ := json.Marshal(va)
+	cb, _ := json.Marshal(vb)
+	return bytes.Equal(ca, cb)
+}
+
+// Origin reports which layer supplied key ("section.field"), e.g. "default",
+// "global:/home/me/.devflow/config.json", "project:/repo/.devflow.json" or
+// "env:DEVFLOW_JIRA_URL".
+func (c *Config) Origin(key string) string {
+	if origin, ok := c.origins[key]; ok {
+		return origin
+	}
+	return OriginDefault
+}
+
+// Keys returns every known configuration key in sorted order.
+func Keys() []string {
+	known := knownKeys()
+	keys := make([]string, 0, len(known))
+	for key := range known {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (c *Config) profile() Profile {
+	return Profile{Jira: c.Jira, Bitbucket: c.Bitbucket, Jenkins: c.Jenkins, HTTP: c.HTTP}
+}
+
+func (c *Config) setProfile(profile Profile) {
+	c.Jira = profile.Jira
+	c.Bitbucket = profile.Bitbucket
+	c.Jenkins = profile.Jenkins
+	c.HTTP = profile.HTTP
+}
@@ -0,0 +1,151 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadLayersProjectAndEnv(t *testing.T) {
+	useTempConfig(t)
+
+	if err := Save(&Config{
+		Jira:      JiraConfig{URL: "https://global.atlassian.net", Username: "me@example.com", Token: "global-token"},
+		Bitbucket: BitbucketConfig{Workspace: "global-ws"},
+	}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	repo := t.TempDir()
+	nested := filepath.Join(repo, "src", "pkg")
+	if err := os.MkdirAll(nested, 0700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	project := `{"jira": {"project_key": "ENG", "url": "https://evil.example"}, "bitbucket": {"default_reviewers": ["alice", "bob"]}}`
+	if err := os.WriteFile(filepath.Join(repo, ProjectConfigName), []byte(project), 0600); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+	workingDir = func() (string, error) { return nested, nil }
+	t.Setenv("DEVFLOW_BITBUCKET_WORKSPACE", "ci-ws")
+	t.Setenv("DEVFLOW_JIRA_TOKEN", "env-token")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Jira.URL != "https://global.atlassian.net" {
+		t.Fatalf("project config must not override jira.url, got %q", cfg.Jira.URL)
+	}
+	if cfg.Jira.ProjectKey != "ENG" || len(cfg.Bitbucket.DefaultReviewers) != 2 {
+		t.Fatalf("expected project values, got %+v %+v", cfg.Jira, cfg.Bitbucket)
+	}
+	if cfg.Bitbucket.Workspace != "ci-ws" || cfg.Jira.Token != "env-token" {
+		t.Fatalf("expected env overrides, got workspace=%q token=%q", cfg.Bitbucket.Workspace, cfg.Jira.Token)
+	}
+
+	origins := map[string]string{
+		"jira.url":            OriginGlobal + ":" + configPath,
+		"jira.project_key":    OriginProject + ":" + filepath.Join(repo, ProjectConfigName),
+		"bitbucket.workspace": OriginEnv + ":DEVFLOW_BITBUCKET_WORKSPACE",
+		"jenkins.url":         OriginDefault,
+	}
+	for key, want := range origins {
+		if got := cfg.Origin(key); got != want {
+			t.Errorf("Origin(%q) = %q, want %q", key, got, want)
+		}
+	}
+
+	// Saving must keep overrides out of the global file.
+	cfg.Jira.Username = "new@example.com"
+	if err := Save(cfg); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	raw, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	for _, leaked := range []string{"env-token", "ci-ws", "ENG", "alice"} {
+		if strings.Contains(string(raw), leaked) {
+			t.Fatalf("override %q leaked into global config: %s", leaked, raw)
+		}
+	}
+	if !strings.Contains(string(raw), "new@example.com") || !strings.Contains(string(raw), "global-token") {
+		t.Fatalf("expected global edits to persist: %s", raw)
+	}
+}
+
+func TestSavedQueriesMergeAcrossLayers(t *testing.T) {
+	useTempConfig(t)
+
+	if err := Save(&Config{Jira: JiraConfig{SavedQueries: map[string]string{
+		"mine":  "assignee = currentUser()",
+		"stale": "updated < -30d",
+	}}}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	repo := t.TempDir()
+	project := `{"jira": {"saved_queries": {"sprint": "sprint in openSprints()", "stale": "updated < -14d"}}}`
+	if err := os.WriteFile(filepath.Join(repo, ProjectConfigName), []byte(project), 0600); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+	workingDir = func() (string, error) { return repo, nil }
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	queries := cfg.Jira.SavedQueries
+	if len(queries) != 3 || queries["mine"] != "assignee = currentUser()" || queries["stale"] != "updated < -14d" {
+		t.Fatalf("expected the project queries to merge with the global ones, got %v", queries)
+	}
+
+	cfg.Jira.SavedQueries["bugs"] = "type = Bug"
+	delete(cfg.Jira.SavedQueries, "mine")
+	if err := Save(cfg); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	doc, err := readDocument()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	global, _ := doc.profile(DefaultProfile)
+	want := map[string]string{"bugs": "type = Bug", "stale": "updated < -30d"}
+	if fmt.Sprint(global.Jira.SavedQueries) != fmt.Sprint(want) {
+		t.Fatalf("expected only the user's edits to reach the global file, got %v", global.Jira.SavedQueries)
+	}
+}
+
+func TestSecretsOriginFromGlobalFile(t *testing.T) {
+	useTempConfig(t)
+
+	if err := Save(&Config{Secrets: SecretsConfig{Backend: SecretsPlaintext}}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got, want := cfg.Origin("secrets.backend"), OriginGlobal+":"+configPath; got != want {
+		t.Fatalf("Origin(secrets.backend) = %q, want %q", got, want)
+	}
+	if got := cfg.Origin("secrets.helper"); got != OriginDefault {
+		t.Fatalf("Origin(secrets.helper) = %q, want %q", got, OriginDefault)
+	}
+}
+
+func TestEnvVarName(t *testing.T) {
+	if got := EnvVarName("bitbucket.watched_repos"); got != "DEVFLOW_BITBUCKET_WATCHED_REPOS" {
+		t.Fatalf("unexpected env var name %q", got)
+	}
+	for _, key := range []string{"jira.url", "bitbucket.default_reviewers", "jenkins.token"} {
+		found := false
+		for _, known := range Keys() {
+			found = found || known == key
+		}
+		if !found {
+			t.Fatalf("expected %q in Keys()", key)
+		}
+	}
+}
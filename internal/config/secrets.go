@@ -0,0 +1,383 @@
+package config
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const (
+	// SecretsPlaintext keeps tokens in config.json (the default).
+	SecretsPlaintext = "plaintext"
+	// SecretsVault keeps tokens in a passphrase-encrypted file.
+	SecretsVault = "vault"
+	// SecretsHelper delegates token storage to an external command.
+	SecretsHelper = "helper"
+)
+
+// VaultPassphraseEnvVar supplies the vault passphrase non-interactively.
+const VaultPassphraseEnvVar = "DEVFLOW_VAULT_PASSPHRASE"
+
+// SecretsConfig selects where tokens are stored.
+type SecretsConfig struct {
+	Backend string `json:"backend,omitempty"` // plaintext, vault, or helper
+	Helper  string `json:"helper,omitempty"`  // Command used by the helper backend
+}
+
+// SecretStore persists tokens outside config.json. Keys are opaque strings
+// taken from the part of a token_ref after the backend prefix.
+type SecretStore interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+}
+
+var vaultPath = func() string {
+	return filepath.Join(filepath.Dir(configPath), "secrets.vault")
+}
+
+// vaultPassphrase returns the passphrase for the encrypted vault, prompting
+// on the terminal when the environment does not provide one.
+var vaultPassphrase = func() (string, error) {
+	if passphrase := os.Getenv(VaultPassphraseEnvVar); passphrase != "" {
+		return passphrase, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("vault passphrase required: set %s", VaultPassphraseEnvVar)
+	}
+	fmt.Fprint(os.Stderr, "Vault passphrase: ")
+	data, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read vault passphrase: %w", err)
+	}
+	return string(data), nil
+}
+
+// cachedPassphrase avoids prompting more than once per process.
+var cachedPassphrase string
+
+// cachedKey is the vault key last derived from cachedPassphrase, so the
+// slow key derivation runs once per salt rather than on every vault access.
+var cachedKey struct {
+	passphrase string
+	salt, key  []byte
+}
+
+// deriveVaultKey stretches the passphrase into an AES-256 key.
+var deriveVaultKey = func(passphrase string, salt []byte) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, passphrase, salt, vaultKDFIterations, 32)
+}
+
+// ValidateSecretsBackend reports whether backend names a known secret store.
+func ValidateSecretsBackend(backend string) error {
+	switch backend {
+	case "", SecretsPlaintext, SecretsVault, SecretsHelper:
+		return nil
+	default:
+		return fmt.Errorf("unknown secrets backend %q: must be plaintext, vault, or helper", backend)
+	}
+}
+
+// NewSecretStore returns the store for the given backend, or nil for the
+// plaintext backend.
+func NewSecretStore(backend string, settings SecretsConfig) (SecretStore, error) {
+	switch backend {
+	case "", SecretsPlaintext:
+		return nil, nil
+	case SecretsVault:
+		return &vaultStore{path: vaultPath()}, nil
+	case SecretsHelper:
+		args := strings.Fields(settings.Helper)
+		if len(args) == 0 {
+			return nil, fmt.Errorf("secrets.helper is not configured")
+		}
+		return &helperStore{command: args}, nil
+	default:
+		return nil, ValidateSecretsBackend(backend)
+	}
+}
+
+// splitTokenRef parses "<backend>:<key>" references.
+func splitTokenRef(ref string) (string, string, error) {
+	backend, key, ok := strings.Cut(ref, ":")
+	if !ok || backend == "" || key == "" {
+		return "", "", fmt.Errorf("invalid token_ref %q: expected <backend>:<key>", ref)
+	}
+	return backend, key, nil
+}
+
+// tokenField describes one token/token_ref pair in a resolved Config.
+type tokenField struct {
+	name  string
+	token *string
+	ref   *string
+}
+
+func tokenFields(cfg *Config) []tokenField {
+	return []tokenField{
+		{name: "jira", token: &cfg.Jira.Token, ref: &cfg.Jira.TokenRef},
+		{name: "bitbucket", token: &cfg.Bitbucket.Token, ref: &cfg.Bitbucket.TokenRef},
+		{name: "jenkins", token: &cfg.Jenkins.Token, ref: &cfg.Jenkins.TokenRef},
+	}
+}
+
+// resolveTokens replaces token_ref values with the secrets they point to and
+// remembers what was resolved so Save can tell whether a token changed.
+func resolveTokens(cfg *Config) error {
+	cfg.resolvedTokens = make(map[string]string)
+	for _, field := range tokenFields(cfg) {
+		if *field.ref == "" {
+			continue
+		}
+		backend, key, err := splitTokenRef(*field.ref)
+		if err != nil {
+			return fmt.Errorf("%s.token_ref: %w", field.name, err)
+		}
+		store, err := NewSecretStore(backend, cfg.Secrets)
+		if err != nil {
+			return fmt.Errorf("%s.token_ref: %w", field.name, err)
+		}
+		if store == nil {
+			return fmt.Errorf("%s.token_ref: backend %q cannot hold references", field.name, backend)
+		}
+		value, err := store.Get(key)
+		if err != nil {
+			return fmt.Errorf("resolve %s.token_ref: %w", field.name, err)
+		}
+		*field.token = value
+		cfg.resolvedTokens[field.name] = value
+	}
+	return nil
+}
+
+// storeTokens moves new or changed tokens into the selected backend and
+// returns a copy of cfg that is safe to write to disk. cfg itself keeps its
+// tokens and records the new references.
+func storeTokens(cfg *Config) (*Config, error) {
+	out := *cfg
+	original := tokenFields(cfg)
+	for i, field := range tokenFields(&out) {
+		token := *field.token
+		if *field.ref != "" {
+			if resolved, ok := cfg.resolvedTokens[field.name]; ok && resolved == token {
+				*field.token = ""
+				continue
+			}
+		}
+		if token == "" {
+			continue
+		}
+
+		store, err := NewSecretStore(out.Secrets.Backend, out.Secrets)
+		if err != nil {
+			return nil, err
+		}
+		if store == nil {
+			// Plaintext backend: the token replaces any previous reference.
+			*field.ref = ""
+			*original[i].ref = ""
+			continue
+		}
+		key := out.Profile
+		if key == "" {
+			key = DefaultProfile
+		}
+		key += "/" + field.name
+		if *field.ref != "" {
+			if backend, refKey, err := splitTokenRef(*field.ref); err == nil && backend == out.Secrets.Backend {
+				key = refKey
+			}
+		}
+		if err := store.Set(key, token); err != nil {
+			return nil, fmt.Errorf("store %s token: %w", field.name, err)
+		}
+		*field.ref = out.Secrets.Backend + ":" + key
+		*field.token = ""
+
+		*original[i].ref = *field.ref
+		if cfg.resolvedTokens == nil {
+			cfg.resolvedTokens = make(map[string]string)
+		}
+		cfg.resolvedTokens[field.name] = token
+	}
+	return &out, nil
+}
+
+type vaultStore struct {
+	path string
+}
+
+type vaultFile struct {
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+const vaultKDFIterations = 600000
+
+func (v *vaultStore) Get(key string) (string, error) {
+	secrets, err := v.read()
+	if err != nil {
+		return "", err
+	}
+	value, ok := secrets[key]
+	if !ok {
+		return "", fmt.Errorf("secret %q not found in vault", key)
+	}
+	return value, nil
+}
+
+func (v *vaultStore) Set(key, value string) error {
+	secrets, err := v.read()
+	if err != nil {
+		return err
+	}
+	secrets[key] = value
+	return v.write(secrets)
+}
+
+func (v *vaultStore) read() (map[string]string, error) {
+	data, err := os.ReadFile(v.path)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault: %w", err)
+	}
+
+	var file vaultFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse vault: %w", err)
+	}
+	gcm, err := vaultCipher(file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		cachedPassphrase = ""
+		return nil, errors.New("failed to decrypt vault: wrong passphrase or corrupted file")
+	}
+
+	secrets := map[string]string{}
+	if err := json.Unmarshal(plain, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to parse vault contents: %w", err)
+	}
+	return secrets, nil
+}
+
+func (v *vaultStore) write(secrets map[string]string) error {
+	plain, err := json.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("failed to marshal vault contents: %w", err)
+	}
+
+	// Keep the salt of the key already derived; every write gets a fresh
+	// nonce, so reusing the key is safe.
+	file := vaultFile{Salt: cachedKey.salt}
+	if cachedKey.passphrase == "" || cachedKey.passphrase != cachedPassphrase {
+		file.Salt = make([]byte, 16)
+		if _, err := rand.Read(file.Salt); err != nil {
+			return fmt.Errorf("failed to generate salt: %w", err)
+		}
+	}
+	gcm, err := vaultCipher(file.Salt)
+	if err != nil {
+		return err
+	}
+	file.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	file.Data = gcm.Seal(nil, file.Nonce, plain, nil)
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal vault: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(v.path), 0700); err != nil {
+		return fmt.Errorf("failed to create vault directory: %w", err)
+	}
+	if err := os.WriteFile(v.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write vault: %w", err)
+	}
+	return nil
+}
+
+func vaultCipher(salt []byte) (cipher.AEAD, error) {
+	if cachedPassphrase == "" {
+		passphrase, err := vaultPassphrase()
+		if err != nil {
+			return nil, err
+		}
+		if passphrase == "" {
+			return nil, errors.New("vault passphrase cannot be empty")
+		}
+		cachedPassphrase = passphrase
+	}
+
+	if cachedKey.passphrase != cachedPassphrase || !bytes.Equal(cachedKey.salt, salt) {
+		key, err := deriveVaultKey(cachedPassphrase, salt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive vault key: %w", err)
+		}
+		cachedKey.passphrase, cachedKey.salt, cachedKey.key = cachedPassphrase, salt, key
+	}
+	block, err := aes.NewCipher(cachedKey.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// helperStore runs an external command in the style of git credential
+// helpers: "<helper> get <key>" prints the secret on stdout and
+// "<helper> store <key>" reads it from stdin.
+type helperStore struct {
+	command []string
+}
+
+func (h *helperStore) Get(key string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(h.command[0], append(h.command[1:], "get", key)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("credential helper failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	// Accept either a bare token or git-style "password=<token>" output.
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), "password="); ok {
+			return value, nil
+		}
+	}
+	value := strings.TrimSpace(stdout.String())
+	if value == "" {
+		return "", fmt.Errorf("credential helper returned no secret for %q", key)
+	}
+	return value, nil
+}
+
+func (h *helperStore) Set(key, value string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command(h.command[0], append(h.command[1:], "store", key)...)
+	cmd.Stdin = strings.NewReader(value + "\n")
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("credential helper failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVaultBackendStoresTokensOutsideConfig(t *testing.T) {
+	useTempConfig(t)
+	t.Setenv(VaultPassphraseEnvVar, "correct horse")
+	cachedPassphrase = ""
+	t.Cleanup(func() { cachedPassphrase = "" })
+
+	cfg := &Config{
+		Jira:    JiraConfig{URL: "https://jira.example", Token: "jira-secret"},
+		Secrets: SecretsConfig{Backend: SecretsVault},
+	}
+	if err := Save(cfg); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if cfg.Jira.TokenRef != "vault:default/jira" {
+		t.Fatalf("expected token_ref to be recorded, got %q", cfg.Jira.TokenRef)
+	}
+
+	raw, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if strings.Contains(string(raw), "jira-secret") {
+		t.Fatalf("token leaked into config.json: %s", raw)
+	}
+	vault, err := os.ReadFile(vaultPath())
+	if err != nil {
+		t.Fatalf("read vault: %v", err)
+	}
+	if strings.Contains(string(vault), "jira-secret") {
+		t.Fatal("vault stores the token unencrypted")
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if loaded.Jira.Token != "jira-secret" {
+		t.Fatalf("expected token_ref to resolve, got %q", loaded.Jira.Token)
+	}
+
+	cachedPassphrase = ""
+	t.Setenv(VaultPassphraseEnvVar, "wrong")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Fatalf("expected decrypt failure, got %v", err)
+	}
+}
+
+func TestVaultDerivesKeyOncePerSalt(t *testing.T) {
+	useTempConfig(t)
+	t.Setenv(VaultPassphraseEnvVar, "correct horse")
+	cachedPassphrase = ""
+	origDerive := deriveVaultKey
+	derived := 0
+	deriveVaultKey = func(passphrase string, salt []byte) ([]byte, error) {
+		derived++
+		return origDerive(passphrase, salt)
+	}
+	t.Cleanup(func() {
+		cachedPassphrase = ""
+		cachedKey.passphrase, cachedKey.salt, cachedKey.key = "", nil, nil
+		deriveVaultKey = origDerive
+	})
+
+	store := &vaultStore{path: vaultPath()}
+	for _, key := range []string{"default/jira", "default/bitbucket", "default/jenkins"} {
+		if err := store.Set(key, key+"-secret"); err != nil {
+			t.Fatalf("set %s: %v", key, err)
+		}
+	}
+	if value, err := store.Get("default/bitbucket"); err != nil || value != "default/bitbucket-secret" {
+		t.Fatalf("unexpected vault value %q, %v", value, err)
+	}
+	if derived != 1 {
+		t.Fatalf("expected the vault key to be derived once, got %d", derived)
+	}
+
+	cachedPassphrase = ""
+	t.Setenv(VaultPassphraseEnvVar, "wrong")
+	if _, err := store.Get("default/jira"); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Fatalf("expected a new passphrase to derive a new key, got %v", err)
+	}
+}
+
+func TestHelperBackend(t *testing.T) {
+	useTempConfig(t)
+
+	dir := t.TempDir()
+	store := filepath.Join(dir, "store")
+	helper := filepath.Join(dir, "helper.sh")
+	script := "#!/bin/sh\ncase \"$1\" in\n  get) grep \"^$2=\" " + store + " | cut -d= -f2- ;;\n  store) read v; echo \"$2=$v\" >> " + store + " ;;\nesac\n"
+	if err := os.WriteFile(helper, []byte(script), 0700); err != nil {
+		t.Fatalf("write helper: %v", err)
+	}
+
+	cfg := &Config{
+		Bitbucket: BitbucketConfig{Workspace: "ws", Token: "bb-secret"},
+		Secrets:   SecretsConfig{Backend: SecretsHelper, Helper: helper},
+	}
+	if err := Save(cfg); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if loaded.Bitbucket.TokenRef != "helper:default/bitbucket" || loaded.Bitbucket.Token != "bb-secret" {
+		t.Fatalf("unexpected bitbucket config: %+v", loaded.Bitbucket)
+	}
+
+	// Saving an unchanged token must not call the helper's store again.
+	if err := Save(loaded); err != nil {
+		t.Fatalf("save unchanged: %v", err)
+	}
+	data, _ := os.ReadFile(store)
+	if got := strings.Count(string(data), "default/bitbucket="); got != 1 {
+		t.Fatalf("expected one stored secret, got %d in %q", got, data)
+	}
+}
+
+func TestSplitTokenRef(t *testing.T) {
+	if _, _, err := splitTokenRef("novalue"); err == nil {
+		t.Fatal("expected error for reference without backend")
+	}
+	backend, key, err := splitTokenRef("helper:team/jira")
+	if err != nil || backend != "helper" || key != "team/jira" {
+		t.Fatalf("unexpected split: %q %q %v", backend, key, err)
+	}
+}
@@ -0,0 +1,124 @@
+package httpx
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const (
+	SchemeBasic  = "Basic"
+	SchemeBearer = "Bearer"
+	SchemeNone   = "none"
+)
+
+// AuthScheme reports the scheme ApplyAuth selects for the given credentials.
+func AuthScheme(username, token string) string {
+	if username != "" {
+		return SchemeBasic
+	}
+	if token == "" {
+		return SchemeNone
+	}
+	return SchemeBearer
+}
+
+// BasicAuthScheme reports the scheme ApplyBasicAuth selects for the given
+// credentials.
+func BasicAuthScheme(username, token string) string {
+	if username == "" || token == "" {
+		return SchemeNone
+	}
+	return SchemeBasic
+}
+
+// AuthReport describes who a set of credentials authenticates as.
+type AuthReport struct {
+	Identity string
+	Scheme   string
+	// MissingScopes lists scopes or permissions the server reported as
+	// missing, even when authentication itself succeeded.
+	MissingScopes []string
+}
+
+// AuthError reports credentials rejected with 401 or 403.
+type AuthError struct {
+	StatusCode    int
+	Message       string
+	MissingScopes []string
+}
+
+// HTTPStatus returns the response status code.
+func (e *AuthError) HTTPStatus() int {
+	return e.StatusCode
+}
+
+func (e *AuthError) Error() string {
+	msg := fmt.Sprintf("authentication rejected with status %d", e.StatusCode)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if len(e.MissingScopes) > 0 {
+		msg += " (missing: " + strings.Join(e.MissingScopes, ", ") + ")"
+	}
+	return msg
+}
+
+var missingPermissionPattern = regexp.MustCompile(`missing the ([A-Za-z]+/[A-Za-z]+) permission`)
+
+// NewAuthError builds an AuthError from a rejected response. Missing scopes
+// come from Bitbucket's OAuth scope headers or Jenkins' permission messages;
+// the message from Jira/Bitbucket JSON error bodies.
+func NewAuthError(resp *http.Response, body []byte) *AuthError {
+	authErr := &AuthError{StatusCode: resp.StatusCode, MissingScopes: MissingScopes(resp.Header)}
+	for _, match := range missingPermissionPattern.FindAllStringSubmatch(string(body), -1) {
+		authErr.MissingScopes = appendUnique(authErr.MissingScopes, match[1])
+	}
+
+	if messages, _ := parseErrorBody(body); len(messages) > 0 {
+		authErr.Message = strings.Join(messages, "; ")
+	}
+	if authErr.Message == "" {
+		authErr.Message = http.StatusText(resp.StatusCode)
+	}
+	return authErr
+}
+
+// MissingScopes compares the scopes an endpoint accepts with the scopes
+// granted to the token, as advertised in X-Accepted-OAuth-Scopes and
+// X-OAuth-Scopes response headers.
+func MissingScopes(header http.Header) []string {
+	accepted := splitScopes(header.Get("X-Accepted-OAuth-Scopes"))
+	if len(accepted) == 0 {
+		return nil
+	}
+	granted := map[string]bool{}
+	for _, scope := range splitScopes(header.Get("X-OAuth-Scopes")) {
+		granted[scope] = true
+	}
+	var missing []string
+	for _, scope := range accepted {
+		if !granted[scope] {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+func splitScopes(value string) []string {
+	var scopes []string
+	for _, scope := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+		scopes = appendUnique(scopes, scope)
+	}
+	return scopes
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
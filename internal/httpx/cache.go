@@ -0,0 +1,380 @@
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheMode selects how the response cache is used.
+type CacheMode int
+
+const (
+	// CacheOff neither reads nor writes cached responses.
+	CacheOff CacheMode = iota
+	// CacheNormal serves fresh entries and revalidates stale ones.
+	CacheNormal
+	// CacheRefresh ignores cached entries but stores the new responses.
+	CacheRefresh
+)
+
+// CacheStatusHeader is added to responses to report how the cache handled
+// them: "hit", "revalidated", "miss" or "refresh".
+const CacheStatusHeader = "X-Devflow-Cache"
+
+// maxCachedBody bounds the size of a response body that is cached.
+const maxCachedBody = 8 << 20
+
+// CacheClass assigns a time-to-live to the endpoints whose path matches
+// Pattern. Within the TTL a cached response is served without contacting the
+// server; after it, the response is revalidated with If-None-Match or
+// If-Modified-Since when the server supplied an ETag or Last-Modified.
+type CacheClass struct {
+	Name    string
+	Pattern *regexp.Regexp
+	TTL     time.Duration
+}
+
+// DefaultCacheClasses lists the endpoint classes in match order. Paths that
+// match no class get a zero TTL and are only reused after revalidation.
+var DefaultCacheClasses = []CacheClass{
+	{Name: "repositories", Pattern: regexp.MustCompile(`^/2\.0/repositories/[^/]+(/[^/]+)?/?$`), TTL: 15 * time.Minute},
+	{Name: "sources", Pattern: regexp.MustCompile(`^/2\.0/repositories/[^/]+/[^/]+/src/`), TTL: 15 * time.Minute},
+	{Name: "pullrequests", Pattern: regexp.MustCompile(`^/2\.0/(repositories|workspaces)/.*/pullrequests`), TTL: time.Minute},
+	{Name: "pipelines", Pattern: regexp.MustCompile(`^/2\.0/repositories/[^/]+/[^/]+/pipelines`), TTL: 30 * time.Second},
+	{Name: "projects", Pattern: regexp.MustCompile(`^/rest/api/\d+/project`), TTL: time.Hour},
+	{Name: "users", Pattern: regexp.MustCompile(`^/(rest/api/\d+/(myself|user)|2\.0/user)`), TTL: time.Hour},
+	{Name: "fields", Pattern: regexp.MustCompile(`^/rest/api/\d+/(field|issue/createmeta)`), TTL: 24 * time.Hour},
+	{Name: "issues", Pattern: regexp.MustCompile(`^/rest/(api/\d+/(issue|search)|dev-status/)`), TTL: 30 * time.Second},
+}
+
+// cacheEntry is the on-disk form of a cached response.
+type cacheEntry struct {
+	Method   string      `json:"method"`
+	URL      string      `json:"url"`
+	Status   int         `json:"status"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+	StoredAt time.Time   `json:"stored_at"`
+}
+
+// Cache stores successful GET responses on disk, one directory per host.
+// Entries are keyed by method, URL and a hash of the Authorization header,
+// so different credentials never share responses.
+type Cache struct {
+	mu      sync.RWMutex
+	dir     string
+	mode    CacheMode
+	classes []CacheClass
+	now     func() time.Time
+}
+
+// sharedCache is used by every client built with NewClient. It stays off
+// until ConfigureCache is called.
+var sharedCache = &Cache{classes: DefaultCacheClasses, now: time.Now}
+
+// ConfigureCache enables the shared response cache in dir with the given
+// mode. An empty dir or CacheOff disables it.
+func ConfigureCache(dir string, mode CacheMode) {
+	sharedCache.Configure(dir, mode)
+}
+
+// Configure sets the cache directory and mode.
+func (c *Cache) Configure(dir string, mode CacheMode) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dir = dir
+	c.mode = mode
+	if dir == "" {
+		c.mode = CacheOff
+	}
+}
+
+func (c *Cache) settings() (string, CacheMode, []CacheClass) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.dir, c.mode, c.classes
+}
+
+// cacheTransport serves GET requests from a Cache before handing them to
+// base. Range requests bypass the cache and leave it untouched. Successful
+// requests with unsafe methods drop the cached entries of their host, since
+// they may have changed what those entries describe.
+type cacheTransport struct {
+	cache *Cache
+	base  http.RoundTripper
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	dir, mode, classes := t.cache.settings()
+	if mode == CacheOff {
+		return t.forward(req)
+	}
+	if req.Method == http.MethodGet && req.Header.Get("Range") != "" {
+		return t.forward(req)
+	}
+	if req.Method != http.MethodGet {
+		resp, err := t.forward(req)
+		if err == nil && resp.StatusCode < 400 && req.Method != http.MethodHead && req.Method != http.MethodOptions {
+			_ = os.RemoveAll(t.cache.hostDir(dir, req.URL.Host))
+		}
+		return resp, err
+	}
+
+	path := t.cache.entryPath(dir, req)
+	ttl := classTTL(classes, req.URL.Path)
+	var entry *cacheEntry
+	if mode == CacheNormal {
+		entry = readCacheEntry(path)
+	}
+	if entry != nil && t.cache.now().Sub(entry.StoredAt) < ttl {
+		return entry.response(req, "hit"), nil
+	}
+
+	outgoing := req
+	if entry != nil {
+		outgoing = req.Clone(req.Context())
+		if etag := entry.Header.Get("ETag"); etag != "" {
+			outgoing.Header.Set("If-None-Match", etag)
+		}
+		if modified := entry.Header.Get("Last-Modified"); modified != "" {
+			outgoing.Header.Set("If-Modified-Since", modified)
+		}
+	}
+
+	resp, err := t.forward(outgoing)
+	if err != nil {
+		return nil, err
+	}
+	if entry != nil && resp.StatusCode == http.StatusNotModified {
+		drainBody(resp)
+		entry.StoredAt = t.cache.now()
+		writeCacheEntry(path, entry)
+		return entry.response(req, "revalidated"), nil
+	}
+	if resp.StatusCode != http.StatusOK || !cacheable(resp, ttl) {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedBody+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCachedBody {
+		// Too large to cache: hand back what was read followed by the rest.
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	writeCacheEntry(path, &cacheEntry{
+		Method:   req.Method,
+		URL:      req.URL.String(),
+		Status:   resp.StatusCode,
+		Header:   resp.Header,
+		Body:     body,
+		StoredAt: t.cache.now(),
+	})
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	status := "miss"
+	if mode == CacheRefresh {
+		status = "refresh"
+	}
+	resp.Header.Set(CacheStatusHeader, status)
+	return resp, nil
+}
+
+// forward sends req to the network, after waiting on the gate attached to
+// its context, if any.
+func (t *cacheTransport) forward(req *http.Request) (*http.Response, error) {
+	if wait, ok := req.Context().Value(gateKey{}).(func(context.Context) error); ok {
+		if err := wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+type gateKey struct{}
+
+// WithGate returns a context whose requests call wait before they reach the
+// network, e.g. to apply a client-side rate limit. Responses served from the
+// cache skip the gate.
+func WithGate(ctx context.Context, wait func(context.Context) error) context.Context {
+	return context.WithValue(ctx, gateKey{}, wait)
+}
+
+// cacheable reports whether a 200 response is worth storing: it must not
+// forbid storage, and it must either stay fresh for a while or carry a
+// validator to revalidate it with.
+func cacheable(resp *http.Response, ttl time.Duration) bool {
+	if strings.Contains(strings.ToLower(resp.Header.Get("Cache-Control")), "no-store") {
+		return false
+	}
+	return ttl > 0 || resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != ""
+}
+
+func classTTL(classes []CacheClass, path string) time.Duration {
+	for _, class := range classes {
+		if class.Pattern.MatchString(path) {
+			return class.TTL
+		}
+	}
+	return 0
+}
+
+func (c *Cache) hostDir(dir, host string) string {
+	return filepath.Join(dir, sanitizeHost(host))
+}
+
+func (c *Cache) entryPath(dir string, req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL.String() + "\n" + req.Header.Get("Authorization")))
+	return filepath.Join(c.hostDir(dir, req.URL.Host), hex.EncodeToString(sum[:])+".json")
+}
+
+func sanitizeHost(host string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ':' || r == '/' || r == '\\' {
+			return '_'
+		}
+		return r
+	}, host)
+}
+
+func (e *cacheEntry) response(req *http.Request, status string) *http.Response {
+	header := e.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set(CacheStatusHeader, status)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+func readCacheEntry(path string) *cacheEntry {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if json.Unmarshal(data, &entry) != nil {
+		return nil
+	}
+	return &entry
+}
+
+// writeCacheEntry stores entry atomically. Failures only cost a cache miss
+// later, so they are ignored.
+func writeCacheEntry(path string, entry *cacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil || os.Rename(tmp.Name(), path) != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// CacheHostStats summarizes the cached entries of one host.
+type CacheHostStats struct {
+	Host    string    `json:"host"`
+	Entries int       `json:"entries"`
+	Bytes   int64     `json:"bytes"`
+	Oldest  time.Time `json:"oldest"`
+	Newest  time.Time `json:"newest"`
+}
+
+// CacheStats reports the entries stored in dir, per host, sorted by host.
+func CacheStats(dir string) ([]CacheHostStats, error) {
+	hosts, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	var stats []CacheHostStats
+	for _, host := range hosts {
+		if !host.IsDir() {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(dir, host.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cache directory: %w", err)
+		}
+		hostStats := CacheHostStats{Host: host.Name()}
+		for _, file := range files {
+			if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+				continue
+			}
+			info, err := file.Info()
+			if err != nil {
+				continue
+			}
+			hostStats.Entries++
+			hostStats.Bytes += info.Size()
+			if hostStats.Oldest.IsZero() || info.ModTime().Before(hostStats.Oldest) {
+				hostStats.Oldest = info.ModTime()
+			}
+			if info.ModTime().After(hostStats.Newest) {
+				hostStats.Newest = info.ModTime()
+			}
+		}
+		if hostStats.Entries > 0 {
+			stats = append(stats, hostStats)
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Host < stats[j].Host })
+	return stats, nil
+}
+
+// ClearCache removes every entry stored in dir and reports how many were
+// removed.
+func ClearCache(dir string) (int, error) {
+	stats, err := CacheStats(dir)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, host := range stats {
+		removed += host.Entries
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return 0, fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return removed, nil
+}
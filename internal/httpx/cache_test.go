@@ -0,0 +1,281 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestCache(t *testing.T, mode CacheMode) (*Cache, *time.Time) {
+	t.Helper()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	cache := &Cache{classes: DefaultCacheClasses, now: func() time.Time { return now }}
+	cache.Configure(t.TempDir(), mode)
+	return cache, &now
+}
+
+func cachedGet(t *testing.T, transport http.RoundTripper, url, auth string) (*http.Response, string) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	return resp, string(body)
+}
+
+func TestCacheServesFreshEntriesWithinTTL(t *testing.T) {
+	base := &scriptedTransport{responses: func(call int) (*http.Response, error) {
+		return response(http.StatusOK), nil
+	}}
+	cache, now := newTestCache(t, CacheNormal)
+	transport := &cacheTransport{cache: cache, base: base}
+	url := "https://api.bitbucket.org/2.0/repositories/acme?page=2"
+
+	resp, _ := cachedGet(t, transport, url, "Bearer a")
+	if resp.Header.Get(CacheStatusHeader) != "miss" {
+		t.Fatalf("expected a miss, got %q", resp.Header.Get(CacheStatusHeader))
+	}
+	resp, body := cachedGet(t, transport, url, "Bearer a")
+	if base.calls != 1 || resp.Header.Get(CacheStatusHeader) != "hit" || body != "body" || resp.Request == nil {
+		t.Fatalf("expected a cache hit, got %d calls, status %q, body %q", base.calls, resp.Header.Get(CacheStatusHeader), body)
+	}
+
+	cachedGet(t, transport, url, "Bearer b")
+	if base.calls != 2 {
+		t.Fatalf("expected other credentials to miss the cache, got %d calls", base.calls)
+	}
+
+	*now = now.Add(16 * time.Minute)
+	cachedGet(t, transport, url, "Bearer a")
+	if base.calls != 3 {
+		t.Fatalf("expected an expired entry to be fetched again, got %d calls", base.calls)
+	}
+}
+
+func TestCacheRevalidatesStaleEntries(t *testing.T) {
+	var conditional http.Header
+	base := &scriptedTransport{}
+	base.responses = func(call int) (*http.Response, error) {
+		if call == 0 {
+			resp := response(http.StatusOK, "ETag", `"v1"`, "Last-Modified", "Fri, 02 Jan 2026 03:00:00 GMT")
+			resp.Body = io.NopCloser(strings.NewReader("payload"))
+			return resp, nil
+		}
+		return response(http.StatusNotModified), nil
+	}
+	cache, _ := newTestCache(t, CacheNormal)
+	transport := &cacheTransport{cache: cache, base: recordingTransport{base: base, headers: &conditional}}
+	url := "https://jira.example/rest/api/3/serverInfo"
+
+	cachedGet(t, transport, url, "")
+	resp, body := cachedGet(t, transport, url, "")
+	if base.calls != 2 || resp.StatusCode != http.StatusOK || body != "payload" || resp.Header.Get(CacheStatusHeader) != "revalidated" {
+		t.Fatalf("expected a revalidated cached body, got %d calls, %d %q", base.calls, resp.StatusCode, body)
+	}
+	if conditional.Get("If-None-Match") != `"v1"` || conditional.Get("If-Modified-Since") == "" {
+		t.Fatalf("expected conditional headers, got %v", conditional)
+	}
+}
+
+type recordingTransport struct {
+	base    http.RoundTripper
+	headers *http.Header
+}
+
+func (r recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	*r.headers = req.Header.Clone()
+	return r.base.RoundTrip(req)
+}
+
+func TestCacheModesAndInvalidation(t *testing.T) {
+	base := &scriptedTransport{responses: func(call int) (*http.Response, error) {
+		return response(http.StatusOK), nil
+	}}
+	cache, _ := newTestCache(t, CacheRefresh)
+	transport := &cacheTransport{cache: cache, base: base}
+	url := "https://api.bitbucket.org/2.0/repositories/acme/api"
+
+	cachedGet(t, transport, url, "")
+	resp, _ := cachedGet(t, transport, url, "")
+	if base.calls != 2 || resp.Header.Get(CacheStatusHeader) != "refresh" {
+		t.Fatalf("expected --refresh to bypass the cache, got %d calls", base.calls)
+	}
+
+	cache.Configure(cache.dir, CacheNormal)
+	cachedGet(t, transport, url, "")
+	if base.calls != 2 {
+		t.Fatalf("expected the refreshed entry to be served, got %d calls", base.calls)
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "https://api.bitbucket.org/2.0/repositories/acme/api/pullrequests", strings.NewReader("{}"))
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	cachedGet(t, transport, url, "")
+	if base.calls != 4 {
+		t.Fatalf("expected a write to invalidate the host, got %d calls", base.calls)
+	}
+
+	cache.Configure(cache.dir, CacheOff)
+	resp, _ = cachedGet(t, transport, url, "")
+	if base.calls != 5 || resp.Header.Get(CacheStatusHeader) != "" {
+		t.Fatalf("expected --no-cache to skip the cache, got %d calls", base.calls)
+	}
+}
+
+func TestCacheSkipsUncacheableResponses(t *testing.T) {
+	base := &scriptedTransport{responses: func(call int) (*http.Response, error) {
+		if call < 2 {
+			return response(http.StatusOK, "Cache-Control", "no-store"), nil
+		}
+		return response(http.StatusNotFound), nil
+	}}
+	cache, _ := newTestCache(t, CacheNormal)
+	transport := &cacheTransport{cache: cache, base: base}
+
+	for i := 0; i < 2; i++ {
+		cachedGet(t, transport, "https://api.bitbucket.org/2.0/repositories/acme", "")
+	}
+	for i := 0; i < 2; i++ {
+		cachedGet(t, transport, "https://api.bitbucket.org/2.0/repositories/missing", "")
+	}
+	// Without a TTL or validator there is nothing to reuse.
+	cachedGet(t, transport, "https://jenkins.example/job/x/api/json", "")
+	if base.calls != 5 {
+		t.Fatalf("expected every request to reach the server, got %d calls", base.calls)
+	}
+	stats, err := CacheStats(cache.dir)
+	if err != nil || len(stats) != 0 {
+		t.Fatalf("expected an empty cache, got %v, %v", stats, err)
+	}
+}
+
+func TestCachePassesThroughLargeAndRangeResponses(t *testing.T) {
+	large := strings.Repeat("x", maxCachedBody+10)
+	base := &scriptedTransport{responses: func(call int) (*http.Response, error) {
+		resp := response(http.StatusOK)
+		resp.Body = io.NopCloser(strings.NewReader(large))
+		return resp, nil
+	}}
+	cache, _ := newTestCache(t, CacheNormal)
+	transport := &cacheTransport{cache: cache, base: base}
+	url := "https://api.bitbucket.org/2.0/repositories/acme/app/src/main/big.bin"
+
+	for i := 0; i < 2; i++ {
+		if _, body := cachedGet(t, transport, url, ""); body != large {
+			t.Fatalf("expected the full %d byte body, got %d bytes", len(large), len(body))
+		}
+	}
+	if base.calls != 2 {
+		t.Fatalf("expected an oversized body not to be cached, got %d calls", base.calls)
+	}
+
+	base.responses = func(call int) (*http.Response, error) { return response(http.StatusOK), nil }
+	cachedGet(t, transport, url, "")
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("Range", "bytes=2-")
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if base.calls != 4 || resp.Header.Get(CacheStatusHeader) != "" {
+		t.Fatalf("expected the range request to bypass the cache, got %d calls, status %q", base.calls, resp.Header.Get(CacheStatusHeader))
+	}
+}
+
+func TestCacheRangeResponsesKeepEntries(t *testing.T) {
+	base := &scriptedTransport{responses: func(call int) (*http.Response, error) {
+		if call == 1 {
+			return response(http.StatusPartialContent), nil
+		}
+		return response(http.StatusOK), nil
+	}}
+	cache, _ := newTestCache(t, CacheNormal)
+	transport := &cacheTransport{cache: cache, base: base}
+	url := "https://api.bitbucket.org/2.0/repositories/acme/api"
+
+	cachedGet(t, transport, url, "")
+	req, _ := http.NewRequest(http.MethodGet, url+"/src/main/big.bin", nil)
+	req.Header.Set("Range", "bytes=100-")
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		t.Fatalf("expected the 206 response to be passed through, got %d", resp.StatusCode)
+	}
+
+	resp, _ = cachedGet(t, transport, url, "")
+	if base.calls != 2 || resp.Header.Get(CacheStatusHeader) != "hit" {
+		t.Fatalf("expected the range request to keep cached entries, got %d calls, status %q", base.calls, resp.Header.Get(CacheStatusHeader))
+	}
+}
+
+func TestCacheGateSkippedOnHits(t *testing.T) {
+	base := &scriptedTransport{responses: func(call int) (*http.Response, error) {
+		return response(http.StatusOK), nil
+	}}
+	cache, _ := newTestCache(t, CacheNormal)
+	transport := &cacheTransport{cache: cache, base: base}
+
+	waits := 0
+	ctx := WithGate(context.Background(), func(context.Context) error {
+		waits++
+		return nil
+	})
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.bitbucket.org/2.0/repositories/acme", nil)
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if waits != 1 {
+		t.Fatalf("expected only the network request to wait, got %d waits", waits)
+	}
+
+	blocked := errors.New("blocked")
+	ctx = WithGate(context.Background(), func(context.Context) error { return blocked })
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.bitbucket.org/2.0/workspaces", nil)
+	if _, err := transport.RoundTrip(req); !errors.Is(err, blocked) {
+		t.Fatalf("expected the gate error, got %v", err)
+	}
+}
+
+func TestCacheStatsAndClear(t *testing.T) {
+	base := &scriptedTransport{responses: func(call int) (*http.Response, error) {
+		return response(http.StatusOK), nil
+	}}
+	cache, _ := newTestCache(t, CacheNormal)
+	transport := &cacheTransport{cache: cache, base: base}
+	cachedGet(t, transport, "https://api.bitbucket.org/2.0/repositories/acme", "")
+	cachedGet(t, transport, "https://api.bitbucket.org/2.0/repositories/acme/api", "")
+	cachedGet(t, transport, "https://jira.example:8443/rest/api/3/project", "")
+
+	stats, err := CacheStats(cache.dir)
+	if err != nil || len(stats) != 2 {
+		t.Fatalf("expected two hosts, got %v, %v", stats, err)
+	}
+	if stats[0].Host != "api.bitbucket.org" || stats[0].Entries != 2 || stats[0].Bytes == 0 || stats[1].Host != "jira.example_8443" {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	removed, err := ClearCache(cache.dir)
+	if err != nil || removed != 3 {
+		t.Fatalf("expected 3 entries removed, got %d, %v", removed, err)
+	}
+	if stats, _ := CacheStats(cache.dir); len(stats) != 0 {
+		t.Fatalf("expected an empty cache after clear, got %v", stats)
+	}
+}
@@ -0,0 +1,176 @@
+package httpx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Service names carried by APIError.
+const (
+	ServiceJira      = "jira"
+	ServiceBitbucket = "bitbucket"
+	ServiceJenkins   = "jenkins"
+)
+
+// maxMessageLength bounds how much of an unstructured error body is kept.
+const maxMessageLength = 200
+
+// APIError is returned when a service answers with a non-success status.
+type APIError struct {
+	Service    string
+	StatusCode int
+	// Method and Endpoint identify the failed request; Endpoint is the URL
+	// path without its query string.
+	Method   string
+	Endpoint string
+	// Messages are the human-readable errors parsed from the response body:
+	// Jira's errorMessages and per-field errors, Bitbucket's error.message
+	// and error.detail, or the title of a Jenkins HTML error page.
+	Messages []string
+	// FieldErrors holds Jira's per-field validation errors.
+	FieldErrors map[string]string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s API returned %d %s", e.Service, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Endpoint != "" {
+		msg += " for " + strings.TrimSpace(e.Method+" "+e.Endpoint)
+	}
+	if len(e.Messages) > 0 {
+		msg += ": " + strings.Join(e.Messages, "; ")
+	}
+	return msg
+}
+
+// HTTPStatus returns the response status code.
+func (e *APIError) HTTPStatus() int {
+	return e.StatusCode
+}
+
+// NewAPIError builds an APIError for service from a failed response and its
+// already-read body.
+func NewAPIError(service string, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{Service: service, StatusCode: resp.StatusCode}
+	if resp.Request != nil && resp.Request.URL != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Endpoint = resp.Request.URL.Path
+	}
+	apiErr.Messages, apiErr.FieldErrors = parseErrorBody(body)
+	if len(apiErr.Messages) == 0 {
+		if text := textMessage(body); text != "" {
+			apiErr.Messages = []string{text}
+		}
+	}
+	return apiErr
+}
+
+// parseErrorBody extracts error messages from the JSON error shapes used by
+// Jira and Bitbucket.
+func parseErrorBody(body []byte) ([]string, map[string]string) {
+	var payload struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+		Message       string            `json:"message"`
+		Error         struct {
+			Message string `json:"message"`
+			Detail  string `json:"detail"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &payload) != nil {
+		return nil, nil
+	}
+
+	var messages []string
+	messages = append(messages, payload.ErrorMessages...)
+	fields := make([]string, 0, len(payload.Errors))
+	for field := range payload.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		messages = append(messages, field+": "+payload.Errors[field])
+	}
+	if payload.Error.Message != "" {
+		messages = append(messages, payload.Error.Message)
+	}
+	if payload.Error.Detail != "" {
+		messages = append(messages, payload.Error.Detail)
+	}
+	if len(messages) == 0 && payload.Message != "" {
+		messages = append(messages, payload.Message)
+	}
+
+	var fieldErrors map[string]string
+	if len(payload.Errors) > 0 {
+		fieldErrors = payload.Errors
+	}
+	return messages, fieldErrors
+}
+
+var htmlTitlePattern = regexp.MustCompile(`(?is)<title>(.*?)</title>`)
+
+// textMessage summarizes a non-JSON body: the title of an HTML page, or the
+// first line of plain text.
+func textMessage(body []byte) string {
+	text := strings.TrimSpace(string(body))
+	if text == "" || json.Valid(body) {
+		return ""
+	}
+	if strings.HasPrefix(text, "<") {
+		match := htmlTitlePattern.FindStringSubmatch(text)
+		if match == nil {
+			return ""
+		}
+		text = strings.Join(strings.Fields(html.UnescapeString(match[1])), " ")
+	} else {
+		text, _, _ = strings.Cut(text, "\n")
+		text = strings.TrimSpace(text)
+	}
+	if len(text) > maxMessageLength {
+		text = text[:maxMessageLength] + "..."
+	}
+	return text
+}
+
+// StatusCode returns the HTTP status carried by err, or 0 when err does not
+// come from an HTTP response.
+func StatusCode(err error) int {
+	var statusErr interface{ HTTPStatus() int }
+	if errors.As(err, &statusErr) {
+		return statusErr.HTTPStatus()
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is a 404 response.
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether err is a 401 response, i.e. missing,
+// expired or invalid credentials.
+func IsUnauthorized(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
+}
+
+// IsForbidden reports whether err is a 403 response, i.e. valid credentials
+// without the required permission.
+func IsForbidden(err error) bool {
+	return StatusCode(err) == http.StatusForbidden
+}
+
+// IsRateLimited reports whether err is a 429 response.
+func IsRateLimited(err error) bool {
+	return StatusCode(err) == http.StatusTooManyRequests
+}
+
+// IsServerError reports whether err is a 5xx response.
+func IsServerError(err error) bool {
+	return StatusCode(err) >= 500
+}
@@ -0,0 +1,96 @@
+package httpx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func failedResponse(status int, method, path string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Request:    &http.Request{Method: method, URL: &url.URL{Scheme: "https", Host: "api.example", Path: path, RawQuery: "a=b"}},
+	}
+}
+
+func TestNewAPIErrorParsesServiceBodies(t *testing.T) {
+	tests := []struct {
+		name     string
+		service  string
+		body     string
+		messages []string
+		fields   map[string]string
+	}{
+		{
+			name:     "jira",
+			service:  ServiceJira,
+			body:     `{"errorMessages":["Issue does not exist"],"errors":{"summary":"Summary is required","assignee":"User not found"}}`,
+			messages: []string{"Issue does not exist", "assignee: User not found", "summary: Summary is required"},
+			fields:   map[string]string{"summary": "Summary is required", "assignee": "User not found"},
+		},
+		{
+			name:     "bitbucket",
+			service:  ServiceBitbucket,
+			body:     `{"type":"error","error":{"message":"Repository not found","detail":"Check the slug"}}`,
+			messages: []string{"Repository not found", "Check the slug"},
+		},
+		{
+			name:     "jenkins html",
+			service:  ServiceJenkins,
+			body:     "<html><head><title>Error 404 Not Found</title></head><body><h2>HTTP ERROR 404</h2></body></html>",
+			messages: []string{"Error 404 Not Found"},
+		},
+		{
+			name:     "plain text",
+			service:  ServiceJenkins,
+			body:     "upstream unavailable\nstack trace",
+			messages: []string{"upstream unavailable"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := NewAPIError(tt.service, failedResponse(http.StatusNotFound, http.MethodGet, "/rest/api/3/issue/ABC-1"), []byte(tt.body))
+			if !reflect.DeepEqual(apiErr.Messages, tt.messages) {
+				t.Fatalf("messages = %q, want %q", apiErr.Messages, tt.messages)
+			}
+			if tt.fields != nil && !reflect.DeepEqual(apiErr.FieldErrors, tt.fields) {
+				t.Fatalf("field errors = %v, want %v", apiErr.FieldErrors, tt.fields)
+			}
+			if apiErr.Service != tt.service || apiErr.Method != http.MethodGet || apiErr.Endpoint != "/rest/api/3/issue/ABC-1" {
+				t.Fatalf("unexpected request details: %+v", apiErr)
+			}
+		})
+	}
+}
+
+func TestAPIErrorMessageAndSentinels(t *testing.T) {
+	apiErr := NewAPIError(ServiceJira, failedResponse(http.StatusNotFound, http.MethodGet, "/rest/api/3/issue/ABC-1"), []byte(`{"errorMessages":["Issue does not exist"]}`))
+	want := "jira API returned 404 Not Found for GET /rest/api/3/issue/ABC-1: Issue does not exist"
+	if apiErr.Error() != want {
+		t.Fatalf("Error() = %q, want %q", apiErr.Error(), want)
+	}
+
+	wrapped := fmt.Errorf("fetching issue: %w", apiErr)
+	if !IsNotFound(wrapped) || IsUnauthorized(wrapped) || StatusCode(wrapped) != http.StatusNotFound {
+		t.Fatal("expected sentinels to see through wrapping")
+	}
+	if !IsUnauthorized(&AuthError{StatusCode: http.StatusUnauthorized}) {
+		t.Fatal("expected AuthError to report its status")
+	}
+	if !IsForbidden(NewAPIError(ServiceBitbucket, failedResponse(http.StatusForbidden, http.MethodGet, "/"), nil)) {
+		t.Fatal("expected IsForbidden")
+	}
+	if !IsRateLimited(NewAPIError(ServiceBitbucket, failedResponse(http.StatusTooManyRequests, http.MethodGet, "/"), nil)) {
+		t.Fatal("expected IsRateLimited")
+	}
+	if !IsServerError(NewAPIError(ServiceJenkins, failedResponse(http.StatusBadGateway, http.MethodGet, "/"), nil)) {
+		t.Fatal("expected IsServerError")
+	}
+	if StatusCode(errors.New("plain")) != 0 || IsNotFound(nil) {
+		t.Fatal("expected plain errors to carry no status")
+	}
+}
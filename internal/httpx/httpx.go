@@ -1,6 +1,7 @@
 package httpx
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"sync"
@@ -9,23 +10,61 @@ import (
 
 var testServerRegistry sync.Map
 
-// NewClient returns an HTTP client with a fixed timeout.
+// NewClient returns an HTTP client whose requests go through the shared
+// response cache and RetryTransport; see ConfigureCache and SetRetryPolicy.
+// Each attempt, including reading its response body, is limited to timeout;
+// the waits between retries are not.
 func NewClient(timeout time.Duration) *http.Client {
 	return &http.Client{
-		Timeout:   timeout,
-		Transport: &testAwareTransport{base: http.DefaultTransport},
+		Transport: &timeoutTransport{
+			timeout: timeout,
+			base:    &testAwareTransport{base: &cacheTransport{cache: sharedCache, base: sharedTransport}},
+		},
 	}
 }
 
+// WithoutTimeout returns a copy of client without its timeout, for
+// transfers whose duration depends on their size. They are bounded by their
+// context instead.
+func WithoutTimeout(client *http.Client) *http.Client {
+	copied := *client
+	copied.Timeout = 0
+	if transport, ok := copied.Transport.(*timeoutTransport); ok {
+		copied.Transport = transport.base
+	}
+	return &copied
+}
+
+// attemptTimeoutKey carries the per-attempt timeout of a NewClient client
+// down to RetryTransport.
+type attemptTimeoutKey struct{}
+
+type timeoutTransport struct {
+	timeout time.Duration
+	base    http.RoundTripper
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.timeout > 0 {
+		req = req.WithContext(context.WithValue(req.Context(), attemptTimeoutKey{}, t.timeout))
+	}
+	return t.base.RoundTrip(req)
+}
+
 type testAwareTransport struct {
 	base http.RoundTripper
 }
 
 func (t *testAwareTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	if handler, ok := testServerRegistry.Load(req.URL.Host); ok {
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
 		recorder := httptest.NewRecorder()
 		handler.(http.Handler).ServeHTTP(recorder, req)
-		return recorder.Result(), nil
+		resp := recorder.Result()
+		resp.Request = req
+		return resp, nil
 	}
 
 	if t.base != nil {
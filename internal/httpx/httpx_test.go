@@ -115,3 +115,29 @@ type roundTripperFunc func(req *http.Request) (*http.Response, error)
 func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
 	return f(req)
 }
+
+func TestNewAuthError(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}}
+	resp.Header.Set("X-Accepted-OAuth-Scopes", "pullrequest:write, repository")
+	resp.Header.Set("X-OAuth-Scopes", "repository")
+
+	authErr := NewAuthError(resp, []byte(`{"type":"error","error":{"message":"Forbidden"}}`))
+	if authErr.Message != "Forbidden" || len(authErr.MissingScopes) != 1 || authErr.MissingScopes[0] != "pullrequest:write" {
+		t.Fatalf("unexpected auth error: %+v", authErr)
+	}
+
+	resp = &http.Response{StatusCode: http.StatusUnauthorized, Header: http.Header{}}
+	authErr = NewAuthError(resp, []byte("<html>denied</html>"))
+	if authErr.Message != "Unauthorized" || authErr.Error() != "authentication rejected with status 401: Unauthorized" {
+		t.Fatalf("unexpected auth error: %v", authErr)
+	}
+}
+
+func TestAuthScheme(t *testing.T) {
+	if AuthScheme("user", "token") != SchemeBasic || AuthScheme("", "token") != SchemeBearer || AuthScheme("", "") != SchemeNone {
+		t.Fatal("unexpected AuthScheme result")
+	}
+	if BasicAuthScheme("", "token") != SchemeNone || BasicAuthScheme("user", "token") != SchemeBasic {
+		t.Fatal("unexpected BasicAuthScheme result")
+	}
+}
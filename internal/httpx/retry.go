@@ -0,0 +1,391 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls how RetryTransport retries failed requests.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. Zero
+	// disables retries.
+	MaxRetries int
+	// BaseDelay is the backoff ceiling of the first retry; it doubles on
+	// every following retry. The actual wait is a random duration up to the
+	// ceiling ("full jitter").
+	BaseDelay time.Duration
+	// MaxDelay caps both the backoff and server-requested waits. A
+	// Retry-After or X-RateLimit-Reset further away than MaxDelay is not
+	// waited for and the response is returned as is.
+	MaxDelay time.Duration
+	// HostBudget is the number of retries a single host may consume. Every
+	// retry spends one token and every successful response earns back a
+	// tenth of one, so a failing host stops being retried quickly while an
+	// occasional blip on a healthy one always is. Zero means unlimited.
+	HostBudget int
+}
+
+// DefaultRetryPolicy is used until SetRetryPolicy is called.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   30 * time.Second,
+	HostBudget: 10,
+}
+
+// budgetRefill is the share of a retry token returned by a successful response.
+const budgetRefill = 0.1
+
+// sharedTransport is the retrying transport used by every client built with
+// NewClient, so retry budgets and rate-limit state are shared per host.
+var sharedTransport = NewRetryTransport(http.DefaultTransport, DefaultRetryPolicy)
+
+// SetRetryPolicy replaces the retry policy of the transport shared by all
+// clients and resets its per-host budgets.
+func SetRetryPolicy(policy RetryPolicy) {
+	sharedTransport.SetPolicy(policy)
+}
+
+// RetryTransport is an http.RoundTripper that retries transient failures
+// with exponential backoff and honors server rate-limit hints.
+//
+// Requests are retried when:
+//   - the server answers 429 Too Many Requests (any method, since the
+//     request was not processed), or
+//   - the method is idempotent (or carries an Idempotency-Key header) and the
+//     server answers 500, 502, 503 or 504, or the round trip fails with a
+//     network error, including an attempt running out of its timeout.
+//
+// Requests from a NewClient client limit every attempt to the client's
+// timeout, so waiting between retries never uses up a request's time.
+//
+// Bodies are replayed through Request.GetBody; requests whose body cannot
+// be replayed are never retried.
+type RetryTransport struct {
+	base http.RoundTripper
+
+	mu     sync.Mutex
+	policy RetryPolicy
+	hosts  map[string]*hostState
+
+	// sleep waits for d or until the request is cancelled. Tests replace it
+	// to avoid real delays.
+	sleep func(req *http.Request, d time.Duration) error
+	now   func() time.Time
+}
+
+type hostState struct {
+	budget float64
+	// resetAt is when an exhausted X-RateLimit window reopens.
+	resetAt time.Time
+}
+
+// NewRetryTransport wraps base with retries governed by policy. A nil base
+// uses http.DefaultTransport.
+func NewRetryTransport(base http.RoundTripper, policy RetryPolicy) *RetryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RetryTransport{
+		base:   base,
+		policy: policy,
+		hosts:  make(map[string]*hostState),
+		sleep:  sleepContext,
+		now:    time.Now,
+	}
+}
+
+// SetPolicy replaces the retry policy and resets the per-host budgets.
+func (t *RetryTransport) SetPolicy(policy RetryPolicy) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.policy = policy
+	t.hosts = make(map[string]*hostState)
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	policy := t.policy
+	t.mu.Unlock()
+
+	if err := t.waitForRateLimit(req, policy); err != nil {
+		return nil, err
+	}
+
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := t.attempt(attemptReq)
+		if err == nil {
+			t.observe(req.URL.Host, resp, policy)
+		}
+
+		delay, retry := t.retryDelay(req, resp, err, attempt, policy)
+		if !retry {
+			return resp, err
+		}
+		nextReq, rewindErr := rewindRequest(req)
+		if rewindErr != nil || !t.spendBudget(req.URL.Host, policy) {
+			return resp, err
+		}
+
+		debugf("retrying %s %s in %v (attempt %d/%d): %s", req.Method, req.URL.Redacted(), delay, attempt+1, policy.MaxRetries, retryReason(resp, err))
+		if resp != nil {
+			drainBody(resp)
+		}
+		if sleepErr := t.sleep(req, delay); sleepErr != nil {
+			return nil, sleepErr
+		}
+		attemptReq = nextReq
+	}
+}
+
+// attempt sends one attempt, limited to the timeout its client set. The
+// limit covers reading the response body and is released when it is closed.
+func (t *RetryTransport) attempt(req *http.Request) (*http.Response, error) {
+	timeout, _ := req.Context().Value(attemptTimeoutKey{}).(time.Duration)
+	if timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases an attempt's timeout once its body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// retryDelay decides whether the outcome of an attempt should be retried and
+// how long to wait first.
+func (t *RetryTransport) retryDelay(req *http.Request, resp *http.Response, err error, attempt int, policy RetryPolicy) (time.Duration, bool) {
+	if attempt >= policy.MaxRetries {
+		return 0, false
+	}
+	if err != nil {
+		if req.Context().Err() != nil || !isIdempotent(req) {
+			return 0, false
+		}
+		return backoff(attempt, policy), true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if !isIdempotent(req) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	if wait, ok := serverDelay(resp.Header, t.now()); ok {
+		if policy.MaxDelay > 0 && wait > policy.MaxDelay {
+			return 0, false
+		}
+		return wait, true
+	}
+	return backoff(attempt, policy), true
+}
+
+// waitForRateLimit delays a request while a previous response reported the
+// host's rate-limit window as exhausted.
+func (t *RetryTransport) waitForRateLimit(req *http.Request, policy RetryPolicy) error {
+	t.mu.Lock()
+	var wait time.Duration
+	if state, ok := t.hosts[req.URL.Host]; ok && !state.resetAt.IsZero() {
+		wait = state.resetAt.Sub(t.now())
+		state.resetAt = time.Time{}
+	}
+	t.mu.Unlock()
+
+	if wait <= 0 || (policy.MaxDelay > 0 && wait > policy.MaxDelay) {
+		return nil
+	}
+	debugf("rate limit exhausted for %s, waiting %v", req.URL.Host, wait)
+	return t.sleep(req, wait)
+}
+
+// observe refills the host budget after a success and records an exhausted
+// rate-limit window.
+func (t *RetryTransport) observe(host string, resp *http.Response, policy RetryPolicy) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state := t.host(host, policy)
+	if resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		state.budget += budgetRefill
+		if limit := float64(policy.HostBudget); state.budget > limit {
+			state.budget = limit
+		}
+	}
+	if remaining := resp.Header.Get("X-RateLimit-Remaining"); strings.TrimSpace(remaining) == "0" {
+		if reset, ok := parseRateLimitReset(resp.Header.Get("X-RateLimit-Reset"), t.now()); ok {
+			state.resetAt = reset
+		}
+	}
+}
+
+func (t *RetryTransport) spendBudget(host string, policy RetryPolicy) bool {
+	if policy.HostBudget <= 0 {
+		return true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state := t.host(host, policy)
+	if state.budget < 1 {
+		debugf("retry budget for %s exhausted", host)
+		return false
+	}
+	state.budget--
+	return true
+}
+
+// host returns the state of host, creating it with a full budget. Callers
+// must hold t.mu.
+func (t *RetryTransport) host(host string, policy RetryPolicy) *hostState {
+	state, ok := t.hosts[host]
+	if !ok {
+		state = &hostState{budget: float64(policy.HostBudget)}
+		t.hosts[host] = state
+	}
+	return state
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// backoff returns a random wait between zero and BaseDelay*2^attempt,
+// capped at MaxDelay.
+func backoff(attempt int, policy RetryPolicy) time.Duration {
+	ceiling := policy.BaseDelay
+	for i := 0; i < attempt && ceiling > 0; i++ {
+		ceiling *= 2
+		if policy.MaxDelay > 0 && ceiling >= policy.MaxDelay {
+			break
+		}
+	}
+	if policy.MaxDelay > 0 && ceiling > policy.MaxDelay {
+		ceiling = policy.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling + 1)
+}
+
+// serverDelay reads the wait a server asked for through Retry-After or an
+// exhausted X-RateLimit window.
+func serverDelay(header http.Header, now time.Time) (time.Duration, bool) {
+	if value := strings.TrimSpace(header.Get("Retry-After")); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return max(at.Sub(now), 0), true
+		}
+	}
+	if reset, ok := parseRateLimitReset(header.Get("X-RateLimit-Reset"), now); ok {
+		return max(reset.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// parseRateLimitReset accepts the X-RateLimit-Reset flavours in the wild:
+// a Unix timestamp, a number of seconds from now, or an RFC 3339 timestamp
+// (Jira Cloud).
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil && n >= 0 {
+		// Anything past 2001-09-09 is an epoch timestamp rather than a delta.
+		if n > 1_000_000_000 {
+			return time.Unix(n, 0), true
+		}
+		return now.Add(time.Duration(n) * time.Second), true
+	}
+	if at, err := time.Parse(time.RFC3339, value); err == nil {
+		return at, true
+	}
+	return time.Time{}, false
+}
+
+// rewindRequest returns a copy of req with a fresh body for the next attempt.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return next, nil
+	}
+	if req.GetBody == nil {
+		return nil, errBodyNotReplayable
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	next.Body = body
+	return next, nil
+}
+
+var errBodyNotReplayable = errors.New("request body cannot be replayed")
+
+func drainBody(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	_ = resp.Body.Close()
+}
+
+func retryReason(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return resp.Status
+}
+
+func sleepContext(req *http.Request, d time.Duration) error {
+	if d <= 0 {
+		return req.Context().Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
+
+func debugf(format string, args ...any) {
+	if value := os.Getenv("DEVFLOW_DEBUG"); value == "1" || strings.EqualFold(value, "true") {
+		log.Printf(format, args...)
+	}
+}
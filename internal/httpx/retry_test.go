@@ -0,0 +1,279 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type scriptedTransport struct {
+	calls     int
+	bodies    []string
+	responses func(call int) (*http.Response, error)
+}
+
+func (s *scriptedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	call := s.calls
+	s.calls++
+	if req.Body != nil {
+		data, _ := io.ReadAll(req.Body)
+		s.bodies = append(s.bodies, string(data))
+	}
+	return s.responses(call)
+}
+
+func response(status int, headers ...string) *http.Response {
+	header := http.Header{}
+	for i := 0; i+1 < len(headers); i += 2 {
+		header.Set(headers[i], headers[i+1])
+	}
+	return &http.Response{StatusCode: status, Status: http.StatusText(status), Header: header, Body: io.NopCloser(strings.NewReader("body"))}
+}
+
+func newTestRetryTransport(base http.RoundTripper, policy RetryPolicy) (*RetryTransport, *[]time.Duration) {
+	transport := NewRetryTransport(base, policy)
+	var waits []time.Duration
+	transport.sleep = func(req *http.Request, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	transport.now = func() time.Time { return now }
+	return transport, &waits
+}
+
+func TestRetryTransportRetriesIdempotentServerErrors(t *testing.T) {
+	base := &scriptedTransport{responses: func(call int) (*http.Response, error) {
+		switch call {
+		case 0:
+			return response(http.StatusServiceUnavailable), nil
+		case 1:
+			return nil, errors.New("connection reset by peer")
+		default:
+			return response(http.StatusOK), nil
+		}
+	}}
+	transport, waits := newTestRetryTransport(base, RetryPolicy{MaxRetries: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second})
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.example/items", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected success after retries, got %v, %v", resp, err)
+	}
+	if base.calls != 3 || len(*waits) != 2 {
+		t.Fatalf("expected 3 calls and 2 waits, got %d calls and %v", base.calls, *waits)
+	}
+	if (*waits)[0] > 100*time.Millisecond || (*waits)[1] > 200*time.Millisecond {
+		t.Fatalf("backoff exceeded its ceiling: %v", *waits)
+	}
+}
+
+func TestRetryTransportRetriesInternalServerErrors(t *testing.T) {
+	base := &scriptedTransport{responses: func(call int) (*http.Response, error) {
+		if call == 0 {
+			return response(http.StatusInternalServerError), nil
+		}
+		return response(http.StatusOK), nil
+	}}
+	transport, _ := newTestRetryTransport(base, RetryPolicy{MaxRetries: 3})
+
+	req, _ := http.NewRequest(http.MethodPut, "https://api.example/items/1", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK || base.calls != 2 {
+		t.Fatalf("expected a 500 to be retried, got %d calls (%v)", base.calls, err)
+	}
+}
+
+type deadlineTransport struct {
+	calls     int
+	deadlines []bool
+}
+
+func (d *deadlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	d.calls++
+	_, ok := req.Context().Deadline()
+	d.deadlines = append(d.deadlines, ok)
+	if d.calls == 1 {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}
+	resp := response(http.StatusOK)
+	resp.Body = io.NopCloser(strings.NewReader("late body"))
+	return resp, nil
+}
+
+func TestRetryTransportLimitsEachAttempt(t *testing.T) {
+	base := &deadlineTransport{}
+	transport, waits := newTestRetryTransport(base, RetryPolicy{MaxRetries: 3, BaseDelay: time.Hour, MaxDelay: time.Hour})
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.example/items", nil)
+	req = req.WithContext(context.WithValue(req.Context(), attemptTimeoutKey{}, 20*time.Millisecond))
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("expected the timed out attempt to be retried, got %v", err)
+	}
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil || string(data) != "late body" {
+		t.Fatalf("expected the body to be readable, got %q, %v", data, err)
+	}
+	if base.calls != 2 || len(*waits) != 1 || fmt.Sprint(base.deadlines) != "[true true]" {
+		t.Fatalf("expected two attempts with their own deadline, got %d calls, waits %v, deadlines %v", base.calls, *waits, base.deadlines)
+	}
+}
+
+func TestWithoutTimeout(t *testing.T) {
+	client := WithoutTimeout(NewClient(time.Second))
+	if client.Timeout != 0 {
+		t.Fatalf("expected no client timeout, got %v", client.Timeout)
+	}
+	if _, ok := client.Transport.(*timeoutTransport); ok {
+		t.Fatal("expected the per-attempt timeout to be removed")
+	}
+}
+
+func TestRetryTransportDoesNotRetryNonIdempotentServerErrors(t *testing.T) {
+	base := &scriptedTransport{responses: func(call int) (*http.Response, error) {
+		return response(http.StatusBadGateway), nil
+	}}
+	transport, _ := newTestRetryTransport(base, RetryPolicy{MaxRetries: 3})
+
+	req, _ := http.NewRequest(http.MethodPost, "https://api.example/items", strings.NewReader("{}"))
+	resp, err := transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusBadGateway || base.calls != 1 {
+		t.Fatalf("expected a single POST attempt, got %d calls (%v)", base.calls, err)
+	}
+
+	req, _ = http.NewRequest(http.MethodPost, "https://api.example/items", strings.NewReader("{}"))
+	req.Header.Set("Idempotency-Key", "abc")
+	if _, err := transport.RoundTrip(req); err != nil || base.calls != 5 {
+		t.Fatalf("expected POST with Idempotency-Key to be retried, got %d calls", base.calls)
+	}
+}
+
+func TestRetryTransportHonorsRetryAfterAndReplaysBody(t *testing.T) {
+	base := &scriptedTransport{responses: func(call int) (*http.Response, error) {
+		if call == 0 {
+			return response(http.StatusTooManyRequests, "Retry-After", "2"), nil
+		}
+		return response(http.StatusCreated), nil
+	}}
+	transport, waits := newTestRetryTransport(base, RetryPolicy{MaxRetries: 2, MaxDelay: 5 * time.Second})
+
+	req, _ := http.NewRequest(http.MethodPost, "https://api.example/items", strings.NewReader(`{"a":1}`))
+	resp, err := transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected rate-limited POST to be retried, got %v, %v", resp, err)
+	}
+	if len(*waits) != 1 || (*waits)[0] != 2*time.Second {
+		t.Fatalf("expected a 2s Retry-After wait, got %v", *waits)
+	}
+	if len(base.bodies) != 2 || base.bodies[1] != `{"a":1}` {
+		t.Fatalf("expected the body to be replayed, got %q", base.bodies)
+	}
+}
+
+func TestRetryTransportGivesUpOnLongServerDelay(t *testing.T) {
+	base := &scriptedTransport{responses: func(call int) (*http.Response, error) {
+		return response(http.StatusTooManyRequests, "X-RateLimit-Remaining", "0", "X-RateLimit-Reset", "2026-01-02T04:00:00Z"), nil
+	}}
+	transport, waits := newTestRetryTransport(base, RetryPolicy{MaxRetries: 3, MaxDelay: time.Minute})
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.example/items", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests || base.calls != 1 || len(*waits) != 0 {
+		t.Fatalf("expected no retry for a reset an hour away, got %d calls, waits %v", base.calls, *waits)
+	}
+}
+
+func TestRetryTransportWaitsForExhaustedRateLimit(t *testing.T) {
+	base := &scriptedTransport{responses: func(call int) (*http.Response, error) {
+		if call == 0 {
+			return response(http.StatusOK, "X-RateLimit-Remaining", "0", "X-RateLimit-Reset", "3"), nil
+		}
+		return response(http.StatusOK), nil
+	}}
+	transport, waits := newTestRetryTransport(base, RetryPolicy{MaxDelay: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "https://api.example/items", nil)
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+	}
+	if len(*waits) != 1 || (*waits)[0] != 3*time.Second {
+		t.Fatalf("expected the second request to wait for the reset, got %v", *waits)
+	}
+}
+
+func TestRetryTransportHostBudget(t *testing.T) {
+	base := &scriptedTransport{responses: func(call int) (*http.Response, error) {
+		return response(http.StatusServiceUnavailable), nil
+	}}
+	transport, _ := newTestRetryTransport(base, RetryPolicy{MaxRetries: 5, HostBudget: 2})
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.example/items", nil)
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if base.calls != 3 {
+		t.Fatalf("expected the budget to allow 2 retries, got %d calls", base.calls)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "https://api.example/other", nil)
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if base.calls != 4 {
+		t.Fatalf("expected no retries once the budget is spent, got %d calls", base.calls)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "https://other.example/items", nil)
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if base.calls != 7 {
+		t.Fatalf("expected another host to keep its own budget, got %d calls", base.calls)
+	}
+}
+
+func TestParseRateLimitReset(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	cases := map[string]time.Time{
+		"30":                   now.Add(30 * time.Second),
+		"1767323100":           time.Unix(1767323100, 0),
+		"2026-01-02T03:05:00Z": time.Date(2026, 1, 2, 3, 5, 0, 0, time.UTC),
+	}
+	for value, want := range cases {
+		got, ok := parseRateLimitReset(value, now)
+		if !ok || !got.Equal(want) {
+			t.Fatalf("parseRateLimitReset(%q) = %v, %v; want %v", value, got, ok, want)
+		}
+	}
+	if _, ok := parseRateLimitReset("soon", now); ok {
+		t.Fatal("expected an unparseable reset to be ignored")
+	}
+}
+
+func TestRetryTransportStopsWhenContextIsCancelled(t *testing.T) {
+	base := &scriptedTransport{responses: func(call int) (*http.Response, error) {
+		return response(http.StatusServiceUnavailable), nil
+	}}
+	transport := NewRetryTransport(base, RetryPolicy{MaxRetries: 3, BaseDelay: time.Hour, MaxDelay: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.example/items", nil)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err := transport.RoundTrip(req)
+	if !errors.Is(err, context.Canceled) || base.calls != 1 {
+		t.Fatalf("expected the backoff wait to be cancelled, got %v after %d calls", err, base.calls)
+	}
+}
@@ -1,6 +1,7 @@
 package jenkins
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -67,10 +68,10 @@ func NewClient(cfg *config.JenkinsConfig) *Client {
 	}
 }
 
-func (c *Client) makeRequest(method, path string) (*http.Response, error) {
+func (c *Client) makeRequest(ctx context.Context, method, path string) (*http.Response, error) {
 	url := fmt.Sprintf("%s/%s", c.baseURL, strings.TrimPrefix(path, "/"))
 
-	req, err := http.NewRequest(method, url, nil)
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -88,11 +89,11 @@ func (c *Client) makeRequest(method, path string) (*http.Response, error) {
 	return resp, nil
 }
 
-// GetJobBuilds retrieves recent builds for a job
-func (c *Client) GetJobBuilds(jobName string, limit int) ([]Build, error) {
+// GetJobBuildsContext retrieves recent builds for a job
+func (c *Client) GetJobBuildsContext(ctx context.Context, jobName string, limit int) ([]Build, error) {
 	path := fmt.Sprintf("/job/%s/api/json?tree=builds[number,result,timestamp,duration,url,building]{0,%d}", jobName, limit)
 
-	resp, err := c.makeRequest("GET", path)
+	resp, err := c.makeRequest(ctx, "GET", path)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +101,7 @@ func (c *Client) GetJobBuilds(jobName string, limit int) ([]Build, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+		return nil, httpx.NewAPIError(httpx.ServiceJenkins, resp, body)
 	}
 
 	var job Job
@@ -111,11 +112,16 @@ func (c *Client) GetJobBuilds(jobName string, limit int) ([]Build, error) {
 	return job.Builds, nil
 }
 
-// GetBuildLog retrieves the console log for a specific build
-func (c *Client) GetBuildLog(jobName string, buildNumber int) (string, error) {
+// GetJobBuilds calls GetJobBuildsContext with a background context.
+func (c *Client) GetJobBuilds(jobName string, limit int) ([]Build, error) {
+	return c.GetJobBuildsContext(context.Background(), jobName, limit)
+}
+
+// GetBuildLogContext retrieves the console log for a specific build
+func (c *Client) GetBuildLogContext(ctx context.Context, jobName string, buildNumber int) (string, error) {
 	path := fmt.Sprintf("/job/%s/%d/consoleText", jobName, buildNumber)
 
-	resp, err := c.makeRequest("GET", path)
+	resp, err := c.makeRequest(ctx, "GET", path)
 	if err != nil {
 		return "", err
 	}
@@ -123,7 +129,7 @@ func (c *Client) GetBuildLog(jobName string, buildNumber int) (string, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+		return "", httpx.NewAPIError(httpx.ServiceJenkins, resp, body)
 	}
 
 	log, err := io.ReadAll(resp.Body)
@@ -134,11 +140,16 @@ func (c *Client) GetBuildLog(jobName string, buildNumber int) (string, error) {
 	return string(log), nil
 }
 
-// GetBuildStages retrieves the pipeline stages for a build
-func (c *Client) GetBuildStages(jobName string, buildNumber int) ([]BuildStage, error) {
+// GetBuildLog calls GetBuildLogContext with a background context.
+func (c *Client) GetBuildLog(jobName string, buildNumber int) (string, error) {
+	return c.GetBuildLogContext(context.Background(), jobName, buildNumber)
+}
+
+// GetBuildStagesContext retrieves the pipeline stages for a build
+func (c *Client) GetBuildStagesContext(ctx context.Context, jobName string, buildNumber int) ([]BuildStage, error) {
 	path := fmt.Sprintf("/job/%s/%d/wfapi/describe", jobName, buildNumber)
 
-	resp, err := c.makeRequest("GET", path)
+	resp, err := c.makeRequest(ctx, "GET", path)
 	if err != nil {
 		return nil, err
 	}
@@ -150,7 +161,7 @@ func (c *Client) GetBuildStages(jobName string, buildNumber int) ([]BuildStage,
 	}
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+		return nil, httpx.NewAPIError(httpx.ServiceJenkins, resp, body)
 	}
 
 	var result struct {
@@ -163,16 +174,21 @@ func (c *Client) GetBuildStages(jobName string, buildNumber int) ([]BuildStage,
 	return result.Stages, nil
 }
 
-// GetFailedStepLog attempts to get the log for a failed stage
-func (c *Client) GetFailedStepLog(jobName string, buildNumber int) (string, error) {
-	stages, err := c.GetBuildStages(jobName, buildNumber)
+// GetBuildStages calls GetBuildStagesContext with a background context.
+func (c *Client) GetBuildStages(jobName string, buildNumber int) ([]BuildStage, error) {
+	return c.GetBuildStagesContext(context.Background(), jobName, buildNumber)
+}
+
+// GetFailedStepLogContext attempts to get the log for a failed stage
+func (c *Client) GetFailedStepLogContext(ctx context.Context, jobName string, buildNumber int) (string, error) {
+	stages, err := c.GetBuildStagesContext(ctx, jobName, buildNumber)
 	if err != nil {
 		return "", err
 	}
 
 	if stages == nil {
 		// Not a pipeline job, return full log
-		return c.GetBuildLog(jobName, buildNumber)
+		return c.GetBuildLogContext(ctx, jobName, buildNumber)
 	}
 
 	// Find first failed stage
@@ -187,7 +203,7 @@ func (c *Client) GetFailedStepLog(jobName string, buildNumber int) (string, erro
 	if failedStage != nil {
 		// Try to get stage-specific log
 		path := fmt.Sprintf("/job/%s/%d/execution/node/%s/wfapi/log", jobName, buildNumber, failedStage.ID)
-		resp, err := c.makeRequest("GET", path)
+		resp, err := c.makeRequest(ctx, "GET", path)
 		if err == nil {
 			defer func() { _ = resp.Body.Close() }()
 			if resp.StatusCode == http.StatusOK {
@@ -200,5 +216,75 @@ func (c *Client) GetFailedStepLog(jobName string, buildNumber int) (string, erro
 	}
 
 	// Fallback to full log
-	return c.GetBuildLog(jobName, buildNumber)
+	return c.GetBuildLogContext(ctx, jobName, buildNumber)
+}
+
+// GetFailedStepLog calls GetFailedStepLogContext with a background context.
+func (c *Client) GetFailedStepLog(jobName string, buildNumber int) (string, error) {
+	return c.GetFailedStepLogContext(context.Background(), jobName, buildNumber)
+}
+
+// CheckAuthContext verifies the configured credentials via /whoAmI and checks that
+// the crumb issuer, needed for write operations, is reachable
+func (c *Client) CheckAuthContext(ctx context.Context) (*httpx.AuthReport, error) {
+	report := &httpx.AuthReport{Scheme: httpx.BasicAuthScheme(c.config.Username, c.config.Token)}
+
+	resp, err := c.makeRequest(ctx, "GET", "/whoAmI/api/json")
+	if err != nil {
+		return report, err
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return report, httpx.NewAuthError(resp, body)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return report, httpx.NewAPIError(httpx.ServiceJenkins, resp, body)
+	}
+
+	var whoAmI struct {
+		Name          string `json:"name"`
+		Anonymous     bool   `json:"anonymous"`
+		Authenticated bool   `json:"authenticated"`
+	}
+	if err := json.Unmarshal(body, &whoAmI); err != nil {
+		return report, fmt.Errorf("failed to decode response: %w", err)
+	}
+	report.Identity = whoAmI.Name
+	if whoAmI.Anonymous && report.Scheme != httpx.SchemeNone {
+		return report, fmt.Errorf("credentials for %q were not accepted; Jenkins treats the request as anonymous", c.config.Username)
+	}
+
+	// A missing crumb issuer (404) just means CSRF protection is disabled.
+	crumbResp, err := c.makeRequest(ctx, "GET", "/crumbIssuer/api/json")
+	if err != nil {
+		return report, err
+	}
+	crumbBody, _ := io.ReadAll(crumbResp.Body)
+	_ = crumbResp.Body.Close()
+	if crumbResp.StatusCode == http.StatusUnauthorized || crumbResp.StatusCode == http.StatusForbidden {
+		authErr := httpx.NewAuthError(crumbResp, crumbBody)
+		report.MissingScopes = authErr.MissingScopes
+		if len(report.MissingScopes) == 0 {
+			report.MissingScopes = []string{"crumb issuer access"}
+		}
+	}
+
+	return report, nil
+}
+
+// CheckAuth calls CheckAuthContext with a background context.
+func (c *Client) CheckAuth() (*httpx.AuthReport, error) {
+	return c.CheckAuthContext(context.Background())
+}
+
+// TestAuthContext verifies the configured credentials
+func (c *Client) TestAuthContext(ctx context.Context) error {
+	_, err := c.CheckAuthContext(ctx)
+	return err
+}
+
+// TestAuth calls TestAuthContext with a background context.
+func (c *Client) TestAuth() error {
+	return c.TestAuthContext(context.Background())
 }
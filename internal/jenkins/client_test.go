@@ -218,3 +218,37 @@ func TestGetFailedStepLog_NonPipelineJob(t *testing.T) {
 		t.Errorf("Expected full log fallback, got %q", log)
 	}
 }
+
+func TestCheckAuth(t *testing.T) {
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/whoAmI/api/json":
+			_, _ = fmt.Fprint(w, `{"name":"alice","anonymous":false,"authenticated":true}`)
+		case "/crumbIssuer/api/json":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = fmt.Fprint(w, `<html>alice is missing the Overall/Read permission</html>`)
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+
+	client := NewClient(&config.JenkinsConfig{URL: server.URL, Username: "alice", Token: "t"})
+	report, err := client.CheckAuth()
+	if err != nil {
+		t.Fatalf("CheckAuth failed: %v", err)
+	}
+	if report.Identity != "alice" || report.Scheme != "Basic" || len(report.MissingScopes) != 1 || report.MissingScopes[0] != "Overall/Read" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestCheckAuth_Anonymous(t *testing.T) {
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"name":"anonymous","anonymous":true}`)
+	}))
+
+	client := NewClient(&config.JenkinsConfig{URL: server.URL, Username: "alice", Token: "bad"})
+	if err := client.TestAuth(); err == nil {
+		t.Fatal("expected anonymous session to fail authentication")
+	}
+}
@@ -0,0 +1,316 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"devflow/internal/httpx"
+)
+
+// agileRoot is the REST root of the Jira Software (Agile) API.
+const agileRoot = "rest/agile/1.0/"
+
+// maxSprintMove is the most issues the Agile API moves in one request.
+const maxSprintMove = 50
+
+// maxRank is the most issues the Agile API ranks in one request.
+const maxRank = 50
+
+// Board is a Jira Software board.
+type Board struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"` // scrum or kanban
+	Location struct {
+		ProjectKey string `json:"projectKey"`
+	} `json:"location"`
+}
+
+// Sprint is a sprint of a scrum board. State is future, active or closed.
+type Sprint struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	State         string `json:"state"`
+	StartDate     string `json:"startDate,omitempty"`
+	EndDate       string `json:"endDate,omitempty"`
+	Goal          string `json:"goal,omitempty"`
+	OriginBoardID int    `json:"originBoardId,omitempty"`
+}
+
+// BoardColumn is a column of a board and the IDs of the statuses mapped to
+// it.
+type BoardColumn struct {
+	Name      string   `json:"name"`
+	StatusIDs []string `json:"statusIds"`
+}
+
+// ListBoardsContext lists the boards of a project, or every board visible to
+// the user when projectKey is empty.
+func (c *Client) ListBoardsContext(ctx context.Context, projectKey string) ([]Board, error) {
+	var boards []Board
+	for startAt := 0; ; {
+		query := url.Values{"startAt": {strconv.Itoa(startAt)}, "maxResults": {"50"}}
+		if projectKey != "" {
+			query.Set("projectKeyOrId", projectKey)
+		}
+		var page struct {
+			IsLast bool    `json:"isLast"`
+			Values []Board `json:"values"`
+		}
+		if err := c.agileGet(ctx, "board?"+query.Encode(), &page); err != nil {
+			return nil, err
+		}
+		boards = append(boards, page.Values...)
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			return boards, nil
+		}
+	}
+}
+
+// ListBoards calls ListBoardsContext with a background context.
+func (c *Client) ListBoards(projectKey string) ([]Board, error) {
+	return c.ListBoardsContext(context.Background(), projectKey)
+}
+
+// ListSprintsContext lists the sprints of a board in the board's order,
+// optionally only those in the given states (e.g. "active", "future").
+func (c *Client) ListSprintsContext(ctx context.Context, boardID int, states ...string) ([]Sprint, error) {
+	var sprints []Sprint
+	for startAt := 0; ; {
+		query := url.Values{"startAt": {strconv.Itoa(startAt)}, "maxResults": {"50"}}
+		if len(states) > 0 {
+			query.Set("state", strings.Join(states, ","))
+		}
+		var page struct {
+			IsLast bool     `json:"isLast"`
+			Values []Sprint `json:"values"`
+		}
+		if err := c.agileGet(ctx, fmt.Sprintf("board/%d/sprint?%s", boardID, query.Encode()), &page); err != nil {
+			return nil, err
+		}
+		sprints = append(sprints, page.Values...)
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			return sprints, nil
+		}
+	}
+}
+
+// ListSprints calls ListSprintsContext with a background context.
+func (c *Client) ListSprints(boardID int, states ...string) ([]Sprint, error) {
+	return c.ListSprintsContext(context.Background(), boardID, states...)
+}
+
+// BoardColumnsContext returns the columns of a board from left to right.
+func (c *Client) BoardColumnsContext(ctx context.Context, boardID int) ([]BoardColumn, error) {
+	var configuration struct {
+		ColumnConfig struct {
+			Columns []struct {
+				Name     string `json:"name"`
+				Statuses []struct {
+					ID string `json:"id"`
+				} `json:"statuses"`
+			} `json:"columns"`
+		} `json:"columnConfig"`
+	}
+	if err := c.agileGet(ctx, fmt.Sprintf("board/%d/configuration", boardID), &configuration); err != nil {
+		return nil, err
+	}
+	columns := make([]BoardColumn, 0, len(configuration.ColumnConfig.Columns))
+	for _, column := range configuration.ColumnConfig.Columns {
+		ids := make([]string, 0, len(column.Statuses))
+		for _, status := range column.Statuses {
+			ids = append(ids, status.ID)
+		}
+		columns = append(columns, BoardColumn{Name: column.Name, StatusIDs: ids})
+	}
+	return columns, nil
+}
+
+// BoardColumns calls BoardColumnsContext with a background context.
+func (c *Client) BoardColumns(boardID int) ([]BoardColumn, error) {
+	return c.BoardColumnsContext(context.Background(), boardID)
+}
+
+// SprintIssuesContext returns the issues of a sprint in rank order.
+func (c *Client) SprintIssuesContext(ctx context.Context, sprintID int) ([]Issue, error) {
+	var issues []Issue
+	for startAt := 0; ; {
+		query := url.Values{
+			"startAt":    {strconv.Itoa(startAt)},
+			"maxResults": {"100"},
+			"fields":     {"summary,status,assignee,priority,updated,created"},
+		}
+		var page struct {
+			Total  int     `json:"total"`
+			Issues []Issue `json:"issues"`
+		}
+		if err := c.agileGet(ctx, fmt.Sprintf("sprint/%d/issue?%s", sprintID, query.Encode()), &page); err != nil {
+			return nil, err
+		}
+		issues = append(issues, page.Issues...)
+		startAt += len(page.Issues)
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			return issues, nil
+		}
+	}
+}
+
+// SprintIssues calls SprintIssuesContext with a background context.
+func (c *Client) SprintIssues(sprintID int) ([]Issue, error) {
+	return c.SprintIssuesContext(context.Background(), sprintID)
+}
+
+// MoveIssuesToSprintContext moves issues into a sprint, in batches of the
+// most the API accepts per request.
+func (c *Client) MoveIssuesToSprintContext(ctx context.Context, sprintID int, issueKeys []string) error {
+	return c.moveIssues(ctx, fmt.Sprintf("sprint/%d/issue", sprintID), issueKeys)
+}
+
+// MoveIssuesToSprint calls MoveIssuesToSprintContext with a background
+// context.
+func (c *Client) MoveIssuesToSprint(sprintID int, issueKeys []string) error {
+	return c.MoveIssuesToSprintContext(context.Background(), sprintID, issueKeys)
+}
+
+// MoveIssuesToBacklogContext moves issues out of their sprint into the
+// backlog.
+func (c *Client) MoveIssuesToBacklogContext(ctx context.Context, issueKeys []string) error {
+	return c.moveIssues(ctx, "backlog/issue", issueKeys)
+}
+
+// MoveIssuesToBacklog calls MoveIssuesToBacklogContext with a background
+// context.
+func (c *Client) MoveIssuesToBacklog(issueKeys []string) error {
+	return c.MoveIssuesToBacklogContext(context.Background(), issueKeys)
+}
+
+func (c *Client) moveIssues(ctx context.Context, endpoint string, issueKeys []string) error {
+	for start := 0; start < len(issueKeys); start += maxSprintMove {
+		end := min(start+maxSprintMove, len(issueKeys))
+		payload := map[string]any{"issues": issueKeys[start:end]}
+		if err := c.agileSend(ctx, "POST", endpoint, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RankIssuesContext ranks issues directly before or after another issue.
+// Exactly one of before and after must be set. Issues are ranked in batches
+// of the most the API accepts, each after the last issue of the batch
+// before, so their order holds. When Jira ranks only some of the issues, the
+// error lists the ones it refused.
+func (c *Client) RankIssuesContext(ctx context.Context, issueKeys []string, before, after string) error {
+	if (before == "") == (after == "") {
+		return fmt.Errorf("rank needs exactly one of before and after")
+	}
+	for start := 0; start < len(issueKeys); start += maxRank {
+		end := min(start+maxRank, len(issueKeys))
+		if err := c.rankIssues(ctx, issueKeys[start:end], before, after); err != nil {
+			return err
+		}
+		before, after = "", issueKeys[end-1]
+	}
+	return nil
+}
+
+// RankIssues calls RankIssuesContext with a background context.
+func (c *Client) RankIssues(issueKeys []string, before, after string) error {
+	return c.RankIssuesContext(context.Background(), issueKeys, before, after)
+}
+
+func (c *Client) rankIssues(ctx context.Context, issueKeys []string, before, after string) error {
+	payload := map[string]any{"issues": issueKeys}
+	if before != "" {
+		payload["rankBeforeIssue"] = before
+	} else {
+		payload["rankAfterIssue"] = after
+	}
+
+	resp, err := c.makeRequestPath(ctx, "PUT", agileRoot+"issue/rank", payload)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusOK:
+		return nil
+	case http.StatusMultiStatus:
+		var result struct {
+			Entries []struct {
+				IssueKey string   `json:"issueKey"`
+				Status   int      `json:"status"`
+				Errors   []string `json:"errors"`
+			} `json:"entries"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		var failures []string
+		for _, entry := range result.Entries {
+			if entry.Status >= 300 {
+				failures = append(failures, fmt.Sprintf("%s: %s", entry.IssueKey, strings.Join(entry.Errors, "; ")))
+			}
+		}
+		if len(failures) > 0 {
+			return fmt.Errorf("failed to rank %s", strings.Join(failures, ", "))
+		}
+		return nil
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+}
+
+func (c *Client) agileGet(ctx context.Context, endpoint string, out any) error {
+	resp, err := c.makeRequestPath(ctx, "GET", agileRoot+endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) agileSend(ctx context.Context, method, endpoint string, payload any) error {
+	resp, err := c.makeRequestPath(ctx, method, agileRoot+endpoint, payload)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+	return nil
+}
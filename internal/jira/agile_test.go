@@ -0,0 +1,194 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"devflow/internal/config"
+	"devflow/internal/httpx"
+)
+
+func TestListBoardsAndSprintsPaginate(t *testing.T) {
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/rest/agile/1.0/board":
+			if query.Get("projectKeyOrId") != "ENG" {
+				t.Fatalf("unexpected board query %q", r.URL.RawQuery)
+			}
+			if query.Get("startAt") == "0" {
+				_, _ = w.Write([]byte(`{"isLast":false,"values":[{"id":1,"name":"ENG board","type":"scrum","location":{"projectKey":"ENG"}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":2,"name":"ENG kanban","type":"kanban"}]}`))
+		case "/rest/agile/1.0/board/1/sprint":
+			if query.Get("state") != "active,future" {
+				t.Fatalf("unexpected sprint states %q", query.Get("state"))
+			}
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":10,"name":"Sprint 10","state":"active","goal":"Ship it"},{"id":11,"name":"Sprint 11","state":"future"}]}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	boards, err := client.ListBoards("ENG")
+	if err != nil {
+		t.Fatalf("ListBoards failed: %v", err)
+	}
+	if len(boards) != 2 || boards[0].Location.ProjectKey != "ENG" || boards[1].Type != "kanban" {
+		t.Fatalf("unexpected boards: %+v", boards)
+	}
+	sprints, err := client.ListSprints(1, "active", "future")
+	if err != nil {
+		t.Fatalf("ListSprints failed: %v", err)
+	}
+	if len(sprints) != 2 || sprints[0].Goal != "Ship it" || sprints[1].State != "future" {
+		t.Fatalf("unexpected sprints: %+v", sprints)
+	}
+}
+
+func TestBoardColumnsAndSprintIssues(t *testing.T) {
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/agile/1.0/board/1/configuration":
+			_, _ = w.Write([]byte(`{"columnConfig":{"columns":[
+				{"name":"To Do","statuses":[{"id":"1"}]},
+				{"name":"In Progress","statuses":[{"id":"3"},{"id":"10001"}]}
+			]}}`))
+		case "/rest/agile/1.0/sprint/10/issue":
+			if r.URL.Query().Get("startAt") == "0" {
+				_, _ = w.Write([]byte(`{"total":2,"issues":[{"key":"ENG-1","fields":{"summary":"One","status":{"id":"1","name":"To Do"}}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"total":2,"issues":[{"key":"ENG-2","fields":{"summary":"Two","status":{"id":"3","name":"In Progress"}}}]}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	columns, err := client.BoardColumns(1)
+	if err != nil {
+		t.Fatalf("BoardColumns failed: %v", err)
+	}
+	if len(columns) != 2 || columns[1].Name != "In Progress" || strings.Join(columns[1].StatusIDs, ",") != "3,10001" {
+		t.Fatalf("unexpected columns: %+v", columns)
+	}
+	issues, err := client.SprintIssues(10)
+	if err != nil {
+		t.Fatalf("SprintIssues failed: %v", err)
+	}
+	if len(issues) != 2 || issues[1].Key != "ENG-2" || issues[1].Fields.Status.ID != "3" {
+		t.Fatalf("unexpected issues: %+v", issues)
+	}
+}
+
+func TestMoveIssuesToSprintBatches(t *testing.T) {
+	var batches [][]string
+	var backlog []string
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Issues []string `json:"issues"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/agile/1.0/sprint/11/issue":
+			batches = append(batches, payload.Issues)
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/agile/1.0/backlog/issue":
+			backlog = payload.Issues
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	keys := make([]string, 60)
+	for i := range keys {
+		keys[i] = "ENG-" + string(rune('A'+i%26))
+	}
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	if err := client.MoveIssuesToSprint(11, keys); err != nil {
+		t.Fatalf("MoveIssuesToSprint failed: %v", err)
+	}
+	if len(batches) != 2 || len(batches[0]) != 50 || len(batches[1]) != 10 {
+		t.Fatalf("unexpected batches: %d", len(batches))
+	}
+	if err := client.MoveIssuesToBacklog([]string{"ENG-9"}); err != nil || len(backlog) != 1 {
+		t.Fatalf("MoveIssuesToBacklog failed: %v (%v)", err, backlog)
+	}
+}
+
+func TestRankIssues(t *testing.T) {
+	var payload map[string]any
+	partial := false
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/rest/agile/1.0/issue/rank" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		if partial {
+			w.WriteHeader(http.StatusMultiStatus)
+			_, _ = w.Write([]byte(`{"entries":[{"issueKey":"ENG-5","status":200},{"issueKey":"ENG-6","status":400,"errors":["Cannot rank an issue relative to itself"]}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	if err := client.RankIssues([]string{"ENG-5"}, "ENG-3", ""); err != nil {
+		t.Fatalf("RankIssues failed: %v", err)
+	}
+	if payload["rankBeforeIssue"] != "ENG-3" || payload["rankAfterIssue"] != nil {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+
+	partial = true
+	err := client.RankIssues([]string{"ENG-5", "ENG-6"}, "", "ENG-6")
+	if err == nil || !strings.Contains(err.Error(), "ENG-6: Cannot rank") || strings.Contains(err.Error(), "ENG-5") {
+		t.Fatalf("expected a partial failure for ENG-6, got %v", err)
+	}
+	if payload["rankAfterIssue"] != "ENG-6" {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+
+	if err := client.RankIssues([]string{"ENG-5"}, "", ""); err == nil {
+		t.Fatal("expected rank without a target to fail")
+	}
+}
+
+func TestRankIssuesInBatches(t *testing.T) {
+	var payloads []map[string]any
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		payloads = append(payloads, payload)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	keys := make([]string, 0, 120)
+	for i := 1; i <= 120; i++ {
+		keys = append(keys, fmt.Sprintf("ENG-%d", i))
+	}
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	if err := client.RankIssues(keys, "TOP-1", ""); err != nil {
+		t.Fatalf("RankIssues failed: %v", err)
+	}
+	if len(payloads) != 3 {
+		t.Fatalf("expected 3 batches, got %d", len(payloads))
+	}
+	for i, want := range []struct {
+		size          int
+		before, after any
+	}{{50, "TOP-1", nil}, {50, nil, "ENG-50"}, {20, nil, "ENG-100"}} {
+		got := payloads[i]
+		if len(got["issues"].([]any)) != want.size || got["rankBeforeIssue"] != want.before || got["rankAfterIssue"] != want.after {
+			t.Fatalf("unexpected batch %d: %v issues, before %v, after %v", i, len(got["issues"].([]any)), got["rankBeforeIssue"], got["rankAfterIssue"])
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"devflow/internal/httpx"
+)
+
+// ListAttachmentsContext returns the attachments of an issue.
+func (c *Client) ListAttachmentsContext(ctx context.Context, issueKey string) ([]Attachment, error) {
+	endpoint := fmt.Sprintf("issue/%s?fields=attachment", url.PathEscape(issueKey))
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+
+	var issue struct {
+		Fields struct {
+			Attachment []Attachment `json:"attachment"`
+		} `json:"fields"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return issue.Fields.Attachment, nil
+}
+
+// ListAttachments calls ListAttachmentsContext with a background context.
+func (c *Client) ListAttachments(issueKey string) ([]Attachment, error) {
+	return c.ListAttachmentsContext(context.Background(), issueKey)
+}
+
+// AddAttachmentsContext uploads files to an issue in one multipart request
+// and returns the attachments Jira created. The files are streamed from disk
+// rather than read into memory.
+func (c *Client) AddAttachmentsContext(ctx context.Context, issueKey string, paths []string) ([]Attachment, error) {
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if info.IsDir() {
+			return nil, fmt.Errorf("%s is a directory", path)
+		}
+	}
+
+	reader, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		writer.CloseWithError(writeAttachmentForm(form, paths))
+	}()
+
+	endpoint := fmt.Sprintf("%s/%s/issue/%s/attachments", strings.TrimSuffix(c.config.URL, "/"), c.apiRoot(ctx), url.PathEscape(issueKey))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, reader)
+	if err != nil {
+		_ = reader.Close()
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	c.applyAuth(req)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	// Jira rejects multipart uploads without this header as a CSRF guard.
+	req.Header.Set("X-Atlassian-Token", "no-check")
+
+	resp, err := c.transferClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+
+	var attachments []Attachment
+	if err := json.NewDecoder(resp.Body).Decode(&attachments); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return attachments, nil
+}
+
+// AddAttachments calls AddAttachmentsContext with a background context.
+func (c *Client) AddAttachments(issueKey string, paths []string) ([]Attachment, error) {
+	return c.AddAttachmentsContext(context.Background(), issueKey, paths)
+}
+
+func writeAttachmentForm(form *multipart.Writer, paths []string) error {
+	for _, path := range paths {
+		if err := writeAttachmentPart(form, path); err != nil {
+			return err
+		}
+	}
+	return form.Close()
+}
+
+func writeAttachmentPart(form *multipart.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	name := filepath.Base(path)
+	contentType := mime.TypeByExtension(filepath.Ext(name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{"name": "file", "filename": name}))
+	header.Set("Content-Type", contentType)
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, file)
+	return err
+}
+
+// DownloadAttachmentContext opens the content of an attachment. A positive
+// offset asks the server for the bytes from offset on; resumed reports
+// whether it honoured that, otherwise the body holds the whole file. The
+// caller must close the body.
+func (c *Client) DownloadAttachmentContext(ctx context.Context, attachment Attachment, offset int64) (body io.ReadCloser, resumed bool, err error) {
+	contentURL := attachment.Content
+	if contentURL == "" {
+		contentURL = fmt.Sprintf("%s/%s/attachment/content/%s", strings.TrimSuffix(c.config.URL, "/"), c.apiRoot(ctx), url.PathEscape(attachment.ID))
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", contentURL, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
+	}
+	c.applyAuth(req)
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := c.transferClient().Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to make request: %w", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, false, nil
+	case http.StatusPartialContent:
+		return resp.Body, offset > 0, nil
+	default:
+		data, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		return nil, false, httpx.NewAPIError(httpx.ServiceJira, resp, data)
+	}
+}
+
+// DownloadAttachment calls DownloadAttachmentContext with a background
+// context.
+func (c *Client) DownloadAttachment(attachment Attachment, offset int64) (io.ReadCloser, bool, error) {
+	return c.DownloadAttachmentContext(context.Background(), attachment, offset)
+}
+
+// transferClient is the client's HTTP client without its overall timeout,
+// which also covers reading the body and would cut off large uploads and
+// downloads. Transfers are bounded by their context instead.
+func (c *Client) transferClient() *http.Client {
+	return httpx.WithoutTimeout(c.httpClient)
+}
@@ -0,0 +1,136 @@
+package jira
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"devflow/internal/config"
+	"devflow/internal/httpx"
+)
+
+func TestAddAttachmentsStreamsMultipart(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "notes.txt")
+	second := filepath.Join(dir, "trace.bin")
+	_ = os.WriteFile(first, []byte("hello"), 0o600)
+	_ = os.WriteFile(second, []byte{0, 1, 2}, 0o600)
+
+	type part struct{ name, contentType, body string }
+	var parts []part
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/3/issue/ENG-1/attachments" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("X-Atlassian-Token") != "no-check" {
+			t.Fatalf("missing X-Atlassian-Token header")
+		}
+		reader, err := r.MultipartReader()
+		if err != nil {
+			t.Fatalf("expected a multipart body: %v", err)
+		}
+		for {
+			p, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("read part: %v", err)
+			}
+			if p.FormName() != "file" {
+				t.Fatalf("unexpected form field %q", p.FormName())
+			}
+			data, _ := io.ReadAll(p)
+			parts = append(parts, part{p.FileName(), p.Header.Get("Content-Type"), string(data)})
+		}
+		_, _ = w.Write([]byte(`[{"id":"1","filename":"notes.txt","size":5,"mimeType":"text/plain","content":"https://jira.example/rest/api/3/attachment/content/1"},{"id":"2","filename":"trace.bin","size":3}]`))
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	attachments, err := client.AddAttachments("ENG-1", []string{first, second})
+	if err != nil {
+		t.Fatalf("AddAttachments failed: %v", err)
+	}
+	if len(attachments) != 2 || attachments[0].ID != "1" || attachments[0].MimeType != "text/plain" || attachments[0].Content == "" {
+		t.Fatalf("unexpected attachments: %+v", attachments)
+	}
+	if len(parts) != 2 || parts[0].name != "notes.txt" || parts[0].body != "hello" || parts[0].contentType != "text/plain; charset=utf-8" ||
+		parts[1].name != "trace.bin" || parts[1].contentType != "application/octet-stream" {
+		t.Fatalf("unexpected multipart parts: %+v", parts)
+	}
+
+	if _, err := client.AddAttachments("ENG-1", []string{filepath.Join(dir, "missing")}); err == nil {
+		t.Fatal("expected a missing file to fail before uploading")
+	}
+}
+
+func TestDownloadAttachmentResumes(t *testing.T) {
+	content := "0123456789"
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/attachment/content/7":
+			if r.Header.Get("Range") == "bytes=4-" {
+				w.Header().Set("Content-Range", "bytes 4-9/10")
+				w.WriteHeader(http.StatusPartialContent)
+				_, _ = w.Write([]byte(content[4:]))
+				return
+			}
+			_, _ = w.Write([]byte(content))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	attachment := Attachment{ID: "7", Filename: "digits.txt", Size: 10, Content: server.URL + "/rest/api/3/attachment/content/7"}
+
+	body, resumed, err := client.DownloadAttachment(attachment, 0)
+	if err != nil || resumed {
+		t.Fatalf("unexpected full download: resumed=%v err=%v", resumed, err)
+	}
+	data, _ := io.ReadAll(body)
+	_ = body.Close()
+	if string(data) != content {
+		t.Fatalf("unexpected content %q", data)
+	}
+
+	body, resumed, err = client.DownloadAttachment(attachment, 4)
+	if err != nil || !resumed {
+		t.Fatalf("expected a resumed download: resumed=%v err=%v", resumed, err)
+	}
+	data, _ = io.ReadAll(body)
+	_ = body.Close()
+	if string(data) != content[4:] {
+		t.Fatalf("unexpected partial content %q", data)
+	}
+
+	if _, _, err := client.DownloadAttachment(Attachment{ID: "8"}, 0); err == nil {
+		t.Fatal("expected a missing attachment to fail")
+	}
+}
+
+func TestDownloadAttachmentOutlastsClientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("first "))
+		w.(http.Flusher).Flush()
+		time.Sleep(150 * time.Millisecond)
+		_, _ = w.Write([]byte("second"))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	client.httpClient = &http.Client{Timeout: 50 * time.Millisecond}
+	body, _, err := client.DownloadAttachment(Attachment{ID: "7", Content: server.URL + "/slow"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(body)
+	_ = body.Close()
+	if err != nil || string(data) != "first second" {
+		t.Fatalf("expected the slow download to finish, got %q, %v", data, err)
+	}
+}
@@ -0,0 +1,76 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ChangeHistory is one entry of an issue's changelog: the fields a user
+// changed at once.
+type ChangeHistory struct {
+	ID      string       `json:"id"`
+	Author  User         `json:"author"`
+	Created string       `json:"created"`
+	Items   []ChangeItem `json:"items"`
+}
+
+// ChangeItem is the change of a single field. From and To hold IDs where the
+// field has them (statuses, users, options); FromString and ToString their
+// display values.
+type ChangeItem struct {
+	Field      string `json:"field"`
+	FieldID    string `json:"fieldId,omitempty"`
+	FieldType  string `json:"fieldtype,omitempty"`
+	From       string `json:"from,omitempty"`
+	FromString string `json:"fromString,omitempty"`
+	To         string `json:"to,omitempty"`
+	ToString   string `json:"toString,omitempty"`
+}
+
+// CreatedAt parses Created, which uses the same layout as worklog times.
+func (h ChangeHistory) CreatedAt() (time.Time, error) {
+	return time.Parse(WorklogTimeLayout, h.Created)
+}
+
+// GetChangelogContext returns the whole changelog of an issue, oldest entry
+// first. Server and Data Center lack the paged changelog endpoint, so there
+// the changelog is expanded on the issue itself.
+func (c *Client) GetChangelogContext(ctx context.Context, issueKey string) ([]ChangeHistory, error) {
+	if c.DeploymentContext(ctx) == DeploymentServer {
+		var issue struct {
+			Changelog struct {
+				Histories []ChangeHistory `json:"histories"`
+			} `json:"changelog"`
+		}
+		if err := c.getJSON(ctx, fmt.Sprintf("issue/%s?expand=changelog&fields=created", url.PathEscape(issueKey)), &issue); err != nil {
+			return nil, err
+		}
+		return issue.Changelog.Histories, nil
+	}
+
+	var histories []ChangeHistory
+	for startAt := 0; ; {
+		query := url.Values{"startAt": {strconv.Itoa(startAt)}, "maxResults": {"100"}}
+		var page struct {
+			Total  int             `json:"total"`
+			IsLast bool            `json:"isLast"`
+			Values []ChangeHistory `json:"values"`
+		}
+		if err := c.getJSON(ctx, fmt.Sprintf("issue/%s/changelog?%s", url.PathEscape(issueKey), query.Encode()), &page); err != nil {
+			return nil, err
+		}
+		histories = append(histories, page.Values...)
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 || startAt >= page.Total {
+			return histories, nil
+		}
+	}
+}
+
+// GetChangelog calls GetChangelogContext with a background context.
+func (c *Client) GetChangelog(issueKey string) ([]ChangeHistory, error) {
+	return c.GetChangelogContext(context.Background(), issueKey)
+}
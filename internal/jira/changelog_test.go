@@ -0,0 +1,43 @@
+package jira
+
+import (
+	"net/http"
+	"testing"
+
+	"devflow/internal/config"
+	"devflow/internal/httpx"
+)
+
+func TestGetChangelogPaginates(t *testing.T) {
+	var starts []string
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/ENG-1/changelog" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		starts = append(starts, r.URL.Query().Get("startAt"))
+		if r.URL.Query().Get("startAt") == "0" {
+			_, _ = w.Write([]byte(`{"total":2,"isLast":false,"values":[{"id":"1","author":{"displayName":"Alice"},"created":"2024-05-06T09:00:00.000+0000",
+				"items":[{"field":"status","fieldId":"status","from":"1","fromString":"To Do","to":"3","toString":"In Progress"}]}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"total":2,"isLast":true,"values":[{"id":"2","author":{"displayName":"Bob"},"created":"2024-05-07T10:30:00.000+0000",
+			"items":[{"field":"assignee","fieldId":"assignee","toString":"Bob"}]}]}`))
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	histories, err := client.GetChangelog("ENG-1")
+	if err != nil {
+		t.Fatalf("GetChangelog failed: %v", err)
+	}
+	if len(histories) != 2 || len(starts) != 2 || starts[1] != "1" {
+		t.Fatalf("unexpected pages: %d histories, starts %v", len(histories), starts)
+	}
+	item := histories[0].Items[0]
+	if histories[0].Author.DisplayName != "Alice" || item.FieldID != "status" || item.FromString != "To Do" || item.To != "3" {
+		t.Fatalf("unexpected first history: %+v", histories[0])
+	}
+	created, err := histories[1].CreatedAt()
+	if err != nil || created.UTC().Hour() != 10 || created.Minute() != 30 {
+		t.Fatalf("unexpected created time %v, %v", created, err)
+	}
+}
@@ -2,6 +2,7 @@ package jira
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,10 +10,11 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"devflow/internal/adf"
 	"devflow/internal/config"
 	"devflow/internal/httpx"
 )
@@ -20,6 +22,20 @@ import (
 type Client struct {
 	config     *config.JiraConfig
 	httpClient *http.Client
+
+	// Field metadata rarely changes, so it is fetched once per client.
+	fieldsMu   sync.Mutex
+	fields     []Field
+	createMeta map[string]map[string]FieldMeta
+
+	// The deployment is resolved on the first request.
+	deploymentMu sync.Mutex
+	deployment   Deployment
+
+	// Users resolved by ResolveUser, keyed by lowercased lookup.
+	usersMu sync.Mutex
+	users   map[string]User
+	myself  *User
 }
 
 type Issue struct {
@@ -29,7 +45,12 @@ type Issue struct {
 		Summary     string      `json:"summary"`
 		Description interface{} `json:"description"`
 		Status      struct {
-			Name string `json:"name"`
+			ID             string `json:"id,omitempty"`
+			Name           string `json:"name"`
+			StatusCategory struct {
+				Key  string `json:"key"` // new, indeterminate or done
+				Name string `json:"name"`
+			} `json:"statusCategory"`
 		} `json:"status"`
 		Assignee struct {
 			DisplayName string `json:"displayName"`
@@ -37,9 +58,22 @@ type Issue struct {
 		Priority struct {
 			Name string `json:"name"`
 		} `json:"priority"`
-		Sprint  interface{} `json:"sprint"`
-		Updated string      `json:"updated"`
-		Created string      `json:"created"`
+		IssueType struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+		Sprint interface{} `json:"sprint"`
+		// Parent is only requested by SearchChildren.
+		Parent *struct {
+			ID  string `json:"id"`
+			Key string `json:"key"`
+		} `json:"parent,omitempty"`
+		// Comment is only requested by TakeSnapshot and FindMentions.
+		Comment *struct {
+			Total    int       `json:"total"`
+			Comments []Comment `json:"comments"`
+		} `json:"comment,omitempty"`
+		Updated string `json:"updated"`
+		Created string `json:"created"`
 	} `json:"fields"`
 }
 
@@ -76,11 +110,14 @@ type IssueDetails struct {
 		Comment struct {
 			Comments []Comment `json:"comments"`
 		} `json:"comment"`
-		Attachment   []Attachment `json:"attachment"`
+		Attachment []Attachment `json:"attachment"`
+		IssueLinks []IssueLink  `json:"issuelinks"`
+		// TeamAssigned is read from whichever field the instance names
+		// "Team Assigned", "Assigned Team" or "Team".
 		TeamAssigned struct {
 			ID   string `json:"id"`
 			Name string `json:"name"`
-		} `json:"customfield_11887"`
+		} `json:"-"`
 	} `json:"fields"`
 }
 
@@ -94,10 +131,14 @@ type Comment struct {
 	Updated string      `json:"updated"`
 }
 
-// Attachment represents a Jira attachment
+// Attachment represents a Jira attachment. Content is the URL of the file
+// itself.
 type Attachment struct {
+	ID       string `json:"id"`
 	Filename string `json:"filename"`
 	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Content  string `json:"content"`
 	Created  string `json:"created"`
 }
 
@@ -127,8 +168,11 @@ func escapeJQLStringLiteral(value string) string {
 	return value
 }
 
-func (c *Client) makeRequest(method, endpoint string, body interface{}) (*http.Response, error) {
-	url := fmt.Sprintf("%s/rest/api/3/%s", c.config.URL, endpoint)
+// makeRequest performs a request against the REST API of the deployment,
+// /rest/api/3/ on Cloud and /rest/api/2/ on Server, adapting the body to it.
+func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
+	url := fmt.Sprintf("%s/%s/%s", c.config.URL, c.apiRoot(ctx), endpoint)
+	body = c.forDeployment(ctx, body)
 
 	// Debug: print full URL if enabled
 	if os.Getenv("DEVFLOW_DEBUG") == "1" || strings.ToLower(os.Getenv("DEVFLOW_DEBUG")) == "true" {
@@ -144,22 +188,22 @@ func (c *Client) makeRequest(method, endpoint string, body interface{}) (*http.R
 		reqBody = bytes.NewReader(jsonBody)
 	}
 
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	httpx.ApplyBasicAuth(req, c.config.Username, c.config.Token)
+	c.applyAuth(req)
 	req.Header.Set("Content-Type", "application/json")
 
 	return c.httpClient.Do(req)
 }
 
 // makeRequestPath performs a request against an arbitrary path relative to
-// the configured Jira base URL, bypassing the /rest/api/3/ prefix used by
+// the configured Jira base URL, bypassing the REST API prefix used by
 // makeRequest. This is needed for endpoints living under other REST roots,
 // such as /rest/dev-status/1.0/.
-func (c *Client) makeRequestPath(method, path string, body interface{}) (*http.Response, error) {
+func (c *Client) makeRequestPath(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
 	fullURL := fmt.Sprintf("%s/%s", strings.TrimSuffix(c.config.URL, "/"), strings.TrimPrefix(path, "/"))
 
 	if os.Getenv("DEVFLOW_DEBUG") == "1" || strings.ToLower(os.Getenv("DEVFLOW_DEBUG")) == "true" {
@@ -175,12 +219,12 @@ func (c *Client) makeRequestPath(method, path string, body interface{}) (*http.R
 		reqBody = bytes.NewReader(jsonBody)
 	}
 
-	req, err := http.NewRequest(method, fullURL, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	httpx.ApplyBasicAuth(req, c.config.Username, c.config.Token)
+	c.applyAuth(req)
 	req.Header.Set("Content-Type", "application/json")
 
 	return c.httpClient.Do(req)
@@ -219,13 +263,13 @@ type devStatusResponse struct {
 	Detail []devStatusDetail `json:"detail"`
 }
 
-// GetIssuePullRequests fetches the pull requests linked to a Jira issue via
+// GetIssuePullRequestsContext fetches the pull requests linked to a Jira issue via
 // the dev-status API (works for Bitbucket and GitHub integrations). issueID
 // is the numeric Jira issue ID (IssueDetails.ID), not the issue key.
-func (c *Client) GetIssuePullRequests(issueID string) ([]PullRequestRef, error) {
+func (c *Client) GetIssuePullRequestsContext(ctx context.Context, issueID string) ([]PullRequestRef, error) {
 	path := fmt.Sprintf("rest/dev-status/1.0/issue/detail?issueId=%s&applicationType=bitbucket&dataType=pullrequest", url.QueryEscape(issueID))
 
-	resp, err := c.makeRequestPath("GET", path, nil)
+	resp, err := c.makeRequestPath(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -237,7 +281,7 @@ func (c *Client) GetIssuePullRequests(issueID string) ([]PullRequestRef, error)
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+		return nil, httpx.NewAPIError(httpx.ServiceJira, resp, body)
 	}
 
 	var devResp devStatusResponse
@@ -253,10 +297,15 @@ func (c *Client) GetIssuePullRequests(issueID string) ([]PullRequestRef, error)
 	return prs, nil
 }
 
-// Search performs a Jira search using either raw JQL or a free-text query.
+// GetIssuePullRequests calls GetIssuePullRequestsContext with a background context.
+func (c *Client) GetIssuePullRequests(issueID string) ([]PullRequestRef, error) {
+	return c.GetIssuePullRequestsContext(context.Background(), issueID)
+}
+
+// SearchContext performs a Jira search using either raw JQL or a free-text query.
 // If isJQL is true, the provided query is used as JQL directly. Otherwise
 // the query is treated as free text and converted to a `text ~ "..."` JQL.
-func (c *Client) Search(query string, isJQL bool, maxResults int, startAtArg int) ([]Issue, error) {
+func (c *Client) SearchContext(ctx context.Context, query string, isJQL bool, maxResults int, startAtArg int) ([]Issue, error) {
 	// Backwards compatible: if fetchAll behavior is desired, callers should use SearchAll.
 	var jql string
 	if isJQL {
@@ -277,13 +326,12 @@ func (c *Client) Search(query string, isJQL bool, maxResults int, startAtArg int
 		}
 	}
 
-	encodedJQL := url.QueryEscape(jql)
-	baseEndpoint := fmt.Sprintf("search/jql?jql=%s&fields=key,summary,description,status,assignee,priority,sprint", encodedJQL)
+	baseEndpoint := c.searchEndpoint(ctx, jql, searchFields)
 
 	// If maxResults <= 0, behave as before: single request leaving server to use its default
 	if maxResults <= 0 {
 		endpoint := baseEndpoint
-		resp, err := c.makeRequest("GET", endpoint, nil)
+		resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to make request: %w", err)
 		}
@@ -295,7 +343,7 @@ func (c *Client) Search(query string, isJQL bool, maxResults int, startAtArg int
 
 		if resp.StatusCode != http.StatusOK {
 			body, _ := io.ReadAll(resp.Body)
-			return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+			return nil, httpx.NewAPIError(httpx.ServiceJira, resp, body)
 		}
 
 		var searchResp SearchResponse
@@ -335,7 +383,7 @@ func (c *Client) Search(query string, isJQL bool, maxResults int, startAtArg int
 	// Some Jira servers may have a hard upper limit; request in chunks of at most perPage
 	for {
 		endpoint := fmt.Sprintf("%s&maxResults=%d&startAt=%d", baseEndpoint, perPage, startAt)
-		resp, err := c.makeRequest("GET", endpoint, nil)
+		resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to make request: %w", err)
 		}
@@ -347,7 +395,7 @@ func (c *Client) Search(query string, isJQL bool, maxResults int, startAtArg int
 
 		if resp.StatusCode != http.StatusOK {
 			body, _ := io.ReadAll(resp.Body)
-			return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+			return nil, httpx.NewAPIError(httpx.ServiceJira, resp, body)
 		}
 
 		var searchResp SearchResponse
@@ -392,7 +440,7 @@ func (c *Client) Search(query string, isJQL bool, maxResults int, startAtArg int
 				}
 				// Build endpoint with token (GET style)
 				endpoint := fmt.Sprintf("%s&maxResults=%d&pageToken=%s", baseEndpoint, perPage, url.QueryEscape(token))
-				resp, err := c.makeRequest("GET", endpoint, nil)
+				resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 				if err != nil {
 					return nil, fmt.Errorf("failed to make token-based request: %w", err)
 				}
@@ -403,7 +451,7 @@ func (c *Client) Search(query string, isJQL bool, maxResults int, startAtArg int
 				}()
 				if resp.StatusCode != http.StatusOK {
 					body, _ := io.ReadAll(resp.Body)
-					return nil, fmt.Errorf("API request failed (token) with status: %d, response: %s", resp.StatusCode, string(body))
+					return nil, httpx.NewAPIError(httpx.ServiceJira, resp, body)
 				}
 				var tr SearchResponse
 				if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
@@ -448,7 +496,7 @@ func (c *Client) Search(query string, isJQL bool, maxResults int, startAtArg int
 				return nil, fmt.Errorf("requested page would require a large fallback of %d items which exceeds the cap of %d; please choose a smaller page or increase --max-results accordingly", fallbackSize, fallbackCap)
 			}
 			fallbackEndpoint := fmt.Sprintf("%s&maxResults=%d&startAt=%d", baseEndpoint, fallbackSize, 0)
-			fbResp, err := c.makeRequest("GET", fallbackEndpoint, nil)
+			fbResp, err := c.makeRequest(ctx, "GET", fallbackEndpoint, nil)
 			if err != nil {
 				return nil, fmt.Errorf("failed to make fallback request: %w", err)
 			}
@@ -459,7 +507,7 @@ func (c *Client) Search(query string, isJQL bool, maxResults int, startAtArg int
 			}()
 			if fbResp.StatusCode != http.StatusOK {
 				body, _ := io.ReadAll(fbResp.Body)
-				return nil, fmt.Errorf("API request failed (fallback) with status: %d, response: %s", fbResp.StatusCode, string(body))
+				return nil, httpx.NewAPIError(httpx.ServiceJira, fbResp, body)
 			}
 			var fbSearchResp SearchResponse
 			fbData, err := io.ReadAll(fbResp.Body)
@@ -510,15 +558,46 @@ func (c *Client) Search(query string, isJQL bool, maxResults int, startAtArg int
 	return collected, nil
 }
 
-// GetMyIssues retrieves issues assigned to the current user
-func (c *Client) GetMyIssues() ([]Issue, error) {
+// Search calls SearchContext with a background context.
+func (c *Client) Search(query string, isJQL bool, maxResults int, startAtArg int) ([]Issue, error) {
+	return c.SearchContext(context.Background(), query, isJQL, maxResults, startAtArg)
+}
+
+// GetMyIssuesContext retrieves issues assigned to the current user
+func (c *Client) GetMyIssuesContext(ctx context.Context) ([]Issue, error) {
 	// Default to single-page search with server default paging
-	return c.Search("", false, 0, 0)
+	return c.SearchContext(ctx, "", false, 0, 0)
+}
+
+// GetMyIssues calls GetMyIssuesContext with a background context.
+func (c *Client) GetMyIssues() ([]Issue, error) {
+	return c.GetMyIssuesContext(context.Background())
 }
 
-// SearchAll retrieves issues following token-based or startAt pagination until completion
+// SearchAllContext retrieves issues following token-based or startAt pagination until completion
 // It respects maxResultsPerPage if >0; if maxTotal <= 0 it will fetch all available issues.
-func (c *Client) SearchAll(query string, isJQL bool, maxResultsPerPage int, maxTotal int) ([]Issue, error) {
+func (c *Client) SearchAllContext(ctx context.Context, query string, isJQL bool, maxResultsPerPage int, maxTotal int) ([]Issue, error) {
+	// Build base JQL and endpoint pieces for pagination
+	var jql string
+	if isJQL {
+		jql = query
+		if !strings.Contains(strings.ToLower(jql), "order by") {
+			jql = strings.TrimSpace(jql) + " ORDER BY updated DESC"
+		}
+	} else {
+		trimmed := strings.TrimSpace(query)
+		if trimmed == "" {
+			jql = "assignee = currentUser() ORDER BY updated DESC"
+		} else {
+			jql = fmt.Sprintf("text ~ \"%s\" ORDER BY updated DESC", escapeJQLStringLiteral(trimmed))
+		}
+	}
+	return c.searchPages(ctx, c.searchEndpoint(ctx, jql, searchFields), maxResultsPerPage, maxTotal)
+}
+
+// searchPages follows the pages of the search at baseEndpoint, by token or
+// startAt, until the results run out or maxTotal issues are collected.
+func (c *Client) searchPages(ctx context.Context, baseEndpoint string, maxResultsPerPage int, maxTotal int) ([]Issue, error) {
 	collected := make([]Issue, 0)
 	seen := make(map[string]struct{})
 
@@ -542,24 +621,6 @@ func (c *Client) SearchAll(query string, isJQL bool, maxResultsPerPage int, maxT
 		pageSize = 100 // Default to 100 results per page to minimize requests
 	}
 
-	// Build base JQL and endpoint pieces for pagination
-	var jql string
-	if isJQL {
-		jql = query
-		if !strings.Contains(strings.ToLower(jql), "order by") {
-			jql = strings.TrimSpace(jql) + " ORDER BY updated DESC"
-		}
-	} else {
-		trimmed := strings.TrimSpace(query)
-		if trimmed == "" {
-			jql = "assignee = currentUser() ORDER BY updated DESC"
-		} else {
-			jql = fmt.Sprintf("text ~ \"%s\" ORDER BY updated DESC", escapeJQLStringLiteral(trimmed))
-		}
-	}
-	encodedJQL := url.QueryEscape(jql)
-	baseEndpoint := fmt.Sprintf("search/jql?jql=%s&fields=key,summary,description,status,assignee,priority,sprint", encodedJQL)
-
 	// Pagination loop using tokens or startAt
 	token := ""
 	tokenSeen := make(map[string]int)
@@ -580,7 +641,7 @@ func (c *Client) SearchAll(query string, isJQL bool, maxResultsPerPage int, maxT
 			// Use startAt for subsequent pages if not using tokens
 			endpoint = fmt.Sprintf("%s&startAt=%d", endpoint, startAt)
 		}
-		resp, err := c.makeRequest("GET", endpoint, nil)
+		resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -591,7 +652,7 @@ func (c *Client) SearchAll(query string, isJQL bool, maxResultsPerPage int, maxT
 		}()
 		if resp.StatusCode != http.StatusOK {
 			body, _ := io.ReadAll(resp.Body)
-			return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+			return nil, httpx.NewAPIError(httpx.ServiceJira, resp, body)
 		}
 		var sr SearchResponse
 		if err := json.NewDecoder(resp.Body).Decode(&sr); err != nil {
@@ -672,11 +733,42 @@ func (c *Client) SearchAll(query string, isJQL bool, maxResultsPerPage int, maxT
 	return collected, nil
 }
 
-// GetIssueDetails retrieves detailed information about a specific issue
-func (c *Client) GetIssueDetails(issueKey string) (*IssueDetails, error) {
-	endpoint := fmt.Sprintf("issue/%s?fields=summary,description,status,priority,assignee,reporter,created,updated,comment,attachment,customfield_11887", issueKey)
+// SearchAll calls SearchAllContext with a background context.
+func (c *Client) SearchAll(query string, isJQL bool, maxResultsPerPage int, maxTotal int) ([]Issue, error) {
+	return c.SearchAllContext(context.Background(), query, isJQL, maxResultsPerPage, maxTotal)
+}
 
-	resp, err := c.makeRequest("GET", endpoint, nil)
+// childFields are the fields SearchChildren requests: enough to show an
+// issue in a tree, its parent to place it there and its id to look up its
+// pull requests.
+const childFields = "id,key,summary,status,assignee,priority,parent"
+
+// SearchChildrenContext returns the children of all the parentKeys in one
+// paged search, ordered by status and priority. Each issue carries its
+// parent in Fields.Parent. maxTotal caps the number of issues when positive.
+func (c *Client) SearchChildrenContext(ctx context.Context, parentKeys []string, maxTotal int) ([]Issue, error) {
+	if len(parentKeys) == 0 {
+		return nil, nil
+	}
+	jql := fmt.Sprintf("parent in (%s) ORDER BY status ASC, priority DESC", strings.Join(parentKeys, ","))
+	return c.searchPages(ctx, c.searchEndpoint(ctx, jql, childFields), 100, maxTotal)
+}
+
+// SearchChildren calls SearchChildrenContext with a background context.
+func (c *Client) SearchChildren(parentKeys []string, maxTotal int) ([]Issue, error) {
+	return c.SearchChildrenContext(context.Background(), parentKeys, maxTotal)
+}
+
+// GetIssueDetailsContext retrieves detailed information about a specific issue
+func (c *Client) GetIssueDetailsContext(ctx context.Context, issueKey string) (*IssueDetails, error) {
+	fields := "summary,description,status,priority,assignee,reporter,created,updated,comment,attachment,issuelinks"
+	teamField := c.teamFieldContext(ctx)
+	if teamField != "" {
+		fields += "," + teamField
+	}
+	endpoint := fmt.Sprintf("issue/%s?fields=%s", issueKey, fields)
+
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -688,68 +780,66 @@ func (c *Client) GetIssueDetails(issueKey string) (*IssueDetails, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+		return nil, httpx.NewAPIError(httpx.ServiceJira, resp, body)
 	}
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
 	var issue IssueDetails
-	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+	if err := json.Unmarshal(body, &issue); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
+	if teamField != "" {
+		var raw struct {
+			Fields map[string]json.RawMessage `json:"fields"`
+		}
+		if err := json.Unmarshal(body, &raw); err == nil {
+			issue.Fields.TeamAssigned.ID, issue.Fields.TeamAssigned.Name = decodeTeam(raw.Fields[teamField])
+		}
+	}
 
 	return &issue, nil
 }
 
-// CreateIssueOptions holds optional fields for issue creation
+// GetIssueDetails calls GetIssueDetailsContext with a background context.
+func (c *Client) GetIssueDetails(issueKey string) (*IssueDetails, error) {
+	return c.GetIssueDetailsContext(context.Background(), issueKey)
+}
+
+// CreateIssueOptions holds optional fields for issue creation. Epic,
+// StoryPoints, Sprint and Team are set on whichever custom fields the create
+// screen names accordingly; Fields holds further values keyed by field ID,
+// already encoded for the API.
 type CreateIssueOptions struct {
 	ProjectKey  string
 	Summary     string
 	Description string
 	IssueType   string
 	Priority    string
-	Assignee    string
+	Assignee    string // "me", an email address, display name, username or accountId
 	Labels      []string
 	Epic        string
 	StoryPoints float64
-	Sprint      string
+	Sprint      string // Sprint ID
 	Team        string
+	Parent      string // Parent issue key, for sub-tasks
+	Fields      map[string]interface{}
 }
 
-// CreateIssue creates a new Jira issue with extended options
-func (c *Client) CreateIssue(opts CreateIssueOptions) (*Issue, error) {
-	endpoint := "issue"
-
+// CreateIssueContext creates a new Jira issue with extended options. Planning
+// values whose field is not on the project's create screen are left out with
+// a warning.
+func (c *Client) CreateIssueContext(ctx context.Context, opts CreateIssueOptions) (*Issue, error) {
 	if opts.IssueType == "" {
 		opts.IssueType = "Task"
 	}
 
-	toADF := func(text string) interface{} {
-		if strings.TrimSpace(text) == "" {
-			return map[string]interface{}{
-				"type":    "doc",
-				"version": 1,
-				"content": []interface{}{map[string]interface{}{"type": "paragraph", "content": []interface{}{}}},
-			}
-		}
-		lines := strings.Split(text, "\n")
-		paragraphs := make([]interface{}, 0, len(lines))
-		for _, line := range lines {
-			line = strings.TrimRight(line, "\r")
-			if line == "" {
-				paragraphs = append(paragraphs, map[string]interface{}{"type": "paragraph", "content": []interface{}{}})
-				continue
-			}
-			paragraphs = append(paragraphs, map[string]interface{}{
-				"type":    "paragraph",
-				"content": []interface{}{map[string]interface{}{"type": "text", "text": line}},
-			})
-		}
-		return map[string]interface{}{"type": "doc", "version": 1, "content": paragraphs}
-	}
-
 	fields := map[string]interface{}{
 		"project":     map[string]string{"key": opts.ProjectKey},
 		"summary":     opts.Summary,
-		"description": toADF(opts.Description),
+		"description": adf.FromMarkdown(opts.Description),
 		"issuetype":   map[string]string{"name": opts.IssueType},
 	}
 
@@ -760,39 +850,37 @@ func (c *Client) CreateIssue(opts CreateIssueOptions) (*Issue, error) {
 		fields["labels"] = opts.Labels
 	}
 	if opts.Assignee != "" {
-		fields["assignee"] = map[string]string{"name": opts.Assignee}
-	}
-	if opts.StoryPoints > 0 {
-		fields["customfield_10016"] = opts.StoryPoints
-	}
-	if opts.Epic != "" {
-		fields["customfield_10014"] = opts.Epic
-	}
-	if opts.Sprint != "" {
-		fields["customfield_10020"] = opts.Sprint
-	}
-	if opts.Team != "" {
-		// Team Assigned usually expects an object with id or name. Accept numeric or string.
-		if _, err := strconv.Atoi(opts.Team); err == nil {
-			fields["customfield_11887"] = map[string]string{"id": opts.Team}
-		} else {
-			fields["customfield_11887"] = map[string]string{"name": opts.Team}
+		assignee, err := c.UserFieldContext(ctx, opts.Assignee)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve assignee: %w", err)
 		}
+		fields["assignee"] = assignee
+	}
+	if opts.Parent != "" {
+		fields["parent"] = map[string]string{"key": opts.Parent}
 	}
 
-	attempt := func(f map[string]interface{}) (*http.Response, []byte, error) {
-		body := map[string]interface{}{"fields": f}
-		resp, err := c.makeRequest("POST", endpoint, body)
+	planning := PlanningFields{Epic: opts.Epic, StoryPoints: opts.StoryPoints, Sprint: opts.Sprint, Team: opts.Team}
+	if !planning.IsZero() {
+		screen, err := c.CreateMetaContext(ctx, opts.ProjectKey, opts.IssueType)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to make request: %w", err)
+			return nil, fmt.Errorf("failed to read the create screen: %w", err)
 		}
-		data, _ := io.ReadAll(resp.Body)
-		return resp, data, nil
+		values, omitted := planning.Resolve(screen)
+		for id, value := range values {
+			fields[id] = value
+		}
+		if len(omitted) > 0 {
+			log.Printf("warning: omitted fields not on the %s %s create screen: %s", opts.ProjectKey, opts.IssueType, strings.Join(omitted, ", "))
+		}
+	}
+	for id, value := range opts.Fields {
+		fields[id] = value
 	}
 
-	resp, data, err := attempt(fields)
+	resp, err := c.makeRequest(ctx, "POST", "issue", map[string]interface{}{"fields": fields})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -800,74 +888,31 @@ func (c *Client) CreateIssue(opts CreateIssueOptions) (*Issue, error) {
 		}
 	}()
 
-	if resp.StatusCode == http.StatusBadRequest {
-		var errPayload struct {
-			Errors map[string]string `json:"errors"`
-		}
-		_ = json.Unmarshal(data, &errPayload)
-
-		removed := []string{}
-		for _, cf := range []string{"customfield_10014", "customfield_10016", "customfield_10020", "customfield_11887"} {
-			if msg, bad := errPayload.Errors[cf]; bad && msg != "" {
-				if _, present := fields[cf]; present {
-					delete(fields, cf)
-					removed = append(removed, cf)
-				}
-			}
-		}
-		if len(removed) > 0 {
-			func() {
-				if err := resp.Body.Close(); err != nil {
-					log.Printf("warning: failed to close response body: %v", err)
-				}
-			}()
-			resp2, data2, err2 := attempt(fields)
-			if err2 != nil {
-				return nil, err2
-			}
-			defer func() {
-				if err := resp2.Body.Close(); err != nil {
-					log.Printf("warning: failed to close response body: %v", err)
-				}
-			}()
-			if resp2.StatusCode != http.StatusCreated {
-				return nil, fmt.Errorf("API request failed (after retry) with status: %d, body: %s", resp2.StatusCode, string(data2))
-			}
-			var issue Issue
-			if err := json.Unmarshal(data2, &issue); err != nil {
-				return nil, fmt.Errorf("failed to decode response: %w", err)
-			}
-			fmt.Printf("Warning: omitted unsupported custom fields: %v\n", removed)
-			return &issue, nil
-		}
-	}
-
 	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("API request failed with status: %d, body: %s", resp.StatusCode, string(data))
+		data, _ := io.ReadAll(resp.Body)
+		return nil, httpx.NewAPIError(httpx.ServiceJira, resp, data)
 	}
 
 	var issue Issue
-	if err := json.Unmarshal(data, &issue); err != nil {
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	return &issue, nil
 }
 
-// AddComment adds a comment to an issue
-func (c *Client) AddComment(issueKey, body string) error {
+// CreateIssue calls CreateIssueContext with a background context.
+func (c *Client) CreateIssue(opts CreateIssueOptions) (*Issue, error) {
+	return c.CreateIssueContext(context.Background(), opts)
+}
+
+// AddCommentContext adds a comment to an issue
+func (c *Client) AddCommentContext(ctx context.Context, issueKey, body string) error {
 	endpoint := fmt.Sprintf("issue/%s/comment", issueKey)
 	payload := map[string]interface{}{
-		"body": map[string]interface{}{
-			"type":    "doc",
-			"version": 1,
-			"content": []interface{}{map[string]interface{}{
-				"type":    "paragraph",
-				"content": []interface{}{map[string]interface{}{"type": "text", "text": body}},
-			}},
-		},
-	}
-	resp, err := c.makeRequest("POST", endpoint, payload)
+		"body": adf.FromMarkdown(body),
+	}
+	resp, err := c.makeRequest(ctx, "POST", endpoint, payload)
 	if err != nil {
 		return fmt.Errorf("failed to make request: %w", err)
 	}
@@ -878,13 +923,18 @@ func (c *Client) AddComment(issueKey, body string) error {
 	}()
 	if resp.StatusCode != http.StatusCreated {
 		data, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status: %d, body: %s", resp.StatusCode, string(data))
+		return httpx.NewAPIError(httpx.ServiceJira, resp, data)
 	}
 	return nil
 }
 
-// AddRemoteLink adds a remote link to an issue
-func (c *Client) AddRemoteLink(issueKey, linkURL, title, summary string) error {
+// AddComment calls AddCommentContext with a background context.
+func (c *Client) AddComment(issueKey, body string) error {
+	return c.AddCommentContext(context.Background(), issueKey, body)
+}
+
+// AddRemoteLinkContext adds a remote link to an issue
+func (c *Client) AddRemoteLinkContext(ctx context.Context, issueKey, linkURL, title, summary string) error {
 	endpoint := fmt.Sprintf("issue/%s/remotelink", issueKey)
 	obj := map[string]interface{}{
 		"url": linkURL,
@@ -898,7 +948,7 @@ func (c *Client) AddRemoteLink(issueKey, linkURL, title, summary string) error {
 	payload := map[string]interface{}{
 		"object": obj,
 	}
-	resp, err := c.makeRequest("POST", endpoint, payload)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, payload)
 	if err != nil {
 		return fmt.Errorf("failed to make request: %w", err)
 	}
@@ -909,60 +959,33 @@ func (c *Client) AddRemoteLink(issueKey, linkURL, title, summary string) error {
 	}()
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		data, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status: %d, body: %s", resp.StatusCode, string(data))
+		return httpx.NewAPIError(httpx.ServiceJira, resp, data)
 	}
 	return nil
 }
 
-// FindMentions searches for issues where the current user is mentioned
-func (c *Client) FindMentions() ([]Issue, error) {
-	query := fmt.Sprintf("text ~ \"%s\" ORDER BY updated DESC", c.config.Username)
-	// Use Search with isJQL=true because query already contains JQL syntax
-	issues, err := c.Search(query, true, 50, 0)
-	if err != nil {
-		return nil, err
-	}
-	return issues, nil
+// AddRemoteLink calls AddRemoteLinkContext with a background context.
+func (c *Client) AddRemoteLink(issueKey, linkURL, title, summary string) error {
+	return c.AddRemoteLinkContext(context.Background(), issueKey, linkURL, title, summary)
 }
 
-// UpdateIssue updates the specified fields on an existing issue.
+// UpdateIssueContext updates the specified fields on an existing issue.
 // The caller should pass a map where keys are Jira field keys (e.g., "summary",
 // "description", "priority", "assignee", "labels", "customfield_10016", etc.).
-func (c *Client) UpdateIssue(issueKey string, fields map[string]interface{}) error {
+func (c *Client) UpdateIssueContext(ctx context.Context, issueKey string, fields map[string]interface{}) error {
 	if len(fields) == 0 {
 		return nil
 	}
 
-	// Convert description from plain string to ADF if present and is a string
+	// Descriptions given as strings are Markdown; encode them as ADF
 	if d, ok := fields["description"].(string); ok {
-		if strings.TrimSpace(d) == "" {
-			fields["description"] = map[string]interface{}{
-				"type":    "doc",
-				"version": 1,
-				"content": []interface{}{map[string]interface{}{"type": "paragraph", "content": []interface{}{}}},
-			}
-		} else {
-			lines := strings.Split(d, "\n")
-			paragraphs := make([]interface{}, 0, len(lines))
-			for _, line := range lines {
-				line = strings.TrimRight(line, "\r")
-				if line == "" {
-					paragraphs = append(paragraphs, map[string]interface{}{"type": "paragraph", "content": []interface{}{}})
-					continue
-				}
-				paragraphs = append(paragraphs, map[string]interface{}{
-					"type":    "paragraph",
-					"content": []interface{}{map[string]interface{}{"type": "text", "text": line}},
-				})
-			}
-			fields["description"] = map[string]interface{}{"type": "doc", "version": 1, "content": paragraphs}
-		}
+		fields["description"] = adf.FromMarkdown(d)
 	}
 
 	endpoint := fmt.Sprintf("issue/%s", issueKey)
 	body := map[string]interface{}{"fields": fields}
 
-	resp, err := c.makeRequest("PUT", endpoint, body)
+	resp, err := c.makeRequest(ctx, "PUT", endpoint, body)
 	if err != nil {
 		return fmt.Errorf("failed to make request: %w", err)
 	}
@@ -975,16 +998,59 @@ func (c *Client) UpdateIssue(issueKey string, fields map[string]interface{}) err
 	// Jira returns 204 No Content on success for update
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
 		data, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status: %d, body: %s", resp.StatusCode, string(data))
+		return httpx.NewAPIError(httpx.ServiceJira, resp, data)
 	}
 	return nil
 }
 
-// ListProjects retrieves all Jira projects (spaces) accessible to the user
-func (c *Client) ListProjects() ([]Project, error) {
+// UpdateIssue calls UpdateIssueContext with a background context.
+func (c *Client) UpdateIssue(issueKey string, fields map[string]interface{}) error {
+	return c.UpdateIssueContext(context.Background(), issueKey, fields)
+}
+
+// UpdateLabelsContext adds and removes labels on an issue, leaving its other
+// labels untouched.
+func (c *Client) UpdateLabelsContext(ctx context.Context, issueKey string, add, remove []string) error {
+	ops := make([]map[string]string, 0, len(add)+len(remove))
+	for _, label := range add {
+		ops = append(ops, map[string]string{"add": label})
+	}
+	for _, label := range remove {
+		ops = append(ops, map[string]string{"remove": label})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	endpoint := fmt.Sprintf("issue/%s", issueKey)
+	body := map[string]interface{}{"update": map[string]interface{}{"labels": ops}}
+	resp, err := c.makeRequest(ctx, "PUT", endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return httpx.NewAPIError(httpx.ServiceJira, resp, data)
+	}
+	return nil
+}
+
+// UpdateLabels calls UpdateLabelsContext with a background context.
+func (c *Client) UpdateLabels(issueKey string, add, remove []string) error {
+	return c.UpdateLabelsContext(context.Background(), issueKey, add, remove)
+}
+
+// ListProjectsContext retrieves all Jira projects (spaces) accessible to the user
+func (c *Client) ListProjectsContext(ctx context.Context) ([]Project, error) {
 	endpoint := "project?expand=lead"
 
-	resp, err := c.makeRequest("GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -996,7 +1062,7 @@ func (c *Client) ListProjects() ([]Project, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status: %d, response: %s", resp.StatusCode, string(body))
+		return nil, httpx.NewAPIError(httpx.ServiceJira, resp, body)
 	}
 
 	var projects []Project
@@ -1006,3 +1072,62 @@ func (c *Client) ListProjects() ([]Project, error) {
 
 	return projects, nil
 }
+
+// ListProjects calls ListProjectsContext with a background context.
+func (c *Client) ListProjects() ([]Project, error) {
+	return c.ListProjectsContext(context.Background())
+}
+
+// CheckAuthContext verifies the configured credentials against /myself and reports
+// the authenticated identity
+func (c *Client) CheckAuthContext(ctx context.Context) (*httpx.AuthReport, error) {
+	report := &httpx.AuthReport{Scheme: c.authScheme(ctx)}
+
+	resp, err := c.makeRequest(ctx, "GET", "myself", nil)
+	if err != nil {
+		return report, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return report, httpx.NewAuthError(resp, body)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return report, httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+
+	var myself struct {
+		AccountID    string `json:"accountId"`
+		DisplayName  string `json:"displayName"`
+		EmailAddress string `json:"emailAddress"`
+	}
+	if err := json.Unmarshal(body, &myself); err != nil {
+		return report, fmt.Errorf("failed to decode response: %w", err)
+	}
+	report.Identity = myself.DisplayName
+	if myself.EmailAddress != "" {
+		report.Identity = fmt.Sprintf("%s <%s>", myself.DisplayName, myself.EmailAddress)
+	}
+	return report, nil
+}
+
+// CheckAuth calls CheckAuthContext with a background context.
+func (c *Client) CheckAuth() (*httpx.AuthReport, error) {
+	return c.CheckAuthContext(context.Background())
+}
+
+// TestAuthContext verifies the configured credentials
+func (c *Client) TestAuthContext(ctx context.Context) error {
+	_, err := c.CheckAuthContext(ctx)
+	return err
+}
+
+// TestAuth calls TestAuthContext with a background context.
+func (c *Client) TestAuth() error {
+	return c.TestAuthContext(context.Background())
+}
@@ -1,7 +1,9 @@
 package jira
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 	"testing"
@@ -24,7 +26,7 @@ func TestCreateIssue_Succeeds(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	cfg := &config.JiraConfig{URL: srv.URL, Username: "u", Token: "t"}
+	cfg := &config.JiraConfig{URL: srv.URL, Username: "u", Token: "t", Deployment: "cloud"}
 	c := NewClient(cfg)
 
 	issue, err := c.CreateIssue(CreateIssueOptions{ProjectKey: "PROJ", Summary: "New task"})
@@ -36,48 +38,48 @@ func TestCreateIssue_Succeeds(t *testing.T) {
 	}
 }
 
-func TestCreateIssue_RetryRemovesCustomFields(t *testing.T) {
-	// Simulate server that returns 400 with errors on first attempt, then 201
-	call := 0
+func TestCreateIssue_OmitsFieldsMissingFromScreen(t *testing.T) {
+	var metaCalls int
+	var fields map[string]interface{}
 	srv := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		call++
-		if r.URL.Path != "/rest/api/3/issue" {
-			t.Fatalf("unexpected path: %s", r.URL.Path)
-		}
-		if call == 1 {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			// Return an errors map indicating customfield_10014 is invalid
-			b, _ := json.Marshal(map[string]map[string]string{"errors": {"customfield_10014": "bad field"}})
-			if _, err := w.Write(b); err != nil {
-				t.Fatalf("failed to write response: %v", err)
+		switch r.URL.Path {
+		case "/rest/api/3/issue/createmeta/PROJ/issuetypes":
+			metaCalls++
+			_, _ = w.Write([]byte(`{"values":[{"id":"10","name":"Task"}]}`))
+		case "/rest/api/3/issue/createmeta/PROJ/issuetypes/10":
+			_, _ = w.Write([]byte(`{"total":1,"values":[{"fieldId":"customfield_10016","name":"Story Points","schema":{"type":"number"}}]}`))
+		case "/rest/api/3/issue":
+			var payload struct {
+				Fields map[string]interface{} `json:"fields"`
 			}
-
-			return
-		}
-		// On retry, return created
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusCreated)
-		if _, err := w.Write([]byte(`{"key":"PROJ-456","fields":{"summary":"New task"}}`)); err != nil {
-			t.Fatalf("failed to write response: %v", err)
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			fields = payload.Fields
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"key":"PROJ-456","fields":{"summary":"New task"}}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
 		}
-
 	}))
 	defer srv.Close()
 
-	cfg := &config.JiraConfig{URL: srv.URL, Username: "u", Token: "t"}
+	cfg := &config.JiraConfig{URL: srv.URL, Username: "u", Token: "t", Deployment: "cloud"}
 	c := NewClient(cfg)
 
 	opts := CreateIssueOptions{ProjectKey: "PROJ", Summary: "Task", Epic: "E-1", StoryPoints: 3.0, Sprint: "S1", Team: "42"}
-	issue, err := c.CreateIssue(opts)
-	if err != nil {
-		t.Fatalf("CreateIssue (with retry) failed: %v", err)
+	for range 2 {
+		issue, err := c.CreateIssue(opts)
+		if err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if issue == nil || !strings.HasPrefix(issue.Key, "PROJ-") {
+			t.Fatalf("unexpected issue returned: %+v", issue)
+		}
 	}
-	if issue == nil || !strings.HasPrefix(issue.Key, "PROJ-") {
-		t.Fatalf("unexpected issue returned after retry: %+v", issue)
+	if fields["customfield_10016"] != 3.0 || len(fields) != 5 {
+		t.Fatalf("expected only the story points besides the standard fields, got %+v", fields)
 	}
-	if call != 2 {
-		t.Fatalf("expected 2 calls to server, got %d", call)
+	if metaCalls != 1 {
+		t.Fatalf("expected the create screen to be fetched once, got %d", metaCalls)
 	}
 }
 
@@ -96,7 +98,7 @@ func TestAddCommentAndRemoteLink_Extra(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	cfg := &config.JiraConfig{URL: srv.URL, Username: "u", Token: "t"}
+	cfg := &config.JiraConfig{URL: srv.URL, Username: "u", Token: "t", Deployment: "cloud"}
 	c := NewClient(cfg)
 
 	if err := c.AddComment("PROJ-1", "hello"); err != nil {
@@ -106,3 +108,45 @@ func TestAddCommentAndRemoteLink_Extra(t *testing.T) {
 		t.Fatalf("AddRemoteLink failed: %v", err)
 	}
 }
+
+func TestCheckAuth(t *testing.T) {
+	c := NewClient(&config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok", Deployment: "cloud"})
+	c.httpClient = &http.Client{Transport: fakeTransport{fn: func(req *http.Request) *http.Response {
+		if req.URL.Path != "/rest/api/3/myself" {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		return makeResp(200, `{"accountId":"abc","displayName":"Me","emailAddress":"me@example.com"}`)
+	}}}
+
+	report, err := c.CheckAuth()
+	if err != nil {
+		t.Fatalf("CheckAuth failed: %v", err)
+	}
+	if report.Identity != "Me <me@example.com>" || report.Scheme != "Basic" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	c.httpClient = &http.Client{Transport: fakeTransport{fn: func(req *http.Request) *http.Response {
+		return makeResp(401, `{"errorMessages":["Client must be authenticated to access this resource."]}`)
+	}}}
+	err = c.TestAuth()
+	var authErr *httpx.AuthError
+	if !errors.As(err, &authErr) || authErr.StatusCode != 401 || !strings.Contains(authErr.Message, "must be authenticated") {
+		t.Fatalf("expected AuthError, got %v", err)
+	}
+}
+
+func TestGetIssueDetailsContextCancelled(t *testing.T) {
+	host := "jira-cancel.example"
+	httpx.RegisterTestServer(host, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("request should not reach the server: %s", r.URL.Path)
+	}))
+	t.Cleanup(func() { httpx.UnregisterTestServer(host) })
+
+	c := NewClient(&config.JiraConfig{URL: "https://" + host, Username: "u", Token: "t", Deployment: "cloud"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.GetIssueDetailsContext(ctx, "ABC-1"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
@@ -45,7 +45,7 @@ func TestSearchAll_DedupesAndFollowsTokens(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok"})
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
 	issues, err := client.SearchAll("project = ABC", true, 2, 0)
 	if err != nil {
 		t.Fatalf("SearchAll failed: %v", err)
@@ -90,7 +90,7 @@ func TestSearchAll_StartAtPaging(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok"})
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
 	issues, err := client.SearchAll("project = ABC", true, 2, 0)
 	if err != nil {
 		t.Fatalf("SearchAll failed: %v", err)
@@ -119,10 +119,10 @@ func TestUpdateIssue_ConvertsDescriptionAndSendsPUT(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok"})
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
 	err := client.UpdateIssue("ABC-1", map[string]any{
 		"summary":     "Updated",
-		"description": "first line\n\nsecond line",
+		"description": "first line\n\n- second line",
 	})
 	if err != nil {
 		t.Fatalf("UpdateIssue failed: %v", err)
@@ -137,18 +137,46 @@ func TestUpdateIssue_ConvertsDescriptionAndSendsPUT(t *testing.T) {
 		t.Fatalf("description not converted to ADF: %#v", fields["description"])
 	}
 	content, ok := desc["content"].([]any)
-	if !ok || len(content) != 3 {
+	if !ok || len(content) != 2 {
 		t.Fatalf("unexpected ADF content: %#v", desc["content"])
 	}
+	if list, _ := content[1].(map[string]any); list["type"] != "bulletList" {
+		t.Fatalf("expected the Markdown list to become a bulletList, got %#v", content[1])
+	}
 }
 
 func TestUpdateIssue_EmptyFieldsNoop(t *testing.T) {
-	client := NewClient(&config.JiraConfig{URL: "http://unused", Username: "me", Token: "tok"})
+	client := NewClient(&config.JiraConfig{URL: "http://unused", Username: "me", Token: "tok", Deployment: "cloud"})
 	if err := client.UpdateIssue("ABC-1", map[string]any{}); err != nil {
 		t.Fatalf("expected no-op update to succeed, got %v", err)
 	}
 }
 
+func TestUpdateLabels_SendsUpdateOperations(t *testing.T) {
+	var received map[string]map[string][]map[string]string
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/rest/api/3/issue/ABC-1" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	if err := client.UpdateLabels("ABC-1", []string{"backend"}, []string{"triage"}); err != nil {
+		t.Fatalf("UpdateLabels failed: %v", err)
+	}
+	ops := received["update"]["labels"]
+	if len(ops) != 2 || ops[0]["add"] != "backend" || ops[1]["remove"] != "triage" {
+		t.Fatalf("unexpected label operations: %+v", received)
+	}
+	if err := client.UpdateLabels("ABC-1", nil, nil); err != nil {
+		t.Fatalf("expected an empty label update to be a no-op, got %v", err)
+	}
+}
+
 func TestSearchAll_TerminatesOnRepeatedToken(t *testing.T) {
 	calls := 0
 	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -164,7 +192,7 @@ func TestSearchAll_TerminatesOnRepeatedToken(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok"})
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
 	issues, err := client.SearchAll("project = ABC", true, 1, 0)
 	if err == nil || !strings.Contains(err.Error(), "repeated nextPageToken") {
 		t.Fatalf("expected repeated token error, got issues=%v err=%v", issues, err)
@@ -25,7 +25,7 @@ func TestGetIssuePullRequests(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "user", Token: "token"})
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "user", Token: "token", Deployment: "cloud"})
 	prs, err := client.GetIssuePullRequests("123 456")
 	if err != nil {
 		t.Fatalf("GetIssuePullRequests failed: %v", err)
@@ -42,7 +42,7 @@ func TestGetIssuePullRequestsAPIError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(&config.JiraConfig{URL: server.URL})
+	client := NewClient(&config.JiraConfig{URL: server.URL, Deployment: "cloud"})
 	_, err := client.GetIssuePullRequests("123")
 	if err == nil || !strings.Contains(err.Error(), "502") || !strings.Contains(err.Error(), "upstream unavailable") {
 		t.Fatalf("expected API error details, got %v", err)
@@ -56,7 +56,7 @@ func TestGetIssuePullRequestsInvalidJSON(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(&config.JiraConfig{URL: server.URL})
+	client := NewClient(&config.JiraConfig{URL: server.URL, Deployment: "cloud"})
 	_, err := client.GetIssuePullRequests("123")
 	if err == nil || !strings.Contains(err.Error(), "failed to decode response") {
 		t.Fatalf("expected decode error, got %v", err)
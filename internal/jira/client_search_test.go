@@ -69,9 +69,10 @@ func TestSearch_FreeTextAndJQL(t *testing.T) {
 
 			// Prepare client
 			cfg := &config.JiraConfig{
-				URL:      srv.URL,
-				Username: "me",
-				Token:    "token",
+				URL:        srv.URL,
+				Username:   "me",
+				Token:      "token",
+				Deployment: "cloud",
 			}
 			c := NewClient(cfg)
 
@@ -94,6 +95,10 @@ func TestGetMyIssuesAndFindMentions(t *testing.T) {
 	// This server will validate the JQL for GetMyIssues and FindMentions
 	calls := make([]string, 0)
 	srv := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/api/3/myself" {
+			_, _ = w.Write([]byte(`{"accountId":"acc-me","displayName":"Me User"}`))
+			return
+		}
 		if r.URL.Path != "/rest/api/3/search/jql" {
 			t.Fatalf("unexpected path: %s", r.URL.Path)
 		}
@@ -111,7 +116,7 @@ func TestGetMyIssuesAndFindMentions(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	cfg := &config.JiraConfig{URL: srv.URL, Username: "me_user", Token: "t"}
+	cfg := &config.JiraConfig{URL: srv.URL, Username: "me_user", Token: "t", Deployment: "cloud"}
 	c := NewClient(cfg)
 
 	// GetMyIssues should call assignee = currentUser()
@@ -135,8 +140,8 @@ func TestGetMyIssuesAndFindMentions(t *testing.T) {
 	if len(calls) != 1 {
 		t.Fatalf("expected 1 call to FindMentions, got %d", len(calls))
 	}
-	// The username should be embedded in the jql
-	expected := fmt.Sprintf("text ~ \"%s\" ORDER BY updated DESC", cfg.Username)
+	// The display name should be searched as a phrase
+	expected := fmt.Sprintf("text ~ \"\\\"%s\\\"\" ORDER BY updated DESC", "Me User")
 	if calls[0] != expected {
 		t.Fatalf("unexpected FindMentions jql: got=%q want=%q", calls[0], expected)
 	}
@@ -183,7 +188,7 @@ func TestSearch_MultiPagePaging(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	cfg := &config.JiraConfig{URL: srv.URL, Username: "u", Token: "t"}
+	cfg := &config.JiraConfig{URL: srv.URL, Username: "u", Token: "t", Deployment: "cloud"}
 	c := NewClient(cfg)
 
 	issues, err := c.Search("project = ABC", true, 3, 0)
@@ -238,7 +243,7 @@ func TestSearch_TokenPaging(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	cfg := &config.JiraConfig{URL: srv.URL, Username: "u", Token: "t"}
+	cfg := &config.JiraConfig{URL: srv.URL, Username: "u", Token: "t", Deployment: "cloud"}
 	c := NewClient(cfg)
 
 	issues, err := c.Search("project = TOK", true, 10, 0)
@@ -272,7 +277,7 @@ func TestSearch_EncodingsAndQueryEscape(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	cfg := &config.JiraConfig{URL: srv.URL, Username: "u", Token: "t"}
+	cfg := &config.JiraConfig{URL: srv.URL, Username: "u", Token: "t", Deployment: "cloud"}
 	c := NewClient(cfg)
 
 	query := `summary ~ "needs & review"` // contains spaces and ampersand
@@ -306,7 +311,7 @@ func TestSearch_FallbackPaging(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(&config.JiraConfig{URL: server.URL})
+	client := NewClient(&config.JiraConfig{URL: server.URL, Deployment: "cloud"})
 	issues, err := client.Search("project = ABC", true, 2, 2)
 	if err != nil {
 		t.Fatalf("Search fallback failed: %v", err)
@@ -328,7 +333,7 @@ func TestSearch_TokenPagingRejectsNonzeroStart(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(&config.JiraConfig{URL: server.URL})
+	client := NewClient(&config.JiraConfig{URL: server.URL, Deployment: "cloud"})
 	_, err := client.Search("project = ABC", true, 2, 1)
 	if err == nil || !strings.Contains(err.Error(), "cannot jump to startAt=1") {
 		t.Fatalf("expected token/startAt error, got %v", err)
@@ -352,7 +357,7 @@ func TestSearchAndGetIssueDetailsDecodeAndAPIErrors(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := NewClient(&config.JiraConfig{URL: server.URL})
+			client := NewClient(&config.JiraConfig{URL: server.URL, Deployment: "cloud"})
 			_, err := client.Search("ABC", true, 0, 0)
 			if err == nil {
 				t.Fatal("expected Search error")
@@ -365,7 +370,7 @@ func TestSearchAndGetIssueDetailsDecodeAndAPIErrors(t *testing.T) {
 		_, _ = w.Write([]byte("not json"))
 	}))
 	defer server.Close()
-	client := NewClient(&config.JiraConfig{URL: server.URL})
+	client := NewClient(&config.JiraConfig{URL: server.URL, Deployment: "cloud"})
 	if _, err := client.GetIssueDetails("ABC-1"); err == nil {
 		t.Fatal("expected GetIssueDetails decode error")
 	}
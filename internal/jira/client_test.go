@@ -2,6 +2,7 @@ package jira
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -29,7 +30,7 @@ func makeResp(status int, body string) *http.Response {
 }
 
 func TestNewClient(t *testing.T) {
-	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok"}
+	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok", Deployment: "cloud"}
 	c := NewClient(cfg)
 	if c.config != cfg {
 		t.Fatalf("expected config to be set")
@@ -40,7 +41,7 @@ func TestNewClient(t *testing.T) {
 }
 
 func TestMakeRequest_SetsAuthAndHeaders(t *testing.T) {
-	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok"}
+	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok", Deployment: "cloud"}
 	c := NewClient(cfg)
 	c.httpClient = &http.Client{Transport: fakeTransport{fn: func(req *http.Request) *http.Response {
 		// verify basic auth
@@ -54,7 +55,7 @@ func TestMakeRequest_SetsAuthAndHeaders(t *testing.T) {
 		return makeResp(200, "{}")
 	}}}
 
-	resp, err := c.makeRequest("GET", "status", nil)
+	resp, err := c.makeRequest(context.Background(), "GET", "status", nil)
 	if err != nil {
 		t.Fatalf("makeRequest failed: %v", err)
 	}
@@ -66,7 +67,7 @@ func TestMakeRequest_SetsAuthAndHeaders(t *testing.T) {
 }
 
 func TestGetMyIssues_Success(t *testing.T) {
-	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok"}
+	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok", Deployment: "cloud"}
 	c := NewClient(cfg)
 	payload := SearchResponse{Issues: []Issue{{Key: "ABC-1"}}}
 	b, _ := json.Marshal(payload)
@@ -84,7 +85,7 @@ func TestGetMyIssues_Success(t *testing.T) {
 }
 
 func TestGetIssueDetails_Success(t *testing.T) {
-	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok"}
+	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok", Deployment: "cloud"}
 	c := NewClient(cfg)
 	var det IssueDetails
 	det.Key = "PRJ-1"
@@ -103,26 +104,33 @@ func TestGetIssueDetails_Success(t *testing.T) {
 	}
 }
 
-func TestCreateIssue_SuccessAndRetry(t *testing.T) {
-	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok"}
+func TestCreateIssue_ResolvesPlanningFields(t *testing.T) {
+	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok", Deployment: "cloud"}
 	c := NewClient(cfg)
 
-	// Counter to simulate first-call bad request then success on retry when custom fields removed.
-	call := 0
+	var created map[string]map[string]interface{}
 	c.httpClient = &http.Client{Transport: fakeTransport{fn: func(req *http.Request) *http.Response {
-		call++
-		body, _ := io.ReadAll(req.Body)
-		bs := string(body)
-		// First call: contains custom fields -> return 400 with errors
-		if call == 1 && strings.Contains(bs, "customfield_10014") {
-			errPayload := map[string]map[string]string{"errors": map[string]string{"customfield_10014": "unsupported"}}
-			b, _ := json.Marshal(errPayload)
-			return makeResp(400, string(b))
+		switch req.URL.Path {
+		case "/rest/api/3/issue/createmeta/PRJ/issuetypes":
+			return makeResp(200, `{"issueTypes":[{"id":"1","name":"Story"},{"id":"2","name":"Task"}]}`)
+		case "/rest/api/3/issue/createmeta/PRJ/issuetypes/2":
+			return makeResp(200, `{"total":4,"fields":[
+				{"fieldId":"customfield_20001","name":"Story point estimate","schema":{"type":"number"}},
+				{"fieldId":"customfield_20002","name":"Sprint","schema":{"type":"array","items":"json"}},
+				{"fieldId":"customfield_20003","name":"Team","schema":{"type":"string","custom":"com.atlassian.jira.plugin.system.customfieldtypes:atlassian-team"}},
+				{"fieldId":"parent","name":"Parent","schema":{"type":"issuelink"}}
+			]}`)
+		case "/rest/api/3/user/search":
+			if req.URL.Query().Get("query") != "user" {
+				t.Fatalf("unexpected user query: %s", req.URL.RawQuery)
+			}
+			return makeResp(200, `[{"accountId":"acc-user","displayName":"User"}]`)
+		case "/rest/api/3/issue":
+			_ = json.NewDecoder(req.Body).Decode(&created)
+			b, _ := json.Marshal(Issue{Key: "CRE-1"})
+			return &http.Response{StatusCode: 201, Body: io.NopCloser(bytes.NewBuffer(b)), Header: map[string][]string{"Content-Type": []string{"application/json"}}}
 		}
-		// Otherwise, return created
-		created := Issue{Key: "CRE-1"}
-		b, _ := json.Marshal(created)
-		return &http.Response{StatusCode: 201, Body: io.NopCloser(bytes.NewBuffer(b)), Header: map[string][]string{"Content-Type": []string{"application/json"}}}
+		return makeResp(404, `{"errorMessages":["not found"]}`)
 	}}}
 
 	opts := CreateIssueOptions{
@@ -135,8 +143,9 @@ func TestCreateIssue_SuccessAndRetry(t *testing.T) {
 		Labels:      []string{"a", "b"},
 		StoryPoints: 3.5,
 		Epic:        "EPIC-1",
-		Sprint:      "SPR-1",
-		Team:        "42",
+		Sprint:      "42",
+		Team:        "team-uuid",
+		Fields:      map[string]interface{}{"customfield_30000": "extra"},
 	}
 
 	issue, err := c.CreateIssue(opts)
@@ -146,13 +155,26 @@ func TestCreateIssue_SuccessAndRetry(t *testing.T) {
 	if issue.Key != "CRE-1" {
 		t.Fatalf("unexpected issue key: %s", issue.Key)
 	}
-	if call < 1 {
-		t.Fatalf("expected at least one request")
+	fields := created["fields"]
+	if fields["customfield_20001"] != 3.5 || fields["customfield_20002"] != float64(42) || fields["customfield_20003"] != "team-uuid" ||
+		fields["customfield_30000"] != "extra" {
+		t.Fatalf("unexpected planning fields: %+v", fields)
+	}
+	if parent, _ := fields["parent"].(map[string]interface{}); parent["key"] != "EPIC-1" {
+		t.Fatalf("expected the epic to be set as parent, got %+v", fields["parent"])
+	}
+	if assignee, _ := fields["assignee"].(map[string]interface{}); assignee["accountId"] != "acc-user" {
+		t.Fatalf("expected the assignee to be set by accountId, got %+v", fields["assignee"])
+	}
+	for id := range fields {
+		if strings.HasPrefix(id, "customfield_1") {
+			t.Fatalf("unexpected hard-coded field %s", id)
+		}
 	}
 }
 
 func TestAddCommentAndRemoteLink(t *testing.T) {
-	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok"}
+	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok", Deployment: "cloud"}
 	c := NewClient(cfg)
 	c.httpClient = &http.Client{Transport: fakeTransport{fn: func(req *http.Request) *http.Response {
 		if strings.Contains(req.URL.Path, "comment") {
@@ -173,12 +195,16 @@ func TestAddCommentAndRemoteLink(t *testing.T) {
 }
 
 func TestFindMentions_Success(t *testing.T) {
-	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok"}
+	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok", Deployment: "cloud"}
 	c := NewClient(cfg)
-	payload := SearchResponse{Issues: []Issue{{Key: "ABC-2"}}}
-	b, _ := json.Marshal(payload)
 	c.httpClient = &http.Client{Transport: fakeTransport{fn: func(req *http.Request) *http.Response {
-		return makeResp(200, string(b))
+		if req.URL.Path == "/rest/api/3/myself" {
+			return makeResp(200, `{"accountId":"acc-1","displayName":"Me"}`)
+		}
+		return makeResp(200, `{"issues":[
+			{"key":"ABC-1","fields":{"description":{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"Me, myself"}]}]}}},
+			{"key":"ABC-2","fields":{"description":{"type":"doc","content":[{"type":"paragraph","content":[{"type":"mention","attrs":{"id":"acc-1","text":"@Me"}}]}]}}},
+			{"key":"ABC-3","fields":{"comment":{"comments":[{"body":{"type":"doc","content":[{"type":"paragraph","content":[{"type":"mention","attrs":{"id":"acc-2","text":"@Me Too"}}]}]}}]}}}]}`)
 	}}}
 
 	issues, err := c.FindMentions()
@@ -190,8 +216,33 @@ func TestFindMentions_Success(t *testing.T) {
 	}
 }
 
+func TestFindMentions_PagesThroughCandidates(t *testing.T) {
+	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok", Deployment: "cloud"}
+	c := NewClient(cfg)
+	pages := 0
+	c.httpClient = &http.Client{Transport: fakeTransport{fn: func(req *http.Request) *http.Response {
+		if req.URL.Path == "/rest/api/3/myself" {
+			return makeResp(200, `{"accountId":"acc-1","displayName":"Me"}`)
+		}
+		pages++
+		if req.URL.Query().Get("pageToken") == "" {
+			return makeResp(200, `{"issues":[{"key":"ABC-1","fields":{"summary":"No mention"}}],"nextPageToken":"p2"}`)
+		}
+		return makeResp(200, `{"issues":[
+			{"key":"ABC-2","fields":{"comment":{"comments":[{"body":{"type":"doc","content":[{"type":"paragraph","content":[{"type":"mention","attrs":{"id":"acc-1","text":"@Me"}}]}]}}]}}}],"isLast":true}`)
+	}}}
+
+	issues, err := c.FindMentions()
+	if err != nil {
+		t.Fatalf("FindMentions failed: %v", err)
+	}
+	if pages != 2 || len(issues) != 1 || issues[0].Key != "ABC-2" || issues[0].Fields.Comment != nil {
+		t.Fatalf("expected the mention on the second page, got %d pages and %+v", pages, issues)
+	}
+}
+
 func TestListProjects_Success(t *testing.T) {
-	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok"}
+	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok", Deployment: "cloud"}
 	c := NewClient(cfg)
 	projects := []Project{
 		{ID: "10000", Key: "PROJ", Name: "My Project"},
@@ -223,7 +274,7 @@ func TestListProjects_Success(t *testing.T) {
 }
 
 func TestListProjects_APIError(t *testing.T) {
-	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok"}
+	cfg := &config.JiraConfig{URL: "http://jira.example", Username: "me", Token: "tok", Deployment: "cloud"}
 	c := NewClient(cfg)
 	c.httpClient = &http.Client{Transport: fakeTransport{fn: func(req *http.Request) *http.Response {
 		return makeResp(403, `{"errorMessages":["Forbidden"]}`)
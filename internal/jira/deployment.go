@@ -0,0 +1,249 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"devflow/internal/adf"
+	"devflow/internal/httpx"
+)
+
+// Deployment is the kind of Jira instance a client talks to. Jira Cloud
+// speaks REST v3 with ADF bodies, token-paged search and Basic auth with an
+// email and API token; Server and Data Center speak REST v2 with wiki markup
+// bodies, startAt paging and Bearer personal access tokens.
+type Deployment string
+
+const (
+	DeploymentCloud  Deployment = "cloud"
+	DeploymentServer Deployment = "server"
+	// DeploymentAuto asks the instance through /serverInfo.
+	DeploymentAuto Deployment = "auto"
+)
+
+// ParseDeployment parses a jira.deployment setting. "datacenter" and "dc"
+// are accepted for server; an empty value is auto, the default.
+func ParseDeployment(value string) (Deployment, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "cloud":
+		return DeploymentCloud, nil
+	case "server", "datacenter", "data-center", "dc":
+		return DeploymentServer, nil
+	case "auto", "":
+		return DeploymentAuto, nil
+	}
+	return "", fmt.Errorf("invalid Jira deployment %q: expected cloud, server or auto", value)
+}
+
+// ServerInfo describes a Jira instance as reported by /serverInfo.
+type ServerInfo struct {
+	BaseURL        string `json:"baseUrl"`
+	Version        string `json:"version"`
+	VersionNumbers []int  `json:"versionNumbers"`
+	DeploymentType string `json:"deploymentType"` // Cloud, Server or DataCenter
+	ServerTitle    string `json:"serverTitle"`
+}
+
+// Deployment maps the reported deployment type to a Deployment.
+func (s ServerInfo) Deployment() Deployment {
+	if strings.EqualFold(s.DeploymentType, "Cloud") {
+		return DeploymentCloud
+	}
+	return DeploymentServer
+}
+
+// ServerInfoContext fetches /serverInfo. Both flavours serve it under REST
+// v2, so it is requested before the deployment is known, authenticating
+// with whatever the credentials allow.
+func (c *Client) ServerInfoContext(ctx context.Context) (*ServerInfo, error) {
+	endpoint := fmt.Sprintf("%s/rest/api/2/serverInfo", strings.TrimSuffix(c.config.URL, "/"))
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.config.Token != "" {
+		httpx.ApplyAuth(req, c.config.Username, c.config.Token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+	var info ServerInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &info, nil
+}
+
+// ServerInfo calls ServerInfoContext with a background context.
+func (c *Client) ServerInfo() (*ServerInfo, error) {
+	return c.ServerInfoContext(context.Background())
+}
+
+// DetectDeploymentContext asks the instance which deployment it is.
+func (c *Client) DetectDeploymentContext(ctx context.Context) (Deployment, error) {
+	info, err := c.ServerInfoContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to detect the Jira deployment: %w", err)
+	}
+	return info.Deployment(), nil
+}
+
+// DetectDeployment calls DetectDeploymentContext with a background context.
+func (c *Client) DetectDeployment() (Deployment, error) {
+	return c.DetectDeploymentContext(context.Background())
+}
+
+// DeploymentContext returns the deployment the client talks to, resolving it
+// on first use. Unless jira.deployment is set to cloud or server,
+// Atlassian-hosted URLs are Cloud and any other instance is asked through
+// /serverInfo; when that fails the client assumes Cloud.
+func (c *Client) DeploymentContext(ctx context.Context) Deployment {
+	c.deploymentMu.Lock()
+	defer c.deploymentMu.Unlock()
+	if c.deployment == "" {
+		c.deployment = c.resolveDeployment(ctx)
+	}
+	return c.deployment
+}
+
+// Deployment calls DeploymentContext with a background context.
+func (c *Client) Deployment() Deployment {
+	return c.DeploymentContext(context.Background())
+}
+
+func (c *Client) resolveDeployment(ctx context.Context) Deployment {
+	configured, err := ParseDeployment(c.config.Deployment)
+	if err != nil {
+		log.Printf("warning: %v; assuming cloud", err)
+		return DeploymentCloud
+	}
+	if configured != DeploymentAuto {
+		return configured
+	}
+	if IsCloudURL(c.config.URL) {
+		return DeploymentCloud
+	}
+	detected, err := c.DetectDeploymentContext(ctx)
+	if err != nil {
+		log.Printf("warning: %v; assuming cloud", err)
+		return DeploymentCloud
+	}
+	return detected
+}
+
+// IsCloudURL reports whether rawURL is hosted by Atlassian, which only
+// serves Jira Cloud.
+func IsCloudURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	for _, suffix := range []string{".atlassian.net", ".jira.com", ".jira-dev.com"} {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// apiRoot returns the REST root of the deployment, without slashes.
+func (c *Client) apiRoot(ctx context.Context) string {
+	if c.DeploymentContext(ctx) == DeploymentServer {
+		return "rest/api/2"
+	}
+	return "rest/api/3"
+}
+
+// applyAuth authenticates req: Cloud always uses Basic auth with the email
+// and API token, Server falls back to a Bearer personal access token when no
+// username is configured.
+func (c *Client) applyAuth(req *http.Request) {
+	if c.DeploymentContext(req.Context()) == DeploymentServer && c.config.Token != "" {
+		httpx.ApplyAuth(req, c.config.Username, c.config.Token)
+		return
+	}
+	httpx.ApplyBasicAuth(req, c.config.Username, c.config.Token)
+}
+
+// authScheme reports the scheme applyAuth selects.
+func (c *Client) authScheme(ctx context.Context) string {
+	if c.DeploymentContext(ctx) == DeploymentServer {
+		return httpx.AuthScheme(c.config.Username, c.config.Token)
+	}
+	return httpx.BasicAuthScheme(c.config.Username, c.config.Token)
+}
+
+// searchFields are the issue fields search results include.
+const searchFields = "key,summary,description,status,assignee,priority,sprint"
+
+// searchEndpoint returns the endpoint searching for jql: Cloud's search/jql,
+// which pages with nextPageToken, or Server's search, which pages with
+// startAt.
+func (c *Client) searchEndpoint(ctx context.Context, jql, fields string) string {
+	path := "search/jql"
+	if c.DeploymentContext(ctx) == DeploymentServer {
+		path = "search"
+	}
+	return fmt.Sprintf("%s?jql=%s&fields=%s", path, url.QueryEscape(jql), fields)
+}
+
+// forDeployment adapts a request body built for Jira Cloud to Server and
+// Data Center: ADF documents become wiki markup and users referenced by
+// accountId are referenced by name. Cloud bodies are returned unchanged.
+func (c *Client) forDeployment(ctx context.Context, body interface{}) interface{} {
+	if body == nil || c.DeploymentContext(ctx) != DeploymentServer {
+		return body
+	}
+	return toServerValue(body)
+}
+
+func toServerValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *adf.Node:
+		return adf.ToWiki(v)
+	case map[string]string:
+		if id, ok := v["accountId"]; ok && len(v) == 1 {
+			return map[string]string{"name": id}
+		}
+		return v
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = toServerValue(item)
+		}
+		return out
+	case []map[string]interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = toServerValue(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = toServerValue(item)
+		}
+		return out
+	default:
+		return value
+	}
+}
@@ -0,0 +1,153 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"devflow/internal/config"
+	"devflow/internal/httpx"
+)
+
+func TestServerDeploymentUsesRESTv2(t *testing.T) {
+	var starts []string
+	var comment, description any
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer pat" {
+			t.Fatalf("expected a Bearer personal access token, got %q", got)
+		}
+		switch {
+		case r.URL.Path == "/rest/api/2/search":
+			starts = append(starts, r.URL.Query().Get("startAt"))
+			if r.URL.Query().Get("startAt") == "2" {
+				_, _ = w.Write([]byte(`{"startAt":2,"maxResults":2,"total":3,"issues":[{"key":"OPS-3"}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"startAt":0,"maxResults":2,"total":3,"issues":[{"key":"OPS-1","fields":{"description":"h1. Plain *wiki*"}},{"key":"OPS-2"}]}`))
+		case r.URL.Path == "/rest/api/2/issue/OPS-1/comment":
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			comment = body["body"]
+			w.WriteHeader(http.StatusCreated)
+		case r.URL.Path == "/rest/api/2/issue" && r.Method == http.MethodPost:
+			var body map[string]map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			description = body["fields"]["description"]
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"key":"OPS-4"}`))
+		case r.URL.Path == "/rest/api/2/issue/OPS-1":
+			if r.URL.Query().Get("expand") != "changelog" {
+				t.Fatalf("expected the changelog to be expanded, got %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"changelog":{"histories":[{"id":"7","author":{"name":"alice","displayName":"Alice"},"items":[{"field":"status","toString":"Done"}]}]}}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Token: "pat", Deployment: "server"})
+	issues, err := client.SearchAll("project = OPS", true, 2, 0)
+	if err != nil {
+		t.Fatalf("SearchAll failed: %v", err)
+	}
+	if len(issues) != 3 || strings.Join(starts, ",") != ",2" {
+		t.Fatalf("expected startAt paging over 3 issues, got %d issues, starts %v", len(issues), starts)
+	}
+	if issues[0].Fields.Description != "h1. Plain *wiki*" {
+		t.Fatalf("expected the wiki description as is, got %#v", issues[0].Fields.Description)
+	}
+
+	if err := client.AddComment("OPS-1", "**Deployed** to `staging`\n\n- step one"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if comment != "*Deployed* to {{staging}}\n\n* step one" {
+		t.Fatalf("expected a wiki markup comment, got %#v", comment)
+	}
+	if _, err := client.CreateIssue(CreateIssueOptions{ProjectKey: "OPS", Summary: "Rotate keys", Description: "## Steps"}); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if description != "h2. Steps" {
+		t.Fatalf("expected a wiki markup description, got %#v", description)
+	}
+
+	histories, err := client.GetChangelog("OPS-1")
+	if err != nil || len(histories) != 1 || histories[0].Author.Name != "alice" {
+		t.Fatalf("unexpected changelog %+v, %v", histories, err)
+	}
+}
+
+func TestCloudDeploymentUsesRESTv3(t *testing.T) {
+	var comment any
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, token, ok := r.BasicAuth(); !ok || username != "me@example.com" || token != "tok" {
+			t.Fatalf("expected Basic auth with the email and API token, got %q", r.Header.Get("Authorization"))
+		}
+		switch r.URL.Path {
+		case "/rest/api/3/search/jql":
+			if r.URL.Query().Get("nextPageToken") == "" && r.URL.Query().Get("pageToken") == "" {
+				_, _ = w.Write([]byte(`{"issues":[{"key":"ENG-1"}],"nextPageToken":"p2"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"issues":[{"key":"ENG-2"}],"isLast":true}`))
+		case "/rest/api/3/issue/ENG-1/comment":
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			comment = body["body"]
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me@example.com", Token: "tok", Deployment: "cloud"})
+	issues, err := client.SearchAll("project = ENG", true, 1, 0)
+	if err != nil || len(issues) != 2 {
+		t.Fatalf("expected token paging over 2 issues, got %d, %v", len(issues), err)
+	}
+	if err := client.AddComment("ENG-1", "**Deployed**"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if doc, ok := comment.(map[string]any); !ok || doc["type"] != "doc" {
+		t.Fatalf("expected an ADF comment, got %#v", comment)
+	}
+}
+
+func TestAutoDeploymentAsksServerInfo(t *testing.T) {
+	probes := 0
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/serverInfo":
+			probes++
+			_, _ = w.Write([]byte(`{"baseUrl":"https://jira.corp","version":"9.12.2","deploymentType":"DataCenter"}`))
+		case "/rest/api/2/myself":
+			_, _ = w.Write([]byte(`{"name":"alice","displayName":"Alice"}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	// An unset jira.deployment is detected like auto.
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "alice", Token: "secret"})
+	for range 2 {
+		if user, err := client.GetMyself(); err != nil || user.Name != "alice" {
+			t.Fatalf("unexpected user %+v, %v", user, err)
+		}
+	}
+	if client.Deployment() != DeploymentServer || probes != 1 {
+		t.Fatalf("expected one probe detecting server, got %q after %d probes", client.Deployment(), probes)
+	}
+
+	cloud := NewClient(&config.JiraConfig{URL: "https://acme.atlassian.net", Deployment: "auto"})
+	if cloud.Deployment() != DeploymentCloud {
+		t.Fatalf("expected Atlassian-hosted URLs to be cloud without probing, got %q", cloud.Deployment())
+	}
+	for value, want := range map[string]Deployment{"": DeploymentAuto, "Cloud": DeploymentCloud, "DataCenter": DeploymentServer, "Auto": DeploymentAuto} {
+		if got, err := ParseDeployment(value); err != nil || got != want {
+			t.Fatalf("ParseDeployment(%q) = %q, %v", value, got, err)
+		}
+	}
+	if _, err := ParseDeployment("on-prem"); err == nil {
+		t.Fatal("expected an unknown deployment to be rejected")
+	}
+}
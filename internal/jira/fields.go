@@ -0,0 +1,396 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"devflow/internal/httpx"
+)
+
+// FieldSchema describes the type of a field's value. Type is the JSON shape
+// ("string", "number", "array", "option", "user", "date", ...), Items the
+// element type of arrays, and Custom the plugin type of custom fields.
+type FieldSchema struct {
+	Type   string `json:"type"`
+	Items  string `json:"items,omitempty"`
+	System string `json:"system,omitempty"`
+	Custom string `json:"custom,omitempty"`
+}
+
+// Field is a system or custom field defined on the Jira instance.
+type Field struct {
+	ID     string      `json:"id"`
+	Key    string      `json:"key"`
+	Name   string      `json:"name"`
+	Custom bool        `json:"custom"`
+	Schema FieldSchema `json:"schema"`
+}
+
+// ListFieldsContext lists every field defined on the instance. The result is
+// kept for the lifetime of the client.
+func (c *Client) ListFieldsContext(ctx context.Context) ([]Field, error) {
+	c.fieldsMu.Lock()
+	defer c.fieldsMu.Unlock()
+	if c.fields != nil {
+		return c.fields, nil
+	}
+
+	var fields []Field
+	if err := c.getJSON(ctx, "field", &fields); err != nil {
+		return nil, err
+	}
+	c.fields = fields
+	return fields, nil
+}
+
+// ListFields calls ListFieldsContext with a background context.
+func (c *Client) ListFields() ([]Field, error) {
+	return c.ListFieldsContext(context.Background())
+}
+
+// FindFieldContext looks a field up by ID or, ignoring case, by display
+// name. Several fields sharing the name make the lookup fail with their IDs.
+func (c *Client) FindFieldContext(ctx context.Context, name string) (Field, error) {
+	fields, err := c.ListFieldsContext(ctx)
+	if err != nil {
+		return Field{}, err
+	}
+	var matches []Field
+	for _, field := range fields {
+		if strings.EqualFold(field.ID, name) {
+			return field, nil
+		}
+		if strings.EqualFold(field.Name, name) {
+			matches = append(matches, field)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Field{}, fmt.Errorf("no field named %q", name)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, field := range matches {
+			ids = append(ids, field.ID)
+		}
+		return Field{}, fmt.Errorf("several fields are named %q (%s); use the field ID", name, strings.Join(ids, ", "))
+	}
+}
+
+// FindField calls FindFieldContext with a background context.
+func (c *Client) FindField(name string) (Field, error) {
+	return c.FindFieldContext(context.Background(), name)
+}
+
+// CreateMetaContext returns the fields of the create screen for an issue
+// type of a project, keyed by field ID. The issue type is matched by name,
+// ignoring case, or by ID. Results are kept per project and issue type for
+// the lifetime of the client.
+func (c *Client) CreateMetaContext(ctx context.Context, projectKey, issueType string) (map[string]FieldMeta, error) {
+	cacheKey := strings.ToUpper(projectKey) + "/" + strings.ToLower(issueType)
+	c.fieldsMu.Lock()
+	defer c.fieldsMu.Unlock()
+	if meta, ok := c.createMeta[cacheKey]; ok {
+		return meta, nil
+	}
+
+	var types struct {
+		IssueTypes []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"issueTypes"`
+		// Data Center names the list "values".
+		Values []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"values"`
+	}
+	project := url.PathEscape(projectKey)
+	if err := c.getJSON(ctx, fmt.Sprintf("issue/createmeta/%s/issuetypes?maxResults=200", project), &types); err != nil {
+		return nil, err
+	}
+	typeID := ""
+	var names []string
+	for _, t := range append(types.IssueTypes, types.Values...) {
+		if strings.EqualFold(t.Name, issueType) || t.ID == issueType {
+			typeID = t.ID
+			break
+		}
+		names = append(names, t.Name)
+	}
+	if typeID == "" {
+		return nil, fmt.Errorf("project %s has no issue type %q (available: %s)", projectKey, issueType, strings.Join(names, ", "))
+	}
+
+	meta := make(map[string]FieldMeta)
+	for startAt := 0; ; {
+		var page struct {
+			Total  int         `json:"total"`
+			Fields []FieldMeta `json:"fields"`
+			Values []FieldMeta `json:"values"`
+		}
+		endpoint := fmt.Sprintf("issue/createmeta/%s/issuetypes/%s?startAt=%d&maxResults=100", project, url.PathEscape(typeID), startAt)
+		if err := c.getJSON(ctx, endpoint, &page); err != nil {
+			return nil, err
+		}
+		fields := append(page.Fields, page.Values...)
+		for _, field := range fields {
+			id := field.FieldID
+			if id == "" {
+				id = field.Key
+			}
+			meta[id] = field
+		}
+		startAt += len(fields)
+		if len(fields) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+
+	if c.createMeta == nil {
+		c.createMeta = make(map[string]map[string]FieldMeta)
+	}
+	c.createMeta[cacheKey] = meta
+	return meta, nil
+}
+
+// CreateMeta calls CreateMetaContext with a background context.
+func (c *Client) CreateMeta(projectKey, issueType string) (map[string]FieldMeta, error) {
+	return c.CreateMetaContext(context.Background(), projectKey, issueType)
+}
+
+// EditMetaContext returns the fields that can be edited on an issue, keyed
+// by field ID.
+func (c *Client) EditMetaContext(ctx context.Context, issueKey string) (map[string]FieldMeta, error) {
+	var result struct {
+		Fields map[string]FieldMeta `json:"fields"`
+	}
+	if err := c.getJSON(ctx, fmt.Sprintf("issue/%s/editmeta", url.PathEscape(issueKey)), &result); err != nil {
+		return nil, err
+	}
+	return result.Fields, nil
+}
+
+// EditMeta calls EditMetaContext with a background context.
+func (c *Client) EditMeta(issueKey string) (map[string]FieldMeta, error) {
+	return c.EditMetaContext(context.Background(), issueKey)
+}
+
+// Display names instances commonly give the planning fields.
+var (
+	epicLinkFieldNames    = []string{"Epic Link"}
+	storyPointsFieldNames = []string{"Story Points", "Story point estimate"}
+	sprintFieldNames      = []string{"Sprint"}
+	teamFieldNames        = []string{"Team Assigned", "Assigned Team", "Team"}
+)
+
+// teamFieldContext returns the ID of the instance's team field, or "" when
+// it has none or the fields cannot be listed.
+func (c *Client) teamFieldContext(ctx context.Context) string {
+	fields, err := c.ListFieldsContext(ctx)
+	if err != nil {
+		return ""
+	}
+	for _, name := range teamFieldNames {
+		for _, field := range fields {
+			if strings.EqualFold(field.Name, name) {
+				return field.ID
+			}
+		}
+	}
+	return ""
+}
+
+// decodeTeam reads a team field value: an Atlassian team ID, a team object
+// or a select option.
+func decodeTeam(raw json.RawMessage) (id, name string) {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text, ""
+	}
+	var team struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Title string `json:"title"`
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(raw, &team); err != nil {
+		return "", ""
+	}
+	switch {
+	case team.Name != "":
+		name = team.Name
+	case team.Title != "":
+		name = team.Title
+	default:
+		name = team.Value
+	}
+	return team.ID, name
+}
+
+// StoryPointsContext returns the story point estimates of the issues with
+// keys, read from the "Story Points" or "Story point estimate" fields the
+// instance defines. Issues without an estimate are left out.
+func (c *Client) StoryPointsContext(ctx context.Context, keys []string) (map[string]float64, error) {
+	fields, err := c.ListFieldsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, field := range fields {
+		for _, name := range storyPointsFieldNames {
+			if strings.EqualFold(field.Name, name) {
+				ids = append(ids, field.ID)
+			}
+		}
+	}
+	points := make(map[string]float64)
+	if len(ids) == 0 {
+		return points, nil
+	}
+
+	const batchSize = 100
+	for start := 0; start < len(keys); start += batchSize {
+		batch := keys[start:min(start+batchSize, len(keys))]
+		var page struct {
+			Issues []struct {
+				Key    string                     `json:"key"`
+				Fields map[string]json.RawMessage `json:"fields"`
+			} `json:"issues"`
+		}
+		jql := fmt.Sprintf("key in (%s)", strings.Join(batch, ","))
+		endpoint := fmt.Sprintf("%s&maxResults=%d", c.searchEndpoint(ctx, jql, strings.Join(ids, ",")), len(batch))
+		if err := c.getJSON(ctx, endpoint, &page); err != nil {
+			return nil, err
+		}
+		for _, issue := range page.Issues {
+			for _, id := range ids {
+				var value *float64
+				if err := json.Unmarshal(issue.Fields[id], &value); err == nil && value != nil {
+					points[issue.Key] = *value
+					break
+				}
+			}
+		}
+	}
+	return points, nil
+}
+
+// StoryPoints calls StoryPointsContext with a background context.
+func (c *Client) StoryPoints(keys []string) (map[string]float64, error) {
+	return c.StoryPointsContext(context.Background(), keys)
+}
+
+// PlanningFields holds the agile planning values that live in custom fields
+// whose IDs differ between Jira instances.
+type PlanningFields struct {
+	Epic        string
+	StoryPoints float64
+	Sprint      string // Sprint ID
+	Team        string
+}
+
+// IsZero reports whether no planning value is set.
+func (p PlanningFields) IsZero() bool {
+	return p == PlanningFields{}
+}
+
+// Resolve maps the set values onto the fields of a create or edit screen,
+// finding the fields by display name. Values whose field is not on the
+// screen are returned by name in omitted. Without an "Epic Link" field the
+// epic is set as the issue's parent.
+func (p PlanningFields) Resolve(screen map[string]FieldMeta) (values map[string]interface{}, omitted []string) {
+	values = make(map[string]interface{})
+	set := func(label string, names []string, encode func(FieldMeta) interface{}) {
+		id, meta, ok := screenField(screen, names)
+		if !ok {
+			omitted = append(omitted, label)
+			return
+		}
+		values[id] = encode(meta)
+	}
+
+	if p.Epic != "" {
+		if _, _, ok := screenField(screen, epicLinkFieldNames); ok {
+			set("Epic Link", epicLinkFieldNames, func(FieldMeta) interface{} { return p.Epic })
+		} else if _, ok := screen["parent"]; ok {
+			values["parent"] = map[string]string{"key": p.Epic}
+		} else {
+			omitted = append(omitted, "Epic Link")
+		}
+	}
+	if p.StoryPoints > 0 {
+		set("Story Points", storyPointsFieldNames, func(FieldMeta) interface{} { return p.StoryPoints })
+	}
+	if p.Sprint != "" {
+		set("Sprint", sprintFieldNames, func(FieldMeta) interface{} {
+			// The sprint field takes a sprint ID.
+			if id, err := strconv.Atoi(p.Sprint); err == nil {
+				return id
+			}
+			return p.Sprint
+		})
+	}
+	if p.Team != "" {
+		set("Team", teamFieldNames, func(meta FieldMeta) interface{} {
+			if strings.HasSuffix(meta.Schema.Custom, ":atlassian-team") {
+				return p.Team
+			}
+			if _, err := strconv.Atoi(p.Team); err == nil {
+				return map[string]string{"id": p.Team}
+			}
+			return map[string]string{"name": p.Team}
+		})
+	}
+	return values, omitted
+}
+
+// screenField finds the first of names on a screen, ignoring case. Fields
+// sharing a name are tried in ID order so the choice is stable.
+func screenField(screen map[string]FieldMeta, names []string) (string, FieldMeta, bool) {
+	ids := make([]string, 0, len(screen))
+	for id := range screen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, name := range names {
+		for _, id := range ids {
+			if strings.EqualFold(screen[id].Name, name) {
+				return id, screen[id], true
+			}
+		}
+	}
+	return "", FieldMeta{}, false
+}
+
+// getJSON performs a GET against the REST API and decodes the response
+// into out.
+func (c *Client) getJSON(ctx context.Context, endpoint string, out any) error {
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"devflow/internal/config"
+	"devflow/internal/httpx"
+)
+
+func TestFindFieldByNameOrID(t *testing.T) {
+	calls := 0
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/field" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		calls++
+		_, _ = w.Write([]byte(`[
+			{"id":"summary","key":"summary","name":"Summary","custom":false,"schema":{"type":"string","system":"summary"}},
+			{"id":"customfield_10016","key":"customfield_10016","name":"Story Points","custom":true,"schema":{"type":"number"}},
+			{"id":"customfield_10100","name":"Team","custom":true,"schema":{"type":"string"}},
+			{"id":"customfield_10101","name":"Team","custom":true,"schema":{"type":"option"}}
+		]`))
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	field, err := client.FindField("story points")
+	if err != nil || field.ID != "customfield_10016" || !field.Custom || field.Schema.Type != "number" {
+		t.Fatalf("unexpected field %+v, %v", field, err)
+	}
+	if field, err := client.FindField("CUSTOMFIELD_10101"); err != nil || field.Schema.Type != "option" {
+		t.Fatalf("expected a lookup by ID, got %+v, %v", field, err)
+	}
+	if _, err := client.FindField("Team"); err == nil || !strings.Contains(err.Error(), "customfield_10100, customfield_10101") {
+		t.Fatalf("expected an ambiguous name to fail, got %v", err)
+	}
+	if _, err := client.FindField("Velocity"); err == nil {
+		t.Fatal("expected an unknown field to fail")
+	}
+	if calls != 1 {
+		t.Fatalf("expected the field list to be fetched once, got %d", calls)
+	}
+}
+
+func TestCreateMetaAndEditMeta(t *testing.T) {
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/issue/createmeta/ENG/issuetypes":
+			_, _ = w.Write([]byte(`{"issueTypes":[{"id":"10001","name":"Story"}]}`))
+		case "/rest/api/3/issue/createmeta/ENG/issuetypes/10001":
+			if r.URL.Query().Get("startAt") == "0" {
+				_, _ = w.Write([]byte(`{"total":2,"fields":[{"fieldId":"summary","key":"summary","name":"Summary","required":true,"schema":{"type":"string"}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"total":2,"fields":[{"fieldId":"customfield_10050","name":"Severity","schema":{"type":"option"},"allowedValues":[{"id":"1","value":"High"}]}]}`))
+		case "/rest/api/3/issue/ENG-1/editmeta":
+			_, _ = w.Write([]byte(`{"fields":{"customfield_10016":{"key":"customfield_10016","name":"Story Points","schema":{"type":"number"}}}}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	meta, err := client.CreateMeta("ENG", "story")
+	if err != nil {
+		t.Fatalf("CreateMeta failed: %v", err)
+	}
+	if len(meta) != 2 || !meta["summary"].Required || meta["customfield_10050"].AllowedValues[0].Label() != "High" {
+		t.Fatalf("unexpected create meta: %+v", meta)
+	}
+	if _, err := client.CreateMeta("ENG", "Bug"); err == nil || !strings.Contains(err.Error(), "available: Story") {
+		t.Fatalf("expected an unknown issue type to fail, got %v", err)
+	}
+
+	edit, err := client.EditMeta("ENG-1")
+	if err != nil || edit["customfield_10016"].Name != "Story Points" {
+		t.Fatalf("unexpected edit meta %+v, %v", edit, err)
+	}
+}
+
+func TestPlanningFieldsResolve(t *testing.T) {
+	screen := map[string]FieldMeta{
+		"customfield_10014": {Name: "Epic Link"},
+		"customfield_10016": {Name: "Story Points"},
+		"customfield_11887": {Name: "Team Assigned"},
+	}
+	values, omitted := PlanningFields{Epic: "ENG-1", StoryPoints: 2, Sprint: "7", Team: "42"}.Resolve(screen)
+	if values["customfield_10014"] != "ENG-1" || values["customfield_10016"] != 2.0 {
+		t.Fatalf("unexpected values: %+v", values)
+	}
+	if team, _ := values["customfield_11887"].(map[string]string); team["id"] != "42" {
+		t.Fatalf("unexpected team value: %+v", values["customfield_11887"])
+	}
+	if len(omitted) != 1 || omitted[0] != "Sprint" {
+		t.Fatalf("expected the sprint to be omitted, got %v", omitted)
+	}
+	if !(PlanningFields{}).IsZero() {
+		t.Fatal("expected empty planning fields to be zero")
+	}
+}
+
+func TestDecodeTeam(t *testing.T) {
+	cases := map[string][2]string{
+		`"36885b3c-1bf0-4f85-a357-c5b858c31de4"`:  {"36885b3c-1bf0-4f85-a357-c5b858c31de4", ""},
+		`{"id":"team-1","name":"Platform"}`:       {"team-1", "Platform"},
+		`{"id":"42","title":"Payments"}`:          {"42", "Payments"},
+		`{"id":"10100","value":"Infrastructure"}`: {"10100", "Infrastructure"},
+		`null`: {"", ""},
+	}
+	for raw, want := range cases {
+		if id, name := decodeTeam(json.RawMessage(raw)); id != want[0] || name != want[1] {
+			t.Errorf("decodeTeam(%s) = %q, %q, want %q, %q", raw, id, name, want[0], want[1])
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package jira
+
+import "context"
+
+// Filter is a saved Jira search.
+type Filter struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	JQL         string `json:"jql"`
+	Owner       User   `json:"owner"`
+	ViewURL     string `json:"viewUrl,omitempty"`
+}
+
+// FavouriteFiltersContext lists the filters the user starred in Jira.
+func (c *Client) FavouriteFiltersContext(ctx context.Context) ([]Filter, error) {
+	var filters []Filter
+	if err := c.getJSON(ctx, "filter/favourite", &filters); err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+// FavouriteFilters calls FavouriteFiltersContext with a background context.
+func (c *Client) FavouriteFilters() ([]Filter, error) {
+	return c.FavouriteFiltersContext(context.Background())
+}
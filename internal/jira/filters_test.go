@@ -0,0 +1,27 @@
+package jira
+
+import (
+	"net/http"
+	"testing"
+
+	"devflow/internal/config"
+	"devflow/internal/httpx"
+)
+
+func TestFavouriteFilters(t *testing.T) {
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/filter/favourite" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`[{"id":"10010","name":"My open bugs","jql":"type = Bug AND assignee = currentUser()","owner":{"displayName":"Alice"}}]`))
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	filters, err := client.FavouriteFilters()
+	if err != nil {
+		t.Fatalf("FavouriteFilters failed: %v", err)
+	}
+	if len(filters) != 1 || filters[0].Name != "My open bugs" || filters[0].JQL != "type = Bug AND assignee = currentUser()" || filters[0].Owner.DisplayName != "Alice" {
+		t.Fatalf("unexpected filters: %+v", filters)
+	}
+}
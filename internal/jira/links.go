@@ -0,0 +1,141 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+
+	"devflow/internal/httpx"
+)
+
+// IssueLinkType describes a kind of link between issues. Inward and Outward
+// are the phrases used from either end, e.g. "is blocked by" and "blocks".
+type IssueLinkType struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Inward  string `json:"inward"`
+	Outward string `json:"outward"`
+}
+
+// IssueLink is a link as it appears in an issue's issuelinks field. Exactly
+// one of InwardIssue and OutwardIssue is set: with OutwardIssue the issue
+// "<Type.Outward>" it, with InwardIssue the issue "<Type.Inward>" it.
+type IssueLink struct {
+	ID           string        `json:"id"`
+	Type         IssueLinkType `json:"type"`
+	InwardIssue  *LinkedIssue  `json:"inwardIssue,omitempty"`
+	OutwardIssue *LinkedIssue  `json:"outwardIssue,omitempty"`
+}
+
+// LinkedIssue is the summary of the issue at the other end of an IssueLink.
+type LinkedIssue struct {
+	ID     string `json:"id"`
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string `json:"summary"`
+		Status  struct {
+			Name string `json:"name"`
+		} `json:"status"`
+	} `json:"fields"`
+}
+
+// Direction returns the phrase describing the link from the issue that holds
+// it, and the issue at the other end.
+func (l IssueLink) Direction() (string, *LinkedIssue) {
+	if l.OutwardIssue != nil {
+		return l.Type.Outward, l.OutwardIssue
+	}
+	return l.Type.Inward, l.InwardIssue
+}
+
+// ListIssueLinkTypesContext lists the issue link types configured on the
+// Jira instance.
+func (c *Client) ListIssueLinkTypesContext(ctx context.Context) ([]IssueLinkType, error) {
+	resp, err := c.makeRequest(ctx, "GET", "issueLinkType", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+
+	var result struct {
+		IssueLinkTypes []IssueLinkType `json:"issueLinkTypes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.IssueLinkTypes, nil
+}
+
+// ListIssueLinkTypes calls ListIssueLinkTypesContext with a background context.
+func (c *Client) ListIssueLinkTypes() ([]IssueLinkType, error) {
+	return c.ListIssueLinkTypesContext(context.Background())
+}
+
+// CreateIssueLinkContext links two issues with the named link type so that
+// from "<outward>" to, e.g. from blocks to. Jira calls from the inward issue
+// of the link and to the outward one.
+func (c *Client) CreateIssueLinkContext(ctx context.Context, linkType, from, to string) error {
+	payload := map[string]interface{}{
+		"type":         map[string]string{"name": linkType},
+		"inwardIssue":  map[string]string{"key": from},
+		"outwardIssue": map[string]string{"key": to},
+	}
+	resp, err := c.makeRequest(ctx, "POST", "issueLink", payload)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+	return nil
+}
+
+// CreateIssueLink calls CreateIssueLinkContext with a background context.
+func (c *Client) CreateIssueLink(linkType, from, to string) error {
+	return c.CreateIssueLinkContext(context.Background(), linkType, from, to)
+}
+
+// DeleteIssueLinkContext removes the issue link with the given ID.
+func (c *Client) DeleteIssueLinkContext(ctx context.Context, linkID string) error {
+	endpoint := fmt.Sprintf("issueLink/%s", url.PathEscape(linkID))
+	resp, err := c.makeRequest(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+	return nil
+}
+
+// DeleteIssueLink calls DeleteIssueLinkContext with a background context.
+func (c *Client) DeleteIssueLink(linkID string) error {
+	return c.DeleteIssueLinkContext(context.Background(), linkID)
+}
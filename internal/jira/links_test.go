@@ -0,0 +1,86 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"devflow/internal/config"
+	"devflow/internal/httpx"
+)
+
+func TestListIssueLinkTypes(t *testing.T) {
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/rest/api/3/issueLinkType" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"issueLinkTypes":[
+			{"id":"10000","name":"Blocks","inward":"is blocked by","outward":"blocks"},
+			{"id":"10002","name":"Duplicate","inward":"is duplicated by","outward":"duplicates"}
+		]}`))
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	types, err := client.ListIssueLinkTypes()
+	if err != nil {
+		t.Fatalf("ListIssueLinkTypes failed: %v", err)
+	}
+	if len(types) != 2 || types[0].Name != "Blocks" || types[0].Inward != "is blocked by" || types[1].Outward != "duplicates" {
+		t.Fatalf("unexpected link types: %+v", types)
+	}
+}
+
+func TestCreateAndDeleteIssueLink(t *testing.T) {
+	var payload map[string]map[string]string
+	var deleted string
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/3/issueLink":
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodDelete && r.URL.Path == "/rest/api/3/issueLink/10231":
+			deleted = "10231"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorMessages":["No issue link with id"]}`))
+		}
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	if err := client.CreateIssueLink("Blocks", "ENG-12", "ENG-40"); err != nil {
+		t.Fatalf("CreateIssueLink failed: %v", err)
+	}
+	if payload["type"]["name"] != "Blocks" || payload["inwardIssue"]["key"] != "ENG-12" || payload["outwardIssue"]["key"] != "ENG-40" {
+		t.Fatalf("unexpected link payload: %+v", payload)
+	}
+
+	if err := client.DeleteIssueLink("10231"); err != nil || deleted != "10231" {
+		t.Fatalf("DeleteIssueLink failed: %v (deleted %q)", err, deleted)
+	}
+	if err := client.DeleteIssueLink("999"); err == nil {
+		t.Fatal("expected an error for an unknown link")
+	}
+}
+
+func TestIssueLinkDirection(t *testing.T) {
+	var details IssueDetails
+	data := `{"key":"ENG-12","fields":{"issuelinks":[
+		{"id":"1","type":{"name":"Blocks","inward":"is blocked by","outward":"blocks"},"outwardIssue":{"key":"ENG-40","fields":{"summary":"Ship it","status":{"name":"To Do"}}}},
+		{"id":"2","type":{"name":"Blocks","inward":"is blocked by","outward":"blocks"},"inwardIssue":{"key":"ENG-3","fields":{"summary":"Design","status":{"name":"Done"}}}}
+	]}}`
+	if err := json.Unmarshal([]byte(data), &details); err != nil {
+		t.Fatal(err)
+	}
+	if len(details.Fields.IssueLinks) != 2 {
+		t.Fatalf("expected two links, got %+v", details.Fields.IssueLinks)
+	}
+	if phrase, other := details.Fields.IssueLinks[0].Direction(); phrase != "blocks" || other.Key != "ENG-40" {
+		t.Fatalf("unexpected outward link: %s %+v", phrase, other)
+	}
+	if phrase, other := details.Fields.IssueLinks[1].Direction(); phrase != "is blocked by" || other.Key != "ENG-3" || other.Fields.Status.Name != "Done" {
+		t.Fatalf("unexpected inward link: %s %+v", phrase, other)
+	}
+}
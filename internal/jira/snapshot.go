@@ -0,0 +1,203 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Snapshot is the state of the authenticated user's assigned issues at one
+// point in time, as kept in the snapshot store.
+type Snapshot struct {
+	Site    string    `json:"site"` // Jira URL, so profiles do not mix
+	TakenAt time.Time `json:"taken_at"`
+	Issues  []Issue   `json:"issues"`
+}
+
+// snapshotFields are the search fields a snapshot keeps.
+const snapshotFields = searchFields + ",updated,comment"
+
+// TakeSnapshotContext searches every issue assigned to the authenticated
+// user. Descriptions are dropped and only the latest comment, without its
+// body, is kept, which is all DiffSnapshots needs.
+func (c *Client) TakeSnapshotContext(ctx context.Context) (*Snapshot, error) {
+	issues, err := c.searchPages(ctx, c.searchEndpoint(ctx, "assignee = currentUser() ORDER BY updated DESC", snapshotFields), 100, 0)
+	if err != nil {
+		return nil, err
+	}
+	for i := range issues {
+		fields := &issues[i].Fields
+		fields.Description = nil
+		if fields.Comment == nil {
+			continue
+		}
+		if fields.Comment.Total < len(fields.Comment.Comments) {
+			fields.Comment.Total = len(fields.Comment.Comments)
+		}
+		if n := len(fields.Comment.Comments); n > 0 {
+			latest := fields.Comment.Comments[n-1]
+			latest.Body = nil
+			fields.Comment.Comments = []Comment{latest}
+		}
+	}
+	return &Snapshot{Site: strings.TrimSuffix(c.config.URL, "/"), TakenAt: time.Now(), Issues: issues}, nil
+}
+
+// TakeSnapshot calls TakeSnapshotContext with a background context.
+func (c *Client) TakeSnapshot() (*Snapshot, error) {
+	return c.TakeSnapshotContext(context.Background())
+}
+
+// LoadSnapshots reads the snapshots of site from the store at path, oldest
+// first. A missing store holds no snapshots.
+func LoadSnapshots(path, site string) ([]Snapshot, error) {
+	all, err := readSnapshots(path)
+	if err != nil {
+		return nil, err
+	}
+	site = strings.TrimSuffix(site, "/")
+	var snapshots []Snapshot
+	for _, snapshot := range all {
+		if snapshot.Site == site {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots, nil
+}
+
+// SaveSnapshot adds snapshot to the store at path, keeping the newest keep
+// snapshots of its site.
+func SaveSnapshot(path string, snapshot Snapshot, keep int) error {
+	all, err := readSnapshots(path)
+	if err != nil {
+		return err
+	}
+	all = append(all, snapshot)
+	kept := make([]Snapshot, 0, len(all))
+	sameSite := 0
+	for i := len(all) - 1; i >= 0; i-- {
+		if all[i].Site == snapshot.Site {
+			sameSite++
+			if keep > 0 && sameSite > keep {
+				continue
+			}
+		}
+		kept = append(kept, all[i])
+	}
+	for i, j := 0, len(kept)-1; i < j; i, j = i+1, j-1 {
+		kept[i], kept[j] = kept[j], kept[i]
+	}
+
+	data, err := json.MarshalIndent(kept, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshots: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create the snapshot directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write snapshots: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write snapshots: %w", err)
+	}
+	return nil
+}
+
+func readSnapshots(path string) ([]Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+	var snapshots []Snapshot
+	if err := json.Unmarshal(data, &snapshots); err != nil {
+		return nil, fmt.Errorf("unreadable snapshot store %s: %w", path, err)
+	}
+	return snapshots, nil
+}
+
+// Kinds of Change.
+const (
+	ChangeAssigned   = "assigned"
+	ChangeUnassigned = "unassigned"
+	ChangeStatus     = "status"
+	ChangePriority   = "priority"
+	ChangeComments   = "comments"
+)
+
+// Change is one difference between two snapshots.
+type Change struct {
+	Key      string `json:"key"`
+	Summary  string `json:"summary"`
+	Kind     string `json:"kind"`
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+	Comments int    `json:"comments,omitempty"` // number of new comments
+	Author   string `json:"author,omitempty"`   // of the latest comment
+}
+
+// priorityRank orders Jira's default priorities; other names are not
+// ranked, so changes to or from them are not reported as bumps.
+var priorityRank = map[string]int{"lowest": 1, "low": 2, "medium": 3, "high": 4, "highest": 5}
+
+// DiffSnapshots reports how current differs from base: issues newly
+// assigned or no longer assigned, status changes, new comments and raised
+// priorities. Changes follow the order of current's issues; issues no
+// longer assigned come last.
+func DiffSnapshots(base, current Snapshot) []Change {
+	before := make(map[string]Issue, len(base.Issues))
+	for _, issue := range base.Issues {
+		before[issue.Key] = issue
+	}
+	changes := make([]Change, 0)
+	seen := make(map[string]bool, len(current.Issues))
+	for _, issue := range current.Issues {
+		seen[issue.Key] = true
+		change := Change{Key: issue.Key, Summary: issue.Fields.Summary}
+		old, ok := before[issue.Key]
+		if !ok {
+			change.Kind, change.To = ChangeAssigned, issue.Fields.Status.Name
+			changes = append(changes, change)
+			continue
+		}
+		if from, to := old.Fields.Status.Name, issue.Fields.Status.Name; from != to {
+			change.Kind, change.From, change.To = ChangeStatus, from, to
+			changes = append(changes, change)
+		}
+		from, to := old.Fields.Priority.Name, issue.Fields.Priority.Name
+		if rankFrom, rankTo := priorityRank[strings.ToLower(from)], priorityRank[strings.ToLower(to)]; rankFrom > 0 && rankTo > rankFrom {
+			change.Kind, change.From, change.To = ChangePriority, from, to
+			changes = append(changes, change)
+		}
+		if added := commentTotal(issue) - commentTotal(old); added > 0 {
+			change.Kind, change.From, change.To = ChangeComments, "", ""
+			change.Comments = added
+			if comments := issue.Fields.Comment.Comments; len(comments) > 0 {
+				change.Author = comments[len(comments)-1].Author.DisplayName
+			}
+			changes = append(changes, change)
+		}
+	}
+	for _, issue := range base.Issues {
+		if !seen[issue.Key] {
+			changes = append(changes, Change{Key: issue.Key, Summary: issue.Fields.Summary, Kind: ChangeUnassigned, From: issue.Fields.Status.Name})
+		}
+	}
+	return changes
+}
+
+func commentTotal(issue Issue) int {
+	if issue.Fields.Comment == nil {
+		return 0
+	}
+	return issue.Fields.Comment.Total
+}
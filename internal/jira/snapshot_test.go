@@ -0,0 +1,99 @@
+package jira
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"devflow/internal/config"
+	"devflow/internal/httpx"
+)
+
+func snapshotIssue(key, status, priority string, comments int) Issue {
+	issue := Issue{Key: key}
+	issue.Fields.Summary = "Summary of " + key
+	issue.Fields.Status.Name = status
+	issue.Fields.Priority.Name = priority
+	if comments > 0 {
+		issue.Fields.Comment = &struct {
+			Total    int       `json:"total"`
+			Comments []Comment `json:"comments"`
+		}{Total: comments, Comments: make([]Comment, 1)}
+		issue.Fields.Comment.Comments[0].Author.DisplayName = "Bob"
+	}
+	return issue
+}
+
+func TestTakeSnapshot(t *testing.T) {
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("jql") != "assignee = currentUser() ORDER BY updated DESC" || !strings.HasSuffix(query.Get("fields"), ",updated,comment") {
+			t.Fatalf("unexpected search %q with fields %q", query.Get("jql"), query.Get("fields"))
+		}
+		_, _ = w.Write([]byte(`{"issues":[{"id":"1","key":"ENG-1","fields":{"summary":"Crash","description":"long text",
+			"comment":{"total":3,"comments":[{"author":{"displayName":"Alice"},"body":"first"},{"author":{"displayName":"Bob"},"body":"second"}]}}}],"isLast":true}`))
+	}))
+	client := NewClient(&config.JiraConfig{URL: server.URL + "/", Username: "me", Token: "tok", Deployment: "cloud"})
+
+	snapshot, err := client.TakeSnapshot()
+	if err != nil || len(snapshot.Issues) != 1 || snapshot.Site != server.URL {
+		t.Fatalf("unexpected snapshot %+v, %v", snapshot, err)
+	}
+	fields := snapshot.Issues[0].Fields
+	if fields.Description != nil || fields.Comment.Total != 3 || len(fields.Comment.Comments) != 1 ||
+		fields.Comment.Comments[0].Author.DisplayName != "Bob" || fields.Comment.Comments[0].Body != nil {
+		t.Fatalf("expected the snapshot to keep only the latest comment, got %+v", fields)
+	}
+}
+
+func TestSnapshotStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshots.json")
+	if snapshots, err := LoadSnapshots(path, "https://a.example"); err != nil || len(snapshots) != 0 {
+		t.Fatalf("expected a missing store to be empty, got %+v, %v", snapshots, err)
+	}
+	start := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	for i := range 3 {
+		if err := SaveSnapshot(path, Snapshot{Site: "https://a.example", TakenAt: start.Add(time.Duration(i) * time.Hour)}, 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := SaveSnapshot(path, Snapshot{Site: "https://b.example", TakenAt: start}, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots, err := LoadSnapshots(path, "https://a.example/")
+	if err != nil || len(snapshots) != 2 || !snapshots[0].TakenAt.Equal(start.Add(time.Hour)) || !snapshots[1].TakenAt.Equal(start.Add(2*time.Hour)) {
+		t.Fatalf("expected the two newest snapshots of the site, got %+v, %v", snapshots, err)
+	}
+	if other, _ := LoadSnapshots(path, "https://b.example"); len(other) != 1 {
+		t.Fatalf("expected the other site's snapshot to be kept, got %+v", other)
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	base := Snapshot{Issues: []Issue{
+		snapshotIssue("ENG-1", "To Do", "Medium", 1),
+		snapshotIssue("ENG-2", "In Progress", "High", 0),
+		snapshotIssue("ENG-3", "To Do", "Low", 0),
+	}}
+	current := Snapshot{Issues: []Issue{
+		snapshotIssue("ENG-4", "To Do", "Medium", 0),
+		snapshotIssue("ENG-1", "In Progress", "High", 3),
+		snapshotIssue("ENG-2", "In Progress", "Medium", 0),
+	}}
+
+	changes := DiffSnapshots(base, current)
+	var got []string
+	for _, change := range changes {
+		got = append(got, change.Key+" "+change.Kind+" "+change.From+">"+change.To)
+	}
+	want := []string{"ENG-4 assigned >To Do", "ENG-1 status To Do>In Progress", "ENG-1 priority Medium>High", "ENG-1 comments >", "ENG-3 unassigned To Do>"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected changes:\n got %q\nwant %q", got, want)
+	}
+	if changes[3].Comments != 2 || changes[3].Author != "Bob" {
+		t.Fatalf("unexpected comment change %+v", changes[3])
+	}
+}
@@ -0,0 +1,136 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+
+	"devflow/internal/adf"
+	"devflow/internal/httpx"
+)
+
+// Transition is a workflow transition currently available on an issue.
+type Transition struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	To   struct {
+		ID             string `json:"id"`
+		Name           string `json:"name"`
+		StatusCategory struct {
+			Key  string `json:"key"`
+			Name string `json:"name"`
+		} `json:"statusCategory"`
+	} `json:"to"`
+	HasScreen bool `json:"hasScreen"`
+	// Fields lists the fields on the transition screen, keyed by field ID.
+	Fields map[string]FieldMeta `json:"fields"`
+}
+
+// FieldMeta describes a field on a transition, create or edit screen.
+// FieldID is only set by the create screen endpoints, which list fields
+// instead of keying them by ID.
+type FieldMeta struct {
+	FieldID         string         `json:"fieldId,omitempty"`
+	Key             string         `json:"key"`
+	Name            string         `json:"name"`
+	Required        bool           `json:"required"`
+	HasDefaultValue bool           `json:"hasDefaultValue"`
+	Schema          FieldSchema    `json:"schema"`
+	AllowedValues   []AllowedValue `json:"allowedValues"`
+}
+
+// AllowedValue is one of the options of a select-like field. Depending on
+// the field, the option is labelled by Name (resolution, priority) or by
+// Value (custom select lists).
+type AllowedValue struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Label returns the display label of the option.
+func (v AllowedValue) Label() string {
+	if v.Name != "" {
+		return v.Name
+	}
+	return v.Value
+}
+
+// GetTransitionsContext lists the transitions the current user can perform on
+// an issue, including the fields of each transition screen.
+func (c *Client) GetTransitionsContext(ctx context.Context, issueKey string) ([]Transition, error) {
+	endpoint := fmt.Sprintf("issue/%s/transitions?expand=transitions.fields", url.PathEscape(issueKey))
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+
+	var result struct {
+		Transitions []Transition `json:"transitions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.Transitions, nil
+}
+
+// GetTransitions calls GetTransitionsContext with a background context.
+func (c *Client) GetTransitions(issueKey string) ([]Transition, error) {
+	return c.GetTransitionsContext(context.Background(), issueKey)
+}
+
+// DoTransitionContext performs a workflow transition on an issue. fields sets
+// values on the transition screen (e.g. "resolution"), keyed by field ID, and
+// a non-empty comment is added in the same request.
+func (c *Client) DoTransitionContext(ctx context.Context, issueKey, transitionID string, fields map[string]interface{}, comment string) error {
+	payload := map[string]interface{}{
+		"transition": map[string]string{"id": transitionID},
+	}
+	if len(fields) > 0 {
+		payload["fields"] = fields
+	}
+	if comment != "" {
+		payload["update"] = map[string]interface{}{
+			"comment": []interface{}{map[string]interface{}{
+				"add": map[string]interface{}{"body": adf.FromMarkdown(comment)},
+			}},
+		}
+	}
+
+	endpoint := fmt.Sprintf("issue/%s/transitions", url.PathEscape(issueKey))
+	resp, err := c.makeRequest(ctx, "POST", endpoint, payload)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	// Jira returns 204 No Content on success
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+	return nil
+}
+
+// DoTransition calls DoTransitionContext with a background context.
+func (c *Client) DoTransition(issueKey, transitionID string, fields map[string]interface{}, comment string) error {
+	return c.DoTransitionContext(context.Background(), issueKey, transitionID, fields, comment)
+}
@@ -0,0 +1,89 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"devflow/internal/config"
+	"devflow/internal/httpx"
+)
+
+func TestGetTransitions(t *testing.T) {
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/rest/api/3/issue/ENG-1/transitions" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.URL.Query().Get("expand") != "transitions.fields" {
+			t.Fatalf("expected transition fields to be expanded, got %q", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"transitions":[
+			{"id":"21","name":"Start review","to":{"id":"3","name":"In Review","statusCategory":{"key":"indeterminate"}}},
+			{"id":"31","name":"Done","to":{"name":"Done"},"hasScreen":true,"fields":{
+				"resolution":{"key":"resolution","name":"Resolution","required":true,"schema":{"type":"resolution","system":"resolution"},
+					"allowedValues":[{"id":"1","name":"Fixed"},{"id":"2","name":"Won't Do"}]}}}
+		]}`))
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	transitions, err := client.GetTransitions("ENG-1")
+	if err != nil {
+		t.Fatalf("GetTransitions failed: %v", err)
+	}
+	if len(transitions) != 2 || transitions[0].To.Name != "In Review" || transitions[0].To.StatusCategory.Key != "indeterminate" {
+		t.Fatalf("unexpected transitions: %+v", transitions)
+	}
+	resolution := transitions[1].Fields["resolution"]
+	if !resolution.Required || resolution.Schema.Type != "resolution" || len(resolution.AllowedValues) != 2 || resolution.AllowedValues[1].Label() != "Won't Do" {
+		t.Fatalf("unexpected resolution field: %+v", resolution)
+	}
+}
+
+func TestDoTransition(t *testing.T) {
+	var payload map[string]interface{}
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/3/issue/ENG-1/transitions" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	fields := map[string]interface{}{"resolution": map[string]string{"id": "1"}}
+	if err := client.DoTransition("ENG-1", "31", fields, "Shipped"); err != nil {
+		t.Fatalf("DoTransition failed: %v", err)
+	}
+
+	if payload["transition"].(map[string]interface{})["id"] != "31" {
+		t.Fatalf("unexpected transition: %v", payload["transition"])
+	}
+	if payload["fields"].(map[string]interface{})["resolution"].(map[string]interface{})["id"] != "1" {
+		t.Fatalf("unexpected fields: %v", payload["fields"])
+	}
+	comments := payload["update"].(map[string]interface{})["comment"].([]interface{})
+	body := comments[0].(map[string]interface{})["add"].(map[string]interface{})["body"].(map[string]interface{})
+	if body["type"] != "doc" {
+		t.Fatalf("expected an ADF comment body, got %v", body)
+	}
+}
+
+func TestDoTransitionReturnsAPIError(t *testing.T) {
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errorMessages":[],"errors":{"resolution":"Resolution is required."}}`))
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	err := client.DoTransition("ENG-1", "31", nil, "")
+	if httpx.StatusCode(err) != http.StatusBadRequest {
+		t.Fatalf("expected a 400 API error, got %v", err)
+	}
+	var apiErr *httpx.APIError
+	if !errors.As(err, &apiErr) || apiErr.FieldErrors["resolution"] == "" {
+		t.Fatalf("expected the resolution field error, got %v", err)
+	}
+}
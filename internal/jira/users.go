@@ -0,0 +1,369 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"devflow/internal/adf"
+	"devflow/internal/httpx"
+)
+
+// User is a Jira user account. Cloud identifies users by AccountID, Server
+// and Data Center by Name and Key.
+type User struct {
+	AccountID    string `json:"accountId"`
+	Name         string `json:"name,omitempty"`
+	Key          string `json:"key,omitempty"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress,omitempty"`
+	Active       bool   `json:"active"`
+	TimeZone     string `json:"timeZone,omitempty"`
+}
+
+// ID returns the identifier Jira knows the user by: the accountId on Cloud,
+// the username on Server and Data Center.
+func (u User) ID() string {
+	if u.AccountID != "" {
+		return u.AccountID
+	}
+	return u.Name
+}
+
+// String returns the display name and, when known, the email address.
+func (u User) String() string {
+	if u.EmailAddress != "" {
+		return fmt.Sprintf("%s <%s>", u.DisplayName, u.EmailAddress)
+	}
+	return u.DisplayName
+}
+
+// GetMyselfContext returns the user the client authenticates as. The answer
+// is kept for the life of the client.
+func (c *Client) GetMyselfContext(ctx context.Context) (*User, error) {
+	c.usersMu.Lock()
+	defer c.usersMu.Unlock()
+	if c.myself != nil {
+		me := *c.myself
+		return &me, nil
+	}
+
+	resp, err := c.makeRequest(ctx, "GET", "myself", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+
+	var user User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	c.myself = &user
+	me := user
+	return &me, nil
+}
+
+// GetMyself calls GetMyselfContext with a background context.
+func (c *Client) GetMyself() (*User, error) {
+	return c.GetMyselfContext(context.Background())
+}
+
+// GetUserContext returns the Cloud user with accountID.
+func (c *Client) GetUserContext(ctx context.Context, accountID string) (*User, error) {
+	var user User
+	if err := c.getJSON(ctx, "user?accountId="+url.QueryEscape(accountID), &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUser calls GetUserContext with a background context.
+func (c *Client) GetUser(accountID string) (*User, error) {
+	return c.GetUserContext(context.Background(), accountID)
+}
+
+// SearchUsersContext returns the users whose name, display name or email
+// address starts with query. Jira Cloud hides email addresses of users who
+// chose so, but still matches them.
+func (c *Client) SearchUsersContext(ctx context.Context, query string) ([]User, error) {
+	param := "query"
+	if c.DeploymentContext(ctx) == DeploymentServer {
+		param = "username"
+	}
+	var users []User
+	if err := c.getJSON(ctx, fmt.Sprintf("user/search?%s=%s&maxResults=20", param, url.QueryEscape(query)), &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// SearchUsers calls SearchUsersContext with a background context.
+func (c *Client) SearchUsers(query string) ([]User, error) {
+	return c.SearchUsersContext(context.Background(), query)
+}
+
+// accountIDPattern matches Cloud accountIds: 24 hex digits, or a numeric
+// prefix and a UUID such as "712020:0e5d...".
+var accountIDPattern = regexp.MustCompile(`^([0-9a-f]{24}|\d+:[0-9a-f-]{36})$`)
+
+// IsMe reports whether who refers to the authenticated user.
+func IsMe(who string) bool {
+	switch strings.ToLower(strings.TrimSpace(who)) {
+	case "me", "myself", "currentuser()", "@me":
+		return true
+	}
+	return false
+}
+
+// ResolveUserContext finds the user who refers to: "me", an accountId, an
+// email address, a username or a display name. Email addresses, usernames
+// and display names must match exactly when the search returns several
+// users. Resolved users are kept on the client and in the user cache set up
+// with ConfigureUserCache.
+func (c *Client) ResolveUserContext(ctx context.Context, who string) (*User, error) {
+	who = strings.TrimSpace(who)
+	if who == "" {
+		return nil, fmt.Errorf("no user given")
+	}
+	if IsMe(who) {
+		return c.GetMyselfContext(ctx)
+	}
+
+	key := strings.ToLower(who)
+	c.usersMu.Lock()
+	user, ok := c.users[key]
+	c.usersMu.Unlock()
+	cacheKey := strings.TrimSuffix(c.config.URL, "/") + " " + key
+	if !ok {
+		user, ok = sharedUserCache.get(cacheKey)
+	}
+	if !ok {
+		found, err := c.lookupUser(ctx, who)
+		if err != nil {
+			return nil, err
+		}
+		user = *found
+		sharedUserCache.put(cacheKey, user)
+	}
+
+	c.usersMu.Lock()
+	if c.users == nil {
+		c.users = make(map[string]User)
+	}
+	c.users[key] = user
+	c.usersMu.Unlock()
+	return &user, nil
+}
+
+// ResolveUser calls ResolveUserContext with a background context.
+func (c *Client) ResolveUser(who string) (*User, error) {
+	return c.ResolveUserContext(context.Background(), who)
+}
+
+func (c *Client) lookupUser(ctx context.Context, who string) (*User, error) {
+	if accountIDPattern.MatchString(who) && c.DeploymentContext(ctx) == DeploymentCloud {
+		return c.GetUserContext(ctx, who)
+	}
+	candidates, err := c.SearchUsersContext(ctx, who)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	var exact []User
+	for _, candidate := range candidates {
+		if strings.EqualFold(candidate.EmailAddress, who) || strings.EqualFold(candidate.Name, who) ||
+			strings.EqualFold(candidate.DisplayName, who) || candidate.AccountID == who {
+			exact = append(exact, candidate)
+		}
+	}
+	switch {
+	case len(exact) == 1:
+		return &exact[0], nil
+	case len(exact) == 0 && len(candidates) == 1:
+		return &candidates[0], nil
+	case len(exact) == 0 && len(candidates) == 0:
+		return nil, fmt.Errorf("no Jira user matches %q", who)
+	}
+	if len(exact) > 1 {
+		candidates = exact
+	}
+	names := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		names = append(names, candidate.String())
+	}
+	return nil, fmt.Errorf("%q matches %d Jira users (%s); use an email address or accountId", who, len(candidates), strings.Join(names, ", "))
+}
+
+// UserFieldContext returns the value of a user field such as assignee for
+// who: {"accountId": ...} on Cloud, {"name": ...} on Server. "none" and
+// "unassigned" clear the field.
+func (c *Client) UserFieldContext(ctx context.Context, who string) (interface{}, error) {
+	switch strings.ToLower(strings.TrimSpace(who)) {
+	case "none", "unassigned":
+		return nil, nil
+	}
+	user, err := c.ResolveUserContext(ctx, who)
+	if err != nil {
+		return nil, err
+	}
+	if c.DeploymentContext(ctx) == DeploymentServer {
+		return map[string]string{"name": user.Name}, nil
+	}
+	return map[string]string{"accountId": user.AccountID}, nil
+}
+
+// UserField calls UserFieldContext with a background context.
+func (c *Client) UserField(who string) (interface{}, error) {
+	return c.UserFieldContext(context.Background(), who)
+}
+
+// FindMentionsContext returns the issues whose description or comments
+// mention the authenticated user, most recently updated first. Jira indexes
+// a mention by the display name it shows, so a text search for that name
+// finds the candidates; only those whose bodies hold a mention node for the
+// user's accountId (or, on Server, a [~username] mention) are kept.
+func (c *Client) FindMentionsContext(ctx context.Context) ([]Issue, error) {
+	me, err := c.GetMyselfContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to identify the current user: %w", err)
+	}
+	term := me.DisplayName
+	if c.DeploymentContext(ctx) == DeploymentServer && me.Name != "" {
+		term = me.Name
+	}
+	if term == "" {
+		return nil, fmt.Errorf("the current user has no display name to search for")
+	}
+	jql := fmt.Sprintf(`text ~ "\"%s\"" ORDER BY updated DESC`, escapeJQLStringLiteral(term))
+
+	candidates, err := c.searchPages(ctx, c.searchEndpoint(ctx, jql, searchFields+",updated,comment"), 100, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]Issue, 0, len(candidates))
+	for _, issue := range candidates {
+		mentioned := mentions(issue.Fields.Description, me.ID())
+		if issue.Fields.Comment != nil {
+			for _, comment := range issue.Fields.Comment.Comments {
+				mentioned = mentioned || mentions(comment.Body, me.ID())
+			}
+		}
+		if mentioned {
+			issue.Fields.Comment = nil
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// FindMentions calls FindMentionsContext with a background context.
+func (c *Client) FindMentions() ([]Issue, error) {
+	return c.FindMentionsContext(context.Background())
+}
+
+// mentions reports whether a description or comment body mentions the user
+// with id.
+func mentions(body interface{}, id string) bool {
+	return slices.Contains(adf.Mentions(body), id)
+}
+
+// userCacheTTL is how long a resolved user is reused from the user cache.
+const userCacheTTL = 7 * 24 * time.Hour
+
+// userCache remembers users resolved by email, username or display name in
+// a JSON file, keyed by Jira URL and lookup, so later runs skip the search.
+type userCache struct {
+	mu   sync.Mutex
+	path string
+	mode httpx.CacheMode
+	now  func() time.Time
+}
+
+type cachedUser struct {
+	User     User      `json:"user"`
+	StoredAt time.Time `json:"stored_at"`
+}
+
+// sharedUserCache is used by every client. It stays off until
+// ConfigureUserCache is called.
+var sharedUserCache = &userCache{now: time.Now}
+
+// ConfigureUserCache keeps resolved users in the JSON file at path.
+// CacheRefresh ignores stored users but records new ones; an empty path or
+// CacheOff disables the file, leaving only the per-client memory.
+func ConfigureUserCache(path string, mode httpx.CacheMode) {
+	sharedUserCache.mu.Lock()
+	defer sharedUserCache.mu.Unlock()
+	sharedUserCache.path = path
+	sharedUserCache.mode = mode
+	if path == "" {
+		sharedUserCache.mode = httpx.CacheOff
+	}
+}
+
+func (c *userCache) get(key string) (User, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mode != httpx.CacheNormal {
+		return User{}, false
+	}
+	entry, ok := c.read()[key]
+	if !ok || c.now().Sub(entry.StoredAt) > userCacheTTL {
+		return User{}, false
+	}
+	return entry.User, true
+}
+
+func (c *userCache) put(key string, user User) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mode == httpx.CacheOff {
+		return
+	}
+	entries := c.read()
+	entries[key] = cachedUser{User: user, StoredAt: c.now()}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(c.path), 0o700)
+	}
+	if err == nil {
+		err = os.WriteFile(c.path, data, 0o600)
+	}
+	if err != nil {
+		log.Printf("warning: failed to write the user cache: %v", err)
+	}
+}
+
+func (c *userCache) read() map[string]cachedUser {
+	entries := make(map[string]cachedUser)
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return entries
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		log.Printf("warning: ignoring unreadable user cache %s: %v", c.path, err)
+		return make(map[string]cachedUser)
+	}
+	return entries
+}
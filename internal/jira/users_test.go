@@ -0,0 +1,113 @@
+package jira
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"devflow/internal/config"
+	"devflow/internal/httpx"
+)
+
+func TestResolveUser(t *testing.T) {
+	searches := 0
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/myself":
+			_, _ = w.Write([]byte(`{"accountId":"acc-me","displayName":"Me"}`))
+		case "/rest/api/3/user":
+			if r.URL.Query().Get("accountId") != "5b10ac8d82e05b22cc7d4ef5" {
+				t.Fatalf("unexpected accountId lookup: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"accountId":"5b10ac8d82e05b22cc7d4ef5","displayName":"Bob"}`))
+		case "/rest/api/3/user/search":
+			searches++
+			switch strings.ToLower(r.URL.Query().Get("query")) {
+			case "alice@example.com":
+				_, _ = w.Write([]byte(`[{"accountId":"acc-alice","displayName":"Alice","emailAddress":"alice@example.com"},{"accountId":"acc-alicia","displayName":"Alicia","emailAddress":"alice@example.company"}]`))
+			case "sam":
+				_, _ = w.Write([]byte(`[{"accountId":"acc-sam1","displayName":"Sam"},{"accountId":"acc-sam2","displayName":"Sam"}]`))
+			default:
+				_, _ = w.Write([]byte(`[]`))
+			}
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me@example.com", Token: "tok", Deployment: "cloud"})
+	if me, err := client.ResolveUser("me"); err != nil || me.AccountID != "acc-me" {
+		t.Fatalf("unexpected current user %+v, %v", me, err)
+	}
+	for range 2 {
+		if alice, err := client.ResolveUser("Alice@example.com"); err != nil || alice.AccountID != "acc-alice" {
+			t.Fatalf("expected the exact email match, got %+v, %v", alice, err)
+		}
+	}
+	if searches != 1 {
+		t.Fatalf("expected the resolved user to be remembered, got %d searches", searches)
+	}
+	if bob, err := client.ResolveUser("5b10ac8d82e05b22cc7d4ef5"); err != nil || bob.DisplayName != "Bob" {
+		t.Fatalf("unexpected accountId lookup %+v, %v", bob, err)
+	}
+	if _, err := client.ResolveUser("Sam"); err == nil || !strings.Contains(err.Error(), "matches 2 Jira users") {
+		t.Fatalf("expected an ambiguous name to fail, got %v", err)
+	}
+	if _, err := client.ResolveUser("nobody"); err == nil || !strings.Contains(err.Error(), `no Jira user matches "nobody"`) {
+		t.Fatalf("expected an unknown user to fail, got %v", err)
+	}
+
+	if field, err := client.UserField("alice@example.com"); err != nil || field.(map[string]string)["accountId"] != "acc-alice" {
+		t.Fatalf("unexpected Cloud user field %#v, %v", field, err)
+	}
+	if field, err := client.UserField("none"); err != nil || field != nil {
+		t.Fatalf("expected none to clear the field, got %#v, %v", field, err)
+	}
+}
+
+func TestResolveUserOnServer(t *testing.T) {
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/user/search" || r.URL.Query().Get("username") != "alice@corp.example" {
+			t.Fatalf("unexpected request: %s %s", r.URL.Path, r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`[{"name":"alice","key":"JIRAUSER1","displayName":"Alice","emailAddress":"alice@corp.example"}]`))
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Token: "pat", Deployment: "server"})
+	field, err := client.UserField("alice@corp.example")
+	if err != nil || field.(map[string]string)["name"] != "alice" {
+		t.Fatalf("expected a Server user field by name, got %#v, %v", field, err)
+	}
+}
+
+func TestUserCachePersistsResolvedUsers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jira-users.json")
+	ConfigureUserCache(path, httpx.CacheNormal)
+	t.Cleanup(func() { ConfigureUserCache("", httpx.CacheOff) })
+
+	searches := 0
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		searches++
+		_, _ = w.Write([]byte(`[{"accountId":"acc-alice","displayName":"Alice","emailAddress":"alice@example.com"}]`))
+	}))
+	cfg := &config.JiraConfig{URL: server.URL, Username: "me@example.com", Token: "tok", Deployment: "cloud"}
+
+	for range 2 {
+		// A new client per run leaves only the file to remember the user.
+		if alice, err := NewClient(cfg).ResolveUser("alice@example.com"); err != nil || alice.AccountID != "acc-alice" {
+			t.Fatalf("unexpected user %+v, %v", alice, err)
+		}
+	}
+	if searches != 1 {
+		t.Fatalf("expected the second run to be served from the cache, got %d searches", searches)
+	}
+
+	origNow := sharedUserCache.now
+	t.Cleanup(func() { sharedUserCache.now = origNow })
+	sharedUserCache.now = func() time.Time { return time.Now().Add(userCacheTTL + time.Hour) }
+	if _, err := NewClient(cfg).ResolveUser("alice@example.com"); err != nil || searches != 2 {
+		t.Fatalf("expected an expired entry to be looked up again, got %d searches, %v", searches, err)
+	}
+}
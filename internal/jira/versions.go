@@ -0,0 +1,160 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"devflow/internal/httpx"
+)
+
+// Version is a release of a project, as used by the Fix Version/s field.
+type Version struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Archived    bool   `json:"archived"`
+	Released    bool   `json:"released"`
+	StartDate   string `json:"startDate,omitempty"`   // YYYY-MM-DD
+	ReleaseDate string `json:"releaseDate,omitempty"` // YYYY-MM-DD
+	ProjectID   int    `json:"projectId,omitempty"`
+}
+
+// VersionInput describes a version to create. Dates are YYYY-MM-DD and
+// optional.
+type VersionInput struct {
+	Name        string
+	Description string
+	StartDate   string
+	ReleaseDate string
+}
+
+// ListVersionsContext lists the versions of a project, oldest first.
+func (c *Client) ListVersionsContext(ctx context.Context, projectKey string) ([]Version, error) {
+	var versions []Version
+	if err := c.getJSON(ctx, fmt.Sprintf("project/%s/versions", url.PathEscape(projectKey)), &versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// ListVersions calls ListVersionsContext with a background context.
+func (c *Client) ListVersions(projectKey string) ([]Version, error) {
+	return c.ListVersionsContext(context.Background(), projectKey)
+}
+
+// FindVersionContext looks a version of a project up by name, ignoring
+// case, or by ID.
+func (c *Client) FindVersionContext(ctx context.Context, projectKey, name string) (*Version, error) {
+	versions, err := c.ListVersionsContext(ctx, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(versions))
+	for _, version := range versions {
+		if strings.EqualFold(version.Name, name) || version.ID == name {
+			return &version, nil
+		}
+		names = append(names, version.Name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("project %s has no versions", projectKey)
+	}
+	return nil, fmt.Errorf("project %s has no version %q (available: %s)", projectKey, name, strings.Join(names, ", "))
+}
+
+// FindVersion calls FindVersionContext with a background context.
+func (c *Client) FindVersion(projectKey, name string) (*Version, error) {
+	return c.FindVersionContext(context.Background(), projectKey, name)
+}
+
+// CreateVersionContext creates an unreleased version in a project.
+func (c *Client) CreateVersionContext(ctx context.Context, projectKey string, in VersionInput) (*Version, error) {
+	var project struct {
+		ID string `json:"id"`
+	}
+	if err := c.getJSON(ctx, "project/"+url.PathEscape(projectKey), &project); err != nil {
+		return nil, fmt.Errorf("failed to look up project %s: %w", projectKey, err)
+	}
+	projectID, err := strconv.Atoi(project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected id %q of project %s", project.ID, projectKey)
+	}
+
+	payload := map[string]interface{}{"name": in.Name, "projectId": projectID}
+	if in.Description != "" {
+		payload["description"] = in.Description
+	}
+	if in.StartDate != "" {
+		payload["startDate"] = in.StartDate
+	}
+	if in.ReleaseDate != "" {
+		payload["releaseDate"] = in.ReleaseDate
+	}
+	return c.sendVersion(ctx, "POST", "version", payload, http.StatusCreated)
+}
+
+// CreateVersion calls CreateVersionContext with a background context.
+func (c *Client) CreateVersion(projectKey string, in VersionInput) (*Version, error) {
+	return c.CreateVersionContext(context.Background(), projectKey, in)
+}
+
+// ReleaseVersionContext marks a version as released on releaseDate
+// (YYYY-MM-DD), or on the date Jira keeps when releaseDate is empty.
+func (c *Client) ReleaseVersionContext(ctx context.Context, versionID, releaseDate string) (*Version, error) {
+	payload := map[string]interface{}{"released": true}
+	if releaseDate != "" {
+		payload["releaseDate"] = releaseDate
+	}
+	return c.sendVersion(ctx, "PUT", "version/"+url.PathEscape(versionID), payload, http.StatusOK)
+}
+
+// ReleaseVersion calls ReleaseVersionContext with a background context.
+func (c *Client) ReleaseVersion(versionID, releaseDate string) (*Version, error) {
+	return c.ReleaseVersionContext(context.Background(), versionID, releaseDate)
+}
+
+// versionFields are the fields release notes need of each issue.
+const versionFields = "id,key,summary,status,issuetype,assignee,priority"
+
+// VersionIssuesContext returns the issues of a project whose fix versions
+// include version, ordered by type and key.
+func (c *Client) VersionIssuesContext(ctx context.Context, projectKey, version string) ([]Issue, error) {
+	jql := fmt.Sprintf(`project = "%s" AND fixVersion = "%s" ORDER BY issuetype ASC, key ASC`,
+		escapeJQLStringLiteral(projectKey), escapeJQLStringLiteral(version))
+	return c.searchPages(ctx, c.searchEndpoint(ctx, jql, versionFields), 100, 0)
+}
+
+// VersionIssues calls VersionIssuesContext with a background context.
+func (c *Client) VersionIssues(projectKey, version string) ([]Issue, error) {
+	return c.VersionIssuesContext(context.Background(), projectKey, version)
+}
+
+func (c *Client) sendVersion(ctx context.Context, method, endpoint string, payload map[string]interface{}, wantStatus int) (*Version, error) {
+	resp, err := c.makeRequest(ctx, method, endpoint, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != wantStatus {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+
+	var version Version
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &version, nil
+}
@@ -0,0 +1,72 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"devflow/internal/config"
+	"devflow/internal/httpx"
+)
+
+func TestVersions(t *testing.T) {
+	var created, released map[string]interface{}
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/3/project/ENG/versions":
+			_, _ = w.Write([]byte(`[{"id":"100","name":"2.3.0","released":true,"releaseDate":"2024-05-01"},{"id":"101","name":"2.4.0"}]`))
+		case r.URL.Path == "/rest/api/3/project/ENG":
+			_, _ = w.Write([]byte(`{"id":"10000","key":"ENG"}`))
+		case r.URL.Path == "/rest/api/3/version" && r.Method == http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"102","name":"2.5.0","projectId":10000}`))
+		case r.URL.Path == "/rest/api/3/version/101" && r.Method == http.MethodPut:
+			_ = json.NewDecoder(r.Body).Decode(&released)
+			_, _ = w.Write([]byte(`{"id":"101","name":"2.4.0","released":true,"releaseDate":"2024-06-01"}`))
+		case r.URL.Path == "/rest/api/3/search/jql":
+			jql := r.URL.Query().Get("jql")
+			if jql != `project = "ENG" AND fixVersion = "2.4.0" ORDER BY issuetype ASC, key ASC` || !strings.Contains(r.URL.Query().Get("fields"), "issuetype") {
+				t.Fatalf("unexpected search %q with fields %q", jql, r.URL.Query().Get("fields"))
+			}
+			_, _ = w.Write([]byte(`{"issues":[{"id":"1","key":"ENG-1","fields":{"summary":"Crash","issuetype":{"name":"Bug"}}}],"isLast":true}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+
+	versions, err := client.ListVersions("ENG")
+	if err != nil || len(versions) != 2 || !versions[0].Released || versions[1].Name != "2.4.0" {
+		t.Fatalf("unexpected versions %+v, %v", versions, err)
+	}
+	version, err := client.FindVersion("ENG", "2.4.0")
+	if err != nil || version.ID != "101" {
+		t.Fatalf("unexpected version %+v, %v", version, err)
+	}
+	if _, err := client.FindVersion("ENG", "3.0.0"); err == nil || !strings.Contains(err.Error(), "available: 2.3.0, 2.4.0") {
+		t.Fatalf("expected the available versions to be listed, got %v", err)
+	}
+
+	version, err = client.CreateVersion("ENG", VersionInput{Name: "2.5.0", ReleaseDate: "2024-07-01"})
+	if err != nil || version.ID != "102" {
+		t.Fatalf("unexpected created version %+v, %v", version, err)
+	}
+	if created["projectId"] != float64(10000) || created["name"] != "2.5.0" || created["releaseDate"] != "2024-07-01" {
+		t.Fatalf("unexpected create payload %+v", created)
+	}
+
+	version, err = client.ReleaseVersion("101", "2024-06-01")
+	if err != nil || !version.Released {
+		t.Fatalf("unexpected released version %+v, %v", version, err)
+	}
+	if released["released"] != true || released["releaseDate"] != "2024-06-01" {
+		t.Fatalf("unexpected release payload %+v", released)
+	}
+
+	issues, err := client.VersionIssues("ENG", "2.4.0")
+	if err != nil || len(issues) != 1 || issues[0].Fields.IssueType.Name != "Bug" {
+		t.Fatalf("unexpected version issues %+v, %v", issues, err)
+	}
+}
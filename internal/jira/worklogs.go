@@ -0,0 +1,224 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"devflow/internal/adf"
+	"devflow/internal/httpx"
+)
+
+// WorklogTimeLayout is the timestamp layout Jira uses for worklog start
+// times.
+const WorklogTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// Worklog is time logged against an issue.
+type Worklog struct {
+	ID               string      `json:"id"`
+	IssueID          string      `json:"issueId"`
+	Author           User        `json:"author"`
+	Comment          interface{} `json:"comment,omitempty"`
+	Started          string      `json:"started"`
+	TimeSpent        string      `json:"timeSpent"`
+	TimeSpentSeconds int         `json:"timeSpentSeconds"`
+}
+
+// StartedAt parses Started.
+func (w Worklog) StartedAt() (time.Time, error) {
+	return time.Parse(WorklogTimeLayout, w.Started)
+}
+
+// Estimate adjustment modes accepted by the worklog endpoints.
+const (
+	// AdjustAuto reduces the remaining estimate by the time spent.
+	AdjustAuto = "auto"
+	// AdjustLeave leaves the remaining estimate unchanged.
+	AdjustLeave = "leave"
+	// AdjustNew sets the remaining estimate to EstimateAdjustment.Value.
+	AdjustNew = "new"
+	// AdjustManual reduces the remaining estimate by EstimateAdjustment.Value
+	// when logging work, and increases it by Value when deleting a worklog.
+	AdjustManual = "manual"
+)
+
+// EstimateAdjustment describes how logging work changes the issue's
+// remaining estimate. The zero value lets Jira adjust it automatically.
+// Value is a Jira duration such as "2d" or "3h 30m".
+type EstimateAdjustment struct {
+	Mode  string
+	Value string
+}
+
+func (a EstimateAdjustment) query(deleting bool) string {
+	if a.Mode == "" || a.Mode == AdjustAuto {
+		return ""
+	}
+	values := url.Values{"adjustEstimate": {a.Mode}}
+	switch {
+	case a.Mode == AdjustNew:
+		values.Set("newEstimate", a.Value)
+	case a.Mode == AdjustManual && deleting:
+		values.Set("increaseBy", a.Value)
+	case a.Mode == AdjustManual:
+		values.Set("reduceBy", a.Value)
+	}
+	return "?" + values.Encode()
+}
+
+// WorklogInput is the content of a worklog to add or update. A zero Started
+// means now when adding and leaves the start unchanged when updating;
+// Comment is Markdown.
+type WorklogInput struct {
+	TimeSpentSeconds int
+	Started          time.Time
+	Comment          string
+}
+
+func (in WorklogInput) payload(update bool) map[string]interface{} {
+	payload := map[string]interface{}{"timeSpentSeconds": in.TimeSpentSeconds}
+	started := in.Started
+	if started.IsZero() && !update {
+		started = time.Now()
+	}
+	if !started.IsZero() {
+		payload["started"] = started.Format(WorklogTimeLayout)
+	}
+	if in.Comment != "" {
+		payload["comment"] = adf.FromMarkdown(in.Comment)
+	}
+	return payload
+}
+
+// AddWorklogContext logs work on an issue and returns the created worklog.
+func (c *Client) AddWorklogContext(ctx context.Context, issueKey string, in WorklogInput, adjust EstimateAdjustment) (*Worklog, error) {
+	endpoint := fmt.Sprintf("issue/%s/worklog%s", url.PathEscape(issueKey), adjust.query(false))
+	return c.sendWorklog(ctx, "POST", endpoint, in.payload(false), http.StatusCreated)
+}
+
+// AddWorklog calls AddWorklogContext with a background context.
+func (c *Client) AddWorklog(issueKey string, in WorklogInput, adjust EstimateAdjustment) (*Worklog, error) {
+	return c.AddWorklogContext(context.Background(), issueKey, in, adjust)
+}
+
+// UpdateWorklogContext replaces the time spent and comment of a worklog,
+// and its start unless in.Started is zero.
+func (c *Client) UpdateWorklogContext(ctx context.Context, issueKey, worklogID string, in WorklogInput, adjust EstimateAdjustment) (*Worklog, error) {
+	endpoint := fmt.Sprintf("issue/%s/worklog/%s%s", url.PathEscape(issueKey), url.PathEscape(worklogID), adjust.query(false))
+	return c.sendWorklog(ctx, "PUT", endpoint, in.payload(true), http.StatusOK)
+}
+
+// UpdateWorklog calls UpdateWorklogContext with a background context.
+func (c *Client) UpdateWorklog(issueKey, worklogID string, in WorklogInput, adjust EstimateAdjustment) (*Worklog, error) {
+	return c.UpdateWorklogContext(context.Background(), issueKey, worklogID, in, adjust)
+}
+
+func (c *Client) sendWorklog(ctx context.Context, method, endpoint string, payload map[string]interface{}, wantStatus int) (*Worklog, error) {
+	resp, err := c.makeRequest(ctx, method, endpoint, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != wantStatus {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+
+	var worklog Worklog
+	if err := json.NewDecoder(resp.Body).Decode(&worklog); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &worklog, nil
+}
+
+// DeleteWorklogContext deletes a worklog. With AdjustManual the remaining
+// estimate is increased by adjust.Value.
+func (c *Client) DeleteWorklogContext(ctx context.Context, issueKey, worklogID string, adjust EstimateAdjustment) error {
+	endpoint := fmt.Sprintf("issue/%s/worklog/%s%s", url.PathEscape(issueKey), url.PathEscape(worklogID), adjust.query(true))
+	resp, err := c.makeRequest(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+	return nil
+}
+
+// DeleteWorklog calls DeleteWorklogContext with a background context.
+func (c *Client) DeleteWorklog(issueKey, worklogID string, adjust EstimateAdjustment) error {
+	return c.DeleteWorklogContext(context.Background(), issueKey, worklogID, adjust)
+}
+
+// ListWorklogsContext returns the worklogs of an issue, following
+// pagination. A non-zero startedAfter skips worklogs started before it.
+func (c *Client) ListWorklogsContext(ctx context.Context, issueKey string, startedAfter time.Time) ([]Worklog, error) {
+	var worklogs []Worklog
+	for startAt := 0; ; {
+		query := url.Values{"startAt": {strconv.Itoa(startAt)}, "maxResults": {"100"}}
+		if !startedAfter.IsZero() {
+			query.Set("startedAfter", strconv.FormatInt(startedAfter.UnixMilli(), 10))
+		}
+		page, err := c.worklogPage(ctx, fmt.Sprintf("issue/%s/worklog?%s", url.PathEscape(issueKey), query.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		worklogs = append(worklogs, page.Worklogs...)
+		startAt += len(page.Worklogs)
+		if len(page.Worklogs) == 0 || startAt >= page.Total {
+			return worklogs, nil
+		}
+	}
+}
+
+// ListWorklogs calls ListWorklogsContext with a background context.
+func (c *Client) ListWorklogs(issueKey string, startedAfter time.Time) ([]Worklog, error) {
+	return c.ListWorklogsContext(context.Background(), issueKey, startedAfter)
+}
+
+type worklogPage struct {
+	StartAt  int       `json:"startAt"`
+	Total    int       `json:"total"`
+	Worklogs []Worklog `json:"worklogs"`
+}
+
+func (c *Client) worklogPage(ctx context.Context, endpoint string) (*worklogPage, error) {
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, httpx.NewAPIError(httpx.ServiceJira, resp, body)
+	}
+
+	var page worklogPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &page, nil
+}
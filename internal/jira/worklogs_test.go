@@ -0,0 +1,121 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"devflow/internal/config"
+	"devflow/internal/httpx"
+)
+
+func TestAddAndUpdateWorklog(t *testing.T) {
+	var requests []string
+	var payload map[string]interface{}
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+		payload = nil
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		_, _ = w.Write([]byte(`{"id":"100","issueId":"10001","timeSpent":"1h 30m","timeSpentSeconds":5400,"started":"2024-05-06T09:00:00.000+0200"}`))
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	started := time.Date(2024, 5, 6, 9, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	worklog, err := client.AddWorklog("ENG-1", WorklogInput{TimeSpentSeconds: 5400, Started: started, Comment: "pairing on **parser**"}, EstimateAdjustment{})
+	if err != nil {
+		t.Fatalf("AddWorklog failed: %v", err)
+	}
+	if worklog.ID != "100" || worklog.TimeSpentSeconds != 5400 {
+		t.Fatalf("unexpected worklog: %+v", worklog)
+	}
+	if at, err := worklog.StartedAt(); err != nil || !at.Equal(started) {
+		t.Fatalf("unexpected start time %v (%v)", at, err)
+	}
+	if payload["started"] != "2024-05-06T09:00:00.000+0200" || payload["timeSpentSeconds"] != float64(5400) {
+		t.Fatalf("unexpected worklog payload: %+v", payload)
+	}
+	encoded, _ := json.Marshal(payload["comment"])
+	if want := `"marks":[{"type":"strong"}]`; !strings.Contains(string(encoded), want) {
+		t.Fatalf("expected the comment to be converted to ADF, got %s", encoded)
+	}
+
+	if _, err := client.UpdateWorklog("ENG-1", "100", WorklogInput{TimeSpentSeconds: 3600, Started: started}, EstimateAdjustment{Mode: AdjustNew, Value: "2d"}); err != nil {
+		t.Fatalf("UpdateWorklog failed: %v", err)
+	}
+	if payload["started"] != "2024-05-06T09:00:00.000+0200" {
+		t.Fatalf("expected an explicit start to be sent, got %+v", payload)
+	}
+	if _, err := client.UpdateWorklog("ENG-1", "100", WorklogInput{TimeSpentSeconds: 1800}, EstimateAdjustment{}); err != nil {
+		t.Fatalf("UpdateWorklog failed: %v", err)
+	}
+	if _, ok := payload["started"]; ok || payload["timeSpentSeconds"] != float64(1800) {
+		t.Fatalf("expected an update without a start to leave it out, got %+v", payload)
+	}
+
+	want := []string{
+		"POST /rest/api/3/issue/ENG-1/worklog?",
+		"PUT /rest/api/3/issue/ENG-1/worklog/100?adjustEstimate=new&newEstimate=2d",
+		"PUT /rest/api/3/issue/ENG-1/worklog/100?",
+	}
+	if fmt.Sprint(requests) != fmt.Sprint(want) {
+		t.Fatalf("unexpected requests:\n%v\nwant:\n%v", requests, want)
+	}
+}
+
+func TestDeleteWorklogAdjustsEstimate(t *testing.T) {
+	var query string
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/rest/api/3/issue/ENG-1/worklog/100" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		query = r.URL.RawQuery
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	if err := client.DeleteWorklog("ENG-1", "100", EstimateAdjustment{Mode: AdjustManual, Value: "1h"}); err != nil {
+		t.Fatalf("DeleteWorklog failed: %v", err)
+	}
+	if query != "adjustEstimate=manual&increaseBy=1h" {
+		t.Fatalf("unexpected query %q", query)
+	}
+}
+
+func TestListWorklogsPaginates(t *testing.T) {
+	var startedAfter []string
+	server := httpx.NewTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/ENG-1/worklog" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		startedAfter = append(startedAfter, r.URL.Query().Get("startedAfter"))
+		switch r.URL.Query().Get("startAt") {
+		case "0":
+			_, _ = w.Write([]byte(`{"startAt":0,"total":3,"worklogs":[{"id":"1","timeSpentSeconds":60},{"id":"2","timeSpentSeconds":120}]}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"startAt":2,"total":3,"worklogs":[{"id":"3","timeSpentSeconds":180}]}`))
+		default:
+			t.Fatalf("unexpected page %q", r.URL.Query().Get("startAt"))
+		}
+	}))
+
+	client := NewClient(&config.JiraConfig{URL: server.URL, Username: "me", Token: "tok", Deployment: "cloud"})
+	since := time.UnixMilli(1714946400000)
+	worklogs, err := client.ListWorklogs("ENG-1", since)
+	if err != nil {
+		t.Fatalf("ListWorklogs failed: %v", err)
+	}
+	if len(worklogs) != 3 || worklogs[2].ID != "3" {
+		t.Fatalf("unexpected worklogs: %+v", worklogs)
+	}
+	if fmt.Sprint(startedAfter) != "[1714946400000 1714946400000]" {
+		t.Fatalf("unexpected startedAfter values: %v", startedAfter)
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"log"
 	"os"
 
 	"devflow/cmd"
@@ -9,7 +8,6 @@ import (
 
 func main() {
 	if err := cmd.Execute(); err != nil {
-		log.Fatal(err)
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }